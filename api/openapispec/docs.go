@@ -23,6 +23,100 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin-api/v1/action-logs": {
+            "get": {
+                "description": "This endpoint returns the recorded actions of users, filtered by user, action, cluster, outcome and time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "actionlog"
+                ],
+                "summary": "List the action logs of users.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The user who performed the actions",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the action, e.g. cluster.create",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The cluster the actions are performed on",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The outcome of the actions, either success or failure",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The start time in RFC3339 format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The end time in RFC3339 format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The current page to fetch. Default to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The size of the page. Default to 20",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The action logs",
+                        "schema": {
+                            "$ref": "#/definitions/storage.ActionLogResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/authn": {
             "get": {
                 "description": "This endpoint returns an authn result.",
@@ -79,6 +173,134 @@ var doc = `{
                 }
             }
         },
+        "/authn/oidc/callback": {
+            "get": {
+                "description": "This endpoint exchanges the authorization code, starts a session and redirects to the page the login started from.",
+                "tags": [
+                    "authn"
+                ],
+                "summary": "Callback completes the OpenID Connect login.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The state of the login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/authn/oidc/login": {
+            "get": {
+                "description": "This endpoint starts the OpenID Connect authorization code flow with PKCE and redirects to the OpenID provider.",
+                "tags": [
+                    "authn"
+                ],
+                "summary": "Login redirects to the OpenID provider.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The local path to return to after login",
+                        "name": "redirect",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/authn/oidc/logout": {
+            "post": {
+                "description": "This endpoint ends the OpenID Connect login session and removes the session cookie.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authn"
+                ],
+                "summary": "Logout ends the login session.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/authn/user": {
+            "get": {
+                "description": "This endpoint returns the name and groups of the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authn"
+                ],
+                "summary": "GetUser returns the authenticated user.",
+                "responses": {
+                    "200": {
+                        "description": "The authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/authn.UserInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/endpoints": {
             "get": {
                 "description": "List all registered endpoints in the router",
@@ -376,35 +598,95 @@ var doc = `{
                 }
             }
         },
-        "/insight/issue/interpret/stream": {
-            "post": {
-                "description": "This endpoint analyzes scanner issues using AI to provide detailed interpretation and insights",
-                "consumes": [
-                    "application/json"
-                ],
+        "/insight/aggregator/log/pods": {
+            "get": {
+                "description": "This endpoint streams the logs of the pods of a workload, of the pods selected by a label selector, or of the pods in a resource group, prefixed by pod and container. New replicas and restarted containers are followed.",
                 "produces": [
                     "text/event-stream"
                 ],
                 "tags": [
                     "insight"
                 ],
-                "summary": "Interpret scanner issues using AI",
+                "summary": "Stream the logs of multiple pods using Server-Sent Events",
                 "parameters": [
                     {
-                        "description": "The audit data to interpret",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/scanner.InterpretRequest"
-                        }
+                        "type": "string",
+                        "description": "The cluster name, required for workloads and label selectors",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The namespace name, required for workloads and label selectors",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The kind of the workload, one of Pod, Deployment, StatefulSet, DaemonSet, ReplicaSet and Job",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the workload, or of the pods in the resource group",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the pods, such as 'app=foo'",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The labels of the pods in the resource group, such as 'app=foo'",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The annotations of the pods in the resource group",
+                        "name": "annotations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only stream the containers of the name",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only stream the lines matching the regular expression",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return logs newer than a relative duration like 5s, 2m, or 3h",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lines from the end of the logs of every container to show",
+                        "name": "tailLines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Follow the logs, new pods and restarted containers. Default to true",
+                        "name": "follow",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ai.InterpretEvent"
+                            "$ref": "#/definitions/logs.Line"
                         }
                     },
                     "400": {
@@ -424,25 +706,13 @@ var doc = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
             }
         },
-        "/insight/yaml/interpret/stream": {
+        "/insight/issue/interpret/stream": {
             "post": {
-                "description": "This endpoint analyzes YAML content using AI to provide detailed interpretation and insights",
+                "description": "This endpoint analyzes scanner issues using AI to provide detailed interpretation and insights",
                 "consumes": [
                     "application/json"
                 ],
@@ -452,15 +722,15 @@ var doc = `{
                 "tags": [
                     "insight"
                 ],
-                "summary": "Interpret YAML using AI",
+                "summary": "Interpret scanner issues using AI",
                 "parameters": [
                     {
-                        "description": "The YAML content to interpret",
+                        "description": "The audit data to interpret",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/detail.InterpretRequest"
+                            "$ref": "#/definitions/scanner.InterpretRequest"
                         }
                     }
                 ],
@@ -504,64 +774,63 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/cluster/config/file": {
+        "/insight/yaml/interpret/stream": {
             "post": {
-                "description": "Uploads a KubeConfig file for cluster, with a maximum size of 2MB.",
+                "description": "This endpoint analyzes YAML content using AI to provide detailed interpretation and insights",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "cluster"
+                    "insight"
                 ],
-                "summary": "Upload kubeConfig file for cluster",
+                "summary": "Interpret YAML using AI",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Upload file with field name 'file'",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "cluster name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "cluster display name",
-                        "name": "displayName",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "cluster description",
-                        "name": "description",
-                        "in": "formData",
-                        "required": true
+                        "description": "The YAML content to interpret",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/detail.InterpretRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns the content of the uploaded KubeConfig file.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/cluster.UploadData"
+                            "$ref": "#/definitions/ai.InterpretEvent"
                         }
                     },
                     "400": {
-                        "description": "The uploaded file is too large or the request is invalid.",
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -569,36 +838,35 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/cluster/config/validate": {
+        "/rest-api/v1/alert-rule": {
             "post": {
-                "description": "Validates the provided KubeConfig using cluster manager methods.",
+                "description": "This endpoint creates a new AlertRule using the payload.",
                 "consumes": [
-                    "text/plain",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "Validate KubeConfig",
+                "summary": "Create creates an AlertRule.",
                 "parameters": [
                     {
-                        "description": "KubeConfig payload to validate",
+                        "description": "alertRule to create",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/cluster.ValidatePayload"
+                            "$ref": "#/definitions/alert.AlertRulePayload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Verification passed server version",
+                        "description": "AlertRule object",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/entity.AlertRule"
                         }
                     },
                     "400": {
@@ -619,6 +887,18 @@ var doc = `{
                             "type": "string"
                         }
                     },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -634,36 +914,30 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/cluster/{clusterName}": {
+        "/rest-api/v1/alert-rule/{alertRuleName}": {
             "get": {
-                "description": "This endpoint returns a cluster resource by name.",
+                "description": "This endpoint returns an AlertRule with the status of its last evaluation by name. The channel destinations are only shown to the owner, and the matching objects are restricted to the data access of the user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "Get returns a cluster resource by name.",
+                "summary": "Get returns an AlertRule by name.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the cluster",
-                        "name": "clusterName",
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The format of the response. Either in json or yaml",
-                        "name": "format",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "AlertRule object",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "$ref": "#/definitions/entity.AlertRule"
                         }
                     },
                     "400": {
@@ -705,41 +979,40 @@ var doc = `{
                 }
             },
             "put": {
-                "description": "This endpoint updates the display name and description of an existing cluster resource.",
+                "description": "This endpoint updates an AlertRule by name, the status of the AlertRule is reset.",
                 "consumes": [
-                    "text/plain",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "Update updates the cluster metadata by name.",
+                "summary": "Update updates an AlertRule by name.",
                 "parameters": [
                     {
-                        "description": "cluster to update (either plain text or JSON format)",
+                        "type": "string",
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "alertRule to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/cluster.ClusterPayload"
+                            "$ref": "#/definitions/alert.AlertRulePayload"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "The name of the cluster",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "AlertRule object",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "$ref": "#/definitions/entity.AlertRule"
                         }
                     },
                     "400": {
@@ -780,42 +1053,29 @@ var doc = `{
                     }
                 }
             },
-            "post": {
-                "description": "This endpoint creates a new cluster resource using the payload.",
-                "consumes": [
-                    "text/plain",
-                    "application/json"
-                ],
+            "delete": {
+                "description": "This endpoint deletes an AlertRule by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "Create creates a cluster resource.",
+                "summary": "Delete removes an AlertRule by name.",
                 "parameters": [
-                    {
-                        "description": "cluster to create (either plain text or JSON format)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/cluster.ClusterPayload"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "The name of the cluster",
-                        "name": "clusterName",
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "Operation status",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -855,30 +1115,39 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "This endpoint deletes the cluster resource by name.",
+            }
+        },
+        "/rest-api/v1/alert-rule/{alertRuleName}/silence": {
+            "put": {
+                "description": "This endpoint suppresses the notifications of an AlertRule for the duration, the AlertRule is still evaluated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "Delete removes a cluster resource by name.",
+                "summary": "Silence silences an AlertRule.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the cluster",
-                        "name": "clusterName",
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "How long the AlertRule is silenced, such as '2h'",
+                        "name": "duration",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operation status",
+                        "description": "AlertRule object",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/entity.AlertRule"
                         }
                     },
                     "400": {
@@ -918,46 +1187,30 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/rest-api/v1/clusters": {
-            "get": {
-                "description": "This endpoint lists all cluster resources.",
+            },
+            "delete": {
+                "description": "This endpoint lifts the silence of an AlertRule, so that its notifications are sent again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cluster"
+                    "alert"
                 ],
-                "summary": "List lists all cluster resources.",
+                "summary": "Unsilence lifts the silence of an AlertRule.",
                 "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "Whether to display summary or not. Default to false",
-                        "name": "summary",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "The order to list the cluster. Default to order by name",
-                        "name": "orderBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Whether to sort the list in descending order. Default to false",
-                        "name": "descending",
-                        "in": "query"
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of cluster objects",
+                        "description": "AlertRule object",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/unstructured.Unstructured"
-                            }
+                            "$ref": "#/definitions/entity.AlertRule"
                         }
                     },
                     "400": {
@@ -999,59 +1252,30 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/insight/audit": {
-            "get": {
-                "description": "This endpoint audits based on the specified resource group.",
+        "/rest-api/v1/alert-rule/{alertRuleName}/test": {
+            "post": {
+                "description": "This endpoint evaluates an AlertRule and returns whether it fires, without notifying its channels or updating its status.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
+                    "alert"
                 ],
-                "summary": "Audit based on resource group.",
+                "summary": "Test evaluates an AlertRule.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'",
-                        "name": "apiVersion",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Switch for forced scanning, default is 'false'",
-                        "name": "forceNew",
-                        "in": "query"
+                        "description": "The name of the alert rule",
+                        "name": "alertRuleName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Audit results",
+                        "description": "Evaluation of the AlertRule",
                         "schema": {
-                            "$ref": "#/definitions/ai.AuditData"
+                            "$ref": "#/definitions/alert.Evaluation"
                         }
                     },
                     "400": {
@@ -1072,6 +1296,12 @@ var doc = `{
                             "type": "string"
                         }
                     },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1087,59 +1317,24 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/insight/detail": {
+        "/rest-api/v1/alert-rules": {
             "get": {
-                "description": "This endpoint returns a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+                "description": "This endpoint lists all AlertRules with the status of their last evaluation. The channel destinations are only shown to the owners, and the matching objects are restricted to the data access of the user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
-                ],
-                "summary": "GetDetail returns a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The format of the response. Either in json or yaml. Default to json",
-                        "name": "format",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
-                        "name": "apiVersion",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
-                    }
+                    "alert"
                 ],
+                "summary": "List lists all AlertRules.",
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "List of AlertRule objects",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AlertRule"
+                            }
                         }
                     },
                     "400": {
@@ -1181,56 +1376,100 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/insight/events": {
-            "get": {
-                "description": "This endpoint returns events for a Kubernetes resource YAML by name, namespace, cluster, apiVersion and kind.",
+        "/rest-api/v1/cluster/config/file": {
+            "post": {
+                "description": "Uploads a KubeConfig file for cluster, with a maximum size of 2MB.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "insight"
+                    "cluster"
                 ],
-                "summary": "GetEvents returns events for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+                "summary": "Upload kubeConfig file for cluster",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
+                        "type": "file",
+                        "description": "Upload file with field name 'file'",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
-                        "name": "apiVersion",
-                        "in": "query"
+                        "description": "cluster name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "cluster display name",
+                        "name": "displayName",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
+                        "description": "cluster description",
+                        "name": "description",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the content of the uploaded KubeConfig file.",
+                        "schema": {
+                            "$ref": "#/definitions/cluster.UploadData"
+                        }
+                    },
+                    "400": {
+                        "description": "The uploaded file is too large or the request is invalid.",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/cluster/config/import": {
+            "post": {
+                "description": "Imports a cluster for each context of the kubeconfigs. The clusters are validated in parallel and created or updated idempotently, with dryRun the resulting clusters are only previewed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cluster"
+                ],
+                "summary": "Import clusters from kubeconfigs",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
+                        "description": "The kubeconfigs to import and the import options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cluster.ImportPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of events",
+                        "description": "The results of the import of each context",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/unstructured.Unstructured"
-                            }
+                            "$ref": "#/definitions/cluster.ImportResponse"
                         }
                     },
                     "400": {
@@ -1272,59 +1511,36 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/insight/score": {
-            "get": {
-                "description": "This endpoint calculates a score for the provided manifest based on the number and severity of issues detected during the audit.",
+        "/rest-api/v1/cluster/config/validate": {
+            "post": {
+                "description": "Validates the provided KubeConfig using cluster manager methods.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
+                    "cluster"
                 ],
-                "summary": "ScoreHandler calculates a score for the audited manifest.",
+                "summary": "Validate KubeConfig",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'",
-                        "name": "apiVersion",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Switch for forced compute score, default is 'false'",
-                        "name": "forceNew",
-                        "in": "query"
+                        "description": "KubeConfig payload to validate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cluster.ValidatePayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Score calculation result",
+                        "description": "Verification passed server version",
                         "schema": {
-                            "$ref": "#/definitions/insight.ScoreData"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1360,21 +1576,36 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/insight/stats": {
+        "/rest-api/v1/cluster/{clusterName}": {
             "get": {
-                "description": "This endpoint returns a global statistics info.",
+                "description": "This endpoint returns a cluster resource by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
+                    "cluster"
+                ],
+                "summary": "Get returns a cluster resource by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the cluster",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The format of the response. Either in json or yaml",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get returns a global statistics info.",
                 "responses": {
                     "200": {
-                        "description": "Global statistics info",
+                        "description": "Unstructured object",
                         "schema": {
-                            "$ref": "#/definitions/insight.Statistics"
+                            "$ref": "#/definitions/unstructured.Unstructured"
                         }
                     },
                     "400": {
@@ -1414,55 +1645,43 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/rest-api/v1/insight/summary": {
-            "get": {
-                "description": "This endpoint returns a Kubernetes resource summary by name, namespace, cluster, apiVersion and kind.",
+            },
+            "put": {
+                "description": "This endpoint updates the display name, description and labels of an existing cluster resource.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
+                    "cluster"
                 ],
-                "summary": "Get returns a Kubernetes resource summary by name, namespace, cluster, apiVersion and kind.",
+                "summary": "Update updates the cluster metadata by name.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
+                        "description": "cluster to update (either plain text or JSON format)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cluster.ClusterPayload"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
-                        "name": "apiVersion",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
+                        "description": "The name of the cluster",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Resource Summary",
+                        "description": "Unstructured object",
                         "schema": {
-                            "$ref": "#/definitions/insight.ResourceSummary"
+                            "$ref": "#/definitions/unstructured.Unstructured"
                         }
                     },
                     "400": {
@@ -1502,67 +1721,43 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/rest-api/v1/insight/topology": {
-            "get": {
-                "description": "This endpoint returns a topology map for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+            },
+            "post": {
+                "description": "This endpoint creates a new cluster resource using the payload.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "insight"
+                    "cluster"
                 ],
-                "summary": "GetTopology returns a topology map for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+                "summary": "Create creates a cluster resource.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The specified cluster name, such as 'example-cluster'",
-                        "name": "cluster",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
-                        "name": "apiVersion",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified kind, such as 'Deployment'",
-                        "name": "kind",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The specified namespace, such as 'default'",
-                        "name": "namespace",
-                        "in": "query"
+                        "description": "cluster to create (either plain text or JSON format)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cluster.ClusterPayload"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "The specified resource name, such as 'foo'",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Force re-generating the topology, default is 'false'",
-                        "name": "forceNew",
-                        "in": "query"
+                        "description": "The name of the cluster",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "map from string to resource.ResourceTopology",
+                        "description": "Unstructured object",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "object",
-                                "additionalProperties": {
-                                    "$ref": "#/definitions/insight.ResourceTopology"
-                                }
-                            }
+                            "$ref": "#/definitions/unstructured.Unstructured"
                         }
                     },
                     "400": {
@@ -1602,38 +1797,30 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/rest-api/v1/resource-group-rule": {
-            "put": {
-                "description": "This endpoint updates the display name and description of an existing ResourceGroupRule.",
-                "consumes": [
-                    "text/plain",
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "This endpoint deletes the cluster resource by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resourcegrouprule"
+                    "cluster"
                 ],
-                "summary": "Update updates the ResourceGroupRule metadata by name.",
+                "summary": "Delete removes a cluster resource by name.",
                 "parameters": [
                     {
-                        "description": "resourceGroupRule to update (either plain text or JSON format)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/resourcegrouprule.ResourceGroupRulePayload"
-                        }
+                        "type": "string",
+                        "description": "The name of the cluster",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "Operation status",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1673,36 +1860,52 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "This endpoint creates a new ResourceGroupRule using the payload.",
-                "consumes": [
-                    "text/plain",
-                    "application/json"
-                ],
+            }
+        },
+        "/rest-api/v1/clusters": {
+            "get": {
+                "description": "This endpoint lists all cluster resources, optionally filtered by a label selector.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resourcegrouprule"
+                    "cluster"
                 ],
-                "summary": "Create creates a ResourceGroupRule.",
+                "summary": "List lists all cluster resources.",
                 "parameters": [
                     {
-                        "description": "resourceGroupRule to create (either plain text or JSON format)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/resourcegrouprule.ResourceGroupRulePayload"
-                        }
+                        "type": "boolean",
+                        "description": "Whether to display summary or not. Default to false",
+                        "name": "summary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The order to list the cluster. Default to order by name",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to sort the list in descending order. Default to false",
+                        "name": "descending",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to list, such as 'env=prod,region in (eu-west,eu-north)'",
+                        "name": "labelSelector",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "List of cluster objects",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/unstructured.Unstructured"
+                            }
                         }
                     },
                     "400": {
@@ -1744,30 +1947,74 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/resource-group-rule/{resourceGroupRuleName}": {
+        "/rest-api/v1/insight/audit": {
             "get": {
-                "description": "This endpoint returns a ResourceGroupRule by name.",
+                "description": "This endpoint audits based on the specified resource group. The result is exported as a report file when the format is sarif, junit or csv.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/sarif+json",
+                    "text/xml",
+                    "text/csv"
                 ],
                 "tags": [
-                    "resourcegrouprule"
+                    "insight"
                 ],
-                "summary": "Get returns a ResourceGroupRule by name.",
+                "summary": "Audit based on resource group.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the resource group rule",
-                        "name": "resourceGroupRuleName",
-                        "in": "path",
-                        "required": true
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Switch for forced scanning, default is 'false'",
+                        "name": "forceNew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The format of the result, one of 'json', 'sarif', 'junit' or 'csv', default is 'json'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to cover, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unstructured object",
+                        "description": "Audit results",
                         "schema": {
-                            "$ref": "#/definitions/unstructured.Unstructured"
+                            "$ref": "#/definitions/ai.AuditData"
                         }
                     },
                     "400": {
@@ -1788,12 +2035,6 @@ var doc = `{
                             "type": "string"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1807,30 +2048,61 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "This endpoint deletes the ResourceGroupRule by name.",
+            }
+        },
+        "/rest-api/v1/insight/detail": {
+            "get": {
+                "description": "This endpoint returns a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resourcegrouprule"
+                    "insight"
                 ],
-                "summary": "Delete removes a ResourceGroupRule by name.",
+                "summary": "GetDetail returns a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the resource group rule",
-                        "name": "resourceGroupRuleName",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
+                        "description": "The format of the response. Either in json or yaml. Default to json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "Operation status",
+                        "description": "Unstructured object",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/unstructured.Unstructured"
                         }
                     },
                     "400": {
@@ -1872,39 +2144,51 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/resource-group-rules": {
+        "/rest-api/v1/insight/events": {
             "get": {
-                "description": "This endpoint lists all ResourceGroupRules.",
+                "description": "This endpoint returns events for a Kubernetes resource YAML by name, namespace, cluster, apiVersion and kind.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resourcegrouprule"
+                    "insight"
                 ],
-                "summary": "List lists all ResourceGroupRules.",
+                "summary": "GetEvents returns events for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Whether to display summary or not. Default to false",
-                        "name": "summary",
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "The order to list the resourceGroupRule. Default to order by name",
-                        "name": "orderBy",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Whether to sort the list in descending order. Default to false",
-                        "name": "descending",
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of resourceGroupRule objects",
+                        "description": "List of events",
                         "schema": {
                             "type": "array",
                             "items": {
@@ -1951,33 +2235,89 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/resource-groups/{resourceGroupRuleName}": {
+        "/rest-api/v1/insight/events/history": {
             "get": {
-                "description": "This endpoint lists all ResourceGroups.",
+                "description": "This endpoint returns the archived events, the latest first, filtered by the involved object, type, reason and time across clusters.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resourcegroup"
+                    "insight"
                 ],
-                "summary": "List lists all ResourceGroups by rule name.",
+                "summary": "GetEventHistory returns the archived events.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the resource group rule",
-                        "name": "resourceGroupRuleName",
-                        "in": "path",
-                        "required": true
+                        "description": "The cluster of the events, all clusters if empty",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The apiVersion of the involved objects, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The kind of the involved objects, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The namespace of the involved objects, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the involved object, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The type of the events, either Normal or Warning",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reason of the events, such as 'BackOff'",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The start time in RFC3339 format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The end time in RFC3339 format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The current page to fetch. Default to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The size of the page. Default to 20",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of resourceGroup objects",
+                        "description": "The archived events",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/unstructured.Unstructured"
-                            }
+                            "$ref": "#/definitions/storage.EventResult"
                         }
                     },
                     "400": {
@@ -2019,56 +2359,68 @@ var doc = `{
                 }
             }
         },
-        "/rest-api/v1/search": {
-            "get": {
-                "description": "This endpoint returns an array of Kubernetes runtime Object matched using the query from context.",
-                "produces": [
+        "/rest-api/v1/insight/issue/remediation/stream": {
+            "post": {
+                "description": "This endpoint streams a patch suggested by AI for the issues of a resource, then validates it by applying it to a copy of the resource and scanning the result, and optionally by a server-side dry run. The patch is never applied to the cluster.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "search"
+                    "insight"
                 ],
-                "summary": "SearchForResource returns an array of Kubernetes runtime Object matched using the query from context.",
+                "summary": "Suggest a patch fixing the issues of a resource using AI",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The query to use for search. Required",
-                        "name": "query",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The search pattern. Can be either sql, dsl or nl. Required",
-                        "name": "pattern",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The size of the page. Default to 10",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "The current page to fetch. Default to 1",
-                        "name": "page",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "The keyword to use for search. Optional",
-                        "name": "keyword",
-                        "in": "query"
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "The language, the issues to fix and whether to dry run the patch",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/scanner.RemediateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Array of runtime.Object",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "$ref": "#/definitions/ai.RemediationEvent"
                         }
                     },
                     "400": {
@@ -2089,12 +2441,6 @@ var doc = `{
                             "type": "string"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2110,243 +2456,3207 @@ var doc = `{
                 }
             }
         },
-        "/server-configs": {
+        "/rest-api/v1/insight/issues/history": {
             "get": {
-                "description": "Returns server configuration",
+                "description": "This endpoint returns the issues of the specified resource group which are introduced or resolved between two dates, except the ones suppressed by audit exceptions.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "debug"
+                    "insight"
+                ],
+                "summary": "Get the issues introduced and resolved between two dates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The end time in RFC3339 or YYYY-MM-DD format, default is now",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get server configurations",
                 "responses": {
                     "200": {
-                        "description": "Server configurations",
+                        "description": "Introduced and resolved issues",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/insight.IssueHistory"
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "aggregator.DiagnoseRequest": {
-            "type": "object",
-            "properties": {
-                "language": {
-                    "description": "Language code for AI response",
-                    "type": "string"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/resource/diagnosis/stream": {
+            "post": {
+                "description": "This endpoint assembles a bounded context of a resource, including its YAML, owner chain, related resources, recent events, logs of related pods and audit issues, and streams a root-cause analysis citing the evidence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "Diagnose a resource using AI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "The language and the token budget of the diagnosis",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/detail.DiagnoseResourceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ai.DiagnosisEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/score": {
+            "get": {
+                "description": "This endpoint calculates a score for the provided manifest based on the number and severity of issues detected during the audit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "ScoreHandler calculates a score for the audited manifest.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Switch for forced compute score, default is 'false'",
+                        "name": "forceNew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to cover, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Score calculation result",
+                        "schema": {
+                            "$ref": "#/definitions/insight.ScoreData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/score/trend": {
+            "get": {
+                "description": "This endpoint returns the daily or weekly score of the specified resource group, recorded by the background audit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "Get the score trend of the resource group.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The period of each point, 'day' or 'week', default is 'day'",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The end time in RFC3339 or YYYY-MM-DD format, default is now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Score trend",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/insight.ScoreTrendPoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/stats": {
+            "get": {
+                "description": "This endpoint returns a global statistics info, optionally restricted to the clusters selected by labels.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "Get returns a global statistics info.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to cover, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Global statistics info",
+                        "schema": {
+                            "$ref": "#/definitions/insight.Statistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/summary": {
+            "get": {
+                "description": "This endpoint returns a Kubernetes resource summary by name, namespace, cluster, apiVersion and kind.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "Get returns a Kubernetes resource summary by name, namespace, cluster, apiVersion and kind.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resource Summary",
+                        "schema": {
+                            "$ref": "#/definitions/insight.ResourceSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/summary/stream": {
+            "post": {
+                "description": "This endpoint collects the nodes, the capacity usage, the resource counts, the audit score and its change, the unhealthy pods and the recent warning events of a cluster or a namespace, and streams a short operational report suitable for standups and handover notes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "Summarize the state of a cluster or a namespace using AI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "The language and the token budget of the summary",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/summary.SummarizeStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ai.InterpretEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/insight/topology": {
+            "get": {
+                "description": "This endpoint returns a topology map for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "insight"
+                ],
+                "summary": "GetTopology returns a topology map for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Force re-generating the topology, default is 'false'",
+                        "name": "forceNew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to cover, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "map from string to resource.ResourceTopology",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "$ref": "#/definitions/insight.ResourceTopology"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-group-rule": {
+            "put": {
+                "description": "This endpoint updates the display name and description of an existing ResourceGroupRule.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegrouprule"
+                ],
+                "summary": "Update updates the ResourceGroupRule metadata by name.",
+                "parameters": [
+                    {
+                        "description": "resourceGroupRule to update (either plain text or JSON format)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/resourcegrouprule.ResourceGroupRulePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unstructured object",
+                        "schema": {
+                            "$ref": "#/definitions/unstructured.Unstructured"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "This endpoint creates a new ResourceGroupRule using the payload.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegrouprule"
+                ],
+                "summary": "Create creates a ResourceGroupRule.",
+                "parameters": [
+                    {
+                        "description": "resourceGroupRule to create (either plain text or JSON format)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/resourcegrouprule.ResourceGroupRulePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unstructured object",
+                        "schema": {
+                            "$ref": "#/definitions/unstructured.Unstructured"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-group-rule/{resourceGroupRuleName}": {
+            "get": {
+                "description": "This endpoint returns a ResourceGroupRule by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegrouprule"
+                ],
+                "summary": "Get returns a ResourceGroupRule by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unstructured object",
+                        "schema": {
+                            "$ref": "#/definitions/unstructured.Unstructured"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "This endpoint deletes the ResourceGroupRule by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegrouprule"
+                ],
+                "summary": "Delete removes a ResourceGroupRule by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operation status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-group-rules": {
+            "get": {
+                "description": "This endpoint lists all ResourceGroupRules.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegrouprule"
+                ],
+                "summary": "List lists all ResourceGroupRules.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Whether to display summary or not. Default to false",
+                        "name": "summary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The order to list the resourceGroupRule. Default to order by name",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to sort the list in descending order. Default to false",
+                        "name": "descending",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of resourceGroupRule objects",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/unstructured.Unstructured"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-groups/{resourceGroupRuleName}": {
+            "get": {
+                "description": "This endpoint lists all ResourceGroups.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegroup"
+                ],
+                "summary": "List lists all ResourceGroups by rule name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of resourceGroup objects",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/unstructured.Unstructured"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-groups/{resourceGroupRuleName}/snapshots": {
+            "get": {
+                "description": "This endpoint lists the membership snapshots of the specified resource group of the rule in a period of time, without their members.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegroup"
+                ],
+                "summary": "List the snapshots of a resource group.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The end time in RFC3339 or YYYY-MM-DD format, default is now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of snapshots",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ResourceGroupSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "This endpoint records the current members and the resource counts of the specified resource group of the rule.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegroup"
+                ],
+                "summary": "Record a snapshot of a resource group.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified cluster name, such as 'example-cluster'",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified apiVersion, such as 'apps/v1'",
+                        "name": "apiVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified kind, such as 'Deployment'",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified namespace, such as 'default'",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The specified resource name, such as 'foo'",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recorded snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ResourceGroupSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/resource-groups/{resourceGroupRuleName}/snapshots/diff": {
+            "get": {
+                "description": "This endpoint returns the resources added to and removed from the resource group between two snapshots, and the changes of the resource counts. The snapshot is compared with the current membership if 'to' is not specified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resourcegroup"
+                ],
+                "summary": "Compare two snapshots of a resource group.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the resource group rule",
+                        "name": "resourceGroupRuleName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the earlier snapshot",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the later snapshot, default is the current membership",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changes between the snapshots",
+                        "schema": {
+                            "$ref": "#/definitions/resourcegroup.ResourceGroupChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/saved-search": {
+            "post": {
+                "description": "This endpoint creates a new SavedSearch owned by the user using the payload.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Create creates a SavedSearch.",
+                "parameters": [
+                    {
+                        "description": "savedSearch to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/savedsearch.SavedSearchPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SavedSearch object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/saved-search/{savedSearchName}": {
+            "get": {
+                "description": "This endpoint returns a SavedSearch visible to the user by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Get returns a SavedSearch by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SavedSearch object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "This endpoint updates the query, format and visibility of a SavedSearch owned by the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Update updates a SavedSearch by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "savedSearch to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/savedsearch.SavedSearchPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SavedSearch object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "This endpoint deletes a SavedSearch owned by the user by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Delete removes a SavedSearch by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operation status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/saved-search/{savedSearchName}/favorite": {
+            "put": {
+                "description": "This endpoint adds a SavedSearch visible to the user to the favorites of the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Favorite marks a SavedSearch as a favorite of the user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SavedSearch object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "This endpoint removes a SavedSearch visible to the user from the favorites of the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "Unfavorite removes a SavedSearch from the favorites of the user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SavedSearch object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/saved-search/{savedSearchName}/run": {
+            "get": {
+                "description": "This endpoint returns the resources matched by the query of the saved search, in the format of the saved search.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "RunSavedSearch runs a saved search by name.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the saved search",
+                        "name": "savedSearchName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The size of the page. Default to 10",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The current page to fetch. Default to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to search, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Array of runtime.Object",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/saved-searches": {
+            "get": {
+                "description": "This endpoint lists the shared SavedSearches and the private ones of the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "savedsearch"
+                ],
+                "summary": "List lists the SavedSearches visible to the user.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Whether to only list the favorites of the user. Default to false",
+                        "name": "favorite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of SavedSearch objects",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.SavedSearch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rest-api/v1/search": {
+            "get": {
+                "description": "This endpoint returns an array of Kubernetes runtime Object matched using the query from context.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "SearchForResource returns an array of Kubernetes runtime Object matched using the query from context.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The query to use for search. Required",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The search pattern. Can be either sql, dsl or nl. Required",
+                        "name": "pattern",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The size of the page. Default to 10",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The current page to fetch. Default to 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The keyword to use for search. Optional",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The label selector of the clusters to search, such as 'env=prod'",
+                        "name": "clusterSelector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Array of runtime.Object",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-configs": {
+            "get": {
+                "description": "Returns server configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "debug"
+                ],
+                "summary": "Get server configurations",
+                "responses": {
+                    "200": {
+                        "description": "Server configurations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "aggregator.DiagnoseRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "Language code for AI response",
+                    "type": "string"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "aggregator.EventDiagnoseRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.Event"
+                    }
+                },
+                "language": {
+                    "type": "string"
+                }
+            }
+        },
+        "aggregator.LogEntry": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "ai.AuditData": {
+            "type": "object",
+            "properties": {
+                "bySeverity": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "issueGroups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.IssueGroup"
+                    }
+                },
+                "issueTotal": {
+                    "type": "integer"
+                },
+                "resourceTotal": {
+                    "type": "integer"
+                },
+                "suppressedTotal": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ai.DiagnosisEvent": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Event content",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Event type: start/chunk/error/complete",
+                    "type": "string"
+                }
+            }
+        },
+        "ai.DryRunResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "ai.Event": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "firstTimestamp": {
+                    "type": "string"
+                },
+                "lastTimestamp": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "object": {
+                    "description": "Object is the object the event is about, if it is not the diagnosed\nresource itself.",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "ai.InterpretEvent": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Event content or error message",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Event type: start, chunk, error, complete",
+                    "type": "string"
+                }
+            }
+        },
+        "ai.IssueGroup": {
+            "type": "object",
+            "properties": {
+                "issue": {
+                    "$ref": "#/definitions/scanner.Issue"
+                },
+                "resourceGroups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroup"
+                    }
+                }
+            }
+        },
+        "ai.Patch": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content is the patch in JSON.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is the type of the patch, a strategic merge patch or a JSON patch.\nA strategic merge patch of a resource without a registered schema,\nsuch as a custom resource, is applied as a JSON merge patch.",
+                    "type": "string"
+                }
+            }
+        },
+        "ai.RemediationEvent": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Event content or error message",
+                    "type": "string"
+                },
+                "result": {
+                    "description": "The validated patch of the result event",
+                    "$ref": "#/definitions/ai.RemediationResult"
+                },
+                "type": {
+                    "description": "Event type: start, chunk, result, error, complete",
+                    "type": "string"
+                }
+            }
+        },
+        "ai.RemediationResult": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "description": "Diff is the unified diff of the YAML of the resource after the patch.",
+                    "type": "string"
+                },
+                "dryRun": {
+                    "description": "DryRun is the result of the server-side dry run, nil if it is not\nrequested.",
+                    "$ref": "#/definitions/ai.DryRunResult"
+                },
+                "introducedIssues": {
+                    "description": "IntroducedIssues are the titles of the issues caused by the patch.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issues": {
+                    "description": "Issues are the issues the patch is suggested for.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scanner.Issue"
+                    }
+                },
+                "patch": {
+                    "$ref": "#/definitions/ai.Patch"
+                },
+                "remainingIssues": {
+                    "description": "RemainingIssues are the issues found by scanning the patched resource\nagain.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scanner.Issue"
+                    }
+                },
+                "resolvedIssues": {
+                    "description": "ResolvedIssues are the titles of the issues fixed by the patch.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "alert.AlertRulePayload": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AlertChannel"
+                    }
+                },
+                "condition": {
+                    "$ref": "#/definitions/entity.AlertCondition"
+                },
+                "dedupeWindow": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resourceGroup": {
+                    "$ref": "#/definitions/entity.ResourceGroup"
+                },
+                "savedSearch": {
+                    "type": "string"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/entity.AlertTrigger"
+                }
+            }
+        },
+        "alert.Evaluation": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of matching objects.",
+                    "type": "integer"
+                },
+                "deduplicated": {
+                    "description": "Deduplicated reports whether the notification is suppressed because\nthe same notification was sent within the dedupe window.",
+                    "type": "boolean"
+                },
+                "fingerprint": {
+                    "description": "Fingerprint identifies the notification for dedupe.",
+                    "type": "string"
+                },
+                "firing": {
+                    "description": "Firing reports whether the condition of the alert rule fired.",
+                    "type": "boolean"
+                },
+                "matches": {
+                    "description": "Matches are the keys of the matching objects, which are not tracked\nif more than maxAlertMatches objects match.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "notification": {
+                    "description": "Notification is sent to the channels of the alert rule, it is nil if\nthe condition did not fire.",
+                    "$ref": "#/definitions/entity.AlertNotification"
+                },
+                "silenced": {
+                    "description": "Silenced reports whether the notification is suppressed because the\nalert rule is silenced.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "authn.UserInfo": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "cluster.ClusterPayload": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "ClusterDescription is the description of cluster to be created",
+                    "type": "string"
+                },
+                "displayName": {
+                    "description": "ClusterDisplayName is the display name of cluster to be created",
+                    "type": "string"
+                },
+                "kubeConfig": {
+                    "description": "ClusterKubeConfig is the kubeconfig of cluster to be created",
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "ClusterLabels are the labels of cluster, such as its environment, region and team",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "cluster.ImportPayload": {
+            "type": "object",
+            "properties": {
+                "concurrency": {
+                    "description": "Concurrency is the number of clusters imported in parallel, it is at\nmost 32.",
+                    "type": "integer"
+                },
+                "contexts": {
+                    "description": "Contexts limits the import to the given contexts, all contexts are\nimported if it is empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "description": "DryRun previews the clusters without creating or updating them.",
+                    "type": "boolean"
+                },
+                "kubeConfigs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cluster.KubeConfigSource"
+                    }
+                },
+                "labelPattern": {
+                    "description": "LabelPattern is a regular expression matched against the context\nnames, its named groups become labels of the imported clusters.",
+                    "type": "string"
+                },
+                "skipValidation": {
+                    "description": "SkipValidation skips checking that the clusters are reachable.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "cluster.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cluster.ImportResult"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/cluster.ImportSummary"
+                }
+            }
+        },
+        "cluster.ImportResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "cluster": {
+                    "$ref": "#/definitions/unstructured.Unstructured"
+                },
+                "clusterName": {
+                    "type": "string"
+                },
+                "context": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "serverVersion": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "cluster.ImportSummary": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "cluster.KubeConfigSource": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name identifies the kubeconfig in the results, such as its file name.",
+                    "type": "string"
+                }
+            }
+        },
+        "cluster.UploadData": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                },
+                "sanitizedClusterContent": {
+                    "type": "string"
+                }
+            }
+        },
+        "cluster.ValidatePayload": {
+            "type": "object",
+            "properties": {
+                "kubeConfig": {
+                    "type": "string"
+                }
+            }
+        },
+        "detail.DiagnoseResourceRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "tokenBudget": {
+                    "description": "TokenBudget limits the size of the context sent to the model, default\nto ai.DefaultDiagnosisTokenBudget",
+                    "type": "integer"
+                }
+            }
+        },
+        "detail.InterpretRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "yaml": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ActionLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the name of the action, e.g. cluster.create.",
+                    "type": "string"
                 },
-                "logs": {
+                "details": {
+                    "description": "Details are additional attributes of the action, e.g. the prompt type\nof an AI call.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "durationMillis": {
+                    "description": "DurationMillis is how long the action took in milliseconds.",
+                    "type": "integer"
+                },
+                "groups": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "id": {
+                    "description": "ID is the unique id of the action log.",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "Method and Path are the HTTP method and path of the request.",
+                    "type": "string"
+                },
+                "outcome": {
+                    "description": "Outcome is either success or failure.",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "requestID": {
+                    "description": "RequestID is the id of the request which performed the action.",
+                    "type": "string"
+                },
+                "sourceIP": {
+                    "description": "SourceIP is the address of the client.",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "StatusCode is the HTTP status code of the response.",
+                    "type": "integer"
+                },
+                "target": {
+                    "description": "Target locates the object the action is performed on.",
+                    "$ref": "#/definitions/entity.ActionTarget"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time the action started.",
+                    "type": "string"
+                },
+                "user": {
+                    "description": "User and Groups identify the user who performed the action.",
+                    "type": "string"
                 }
             }
         },
-        "aggregator.EventDiagnoseRequest": {
+        "entity.ActionTarget": {
             "type": "object",
             "properties": {
-                "events": {
+                "cluster": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.AlertChannel": {
+            "type": "object",
+            "properties": {
+                "to": {
+                    "description": "To are the recipients of the email notifications.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ai.Event"
+                        "type": "string"
                     }
                 },
-                "language": {
+                "type": {
+                    "description": "Type is either webhook, slack or email.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL is the URL the webhook and slack notifications are posted to.",
                     "type": "string"
                 }
             }
         },
-        "aggregator.LogEntry": {
+        "entity.AlertCondition": {
             "type": "object",
             "properties": {
-                "content": {
+                "operator": {
+                    "description": "Operator compares the number of matching objects with the threshold,\none of \u003e, \u003e=, \u003c, \u003c=, == and !=.",
                     "type": "string"
                 },
-                "error": {
+                "threshold": {
+                    "description": "Threshold is the number of matching objects compared with.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is either threshold, appear, disappear or change.",
                     "type": "string"
+                }
+            }
+        },
+        "entity.AlertNotification": {
+            "type": "object",
+            "properties": {
+                "appeared": {
+                    "description": "Appeared and Disappeared are the keys of the objects which started\nand stopped matching the query.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "timestamp": {
+                "count": {
+                    "description": "Count is the number of matching objects.",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "Description is the description of the alert rule.",
+                    "type": "string"
+                },
+                "disappeared": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "firedAt": {
+                    "description": "FiredAt is the time the alert rule fired.",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule is the name of the alert rule.",
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "Summary is a human-readable summary of why the alert rule fired.",
                     "type": "string"
                 }
             }
         },
-        "ai.AuditData": {
+        "entity.AlertRule": {
             "type": "object",
             "properties": {
-                "bySeverity": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                "access": {
+                    "description": "Access is the data access of the user who last created or updated\nthe alert rule, the rule only matches the objects within it. It is\nnil if the access of the user is not restricted.",
+                    "$ref": "#/definitions/entity.AlertRuleAccess"
+                },
+                "channels": {
+                    "description": "Channels are notified when the alert rule fires.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AlertChannel"
                     }
                 },
-                "issueGroups": {
+                "condition": {
+                    "description": "Condition decides when the alert rule fires.",
+                    "$ref": "#/definitions/entity.AlertCondition"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp of the creation of the alert rule.",
+                    "type": "string"
+                },
+                "dedupeWindow": {
+                    "description": "DedupeWindow is how long the same notification is not sent again,\ne.g. while a threshold condition keeps firing.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description is a human-readable description of the alert rule.",
+                    "type": "string"
+                },
+                "disabled": {
+                    "description": "Disabled alert rules are not evaluated.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID is the unique id of the alert rule.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the unique name of the alert rule.",
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Owner is the user who created the alert rule.",
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resourceGroup": {
+                    "$ref": "#/definitions/entity.ResourceGroup"
+                },
+                "savedSearch": {
+                    "description": "SavedSearch, Query and ResourceGroup are the sources of the matching\nobjects, exactly one of them is set. SavedSearch is the name of a\nsaved search with the sql or dsl pattern, Query is a SQL query and\nResourceGroup matches the objects by terms.",
+                    "type": "string"
+                },
+                "silencedUntil": {
+                    "description": "SilencedUntil suppresses the notifications until the time.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the state of the last evaluation of the alert rule.",
+                    "$ref": "#/definitions/entity.AlertRuleStatus"
+                },
+                "trigger": {
+                    "description": "Trigger decides when the alert rule is evaluated.",
+                    "$ref": "#/definitions/entity.AlertTrigger"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp of the last update of the alert rule.",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.AlertRuleAccess": {
+            "type": "object",
+            "properties": {
+                "scopes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ai.IssueGroup"
+                        "$ref": "#/definitions/entity.AlertRuleAccessScope"
+                    }
+                }
+            }
+        },
+        "entity.AlertRuleAccessScope": {
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
-                "issueTotal": {
-                    "type": "integer"
+                "kinds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "resourceTotal": {
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.AlertRuleStatus": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of matching objects.",
                     "type": "integer"
+                },
+                "firing": {
+                    "description": "Firing reports whether the condition fired in the last evaluation.",
+                    "type": "boolean"
+                },
+                "lastError": {
+                    "description": "LastError is the error of the last evaluation or notification.",
+                    "type": "string"
+                },
+                "lastEvaluatedAt": {
+                    "description": "LastEvaluatedAt is the time the alert rule was last evaluated.",
+                    "type": "string"
+                },
+                "lastFingerprint": {
+                    "description": "LastFingerprint identifies the last sent notification, for dedupe.",
+                    "type": "string"
+                },
+                "lastFiredAt": {
+                    "description": "LastFiredAt is the time the alert rule last sent notifications.",
+                    "type": "string"
+                },
+                "lastSyncAt": {
+                    "description": "LastSyncAt is the time the resources were last synced when the alert\nrule was last evaluated, in microseconds so that the syncs within the\nsame second are told apart.",
+                    "type": "string"
+                },
+                "matches": {
+                    "description": "Matches are the keys of the matching objects, which the objects\nappearing and disappearing are found by. They are not tracked if too\nmany objects match.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "ai.DiagnosisEvent": {
+        "entity.AlertTrigger": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "Event content",
+                "interval": {
+                    "description": "Interval is the interval of the schedule trigger.",
                     "type": "string"
                 },
                 "type": {
-                    "description": "Event type: start/chunk/error/complete",
+                    "description": "Type is either schedule or sync.",
                     "type": "string"
                 }
             }
         },
-        "ai.Event": {
+        "entity.ArchivedEvent": {
             "type": "object",
             "properties": {
                 "count": {
+                    "description": "Count is the number of occurrences of the event in the series.",
                     "type": "integer"
                 },
                 "firstTimestamp": {
+                    "description": "FirstTimestamp is the time the event first occurred.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the id of the series, which is derived from the involved object\nand the event so that the same event is archived once.",
                     "type": "string"
                 },
+                "involvedObject": {
+                    "description": "InvolvedObject locates the object the event is about, its cluster is\nthe cluster the event is reported in.",
+                    "$ref": "#/definitions/entity.ResourceGroup"
+                },
                 "lastTimestamp": {
+                    "description": "LastTimestamp is the time the event last occurred.",
                     "type": "string"
                 },
                 "message": {
+                    "description": "Message is the human-readable description of the event.",
                     "type": "string"
                 },
                 "reason": {
+                    "description": "Reason is the short machine-readable reason of the event.",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the component which reported the event.",
                     "type": "string"
                 },
                 "type": {
+                    "description": "Type is the type of the event, either Normal or Warning.",
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "UID is the uid of the involved object, which tells apart the objects\nrecreated with the same name.",
                     "type": "string"
                 }
             }
         },
-        "ai.InterpretEvent": {
+        "entity.AuditIssue": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "Event content or error message",
+                "firstSeen": {
+                    "description": "FirstSeen is the timestamp when the issue is found for the first time.",
                     "type": "string"
                 },
-                "type": {
-                    "description": "Event type: start, chunk, error, complete",
+                "id": {
+                    "description": "ID is the id of the audit issue, which is derived from the resource and\nthe issue so that the same issue on the same resource is stored once.",
                     "type": "string"
-                }
-            }
-        },
-        "ai.IssueGroup": {
-            "type": "object",
-            "properties": {
-                "issue": {
-                    "$ref": "#/definitions/scanner.Issue"
                 },
-                "resourceGroups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.ResourceGroup"
+                "labels": {
+                    "description": "Labels are the labels of the resource when the issue is last seen, so\nthat audit exceptions selecting resources by labels can be applied.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
+                },
+                "lastSeen": {
+                    "description": "LastSeen is the timestamp of the latest audit which found the issue.",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message is a detailed description of the issue.",
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "description": "ResolvedAt is the timestamp of the first audit which no longer found\nthe issue, it is empty while the issue is still open.",
+                    "type": "string"
+                },
+                "resource": {
+                    "description": "Resource locates the resource on which the issue is found.",
+                    "$ref": "#/definitions/entity.ResourceGroup"
+                },
+                "scanner": {
+                    "description": "Scanner is the name of the scanner which reported the issue.",
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "Severity is the severity level name of the issue, e.g. High.",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title is a brief summary of the issue.",
+                    "type": "string"
                 }
             }
         },
-        "cluster.ClusterPayload": {
+        "entity.ResourceGroup": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "ClusterDescription is the description of cluster to be created",
+                "annotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "apiVersion": {
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "Filter restricts the resources of the group, it is the filter of the\nresource group rule the group is listed by.",
+                    "$ref": "#/definitions/entity.ResourceGroupFilter"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "displayName": {
-                    "description": "ClusterDisplayName is the display name of cluster to be created",
+                "namespace": {
                     "type": "string"
                 },
-                "kubeConfig": {
-                    "description": "ClusterKubeConfig is the kubeconfig of cluster to be created",
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "cluster.UploadData": {
+        "entity.ResourceGroupFilter": {
             "type": "object",
             "properties": {
-                "content": {
+                "query": {
+                    "description": "Query is a SQL where clause, such as \"cluster LIKE 'prod-%'\".",
                     "type": "string"
                 },
-                "fileName": {
+                "terms": {
+                    "description": "Terms match the resources whose field equals any of the values, such\nas {\"kind\": [\"Deployment\"], \"labels.env\": [\"prod\"]}.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "entity.ResourceGroupLink": {
+            "type": "object",
+            "properties": {
+                "title": {
                     "type": "string"
                 },
-                "fileSize": {
-                    "type": "integer"
-                },
-                "sanitizedClusterContent": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "cluster.ValidatePayload": {
+        "entity.ResourceGroupMetadata": {
             "type": "object",
             "properties": {
-                "kubeConfig": {
-                    "type": "string"
+                "links": {
+                    "description": "Links are the links of the groups, such as dashboards and runbooks.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroupLink"
+                    }
+                },
+                "match": {
+                    "description": "Match selects the groups by the values of their fields, such as\n{\"labels.app\": \"payments\"}, all groups are selected if it is empty.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "owners": {
+                    "description": "Owners are the users or teams who own the groups.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "detail.InterpretRequest": {
+        "entity.ResourceGroupSnapshot": {
             "type": "object",
             "properties": {
-                "language": {
+                "countByGVK": {
+                    "description": "CountByGVK is the number of resources in the resource group by GVK.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "description": "ID is the id of the resource group snapshot.",
                     "type": "string"
                 },
-                "yaml": {
+                "members": {
+                    "description": "Members are the resources in the resource group, identified by their\ncluster, apiVersion, kind, namespace and name. They are omitted when\nthe snapshots are listed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroup"
+                    }
+                },
+                "membersTruncated": {
+                    "description": "MembersTruncated reports whether only the first of the resources in\nthe resource group are recorded as the members, as the resource group\nhas too many resources. The total and the counts are still complete.",
+                    "type": "boolean"
+                },
+                "resourceGroup": {
+                    "description": "ResourceGroup is the resource group whose membership is recorded.",
+                    "$ref": "#/definitions/entity.ResourceGroup"
+                },
+                "resourceTotal": {
+                    "description": "ResourceTotal is the number of resources in the resource group.",
+                    "type": "integer"
+                },
+                "rule": {
+                    "description": "Rule is the name of the resource group rule of the resource group.",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time when the membership is recorded.",
                     "type": "string"
                 }
             }
         },
-        "entity.ResourceGroup": {
+        "entity.SavedSearch": {
             "type": "object",
             "properties": {
-                "annotations": {
-                    "type": "object",
-                    "additionalProperties": {
+                "columns": {
+                    "description": "Columns are the \u003cheader\u003e:\u003cjson-path-expr\u003e pairs of the custom-columns\nformat.",
+                    "type": "array",
+                    "items": {
                         "type": "string"
                     }
                 },
-                "apiVersion": {
-                    "type": "string"
-                },
-                "cluster": {
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp of the creation of the saved search.",
                     "type": "string"
                 },
-                "kind": {
+                "description": {
+                    "description": "Description is a human-readable description of the saved search.",
                     "type": "string"
                 },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
+                "favoritedBy": {
+                    "description": "FavoritedBy are the users who marked the saved search as a favorite.",
+                    "type": "array",
+                    "items": {
                         "type": "string"
                     }
                 },
+                "format": {
+                    "description": "Format is the format of the results, either origin or custom-columns.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the unique id of the saved search.",
+                    "type": "string"
+                },
                 "name": {
+                    "description": "Name is the unique name of the saved search.",
                     "type": "string"
                 },
-                "namespace": {
+                "owner": {
+                    "description": "Owner is the user who created the saved search.",
                     "type": "string"
                 },
-                "status": {
+                "pattern": {
+                    "description": "Pattern is the pattern of the query, either sql, dsl or nl.",
                     "type": "string"
+                },
+                "query": {
+                    "description": "Query is the search query.",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp of the last update of the saved search.",
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "Visibility is either private or shared.",
+                    "type": "string"
+                }
+            }
+        },
+        "insight.IssueHistory": {
+            "type": "object",
+            "properties": {
+                "introduced": {
+                    "description": "Introduced is the list of issues found for the first time in the period.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AuditIssue"
+                    }
+                },
+                "resolved": {
+                    "description": "Resolved is the list of issues no longer found in the period.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AuditIssue"
+                    }
                 }
             }
         },
@@ -2391,7 +5701,35 @@ var doc = `{
             "type": "object",
             "properties": {
                 "issuesTotal": {
-                    "description": "IssuesTotal is the total count of all issues found during the audit.\nThis count can be used to understand the overall number of problems\nthat need to be addressed.",
+                    "description": "IssuesTotal is the total count of all issues found during the audit.\nThis count can be used to understand the overall number of problems\nthat need to be addressed. Suppressed issues are not included.",
+                    "type": "integer"
+                },
+                "resourceTotal": {
+                    "description": "ResourceTotal is the count of unique resources audited during the scan.",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Score represents the calculated score of the audited manifest based on\nthe number and severity of issues. It provides a quantitative measure\nof the security posture of the resources in the manifest.",
+                    "type": "number"
+                },
+                "severityStatistic": {
+                    "description": "SeverityStatistic is a mapping of severity levels to their respective\nnumber of occurrences. It allows for a quick overview of the distribution\nof issues across different severity categories.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "suppressedTotal": {
+                    "description": "SuppressedTotal is the count of issues suppressed by audit exceptions,\nwhich are excluded from the score and the severity statistic.",
+                    "type": "integer"
+                }
+            }
+        },
+        "insight.ScoreTrendPoint": {
+            "type": "object",
+            "properties": {
+                "issuesTotal": {
+                    "description": "IssuesTotal is the total count of all issues found during the audit.\nThis count can be used to understand the overall number of problems\nthat need to be addressed. Suppressed issues are not included.",
                     "type": "integer"
                 },
                 "resourceTotal": {
@@ -2408,6 +5746,14 @@ var doc = `{
                     "additionalProperties": {
                         "type": "integer"
                     }
+                },
+                "suppressedTotal": {
+                    "description": "SuppressedTotal is the count of issues suppressed by audit exceptions,\nwhich are excluded from the score and the severity statistic.",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the start of the period.",
+                    "type": "string"
                 }
             }
         },
@@ -2425,6 +5771,72 @@ var doc = `{
                 }
             }
         },
+        "logs.Line": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "container": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "pod": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix identifies the container the line is from, in the form of\npod/container.",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "resourcegroup.ResourceGroupChange": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Added and Removed are the resources added to and removed from the\nresource group. They are empty if the members of either snapshot are\ntruncated, as the changes beyond the recorded members are unknown.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroup"
+                    }
+                },
+                "countDelta": {
+                    "description": "CountDelta is the change of the number of resources by GVK, the GVKs\nwhose numbers are unchanged are omitted.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "from": {
+                    "description": "From and To are the snapshots compared, without their members.",
+                    "$ref": "#/definitions/entity.ResourceGroupSnapshot"
+                },
+                "membersTruncated": {
+                    "description": "MembersTruncated reports whether the members of either snapshot are\ntruncated, in which case only the count deltas are reported.",
+                    "type": "boolean"
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroup"
+                    }
+                },
+                "to": {
+                    "$ref": "#/definitions/entity.ResourceGroupSnapshot"
+                }
+            }
+        },
         "resourcegrouprule.ResourceGroupRulePayload": {
             "type": "object",
             "properties": {
@@ -2437,8 +5849,46 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "filter": {
+                    "$ref": "#/definitions/entity.ResourceGroupFilter"
+                },
+                "metadata": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ResourceGroupMetadata"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "savedsearch.SavedSearchPayload": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
                 }
             }
         },
@@ -2458,6 +5908,10 @@ var doc = `{
         "scanner.Issue": {
             "type": "object",
             "properties": {
+                "exception": {
+                    "description": "Exception is the name of the audit exception suppressing the issue.",
+                    "type": "string"
+                },
                 "message": {
                     "description": "Message provides a detailed human-readable description of the issue.",
                     "type": "string"
@@ -2470,12 +5924,75 @@ var doc = `{
                     "description": "Severity indicates how critical the issue is, using the IssueSeverityLevel constants.",
                     "type": "integer"
                 },
+                "suppressed": {
+                    "description": "Suppressed indicates that the issue is an accepted risk, it is still\nreported but not counted in the score.",
+                    "type": "boolean"
+                },
                 "title": {
                     "description": "Title is a brief summary of the issue.",
                     "type": "string"
                 }
             }
         },
+        "scanner.RemediateRequest": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "DryRun performs a server-side dry run of the patch against the cluster",
+                    "type": "boolean"
+                },
+                "issues": {
+                    "description": "Issues are the issues to fix, default to the issues found by scanning\nthe resource",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scanner.Issue"
+                    }
+                },
+                "language": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.ActionLogResult": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ActionLog"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.EventResult": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ArchivedEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "summary.SummarizeStateRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "tokenBudget": {
+                    "description": "TokenBudget limits the size of the state sent to the model, default\nto ai.DefaultSummaryTokenBudget",
+                    "type": "integer"
+                }
+            }
+        },
         "unstructured.Unstructured": {
             "type": "object",
             "properties": {
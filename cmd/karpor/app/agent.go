@@ -0,0 +1,84 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/KusionStack/karpor/pkg/infra/tunnel"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type agentOptions struct {
+	HubKubeConfig string
+	ClusterName   string
+	KubeConfig    string
+}
+
+func NewAgentOptions() *agentOptions {
+	return &agentOptions{}
+}
+
+func (o *agentOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.HubKubeConfig, "hub-kubeconfig", "", "The kubeconfig of the karpor hub the agent connects to.")
+	fs.StringVar(&o.ClusterName, "cluster-name", "", "The name of the cluster of the agent on the karpor hub.")
+	fs.StringVar(&o.KubeConfig, "kubeconfig", "", "The kubeconfig of the cluster of the agent, the in-cluster config is used if not set.")
+}
+
+func NewAgentCommand(ctx context.Context) *cobra.Command {
+	options := NewAgentOptions()
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "start an agent connecting a cluster without inbound access to the karpor hub",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgent(ctx, options)
+		},
+	}
+	options.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runAgent(ctx context.Context, options *agentOptions) error {
+	if options.HubKubeConfig == "" {
+		return errors.New("--hub-kubeconfig is required")
+	}
+	if options.ClusterName == "" {
+		return errors.New("--cluster-name is required")
+	}
+
+	hubConfig, err := clientcmd.BuildConfigFromFlags("", options.HubKubeConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the hub kubeconfig")
+	}
+	var clusterConfig *rest.Config
+	if options.KubeConfig != "" {
+		clusterConfig, err = clientcmd.BuildConfigFromFlags("", options.KubeConfig)
+	} else {
+		clusterConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to load the cluster config")
+	}
+
+	agent, err := tunnel.NewAgent(hubConfig, options.ClusterName, clusterConfig)
+	if err != nil {
+		return err
+	}
+	return agent.Run(ctx)
+}
@@ -0,0 +1,143 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type importOptions struct {
+	HubKubeConfig string
+	From          []string
+	cluster.ImportOptions
+}
+
+func NewImportOptions() *importOptions {
+	// The kubeconfigs are local to the command line, so their credential
+	// files and plugins are allowed.
+	return &importOptions{ImportOptions: cluster.ImportOptions{AllowLocalCredentials: true}}
+}
+
+func (o *importOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.HubKubeConfig, "hub-kubeconfig", "", "The kubeconfig of the karpor hub to import the clusters into.")
+	fs.StringSliceVar(&o.From, "from", nil, "The kubeconfig files, or directories of kubeconfig files, to import the clusters from.")
+	fs.StringSliceVar(&o.Contexts, "context", nil, "The contexts to import, all contexts are imported if not set.")
+	fs.StringVar(&o.LabelPattern, "label-pattern", "", "The regular expression matched against the context names, its named groups become labels of the clusters.")
+	fs.BoolVar(&o.DryRun, "dry-run", false, "Preview the clusters without creating or updating them.")
+	fs.BoolVar(&o.SkipValidation, "skip-validation", false, "Skip checking that the clusters are reachable.")
+	fs.IntVar(&o.Concurrency, "concurrency", 0, "The number of clusters imported in parallel.")
+}
+
+func NewImportCommand(ctx context.Context) *cobra.Command {
+	options := NewImportOptions()
+	cmd := &cobra.Command{
+		Use:   "import-clusters",
+		Short: "import clusters in bulk from the contexts of kubeconfigs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(ctx, options)
+		},
+	}
+	options.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runImport(ctx context.Context, options *importOptions) error {
+	if options.HubKubeConfig == "" {
+		return errors.New("--hub-kubeconfig is required")
+	}
+	sources, err := readKubeConfigSources(options.From)
+	if err != nil {
+		return err
+	}
+
+	hubConfig, err := clientcmd.BuildConfigFromFlags("", options.HubKubeConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the hub kubeconfig")
+	}
+	client, err := multicluster.BuildMultiClusterClient(ctx, hubConfig, "")
+	if err != nil {
+		return err
+	}
+
+	results, err := cluster.NewClusterManager().ImportClusters(ctx, client, sources, options.ImportOptions)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tCONTEXT\tCLUSTER\tACTION\tVERSION\tERROR")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", result.Source, result.Context, result.ClusterName, result.Action, result.ServerVersion, result.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if summary := cluster.SummarizeImport(results); summary.Failed > 0 {
+		return fmt.Errorf("failed to import %d of %d clusters", summary.Failed, summary.Total)
+	}
+	return nil
+}
+
+// readKubeConfigSources reads the kubeconfig files, the regular files of the
+// directories are read as kubeconfigs except for the hidden ones.
+func readKubeConfigSources(paths []string) ([]cluster.KubeConfigSource, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("--from is required")
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	sources := make([]cluster.KubeConfigSource, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, cluster.KubeConfigSource{Name: file, Content: string(content)})
+	}
+	return sources, nil
+}
@@ -15,6 +15,9 @@
 package options
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
 	"github.com/spf13/pflag"
 )
@@ -31,6 +34,15 @@ type AIOptions struct {
 	AIHTTPProxy    string
 	AIHTTPSProxy   string
 	AINoProxy      string
+	// caching and budget options
+	AICacheTTL                time.Duration
+	AIUserRequestsPerMinute   int
+	AIUserTokensPerDay        int
+	AIGlobalRequestsPerMinute int
+	AIGlobalTokensPerDay      int
+	// prompt template options
+	AIPromptDir            string
+	AIPromptReloadInterval time.Duration
 }
 
 const (
@@ -38,6 +50,10 @@ const (
 	defaultModel       = "gpt-3.5-turbo"
 	defaultTemperature = 1
 	defaultTopP        = 1
+	defaultCacheTTL    = time.Hour
+	// defaultPromptReloadInterval is the default interval the prompt
+	// templates are reloaded in
+	defaultPromptReloadInterval = 30 * time.Second
 )
 
 func NewAIOptions() *AIOptions {
@@ -45,7 +61,28 @@ func NewAIOptions() *AIOptions {
 }
 
 func (o *AIOptions) Validate() []error {
-	return nil
+	errors := []error{}
+	if o.AICacheTTL < 0 {
+		errors = append(errors, fmt.Errorf("--ai-cache-ttl cannot be negative"))
+	}
+	if o.AIPromptReloadInterval < 0 {
+		errors = append(errors, fmt.Errorf("--ai-prompt-reload-interval cannot be negative"))
+	}
+	limits := []struct {
+		flag  string
+		value int
+	}{
+		{"--ai-user-requests-per-minute", o.AIUserRequestsPerMinute},
+		{"--ai-user-tokens-per-day", o.AIUserTokensPerDay},
+		{"--ai-global-requests-per-minute", o.AIGlobalRequestsPerMinute},
+		{"--ai-global-tokens-per-day", o.AIGlobalTokensPerDay},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			errors = append(errors, fmt.Errorf("%s cannot be negative", limit.flag))
+		}
+	}
+	return errors
 }
 
 func (o *AIOptions) ApplyTo(config *registry.ExtraConfig) error {
@@ -64,6 +101,13 @@ func (o *AIOptions) ApplyTo(config *registry.ExtraConfig) error {
 	config.AIHTTPProxy = o.AIHTTPProxy
 	config.AIHTTPSProxy = o.AIHTTPSProxy
 	config.AINoProxy = o.AINoProxy
+	config.AICacheTTL = o.AICacheTTL
+	config.AIUserRequestsPerMinute = o.AIUserRequestsPerMinute
+	config.AIUserTokensPerDay = o.AIUserTokensPerDay
+	config.AIGlobalRequestsPerMinute = o.AIGlobalRequestsPerMinute
+	config.AIGlobalTokensPerDay = o.AIGlobalTokensPerDay
+	config.AIPromptDir = o.AIPromptDir
+	config.AIPromptReloadInterval = o.AIPromptReloadInterval
 	return nil
 }
 
@@ -83,4 +127,11 @@ func (o *AIOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.AIHTTPProxy, "ai-http-proxy", "", "The ai http proxy")
 	fs.StringVar(&o.AIHTTPSProxy, "ai-https-proxy", "", "The ai https proxy")
 	fs.StringVar(&o.AINoProxy, "ai-no-proxy", "", "The ai no-proxy")
+	fs.DurationVar(&o.AICacheTTL, "ai-cache-ttl", defaultCacheTTL, "The time the ai responses are cached for the same prompts, 0 to disable caching")
+	fs.IntVar(&o.AIUserRequestsPerMinute, "ai-user-requests-per-minute", 0, "The maximum number of ai calls of a user per minute, 0 for unlimited")
+	fs.IntVar(&o.AIUserTokensPerDay, "ai-user-tokens-per-day", 0, "The maximum number of estimated ai tokens of a user per day, 0 for unlimited")
+	fs.IntVar(&o.AIGlobalRequestsPerMinute, "ai-global-requests-per-minute", 0, "The maximum number of ai calls of all users per minute, 0 for unlimited")
+	fs.IntVar(&o.AIGlobalTokensPerDay, "ai-global-tokens-per-day", 0, "The maximum number of estimated ai tokens of all users per day, 0 for unlimited")
+	fs.StringVar(&o.AIPromptDir, "ai-prompt-dir", "", "The directory of the ai prompt templates overriding the built-in prompts, named <prompt type>.tmpl or <prompt type>.<language>.tmpl")
+	fs.DurationVar(&o.AIPromptReloadInterval, "ai-prompt-reload-interval", defaultPromptReloadInterval, "The interval the ai prompt templates are reloaded in, 0 to disable reloading")
 }
@@ -0,0 +1,78 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/spf13/pflag"
+)
+
+// AlertOptions holds the options of the evaluation and notifications of the
+// alert rules.
+type AlertOptions struct {
+	EvaluationInterval time.Duration
+	SMTPAddress        string
+	SMTPFrom           string
+	SMTPUsername       string
+	SMTPPassword       string
+}
+
+func NewAlertOptions() *AlertOptions {
+	return &AlertOptions{
+		EvaluationInterval: 30 * time.Second,
+	}
+}
+
+func (o *AlertOptions) Validate() []error {
+	errors := []error{}
+	if o.EvaluationInterval < 0 {
+		errors = append(errors, fmt.Errorf("--alert-evaluation-interval cannot be negative"))
+	}
+	if o.SMTPAddress != "" {
+		if _, _, err := net.SplitHostPort(o.SMTPAddress); err != nil {
+			errors = append(errors, fmt.Errorf("--alert-smtp-address must be host:port: %v", err))
+		}
+		if o.SMTPFrom == "" {
+			errors = append(errors, fmt.Errorf("--alert-smtp-from is required when --alert-smtp-address is set"))
+		}
+	}
+	return errors
+}
+
+func (o *AlertOptions) ApplyTo(config *registry.ExtraConfig) error {
+	config.AlertEvaluationInterval = o.EvaluationInterval
+	config.AlertSMTP.Address = o.SMTPAddress
+	config.AlertSMTP.From = o.SMTPFrom
+	config.AlertSMTP.Username = o.SMTPUsername
+	config.AlertSMTP.Password = o.SMTPPassword
+	return nil
+}
+
+// AddFlags adds flags for a specific Option to the specified FlagSet
+func (o *AlertOptions) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.DurationVar(&o.EvaluationInterval, "alert-evaluation-interval", o.EvaluationInterval, "the interval the alert rules are checked for evaluation, 0 to disable the evaluation")
+	fs.StringVar(&o.SMTPAddress, "alert-smtp-address", o.SMTPAddress, "the host:port of the mail server the email notifications are sent through")
+	fs.StringVar(&o.SMTPFrom, "alert-smtp-from", o.SMTPFrom, "the sender address of the email notifications")
+	fs.StringVar(&o.SMTPUsername, "alert-smtp-username", o.SMTPUsername, "the username to authenticate to the mail server")
+	fs.StringVar(&o.SMTPPassword, "alert-smtp-password", o.SMTPPassword, "the password to authenticate to the mail server")
+}
@@ -15,28 +15,86 @@
 package options
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
 	"github.com/spf13/pflag"
 )
 
 type CoreOptions struct {
-	EnableRBAC   bool
-	ReadOnlyMode bool
-	GithubBadge  bool
-	Version      bool
+	EnableRBAC            bool
+	EnableDataAccessRules bool
+	ReadOnlyMode          bool
+	GithubBadge           bool
+	Version               bool
+	AuditInterval         time.Duration
+	ActionLogFile         string
+
+	ResourceGroupSnapshotInterval  time.Duration
+	ResourceGroupSnapshotRetention time.Duration
+
+	ClusterProxyReadOnly       bool
+	EnableClusterProxyPolicies bool
+	ClusterProxyImpersonation  bool
+
+	ClusterProbeInterval       time.Duration
+	CredentialCheckInterval    time.Duration
+	CredentialExpiryWarning    time.Duration
+	RotateServiceAccountTokens bool
+	RotatedTokenTTL            time.Duration
 }
 
 func NewCoreOptions() *CoreOptions {
-	return &CoreOptions{}
+	return &CoreOptions{
+		AuditInterval:                  time.Hour,
+		ResourceGroupSnapshotInterval:  time.Hour,
+		ResourceGroupSnapshotRetention: 30 * 24 * time.Hour,
+		ClusterProbeInterval:           time.Minute,
+		CredentialCheckInterval:        time.Hour,
+		CredentialExpiryWarning:        7 * 24 * time.Hour,
+		RotatedTokenTTL:                30 * 24 * time.Hour,
+	}
 }
 
 func (o *CoreOptions) Validate() []error {
+	if o.EnableDataAccessRules && !o.EnableRBAC {
+		return []error{fmt.Errorf("--enable-data-access-rules requires --enable-rbac")}
+	}
+	if o.RotateServiceAccountTokens && o.CredentialCheckInterval <= 0 {
+		return []error{fmt.Errorf("--rotate-service-account-tokens requires a positive --credential-check-interval")}
+	}
 	return nil
 }
 
 func (o *CoreOptions) ApplyTo(config *registry.ExtraConfig) error {
+	config.EnableRBAC = o.EnableRBAC
+	config.EnableDataAccessRules = o.EnableDataAccessRules
 	config.ReadOnlyMode = o.ReadOnlyMode
 	config.GithubBadge = o.GithubBadge
+	config.AuditInterval = o.AuditInterval
+	config.ResourceGroupSnapshotInterval = o.ResourceGroupSnapshotInterval
+	config.ResourceGroupSnapshotRetention = o.ResourceGroupSnapshotRetention
+	config.ClusterProxyReadOnly = o.ClusterProxyReadOnly
+	config.EnableClusterProxyPolicies = o.EnableClusterProxyPolicies
+	config.ClusterProxyImpersonation = o.ClusterProxyImpersonation
+	config.ClusterProbeInterval = o.ClusterProbeInterval
+	config.CredentialCheckInterval = o.CredentialCheckInterval
+	config.CredentialExpiryWarning = o.CredentialExpiryWarning
+	config.RotateServiceAccountTokens = o.RotateServiceAccountTokens
+	config.RotatedTokenTTL = o.RotatedTokenTTL
+
+	// The action logs are always written to the storage, which is added by
+	// the core route, and additionally to a file if configured.
+	config.ActionRecorder = actionlog.NewRecorder()
+	if o.ActionLogFile != "" {
+		sink, err := actionlog.NewFileSink(o.ActionLogFile)
+		if err != nil {
+			return fmt.Errorf("failed to open action log file: %w", err)
+		}
+		config.ActionRecorder.AddSink(sink)
+	}
 	return nil
 }
 
@@ -47,7 +105,20 @@ func (o *CoreOptions) AddFlags(fs *pflag.FlagSet) {
 	}
 
 	fs.BoolVar(&o.EnableRBAC, "enable-rbac", false, "trun on to enable RBAC authorization")
+	fs.BoolVar(&o.EnableDataAccessRules, "enable-data-access-rules", false, "turn on to restrict the resources visible to users by DataAccessRule objects, requires --enable-rbac")
 	fs.BoolVar(&o.ReadOnlyMode, "read-only-mode", false, "turn on the read only mode")
+	fs.BoolVar(&o.ClusterProxyReadOnly, "cluster-proxy-read-only", false, "only allow the requests reading resources through the cluster proxy, implied by --read-only-mode")
+	fs.BoolVar(&o.EnableClusterProxyPolicies, "enable-cluster-proxy-policies", false, "turn on to deny the cluster proxy requests which are not allowed by ClusterProxyPolicy objects")
+	fs.BoolVar(&o.ClusterProxyImpersonation, "cluster-proxy-impersonation", false, "send the cluster proxy requests as the requesting users with impersonation headers, the cluster credentials must be allowed to impersonate them")
 	fs.BoolVar(&o.GithubBadge, "github-badge", false, "whether to display the github badge")
+	fs.DurationVar(&o.AuditInterval, "background-audit-interval", o.AuditInterval, "the interval of the background audit of all clusters, 0 to disable it")
+	fs.DurationVar(&o.ResourceGroupSnapshotInterval, "resource-group-snapshot-interval", o.ResourceGroupSnapshotInterval, "the interval of recording the membership of all resource groups, 0 to disable it")
+	fs.DurationVar(&o.ResourceGroupSnapshotRetention, "resource-group-snapshot-retention", o.ResourceGroupSnapshotRetention, "how long the resource group snapshots are kept, 0 to keep them forever")
+	fs.DurationVar(&o.ClusterProbeInterval, "cluster-probe-interval", o.ClusterProbeInterval, "the interval of probing the health of the clusters, 0 to disable it")
+	fs.DurationVar(&o.CredentialCheckInterval, "credential-check-interval", o.CredentialCheckInterval, "the interval of checking the expiry of the cluster credentials, 0 to disable it")
+	fs.DurationVar(&o.CredentialExpiryWarning, "credential-expiry-warning", o.CredentialExpiryWarning, "how long before the expiry of a cluster credential to warn about it")
+	fs.BoolVar(&o.RotateServiceAccountTokens, "rotate-service-account-tokens", false, "request new service account tokens for the clusters whose tokens are expiring")
+	fs.DurationVar(&o.RotatedTokenTTL, "rotated-token-ttl", o.RotatedTokenTTL, "the validity of the rotated service account tokens")
+	fs.StringVar(&o.ActionLogFile, "action-log-file", "", "the file to append the action logs of users to as JSON lines, in addition to the storage")
 	fs.BoolVarP(&o.Version, "version", "V", o.Version, "Print version and exit")
 }
@@ -0,0 +1,126 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authn/oidc"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/spf13/pflag"
+	authnunion "k8s.io/apiserver/pkg/authentication/request/union"
+	"k8s.io/apiserver/pkg/authorization/path"
+	authzunion "k8s.io/apiserver/pkg/authorization/union"
+	"k8s.io/apiserver/pkg/server"
+)
+
+// OIDCOptions holds the options of the OpenID Connect login.
+type OIDCOptions struct {
+	IssuerURL      string
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+	SessionTTL     time.Duration
+	InsecureCookie bool
+}
+
+func NewOIDCOptions() *OIDCOptions {
+	return &OIDCOptions{
+		Scopes:         []string{"email", "profile"},
+		UsernameClaim:  oidc.DefaultUsernameClaim,
+		UsernamePrefix: oidc.DefaultPrefix,
+		GroupsClaim:    oidc.DefaultGroupsClaim,
+		GroupsPrefix:   oidc.DefaultPrefix,
+		SessionTTL:     oidc.DefaultSessionTTL,
+	}
+}
+
+func (o *OIDCOptions) Validate() []error {
+	if o.IssuerURL == "" {
+		return nil
+	}
+	errors := []error{}
+	if o.ClientID == "" {
+		errors = append(errors, fmt.Errorf("--oidc-client-id is required when --oidc-issuer-url is set"))
+	}
+	if o.RedirectURL == "" {
+		errors = append(errors, fmt.Errorf("--oidc-redirect-url is required when --oidc-issuer-url is set"))
+	}
+	if o.SessionTTL <= 0 {
+		errors = append(errors, fmt.Errorf("--oidc-session-ttl must be positive"))
+	}
+	return errors
+}
+
+// ApplyTo enables the OpenID Connect login if an issuer is configured. The
+// session users are authenticated by the apiserver, so that they are subject
+// to RBAC, while the login endpoints are always allowed.
+func (o *OIDCOptions) ApplyTo(genericConfig *server.RecommendedConfig, config *registry.ExtraConfig) error {
+	if o.IssuerURL == "" {
+		return nil
+	}
+
+	sessions := oidc.NewSessionStore()
+	config.OIDCProvider = oidc.NewProvider(oidc.Config{
+		IssuerURL:      o.IssuerURL,
+		ClientID:       o.ClientID,
+		ClientSecret:   o.ClientSecret,
+		RedirectURL:    o.RedirectURL,
+		Scopes:         o.Scopes,
+		UsernameClaim:  o.UsernameClaim,
+		UsernamePrefix: o.UsernamePrefix,
+		GroupsClaim:    o.GroupsClaim,
+		GroupsPrefix:   o.GroupsPrefix,
+		SessionTTL:     o.SessionTTL,
+		InsecureCookie: o.InsecureCookie,
+	}, sessions)
+
+	genericConfig.Authentication.Authenticator = authnunion.New(
+		oidc.NewSessionAuthenticator(sessions),
+		genericConfig.Authentication.Authenticator,
+	)
+
+	loginAuthorizer, err := path.NewAuthorizer([]string{oidc.LoginPath + "/*"})
+	if err != nil {
+		return err
+	}
+	genericConfig.Authorization.Authorizer = authzunion.New(loginAuthorizer, genericConfig.Authorization.Authorizer)
+	return nil
+}
+
+// AddFlags adds flags for a specific Option to the specified FlagSet
+func (o *OIDCOptions) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.StringVar(&o.IssuerURL, "oidc-issuer-url", o.IssuerURL, "The URL of the OpenID provider for the dashboard login, the login is disabled if empty")
+	fs.StringVar(&o.ClientID, "oidc-client-id", o.ClientID, "The client id of karpor registered at the OpenID provider")
+	fs.StringVar(&o.ClientSecret, "oidc-client-secret", o.ClientSecret, "The client secret of karpor registered at the OpenID provider, empty for public clients")
+	fs.StringVar(&o.RedirectURL, "oidc-redirect-url", o.RedirectURL, "The external URL of the login callback, e.g. https://karpor.example.com/rest-api/v1/authn/oidc/callback")
+	fs.StringSliceVar(&o.Scopes, "oidc-scopes", o.Scopes, "The scopes requested in addition to openid")
+	fs.StringVar(&o.UsernameClaim, "oidc-username-claim", o.UsernameClaim, "The ID token claim used as the username")
+	fs.StringVar(&o.UsernamePrefix, "oidc-username-prefix", o.UsernamePrefix, "The prefix of the usernames of OpenID Connect users in RBAC subjects")
+	fs.StringVar(&o.GroupsClaim, "oidc-groups-claim", o.GroupsClaim, "The ID token claim used as the groups")
+	fs.StringVar(&o.GroupsPrefix, "oidc-groups-prefix", o.GroupsPrefix, "The prefix of the groups of OpenID Connect users in RBAC subjects")
+	fs.DurationVar(&o.SessionTTL, "oidc-session-ttl", o.SessionTTL, "How long a login session lasts")
+	fs.BoolVar(&o.InsecureCookie, "oidc-insecure-cookie", o.InsecureCookie, "Allow the session cookie over plain HTTP, only for local development")
+}
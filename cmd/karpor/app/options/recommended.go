@@ -140,7 +140,7 @@ func (o *RecommendedOptions) ApplyTo(config *server.RecommendedConfig) error {
 
 	genericConfig.LongRunningFunc = filters.BasicLongRunningRequestCheck(
 		sets.NewString("watch", "proxy"),
-		sets.NewString("attach", "exec", "proxy", "log", "portforward"),
+		sets.NewString("attach", "exec", "proxy", "log", "portforward", "tunnel"),
 	)
 
 	kubeClientConfig := config.LoopbackClientConfig
@@ -59,6 +59,8 @@ type Options struct {
 	SearchStorageOptions *options.SearchStorageOptions
 	CoreOptions          *options.CoreOptions
 	AIOptions            *options.AIOptions
+	OIDCOptions          *options.OIDCOptions
+	AlertOptions         *options.AlertOptions
 
 	StdOut io.Writer
 	StdErr io.Writer
@@ -76,6 +78,8 @@ func NewOptions(out, errOut io.Writer) (*Options, error) {
 		SearchStorageOptions: options.NewSearchStorageOptions(),
 		CoreOptions:          options.NewCoreOptions(),
 		AIOptions:            options.NewAIOptions(),
+		OIDCOptions:          options.NewOIDCOptions(),
+		AlertOptions:         options.NewAlertOptions(),
 		StdOut:               out,
 		StdErr:               errOut,
 	}
@@ -112,6 +116,16 @@ func NewServerCommand(ctx context.Context) *cobra.Command {
 		displayOpts.AIAuthToken = "[hidden]"
 		return &displayOpts
 	}))
+	expvar.Publish("OIDCOptions", expvar.Func(func() interface{} {
+		displayOpts := *o.OIDCOptions
+		displayOpts.ClientSecret = "[hidden]"
+		return &displayOpts
+	}))
+	expvar.Publish("AlertOptions", expvar.Func(func() interface{} {
+		displayOpts := *o.AlertOptions
+		displayOpts.SMTPPassword = "[hidden]"
+		return &displayOpts
+	}))
 	expvar.Publish("Version", expvar.Func(func() interface{} {
 		return version.GetVersion()
 	}))
@@ -148,6 +162,8 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	o.SearchStorageOptions.AddFlags(fs)
 	o.CoreOptions.AddFlags(fs)
 	o.AIOptions.AddFlags(fs)
+	o.OIDCOptions.AddFlags(fs)
+	o.AlertOptions.AddFlags(fs)
 }
 
 // Validate validates Options
@@ -155,7 +171,10 @@ func (o *Options) Validate(args []string) error {
 	errors := []error{}
 	errors = append(errors, o.RecommendedOptions.Validate()...)
 	errors = append(errors, o.SearchStorageOptions.Validate()...)
+	errors = append(errors, o.CoreOptions.Validate()...)
 	errors = append(errors, o.AIOptions.Validate()...)
+	errors = append(errors, o.OIDCOptions.Validate()...)
+	errors = append(errors, o.AlertOptions.Validate()...)
 	return utilerrors.NewAggregate(errors)
 }
 
@@ -220,6 +239,12 @@ func (o *Options) Config() (*server.Config, error) {
 	if err := o.AIOptions.ApplyTo(config.ExtraConfig); err != nil {
 		return nil, err
 	}
+	if err := o.OIDCOptions.ApplyTo(config.GenericConfig, config.ExtraConfig); err != nil {
+		return nil, err
+	}
+	if err := o.AlertOptions.ApplyTo(config.ExtraConfig); err != nil {
+		return nil, err
+	}
 
 	config.GenericConfig.BuildHandlerChainFunc = func(handler http.Handler, c *genericapiserver.Config) http.Handler {
 		handler = genericapiserver.DefaultBuildHandlerChain(handler, c)
@@ -16,6 +16,7 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
 	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
@@ -32,16 +33,23 @@ type syncerOptions struct {
 	MetricsAddr            string
 	ProbeAddr              string
 	ElasticSearchAddresses []string
+	ArchiveEvents          bool
+	EventRetention         time.Duration
 }
 
 func NewSyncerOptions() *syncerOptions {
-	return &syncerOptions{}
+	return &syncerOptions{
+		ArchiveEvents:  true,
+		EventRetention: 7 * 24 * time.Hour,
+	}
 }
 
 func (o *syncerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.MetricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	fs.StringVar(&o.ProbeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	fs.StringSliceVar(&o.ElasticSearchAddresses, "elastic-search-addresses", nil, "The elastic search address.")
+	fs.BoolVar(&o.ArchiveEvents, "archive-events", o.ArchiveEvents, "Archive the events of the synced clusters, so that they are kept after they expire in the clusters.")
+	fs.DurationVar(&o.EventRetention, "event-retention", o.EventRetention, "How long the archived events are kept after they last occurred, 0 to keep them forever.")
 }
 
 func NewSyncerCommand(ctx context.Context) *cobra.Command {
@@ -81,8 +89,24 @@ func run(ctx context.Context, options *syncerOptions) error {
 		return err
 	}
 
+	recorder := syncer.NewSyncRecorder(mgr.GetClient())
+	if err = mgr.Add(recorder); err != nil {
+		log.Error(err, "unable to create sync recorder")
+		return err
+	}
+	reconciler := syncer.NewSyncReconciler(es).WithSyncRecorder(recorder)
+	if options.ArchiveEvents {
+		reconciler.WithEventArchive(es)
+		if options.EventRetention > 0 {
+			if err = mgr.Add(syncer.NewEventPurger(es, options.EventRetention)); err != nil {
+				log.Error(err, "unable to create event purger")
+				return err
+			}
+		}
+	}
+
 	//nolint:contextcheck
-	if err = syncer.NewSyncReconciler(es).SetupWithManager(mgr); err != nil {
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create resource syncer")
 		return err
 	}
@@ -34,6 +34,10 @@ func main() {
 	cmd := app.NewServerCommand(ctx)
 	syncCmd := app.NewSyncerCommand(ctx)
 	cmd.AddCommand(syncCmd)
+	importCmd := app.NewImportCommand(ctx)
+	cmd.AddCommand(importCmd)
+	agentCmd := app.NewAgentCommand(ctx)
+	cmd.AddCommand(agentCmd)
 
 	code := cli.Run(cmd)
 	os.Exit(code)
@@ -0,0 +1,131 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySink struct {
+	lock sync.Mutex
+	logs []*entity.ActionLog
+	err  error
+}
+
+func (s *memorySink) Write(ctx context.Context, log *entity.ActionLog) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.logs = append(s.logs, log)
+	return s.err
+}
+
+func (s *memorySink) len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.logs)
+}
+
+func TestAnnotateAndSetTarget(t *testing.T) {
+	// Without an action log in the context, annotating is a no-op.
+	Annotate(context.Background(), ActionAICall)
+	SetTarget(context.Background(), entity.ActionTarget{Cluster: "c1"})
+
+	log := &entity.ActionLog{}
+	ctx := WithLog(context.Background(), log)
+	require.Same(t, log, LogFrom(ctx))
+
+	Annotate(ctx, ActionAICall, DetailPromptType, "diagnosis", "dangling")
+	require.Equal(t, ActionAICall, log.Action)
+	require.Equal(t, map[string]string{DetailPromptType: "diagnosis"}, log.Details)
+
+	SetTarget(ctx, entity.ActionTarget{Cluster: "c1", Name: "pod-1"})
+	SetTarget(ctx, entity.ActionTarget{Kind: "Pod"})
+	require.Equal(t, entity.ActionTarget{Cluster: "c1", Kind: "Pod", Name: "pod-1"}, log.Target)
+}
+
+func TestRecorder(t *testing.T) {
+	failing := &memorySink{err: errors.New("unavailable")}
+	sink := &memorySink{}
+	recorder := NewRecorder(failing)
+	recorder.AddSink(sink)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go recorder.Run(ctx)
+
+	recorder.Record(&entity.ActionLog{Action: ActionClusterCreate})
+	recorder.Record(&entity.ActionLog{Action: ActionClusterDelete})
+	require.Eventually(t, func() bool { return sink.len() == 2 }, time.Second, 10*time.Millisecond)
+	// A failing sink does not prevent the other sinks from being written.
+	require.Equal(t, 2, failing.len())
+
+	var nilRecorder *Recorder
+	nilRecorder.Record(&entity.ActionLog{})
+}
+
+func TestRecorderWritesSynchronouslyWhenFull(t *testing.T) {
+	sink := &memorySink{}
+	recorder := NewRecorder(sink)
+	for i := 0; i < queueSize+1; i++ {
+		recorder.Record(&entity.ActionLog{Action: ActionAICall})
+	}
+	require.Equal(t, 1, sink.len())
+}
+
+func TestRecorderDrainsQueueOnShutdown(t *testing.T) {
+	sink := &memorySink{}
+	recorder := NewRecorder(sink)
+	recorder.Record(&entity.ActionLog{Action: ActionClusterCreate})
+	recorder.Record(&entity.ActionLog{Action: ActionClusterDelete})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder.Run(ctx)
+	require.Equal(t, 2, sink.len())
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actions.log")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), &entity.ActionLog{ID: "1", User: "alice", Action: ActionClusterCreate}))
+	require.NoError(t, sink.Write(context.Background(), &entity.ActionLog{ID: "2", User: "bob", Action: ActionClusterProxy}))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var users []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var log entity.ActionLog
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &log))
+		users = append(users, log.User)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []string{"alice", "bob"}, users)
+}
@@ -0,0 +1,74 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionlog
+
+import (
+	"context"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
+
+// logKey is the context key of the action log of a request.
+type logKey struct{}
+
+// WithLog returns a copy of the context carrying the action log of the
+// request, which is filled in by the handlers through Annotate and
+// SetTarget.
+func WithLog(ctx context.Context, log *entity.ActionLog) context.Context {
+	return context.WithValue(ctx, logKey{}, log)
+}
+
+// LogFrom returns the action log carried by the context, or nil.
+func LogFrom(ctx context.Context) *entity.ActionLog {
+	log, _ := ctx.Value(logKey{}).(*entity.ActionLog)
+	return log
+}
+
+// Annotate marks the request as performing the action, the key and value
+// pairs are added to the details of the action log. Requests which are not
+// annotated are not recorded.
+func Annotate(ctx context.Context, action string, keysAndValues ...string) {
+	log := LogFrom(ctx)
+	if log == nil {
+		return
+	}
+	log.Action = action
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if log.Details == nil {
+			log.Details = map[string]string{}
+		}
+		log.Details[keysAndValues[i]] = keysAndValues[i+1]
+	}
+}
+
+// SetTarget sets the non-empty fields of the target of the action.
+func SetTarget(ctx context.Context, target entity.ActionTarget) {
+	log := LogFrom(ctx)
+	if log == nil {
+		return
+	}
+	if target.Cluster != "" {
+		log.Target.Cluster = target.Cluster
+	}
+	if target.Namespace != "" {
+		log.Target.Namespace = target.Namespace
+	}
+	if target.Kind != "" {
+		log.Target.Kind = target.Kind
+	}
+	if target.Name != "" {
+		log.Target.Name = target.Name
+	}
+}
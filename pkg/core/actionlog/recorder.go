@@ -0,0 +1,134 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package actionlog records the actions performed by users in karpor, such
+// as changing clusters, calling the AI backend or proxying requests to
+// clusters, so that there is a queryable audit trail of who did what.
+package actionlog
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"k8s.io/klog/v2"
+)
+
+// Names of the recorded actions.
+const (
+	ActionClusterCreate           = "cluster.create"
+	ActionClusterUpdate           = "cluster.update"
+	ActionClusterDelete           = "cluster.delete"
+	ActionClusterProxy            = "cluster.proxy"
+	ActionClusterImport           = "cluster.import"
+	ActionKubeConfigUpload        = "kubeconfig.upload"
+	ActionResourceGroupRuleCreate = "resourcegrouprule.create"
+	ActionResourceGroupRuleUpdate = "resourcegrouprule.update"
+	ActionResourceGroupRuleDelete = "resourcegrouprule.delete"
+	ActionResourceGroupSnapshot   = "resourcegroup.snapshot"
+	ActionSavedSearchCreate       = "savedsearch.create"
+	ActionSavedSearchUpdate       = "savedsearch.update"
+	ActionSavedSearchDelete       = "savedsearch.delete"
+	ActionAlertRuleCreate         = "alertrule.create"
+	ActionAlertRuleUpdate         = "alertrule.update"
+	ActionAlertRuleDelete         = "alertrule.delete"
+	ActionAlertRuleSilence        = "alertrule.silence"
+	ActionAICall                  = "ai.call"
+	ActionLogDownload             = "log.download"
+)
+
+// DetailPromptType is the detail key of the prompt type of AI calls.
+const DetailPromptType = "promptType"
+
+// queueSize is the number of action logs buffered before they are written
+// synchronously by Record.
+const queueSize = 1024
+
+// Sink receives the recorded action logs.
+type Sink interface {
+	Write(ctx context.Context, log *entity.ActionLog) error
+}
+
+// Recorder writes action logs to its sinks in the background, so that slow
+// sinks do not delay the requests performing the actions.
+type Recorder struct {
+	lock  sync.RWMutex
+	sinks []Sink
+	queue chan *entity.ActionLog
+}
+
+// NewRecorder creates a recorder writing to the given sinks.
+func NewRecorder(sinks ...Sink) *Recorder {
+	return &Recorder{
+		sinks: sinks,
+		queue: make(chan *entity.ActionLog, queueSize),
+	}
+}
+
+// AddSink adds a sink to the recorder.
+func (r *Recorder) AddSink(sink Sink) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sinks = append(r.sinks, sink)
+}
+
+// Record queues the action log to be written by Run. If the queue is full,
+// the log is written synchronously rather than dropped.
+func (r *Recorder) Record(log *entity.ActionLog) {
+	if r == nil {
+		return
+	}
+	select {
+	case r.queue <- log:
+	default:
+		r.write(context.Background(), log)
+	}
+}
+
+// Run writes the queued action logs until the context is done, and then
+// writes the logs left in the queue so that they are not lost on shutdown.
+func (r *Recorder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			r.drain(context.WithoutCancel(ctx))
+			return
+		case log := <-r.queue:
+			r.write(ctx, log)
+		}
+	}
+}
+
+// drain writes the logs left in the queue.
+func (r *Recorder) drain(ctx context.Context) {
+	for {
+		select {
+		case log := <-r.queue:
+			r.write(ctx, log)
+		default:
+			return
+		}
+	}
+}
+
+// write writes the action log to all sinks.
+func (r *Recorder) write(ctx context.Context, log *entity.ActionLog) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, sink := range r.sinks {
+		if err := sink.Write(ctx, log); err != nil {
+			klog.ErrorS(err, "Failed to write action log", "action", log.Action, "user", log.User, "requestID", log.RequestID)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionlog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+)
+
+// StorageSink writes the action logs to the action log storage, from which
+// they are served by the read API.
+type StorageSink struct {
+	storage storage.ActionLogStorage
+}
+
+// NewStorageSink creates a sink writing to the given storage.
+func NewStorageSink(storage storage.ActionLogStorage) *StorageSink {
+	return &StorageSink{storage: storage}
+}
+
+// Write saves the action log to the storage.
+func (s *StorageSink) Write(ctx context.Context, log *entity.ActionLog) error {
+	return s.storage.SaveActionLog(ctx, log)
+}
+
+// FileSink appends the action logs to a file as JSON lines, so that they
+// can be shipped by a log collector.
+type FileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileSink creates a sink appending to the file at the given path, the
+// file is created if it does not exist.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write appends the action log to the file.
+func (s *FileSink) Write(ctx context.Context, log *entity.ActionLog) error {
+	line, err := json.Marshal(log)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.file.Write(line)
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,63 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"net/http"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// SessionCookieName is the name of the cookie carrying the session id.
+const SessionCookieName = "karpor_session"
+
+// SessionAuthenticator authenticates requests by their session cookie, it
+// is meant to be added to the authenticators of the apiserver so that the
+// session users are subject to RBAC authorization.
+type SessionAuthenticator struct {
+	sessions *SessionStore
+}
+
+var _ authenticator.Request = &SessionAuthenticator{}
+
+// NewSessionAuthenticator creates an authenticator of the sessions in the
+// given store.
+func NewSessionAuthenticator(sessions *SessionStore) *SessionAuthenticator {
+	return &SessionAuthenticator{sessions: sessions}
+}
+
+// AuthenticateRequest returns the user of the session cookie of the request.
+// Requests without a valid session are left to the other authenticators.
+func (a *SessionAuthenticator) AuthenticateRequest(req *http.Request) (*authenticator.Response, bool, error) {
+	cookie, err := req.Cookie(SessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil, false, nil
+	}
+	session, ok := a.sessions.Get(cookie.Value)
+	if !ok {
+		return nil, false, nil
+	}
+
+	groups := make([]string, 0, len(session.Groups)+1)
+	groups = append(groups, session.Groups...)
+	groups = append(groups, user.AllAuthenticated)
+	return &authenticator.Response{
+		User: &user.DefaultInfo{
+			Name:   session.Username,
+			Groups: groups,
+		},
+	}, true, nil
+}
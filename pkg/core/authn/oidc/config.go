@@ -0,0 +1,64 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oidc implements the OpenID Connect login of karpor. Users sign in
+// with the authorization code flow with PKCE, and are then authenticated by
+// a session cookie whose user and groups are mapped from the ID token claims,
+// so that they can be bound to RBAC subjects.
+package oidc
+
+import "time"
+
+const (
+	// DefaultUsernameClaim is the ID token claim used as the username.
+	DefaultUsernameClaim = "email"
+	// DefaultGroupsClaim is the ID token claim used as the groups.
+	DefaultGroupsClaim = "groups"
+	// DefaultPrefix is prepended to the usernames and groups mapped from the
+	// ID token, so that they do not collide with the built-in subjects.
+	DefaultPrefix = "oidc:"
+	// DefaultSessionTTL is how long a login session lasts.
+	DefaultSessionTTL = 8 * time.Hour
+)
+
+// Config is the configuration of the OpenID Connect login.
+type Config struct {
+	// IssuerURL is the URL of the OpenID provider, which serves the
+	// discovery document under /.well-known/openid-configuration.
+	IssuerURL string
+	// ClientID and ClientSecret are the credentials of karpor registered at
+	// the OpenID provider. The secret may be empty for public clients.
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the externally reachable URL of the callback endpoint.
+	RedirectURL string
+	// Scopes are requested in addition to the openid scope.
+	Scopes []string
+	// UsernameClaim and GroupsClaim are the ID token claims mapped to the
+	// user, and UsernamePrefix and GroupsPrefix are prepended to them.
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+	// SessionTTL is how long a login session lasts.
+	SessionTTL time.Duration
+	// InsecureCookie allows the session cookie to be sent over plain HTTP,
+	// which is only meant for local development.
+	InsecureCookie bool
+}
+
+// Enabled reports whether the OpenID Connect login is configured.
+func (c *Config) Enabled() bool {
+	return c != nil && c.IssuerURL != ""
+}
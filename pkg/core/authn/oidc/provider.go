@@ -0,0 +1,297 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// LoginPath is the path of the login endpoints, which must be reachable
+	// without authentication.
+	LoginPath = "/rest-api/v1/authn/oidc"
+	// StateCookieName is the name of the cookie binding a pending login to
+	// the browser which started it.
+	StateCookieName = "karpor_oidc_state"
+	// loginTTL is how long a user has to complete a login at the OpenID
+	// provider.
+	loginTTL = 10 * time.Minute
+	// maxPendingLogins is the maximum number of logins pending at the same
+	// time, the login endpoint is reachable without authentication so the
+	// pending logins must not grow without bound.
+	maxPendingLogins = 10000
+)
+
+// ErrInvalidState is returned when the callback does not belong to a
+// pending login, e.g. it is replayed or the login has expired.
+var ErrInvalidState = errors.New("invalid or expired login state")
+
+// pendingLogin is a login started but not yet completed by the callback.
+type pendingLogin struct {
+	codeVerifier string
+	nonce        string
+	returnTo     string
+	expiresAt    time.Time
+}
+
+// Provider performs the authorization code flow with PKCE against the
+// OpenID provider and starts a session for each successful login.
+type Provider struct {
+	config   Config
+	sessions *SessionStore
+	now      func() time.Time
+
+	lock         sync.Mutex
+	oauth2Config *oauth2.Config
+	verifier     *gooidc.IDTokenVerifier
+	logins       map[string]*pendingLogin
+}
+
+// NewProvider creates a provider with the given configuration, the sessions
+// are started in the given store. The OpenID provider is discovered on the
+// first login, so that karpor starts even if it is unreachable.
+func NewProvider(config Config, sessions *SessionStore) *Provider {
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = DefaultUsernameClaim
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = DefaultGroupsClaim
+	}
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = DefaultSessionTTL
+	}
+	return &Provider{
+		config:   config,
+		sessions: sessions,
+		now:      time.Now,
+		logins:   map[string]*pendingLogin{},
+	}
+}
+
+// LoginURL starts a login and returns the URL of the OpenID provider to
+// redirect the user to, along with the state identifying the login. The
+// user is sent back to returnTo once the login completes.
+func (p *Provider) LoginURL(ctx context.Context, returnTo string) (string, string, error) {
+	oauth2Config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	p.lock.Lock()
+	now := p.now()
+	var oldest string
+	for s, login := range p.logins {
+		if !now.Before(login.expiresAt) {
+			delete(p.logins, s)
+		} else if oldest == "" || login.expiresAt.Before(p.logins[oldest].expiresAt) {
+			oldest = s
+		}
+	}
+	// Give up the oldest pending login to make room for the new one.
+	if len(p.logins) >= maxPendingLogins {
+		delete(p.logins, oldest)
+	}
+	p.logins[state] = &pendingLogin{
+		codeVerifier: codeVerifier,
+		nonce:        nonce,
+		returnTo:     SanitizeReturnTo(returnTo),
+		expiresAt:    now.Add(loginTTL),
+	}
+	p.lock.Unlock()
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	authURL := oauth2Config.AuthCodeURL(state,
+		gooidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+	return authURL, state, nil
+}
+
+// Callback completes the login identified by the state, it exchanges the
+// authorization code for an ID token, maps the claims of the token to the
+// user and starts a session. The URL to send the user back to is returned
+// along with the session.
+func (p *Provider) Callback(ctx context.Context, state, code string) (*Session, string, error) {
+	p.lock.Lock()
+	login, ok := p.logins[state]
+	delete(p.logins, state)
+	p.lock.Unlock()
+	if !ok || !p.now().Before(login.expiresAt) {
+		return nil, "", ErrInvalidState
+	}
+
+	oauth2Config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", login.codeVerifier))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, "", fmt.Errorf("no id_token in the token response")
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to verify the ID token: %w", err)
+	}
+	if idToken.Nonce != login.nonce {
+		return nil, "", fmt.Errorf("the nonce of the ID token does not match")
+	}
+
+	claims := map[string]interface{}{}
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, "", fmt.Errorf("failed to parse the ID token claims: %w", err)
+	}
+	username, groups, err := p.mapClaims(claims)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session, err := p.sessions.Create(username, groups, p.config.SessionTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, login.returnTo, nil
+}
+
+// Logout ends the session with the given id.
+func (p *Provider) Logout(sessionID string) {
+	p.sessions.Delete(sessionID)
+}
+
+// SessionCookie returns the cookie carrying the session.
+func (p *Provider) SessionCookie(session *Session) *http.Cookie {
+	return p.cookie(SessionCookieName, session.ID, "/", session.ExpiresAt)
+}
+
+// ClearSessionCookie returns the cookie removing the session cookie.
+func (p *Provider) ClearSessionCookie() *http.Cookie {
+	return p.cookie(SessionCookieName, "", "/", time.Unix(0, 0))
+}
+
+// StateCookie returns the cookie binding the login state to the browser,
+// it is only sent to the endpoints under the given path.
+func (p *Provider) StateCookie(state, path string) *http.Cookie {
+	return p.cookie(StateCookieName, state, path, p.now().Add(loginTTL))
+}
+
+// ClearStateCookie returns the cookie removing the state cookie.
+func (p *Provider) ClearStateCookie(path string) *http.Cookie {
+	return p.cookie(StateCookieName, "", path, time.Unix(0, 0))
+}
+
+// cookie returns an HTTP only cookie which is sent on top-level navigations
+// from the OpenID provider, so that the callback receives it.
+func (p *Provider) cookie(name, value, path string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   !p.config.InsecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// discover fetches the discovery document of the OpenID provider once, and
+// returns the OAuth2 configuration and the ID token verifier.
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.oauth2Config != nil {
+		return p.oauth2Config, p.verifier, nil
+	}
+
+	provider, err := gooidc.NewProvider(ctx, p.config.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover the OpenID provider %s: %w", p.config.IssuerURL, err)
+	}
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       append([]string{gooidc.ScopeOpenID}, p.config.Scopes...),
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+	return p.oauth2Config, p.verifier, nil
+}
+
+// mapClaims maps the claims of the ID token to the username and groups of
+// the user, both prefixed so that they can be bound to RBAC subjects.
+func (p *Provider) mapClaims(claims map[string]interface{}) (string, []string, error) {
+	username, ok := claims[p.config.UsernameClaim].(string)
+	if !ok || username == "" {
+		return "", nil, fmt.Errorf("the ID token has no %q claim", p.config.UsernameClaim)
+	}
+	if p.config.UsernameClaim == "email" {
+		// An unverified email could be claimed by anyone.
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return "", nil, fmt.Errorf("the email %q is not verified", username)
+		}
+	}
+
+	var groups []string
+	switch value := claims[p.config.GroupsClaim].(type) {
+	case string:
+		groups = []string{p.config.GroupsPrefix + value}
+	case []interface{}:
+		for _, v := range value {
+			if group, ok := v.(string); ok && group != "" {
+				groups = append(groups, p.config.GroupsPrefix+group)
+			}
+		}
+	}
+	return p.config.UsernamePrefix + username, groups, nil
+}
+
+// SanitizeReturnTo returns the path to send the user back to after login,
+// only local paths are allowed so that the login cannot be abused as an
+// open redirect.
+func SanitizeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.HasPrefix(returnTo, "/\\") {
+		return "/"
+	}
+	return returnTo
+}
@@ -0,0 +1,287 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+const (
+	testClientID    = "karpor"
+	testRedirectURL = "https://karpor.example.com/rest-api/v1/authn/oidc/callback"
+)
+
+// authorization is an authorization code issued by the fake provider.
+type authorization struct {
+	challenge string
+	nonce     string
+}
+
+// fakeProvider is a local stand-in of an OpenID provider, which signs in
+// a fixed user and checks the PKCE code verifier.
+type fakeProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+
+	lock  sync.Mutex
+	codes map[string]authorization
+}
+
+func newFakeProvider(t *testing.T, claims map[string]interface{}) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &fakeProvider{key: key, claims: claims, codes: map[string]authorization{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+			http.Error(w, "PKCE is required", http.StatusBadRequest)
+			return
+		}
+		code, _ := randomString(16)
+		p.lock.Lock()
+		p.codes[code] = authorization{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+		p.lock.Unlock()
+
+		redirect, _ := url.Parse(q.Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"code": {code}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		p.lock.Lock()
+		auth, ok := p.codes[r.Form.Get("code")]
+		delete(p.codes, r.Form.Get("code"))
+		p.lock.Unlock()
+
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.idToken(t, auth.nonce),
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// idToken returns an ID token signed by the provider with the given nonce.
+func (p *fakeProvider) idToken(t *testing.T, nonce string) string {
+	claims := map[string]interface{}{
+		"iss":   p.URL,
+		"aud":   testClientID,
+		"sub":   "user-1",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": nonce,
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+// authorize follows the login URL at the provider and returns the query of
+// the callback it redirects to.
+func authorize(t *testing.T, authURL string) url.Values {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := resp.Location()
+	require.NoError(t, err)
+	return location.Query()
+}
+
+func newTestProvider(issuer string) *Provider {
+	return NewProvider(Config{
+		IssuerURL:      issuer,
+		ClientID:       testClientID,
+		RedirectURL:    testRedirectURL,
+		UsernamePrefix: DefaultPrefix,
+		GroupsPrefix:   DefaultPrefix,
+	}, NewSessionStore())
+}
+
+func TestProviderLogin(t *testing.T) {
+	idp := newFakeProvider(t, map[string]interface{}{
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"groups":         []string{"sre", "dev"},
+	})
+	provider := newTestProvider(idp.URL)
+	ctx := context.Background()
+
+	authURL, state, err := provider.LoginURL(ctx, "/search?q=pods")
+	require.NoError(t, err)
+	callback := authorize(t, authURL)
+	require.Equal(t, state, callback.Get("state"))
+
+	session, returnTo, err := provider.Callback(ctx, callback.Get("state"), callback.Get("code"))
+	require.NoError(t, err)
+	require.Equal(t, "/search?q=pods", returnTo)
+	require.Equal(t, "oidc:alice@example.com", session.Username)
+	require.Equal(t, []string{"oidc:sre", "oidc:dev"}, session.Groups)
+
+	// The state can only be used once.
+	_, _, err = provider.Callback(ctx, callback.Get("state"), callback.Get("code"))
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	// The session cookie authenticates the user.
+	req := httptest.NewRequest(http.MethodGet, "/rest-api/v1/authn/user", nil)
+	req.AddCookie(provider.SessionCookie(session))
+	resp, ok, err := NewSessionAuthenticator(provider.sessions).AuthenticateRequest(req)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "oidc:alice@example.com", resp.User.GetName())
+	require.Equal(t, []string{"oidc:sre", "oidc:dev", user.AllAuthenticated}, resp.User.GetGroups())
+
+	// The session no longer authenticates after logout.
+	provider.Logout(session.ID)
+	_, ok, err = NewSessionAuthenticator(provider.sessions).AuthenticateRequest(req)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestProviderLoginRejectsWrongVerifier(t *testing.T) {
+	idp := newFakeProvider(t, map[string]interface{}{"email": "alice@example.com"})
+	provider := newTestProvider(idp.URL)
+	ctx := context.Background()
+
+	authURL, state, err := provider.LoginURL(ctx, "")
+	require.NoError(t, err)
+	callback := authorize(t, authURL)
+
+	// Tamper the code verifier, as if the code was intercepted and redeemed
+	// by a different client.
+	provider.logins[state].codeVerifier = "tampered"
+	_, _, err = provider.Callback(ctx, state, callback.Get("code"))
+	require.Error(t, err)
+}
+
+func TestProviderLoginCapsPendingLogins(t *testing.T) {
+	idp := newFakeProvider(t, map[string]interface{}{"email": "alice@example.com"})
+	provider := newTestProvider(idp.URL)
+	ctx := context.Background()
+
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+	provider.logins["expired"] = &pendingLogin{expiresAt: now.Add(-time.Second)}
+	provider.logins["oldest"] = &pendingLogin{expiresAt: now.Add(time.Second)}
+	for i := len(provider.logins) - 1; i < maxPendingLogins; i++ {
+		provider.logins[fmt.Sprintf("pending-%d", i)] = &pendingLogin{expiresAt: now.Add(loginTTL)}
+	}
+
+	_, state, err := provider.LoginURL(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, provider.logins, maxPendingLogins)
+	require.Contains(t, provider.logins, state)
+	require.NotContains(t, provider.logins, "expired")
+	require.NotContains(t, provider.logins, "oldest")
+}
+
+func TestProviderLoginRejectsUnverifiedEmail(t *testing.T) {
+	idp := newFakeProvider(t, map[string]interface{}{
+		"email":          "alice@example.com",
+		"email_verified": false,
+	})
+	provider := newTestProvider(idp.URL)
+	ctx := context.Background()
+
+	authURL, state, err := provider.LoginURL(ctx, "")
+	require.NoError(t, err)
+	callback := authorize(t, authURL)
+
+	_, _, err = provider.Callback(ctx, state, callback.Get("code"))
+	require.ErrorContains(t, err, "not verified")
+}
+
+func TestSanitizeReturnTo(t *testing.T) {
+	require.Equal(t, "/", SanitizeReturnTo(""))
+	require.Equal(t, "/", SanitizeReturnTo("https://evil.example.com"))
+	require.Equal(t, "/", SanitizeReturnTo("//evil.example.com"))
+	require.Equal(t, "/", SanitizeReturnTo("/\\evil.example.com"))
+	require.Equal(t, "/cluster?name=prod", SanitizeReturnTo("/cluster?name=prod"))
+}
+
+func TestSessionStoreExpiry(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	session, err := store.Create("alice", nil, time.Hour)
+	require.NoError(t, err)
+	_, ok := store.Get(session.ID)
+	require.True(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok = store.Get(session.ID)
+	require.False(t, ok)
+}
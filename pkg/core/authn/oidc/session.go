@@ -0,0 +1,109 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// Session is the login session of a user.
+type Session struct {
+	ID        string
+	Username  string
+	Groups    []string
+	ExpiresAt time.Time
+}
+
+// SessionStore keeps the login sessions in memory, so the sessions are lost
+// when the server restarts and users have to sign in again.
+type SessionStore struct {
+	lock     sync.Mutex
+	sessions map[string]*Session
+	now      func() time.Time
+}
+
+// NewSessionStore creates an empty session store.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		sessions: map[string]*Session{},
+		now:      time.Now,
+	}
+}
+
+// Create starts a new session of the user which lasts for the given ttl.
+func (s *SessionStore) Create(username string, groups []string, ttl time.Duration) (*Session, error) {
+	id, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.gc()
+
+	session := &Session{
+		ID:        id,
+		Username:  username,
+		Groups:    groups,
+		ExpiresAt: s.now().Add(ttl),
+	}
+	s.sessions[id] = session
+	return session, nil
+}
+
+// Get returns the unexpired session with the given id.
+func (s *SessionStore) Get(id string) (*Session, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(session.ExpiresAt) {
+		delete(s.sessions, id)
+		return nil, false
+	}
+	return session, true
+}
+
+// Delete ends the session with the given id.
+func (s *SessionStore) Delete(id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.sessions, id)
+}
+
+// gc removes the expired sessions, the caller must hold the lock.
+func (s *SessionStore) gc() {
+	now := s.now()
+	for id, session := range s.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// randomString returns a URL-safe random string encoding n random bytes.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,122 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"time"
+
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/KusionStack/karpor/pkg/util/cache"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// rulesCacheExpiration is how long the data access rules are cached, so that
+// they are not listed on every request.
+const rulesCacheExpiration = 10 * time.Second
+
+// Source provides the data access rules to be evaluated by the authorizer.
+type Source interface {
+	ListDataAccessRules(ctx context.Context) ([]searchv1beta1.DataAccessRule, error)
+}
+
+// Authorizer resolves the data access filter of users from the data access
+// rules provided by the source.
+type Authorizer struct {
+	source Source
+	rules  *cache.Cache[struct{}, []searchv1beta1.DataAccessRule]
+}
+
+// NewAuthorizer creates an authorizer which evaluates the data access rules
+// provided by the given source.
+func NewAuthorizer(source Source) *Authorizer {
+	return &Authorizer{
+		source: source,
+		rules:  cache.NewCache[struct{}, []searchv1beta1.DataAccessRule](rulesCacheExpiration),
+	}
+}
+
+// FilterFor returns the data access filter of the user, which is the union
+// of the scopes of all rules granted to the user or one of its groups.
+// Members of the system:masters group are never restricted.
+func (a *Authorizer) FilterFor(ctx context.Context, u user.Info) (*Filter, error) {
+	groups := map[string]struct{}{}
+	for _, group := range u.GetGroups() {
+		if group == user.SystemPrivilegedGroup {
+			return nil, nil
+		}
+		groups[group] = struct{}{}
+	}
+
+	rules, err := a.listRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &Filter{Scopes: []Scope{}}
+	for _, rule := range rules {
+		if !grants(&rule, u.GetName(), groups) {
+			continue
+		}
+		// A rule without scopes, or with an unrestricted scope, grants access
+		// to all resources.
+		if len(rule.Spec.Scopes) == 0 {
+			return nil, nil
+		}
+		for _, scope := range rule.Spec.Scopes {
+			if len(scope.Clusters) == 0 && len(scope.Namespaces) == 0 && len(scope.Kinds) == 0 {
+				return nil, nil
+			}
+			filter.Scopes = append(filter.Scopes, Scope{
+				Clusters:   scope.Clusters,
+				Namespaces: scope.Namespaces,
+				Kinds:      scope.Kinds,
+			})
+		}
+	}
+
+	return filter, nil
+}
+
+// listRules lists the data access rules from the cache or the source.
+func (a *Authorizer) listRules(ctx context.Context) ([]searchv1beta1.DataAccessRule, error) {
+	if rules, ok := a.rules.Get(struct{}{}); ok {
+		return rules, nil
+	}
+	rules, err := a.source.ListDataAccessRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.rules.Set(struct{}{}, rules)
+	return rules, nil
+}
+
+// grants reports whether the rule grants access to the user or one of the
+// given groups.
+func grants(rule *searchv1beta1.DataAccessRule, username string, groups map[string]struct{}) bool {
+	for _, subject := range rule.Spec.Subjects {
+		switch subject.Kind {
+		case searchv1beta1.DataAccessSubjectUser:
+			if subject.Name == username {
+				return true
+			}
+		case searchv1beta1.DataAccessSubjectGroup:
+			if _, ok := groups[subject.Name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func newRule(name string, subjects []searchv1beta1.DataAccessSubject, scopes ...searchv1beta1.DataAccessScope) searchv1beta1.DataAccessRule {
+	return searchv1beta1.DataAccessRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: searchv1beta1.DataAccessRuleSpec{
+			Subjects: subjects,
+			Scopes:   scopes,
+		},
+	}
+}
+
+func TestFilterAllows(t *testing.T) {
+	filter := &Filter{Scopes: []Scope{
+		{Clusters: []string{"dev"}},
+		{Clusters: []string{"prod"}, Namespaces: []string{"team-a"}, Kinds: []string{"Deployment"}},
+	}}
+
+	tests := []struct {
+		name string
+		rg   entity.ResourceGroup
+		want bool
+	}{
+		{name: "whole cluster", rg: entity.ResourceGroup{Cluster: "dev"}, want: true},
+		{name: "resource in cluster", rg: entity.ResourceGroup{Cluster: "dev", Namespace: "default", Kind: "Pod"}, want: true},
+		{name: "kind ignores case", rg: entity.ResourceGroup{Cluster: "prod", Namespace: "team-a", Kind: "deployment"}, want: true},
+		{name: "other kind", rg: entity.ResourceGroup{Cluster: "prod", Namespace: "team-a", Kind: "Secret"}, want: false},
+		{name: "all kinds of namespace", rg: entity.ResourceGroup{Cluster: "prod", Namespace: "team-a"}, want: false},
+		{name: "other cluster", rg: entity.ResourceGroup{Cluster: "staging"}, want: false},
+		{name: "all clusters", rg: entity.ResourceGroup{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, filter.Allows(tt.rg))
+		})
+	}
+
+	var unrestricted *Filter
+	require.True(t, unrestricted.Allows(entity.ResourceGroup{}))
+	require.False(t, unrestricted.AllowsNone())
+	require.True(t, (&Filter{Scopes: []Scope{}}).AllowsNone())
+}
+
+func TestFilterRestrictClusters(t *testing.T) {
+	var unrestricted *Filter
+	require.Equal(t, &Filter{Scopes: []Scope{{Clusters: []string{"prod-eu"}}}}, unrestricted.RestrictClusters([]string{"prod-eu"}))
+	require.True(t, unrestricted.RestrictClusters(nil).AllowsNone())
+
+	filter := &Filter{Scopes: []Scope{
+		{Clusters: []string{"dev"}},
+		{Clusters: []string{"prod-eu", "prod-us"}, Namespaces: []string{"team-a"}},
+		{Kinds: []string{"ConfigMap"}},
+	}}
+	restricted := filter.RestrictClusters([]string{"prod-eu", "staging"})
+	require.Equal(t, &Filter{Scopes: []Scope{
+		{Clusters: []string{"prod-eu"}, Namespaces: []string{"team-a"}},
+		{Clusters: []string{"prod-eu", "staging"}, Kinds: []string{"ConfigMap"}},
+	}}, restricted)
+	require.True(t, restricted.AllowsCluster("staging"))
+	require.False(t, restricted.AllowsCluster("dev"))
+	require.True(t, unrestricted.AllowsCluster("dev"))
+	// The original filter is left unchanged.
+	require.Equal(t, []string{"prod-eu", "prod-us"}, filter.Scopes[1].Clusters)
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := WithFilter(context.Background(), &Filter{Scopes: []Scope{{Clusters: []string{"dev"}}}})
+	require.NoError(t, Authorize(ctx, entity.ResourceGroup{Cluster: "dev"}))
+
+	err := Authorize(ctx, entity.ResourceGroup{Cluster: "prod"})
+	require.True(t, errors.Is(err, ErrForbidden))
+
+	// A nil filter lifts the restriction of the parent context.
+	require.NoError(t, Authorize(WithFilter(ctx, nil), entity.ResourceGroup{Cluster: "prod"}))
+}
+
+func TestAuthorizerFilterFor(t *testing.T) {
+	authorizer := NewAuthorizer(StaticSource{
+		newRule("alice-dev", []searchv1beta1.DataAccessSubject{
+			{Kind: searchv1beta1.DataAccessSubjectUser, Name: "alice"},
+		}, searchv1beta1.DataAccessScope{Clusters: []string{"dev"}}),
+		newRule("team-a", []searchv1beta1.DataAccessSubject{
+			{Kind: searchv1beta1.DataAccessSubjectGroup, Name: "team-a"},
+		}, searchv1beta1.DataAccessScope{Clusters: []string{"prod"}, Namespaces: []string{"team-a"}}),
+		newRule("admins", []searchv1beta1.DataAccessSubject{
+			{Kind: searchv1beta1.DataAccessSubjectGroup, Name: "admins"},
+		}),
+	})
+
+	tests := []struct {
+		name string
+		user user.Info
+		want *Filter
+	}{
+		{
+			name: "user and group rules are merged",
+			user: &user.DefaultInfo{Name: "alice", Groups: []string{"team-a"}},
+			want: &Filter{Scopes: []Scope{
+				{Clusters: []string{"dev"}},
+				{Clusters: []string{"prod"}, Namespaces: []string{"team-a"}},
+			}},
+		},
+		{
+			name: "rule without scopes is unrestricted",
+			user: &user.DefaultInfo{Name: "bob", Groups: []string{"admins"}},
+			want: nil,
+		},
+		{
+			name: "system masters are unrestricted",
+			user: &user.DefaultInfo{Name: "root", Groups: []string{user.SystemPrivilegedGroup}},
+			want: nil,
+		},
+		{
+			name: "no rule denies all",
+			user: &user.DefaultInfo{Name: "carol"},
+			want: &Filter{Scopes: []Scope{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := authorizer.FilterFor(context.Background(), tt.user)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, filter)
+		})
+	}
+}
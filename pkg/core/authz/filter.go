@@ -0,0 +1,151 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authz provides data-level authorization, which restricts the
+// resources a user can see through search and insight to the clusters,
+// namespaces and kinds granted by DataAccessRule objects.
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
+
+// ErrForbidden is returned when the user has no access to the requested
+// resources.
+var ErrForbidden = errors.New("forbidden")
+
+// Scope is a set of resources which can be accessed. Multiple fields are
+// ANDed, and multiple values of a single field are ORed. An empty field
+// selects all values.
+type Scope struct {
+	Clusters   []string
+	Namespaces []string
+	Kinds      []string
+}
+
+// Filter restricts the accessible resources to the union of its scopes. A
+// nil filter grants access to all resources, while a filter without scopes
+// grants access to none.
+type Filter struct {
+	Scopes []Scope
+}
+
+// Allows reports whether the resources located by the resource group are
+// all accessible. A field left empty in the resource group stands for all of
+// its values, so it is only allowed by scopes which do not restrict it.
+func (f *Filter) Allows(rg entity.ResourceGroup) bool {
+	if f == nil {
+		return true
+	}
+	for _, scope := range f.Scopes {
+		if scope.allows(rg) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsNone reports whether the filter denies access to all resources.
+func (f *Filter) AllowsNone() bool {
+	return f != nil && len(f.Scopes) == 0
+}
+
+// AllowsCluster reports whether any resource of the cluster is accessible.
+func (f *Filter) AllowsCluster(cluster string) bool {
+	if f == nil {
+		return true
+	}
+	for _, scope := range f.Scopes {
+		if matches(scope.Clusters, cluster, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictClusters returns a filter which grants access to the resources
+// allowed by the filter that are also in one of the clusters.
+func (f *Filter) RestrictClusters(clusters []string) *Filter {
+	scopes := []Scope{{}}
+	if f != nil {
+		scopes = f.Scopes
+	}
+
+	restricted := &Filter{Scopes: []Scope{}}
+	for _, scope := range scopes {
+		allowed := []string{}
+		for _, cluster := range clusters {
+			if matches(scope.Clusters, cluster, false) {
+				allowed = append(allowed, cluster)
+			}
+		}
+		if len(allowed) == 0 {
+			continue
+		}
+		scope.Clusters = allowed
+		restricted.Scopes = append(restricted.Scopes, scope)
+	}
+	return restricted
+}
+
+// allows reports whether the resources located by the resource group are
+// all in the scope.
+func (s *Scope) allows(rg entity.ResourceGroup) bool {
+	return matches(s.Clusters, rg.Cluster, false) &&
+		matches(s.Namespaces, rg.Namespace, false) &&
+		matches(s.Kinds, rg.Kind, true)
+}
+
+// matches reports whether the list is empty or contains the non-empty value.
+func matches(list []string, value string, ignoreCase bool) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value || (ignoreCase && value != "" && strings.EqualFold(item, value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterKey is the context key of the data access filter.
+type filterKey struct{}
+
+// WithFilter returns a copy of the context carrying the data access filter.
+// A nil filter lifts any restriction set by the parent context.
+func WithFilter(ctx context.Context, filter *Filter) context.Context {
+	return context.WithValue(ctx, filterKey{}, filter)
+}
+
+// FilterFrom returns the data access filter carried by the context, or nil
+// if the access is not restricted.
+func FilterFrom(ctx context.Context) *Filter {
+	filter, _ := ctx.Value(filterKey{}).(*Filter)
+	return filter
+}
+
+// Authorize returns an ErrForbidden error if the resources located by the
+// resource group are not all accessible with the filter of the context.
+func Authorize(ctx context.Context, rg entity.ResourceGroup) error {
+	if FilterFrom(ctx).Allows(rg) {
+		return nil
+	}
+	return fmt.Errorf("%w: no access to cluster %q, namespace %q, kind %q", ErrForbidden, rg.Cluster, rg.Namespace, rg.Kind)
+}
@@ -0,0 +1,64 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Outcomes of the actions recorded in action logs.
+const (
+	ActionOutcomeSuccess = "success"
+	ActionOutcomeFailure = "failure"
+)
+
+// ActionLog is the record of an action performed by a user in karpor, such
+// as creating a cluster or proxying a request to a cluster.
+type ActionLog struct {
+	// ID is the unique id of the action log.
+	ID string `yaml:"id" json:"id"`
+	// Timestamp is the time the action started.
+	Timestamp *metav1.Time `yaml:"timestamp,omitempty" json:"timestamp,omitempty"`
+	// RequestID is the id of the request which performed the action.
+	RequestID string `yaml:"requestID,omitempty" json:"requestID,omitempty"`
+	// User and Groups identify the user who performed the action.
+	User   string   `yaml:"user" json:"user"`
+	Groups []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+	// SourceIP is the address of the client.
+	SourceIP string `yaml:"sourceIP,omitempty" json:"sourceIP,omitempty"`
+	// Action is the name of the action, e.g. cluster.create.
+	Action string `yaml:"action" json:"action"`
+	// Method and Path are the HTTP method and path of the request.
+	Method string `yaml:"method" json:"method"`
+	Path   string `yaml:"path" json:"path"`
+	// Target locates the object the action is performed on.
+	Target ActionTarget `yaml:"target" json:"target"`
+	// Details are additional attributes of the action, e.g. the prompt type
+	// of an AI call.
+	Details map[string]string `yaml:"details,omitempty" json:"details,omitempty"`
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `yaml:"statusCode" json:"statusCode"`
+	// Outcome is either success or failure.
+	Outcome string `yaml:"outcome" json:"outcome"`
+	// DurationMillis is how long the action took in milliseconds.
+	DurationMillis int64 `yaml:"durationMillis" json:"durationMillis"`
+}
+
+// ActionTarget locates the object an action is performed on, fields not
+// applicable to the action are left empty.
+type ActionTarget struct {
+	Cluster   string `yaml:"cluster,omitempty" json:"cluster,omitempty"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Kind      string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name      string `yaml:"name,omitempty" json:"name,omitempty"`
+}
@@ -0,0 +1,294 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Triggers of the evaluation of alert rules.
+const (
+	// AlertTriggerSchedule rules are evaluated at their interval.
+	AlertTriggerSchedule = "schedule"
+	// AlertTriggerSync rules are evaluated after the resources are synced.
+	AlertTriggerSync = "sync"
+)
+
+// Conditions of alert rules.
+const (
+	// AlertConditionThreshold fires when the number of matching objects
+	// compared with the threshold is true.
+	AlertConditionThreshold = "threshold"
+	// AlertConditionAppear fires when new objects match the query.
+	AlertConditionAppear = "appear"
+	// AlertConditionDisappear fires when objects no longer match the query.
+	AlertConditionDisappear = "disappear"
+	// AlertConditionChange fires when objects appear or disappear.
+	AlertConditionChange = "change"
+)
+
+// Types of the notification channels of alert rules.
+const (
+	AlertChannelWebhook = "webhook"
+	AlertChannelSlack   = "slack"
+	AlertChannelEmail   = "email"
+)
+
+// alertOperators are the operators of threshold conditions.
+var alertOperators = []string{">", ">=", "<", "<=", "==", "!="}
+
+// AlertRule evaluates a query on a schedule or after the resources are
+// synced, and notifies its channels when its condition fires.
+type AlertRule struct {
+	// ID is the unique id of the alert rule.
+	ID string `yaml:"id" json:"id"`
+	// Name is the unique name of the alert rule.
+	Name string `yaml:"name" json:"name"`
+	// Description is a human-readable description of the alert rule.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Owner is the user who created the alert rule.
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	// Access is the data access of the user who last created or updated
+	// the alert rule, the rule only matches the objects within it. It is
+	// nil if the access of the user is not restricted.
+	Access *AlertRuleAccess `yaml:"access,omitempty" json:"access,omitempty"`
+	// SavedSearch, Query and ResourceGroup are the sources of the matching
+	// objects, exactly one of them is set. SavedSearch is the name of a
+	// saved search with the sql or dsl pattern, Query is a SQL query and
+	// ResourceGroup matches the objects by terms.
+	SavedSearch   string         `yaml:"savedSearch,omitempty" json:"savedSearch,omitempty"`
+	Query         string         `yaml:"query,omitempty" json:"query,omitempty"`
+	ResourceGroup *ResourceGroup `yaml:"resourceGroup,omitempty" json:"resourceGroup,omitempty"`
+	// Trigger decides when the alert rule is evaluated.
+	Trigger AlertTrigger `yaml:"trigger" json:"trigger"`
+	// Condition decides when the alert rule fires.
+	Condition AlertCondition `yaml:"condition" json:"condition"`
+	// Channels are notified when the alert rule fires.
+	Channels []AlertChannel `yaml:"channels" json:"channels"`
+	// DedupeWindow is how long the same notification is not sent again,
+	// e.g. while a threshold condition keeps firing.
+	DedupeWindow *metav1.Duration `yaml:"dedupeWindow,omitempty" json:"dedupeWindow,omitempty" swaggertype:"string"`
+	// SilencedUntil suppresses the notifications until the time.
+	SilencedUntil *metav1.Time `yaml:"silencedUntil,omitempty" json:"silencedUntil,omitempty"`
+	// Disabled alert rules are not evaluated.
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	// Status is the state of the last evaluation of the alert rule.
+	Status AlertRuleStatus `yaml:"status" json:"status"`
+	// CreatedAt is the timestamp of the creation of the alert rule.
+	CreatedAt *metav1.Time `yaml:"createdAt,omitempty" json:"createdAt,omitempty"`
+	// UpdatedAt is the timestamp of the last update of the alert rule.
+	UpdatedAt *metav1.Time `yaml:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
+
+// AlertRuleAccess restricts the objects an alert rule matches to the union of
+// its scopes, no object is matched if it has no scopes.
+type AlertRuleAccess struct {
+	Scopes []AlertRuleAccessScope `yaml:"scopes" json:"scopes"`
+}
+
+// AlertRuleAccessScope is a set of objects an alert rule can match. Multiple
+// fields are ANDed, and multiple values of a single field are ORed. An empty
+// field selects all values.
+type AlertRuleAccessScope struct {
+	Clusters   []string `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+	Namespaces []string `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+	Kinds      []string `yaml:"kinds,omitempty" json:"kinds,omitempty"`
+}
+
+// AlertTrigger decides when an alert rule is evaluated.
+type AlertTrigger struct {
+	// Type is either schedule or sync.
+	Type string `yaml:"type" json:"type"`
+	// Interval is the interval of the schedule trigger.
+	Interval *metav1.Duration `yaml:"interval,omitempty" json:"interval,omitempty" swaggertype:"string"`
+}
+
+// AlertCondition decides when an alert rule fires.
+type AlertCondition struct {
+	// Type is either threshold, appear, disappear or change.
+	Type string `yaml:"type" json:"type"`
+	// Operator compares the number of matching objects with the threshold,
+	// one of >, >=, <, <=, == and !=.
+	Operator string `yaml:"operator,omitempty" json:"operator,omitempty"`
+	// Threshold is the number of matching objects compared with.
+	Threshold int `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// AlertChannel is a destination of the notifications of alert rules.
+type AlertChannel struct {
+	// Type is either webhook, slack or email.
+	Type string `yaml:"type" json:"type"`
+	// URL is the URL the webhook and slack notifications are posted to.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// To are the recipients of the email notifications.
+	To []string `yaml:"to,omitempty" json:"to,omitempty"`
+}
+
+// AlertRuleStatus is the state of the last evaluation of an alert rule.
+type AlertRuleStatus struct {
+	// LastEvaluatedAt is the time the alert rule was last evaluated.
+	LastEvaluatedAt *metav1.Time `yaml:"lastEvaluatedAt,omitempty" json:"lastEvaluatedAt,omitempty"`
+	// LastSyncAt is the time the resources were last synced when the alert
+	// rule was last evaluated, in microseconds so that the syncs within the
+	// same second are told apart.
+	LastSyncAt *metav1.MicroTime `yaml:"lastSyncAt,omitempty" json:"lastSyncAt,omitempty" swaggertype:"string"`
+	// Count is the number of matching objects.
+	Count int `yaml:"count" json:"count"`
+	// Matches are the keys of the matching objects, which the objects
+	// appearing and disappearing are found by. They are not tracked if too
+	// many objects match.
+	Matches []string `yaml:"matches,omitempty" json:"matches,omitempty"`
+	// Firing reports whether the condition fired in the last evaluation.
+	Firing bool `yaml:"firing" json:"firing"`
+	// LastFiredAt is the time the alert rule last sent notifications.
+	LastFiredAt *metav1.Time `yaml:"lastFiredAt,omitempty" json:"lastFiredAt,omitempty"`
+	// LastFingerprint identifies the last sent notification, for dedupe.
+	LastFingerprint string `yaml:"lastFingerprint,omitempty" json:"lastFingerprint,omitempty"`
+	// LastError is the error of the last evaluation or notification.
+	LastError string `yaml:"lastError,omitempty" json:"lastError,omitempty"`
+}
+
+// AlertNotification is sent to the channels of an alert rule when it fires.
+type AlertNotification struct {
+	// Rule is the name of the alert rule.
+	Rule string `yaml:"rule" json:"rule"`
+	// Description is the description of the alert rule.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Summary is a human-readable summary of why the alert rule fired.
+	Summary string `yaml:"summary" json:"summary"`
+	// Count is the number of matching objects.
+	Count int `yaml:"count" json:"count"`
+	// Appeared and Disappeared are the keys of the objects which started
+	// and stopped matching the query.
+	Appeared    []string `yaml:"appeared,omitempty" json:"appeared,omitempty"`
+	Disappeared []string `yaml:"disappeared,omitempty" json:"disappeared,omitempty"`
+	// FiredAt is the time the alert rule fired.
+	FiredAt time.Time `yaml:"firedAt" json:"firedAt"`
+}
+
+// Validate checks if the alert rule is valid.
+func (e *AlertRule) Validate() error {
+	if e == nil {
+		return fmt.Errorf("alert rule is nil")
+	}
+	if e.Name == "" {
+		return fmt.Errorf("alert rule must have a name")
+	}
+
+	sources := 0
+	if e.SavedSearch != "" {
+		sources++
+	}
+	if strings.TrimSpace(e.Query) != "" {
+		sources++
+	}
+	if e.ResourceGroup != nil {
+		sources++
+	}
+	if sources != 1 {
+		return fmt.Errorf("alert rule must have exactly one of savedSearch, query and resourceGroup")
+	}
+
+	switch e.Trigger.Type {
+	case AlertTriggerSchedule:
+		if e.Trigger.Interval == nil || e.Trigger.Interval.Duration <= 0 {
+			return fmt.Errorf("alert rule with schedule trigger must have a positive interval")
+		}
+	case AlertTriggerSync:
+	default:
+		return fmt.Errorf("unsupported trigger %q of alert rule, expected schedule or sync", e.Trigger.Type)
+	}
+
+	switch e.Condition.Type {
+	case AlertConditionThreshold:
+		if !contains(alertOperators, e.Condition.Operator) {
+			return fmt.Errorf("unsupported operator %q of alert rule, expected one of %s", e.Condition.Operator, strings.Join(alertOperators, ", "))
+		}
+	case AlertConditionAppear, AlertConditionDisappear, AlertConditionChange:
+	default:
+		return fmt.Errorf("unsupported condition %q of alert rule, expected threshold, appear, disappear or change", e.Condition.Type)
+	}
+
+	if len(e.Channels) == 0 {
+		return fmt.Errorf("alert rule must have at least one channel")
+	}
+	for _, channel := range e.Channels {
+		if err := channel.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if e.DedupeWindow != nil && e.DedupeWindow.Duration < 0 {
+		return fmt.Errorf("dedupe window of alert rule cannot be negative")
+	}
+	return nil
+}
+
+// Validate checks if the alert channel is valid.
+func (c *AlertChannel) Validate() error {
+	switch c.Type {
+	case AlertChannelWebhook, AlertChannelSlack:
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s channel must have a http or https url", c.Type)
+		}
+	case AlertChannelEmail:
+		if len(c.To) == 0 {
+			return fmt.Errorf("email channel must have recipients")
+		}
+	default:
+		return fmt.Errorf("unsupported channel %q, expected webhook, slack or email", c.Type)
+	}
+	return nil
+}
+
+// Silenced reports whether the notifications of the alert rule are
+// suppressed at the time.
+func (e *AlertRule) Silenced(now time.Time) bool {
+	return e.SilencedUntil != nil && now.Before(e.SilencedUntil.Time)
+}
+
+// Matches reports whether the count matches the threshold condition.
+func (c *AlertCondition) Matches(count int) bool {
+	switch c.Operator {
+	case ">":
+		return count > c.Threshold
+	case ">=":
+		return count >= c.Threshold
+	case "<":
+		return count < c.Threshold
+	case "<=":
+		return count <= c.Threshold
+	case "==":
+		return count == c.Threshold
+	case "!=":
+		return count != c.Threshold
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
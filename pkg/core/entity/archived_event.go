@@ -0,0 +1,65 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchivedEvent is a series of Kubernetes events archived by the syncer. The
+// occurrences of the same event on the same object are merged into a single
+// series, which is kept after the events expire in the cluster.
+type ArchivedEvent struct {
+	// ID is the id of the series, which is derived from the involved object
+	// and the event so that the same event is archived once.
+	ID string `yaml:"id" json:"id"`
+	// InvolvedObject locates the object the event is about, its cluster is
+	// the cluster the event is reported in.
+	InvolvedObject ResourceGroup `yaml:"involvedObject" json:"involvedObject"`
+	// UID is the uid of the involved object, which tells apart the objects
+	// recreated with the same name.
+	UID string `yaml:"uid,omitempty" json:"uid,omitempty"`
+	// Type is the type of the event, either Normal or Warning.
+	Type string `yaml:"type" json:"type"`
+	// Reason is the short machine-readable reason of the event.
+	Reason string `yaml:"reason" json:"reason"`
+	// Message is the human-readable description of the event.
+	Message string `yaml:"message" json:"message"`
+	// Source is the component which reported the event.
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+	// Count is the number of occurrences of the event in the series.
+	Count int `yaml:"count" json:"count"`
+	// FirstTimestamp is the time the event first occurred.
+	FirstTimestamp *metav1.Time `yaml:"firstTimestamp,omitempty" json:"firstTimestamp,omitempty"`
+	// LastTimestamp is the time the event last occurred.
+	LastTimestamp *metav1.Time `yaml:"lastTimestamp,omitempty" json:"lastTimestamp,omitempty"`
+}
+
+// ArchivedEventID returns the id of the series of the event, which is stable
+// for the same event reported on the same object.
+func ArchivedEventID(object ResourceGroup, uid, eventType, reason, message string) string {
+	h := sha256.New()
+	for _, s := range []string{
+		object.Cluster, object.APIVersion, object.Kind, object.Namespace, object.Name, uid,
+		eventType, reason, message,
+	} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,64 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditIssue is an issue found on a single resource by a scanner, persisted
+// along with the time it was first and last observed by the background audit.
+type AuditIssue struct {
+	// ID is the id of the audit issue, which is derived from the resource and
+	// the issue so that the same issue on the same resource is stored once.
+	ID string `yaml:"id" json:"id"`
+	// Resource locates the resource on which the issue is found.
+	Resource ResourceGroup `yaml:"resource" json:"resource"`
+	// Labels are the labels of the resource when the issue is last seen, so
+	// that audit exceptions selecting resources by labels can be applied.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Scanner is the name of the scanner which reported the issue.
+	Scanner string `yaml:"scanner" json:"scanner"`
+	// Severity is the severity level name of the issue, e.g. High.
+	Severity string `yaml:"severity" json:"severity"`
+	// Title is a brief summary of the issue.
+	Title string `yaml:"title" json:"title"`
+	// Message is a detailed description of the issue.
+	Message string `yaml:"message" json:"message"`
+	// FirstSeen is the timestamp when the issue is found for the first time.
+	FirstSeen *metav1.Time `yaml:"firstSeen,omitempty" json:"firstSeen,omitempty"`
+	// LastSeen is the timestamp of the latest audit which found the issue.
+	LastSeen *metav1.Time `yaml:"lastSeen,omitempty" json:"lastSeen,omitempty"`
+	// ResolvedAt is the timestamp of the first audit which no longer found
+	// the issue, it is empty while the issue is still open.
+	ResolvedAt *metav1.Time `yaml:"resolvedAt,omitempty" json:"resolvedAt,omitempty"`
+}
+
+// AuditIssueID returns the id of the audit issue, which is stable for the
+// same issue reported on the same resource.
+func AuditIssueID(resource ResourceGroup, scanner, title, message string) string {
+	h := sha256.New()
+	for _, s := range []string{
+		resource.Cluster, resource.APIVersion, resource.Kind, resource.Namespace, resource.Name,
+		scanner, title, message,
+	} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
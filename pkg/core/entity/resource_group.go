@@ -50,6 +50,49 @@ type ResourceGroup struct {
 	Labels      map[string]string `json:"labels,omitempty"      yaml:"labels,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	Status      string            `json:"status,omitempty"      yaml:"status,omitempty"`
+	// Filter restricts the resources of the group, it is the filter of the
+	// resource group rule the group is listed by.
+	Filter *ResourceGroupFilter `json:"filter,omitempty" yaml:"filter,omitempty"`
+}
+
+// IsResourceGroupField reports whether the field is a field of resource
+// groups, which is one of cluster, apiVersion, kind, namespace and name, or a
+// label or an annotation prefixed with "labels." or "annotations.".
+func IsResourceGroupField(field string) bool {
+	switch field {
+	case "cluster", "apiVersion", "kind", "namespace", "name":
+		return true
+	}
+	for _, prefix := range []string{"labels.", "annotations."} {
+		if strings.HasPrefix(field, prefix) && len(field) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldValue returns the value of the field of the ResourceGroup, the field
+// is named as by IsResourceGroupField.
+func (rg *ResourceGroup) FieldValue(field string) string {
+	switch field {
+	case "cluster":
+		return rg.Cluster
+	case "apiVersion":
+		return rg.APIVersion
+	case "kind":
+		return rg.Kind
+	case "namespace":
+		return rg.Namespace
+	case "name":
+		return rg.Name
+	}
+	if key, ok := strings.CutPrefix(field, "labels."); ok {
+		return rg.Labels[key]
+	}
+	if key, ok := strings.CutPrefix(field, "annotations."); ok {
+		return rg.Annotations[key]
+	}
+	return ""
 }
 
 // Hash returns a unique string representation of the ResourceGroup that can be
@@ -86,6 +129,9 @@ func (rg *ResourceGroup) Hash() ResourceGroupHash {
 		hash.WriteString(k + ":")
 		hash.WriteString(rg.Annotations[k] + "-")
 	}
+	if where := rg.Filter.ToSQL(); where != "" {
+		hash.WriteString("filter:" + where)
+	}
 
 	return ResourceGroupHash(hash.String())
 }
@@ -158,6 +204,10 @@ func (rg *ResourceGroup) ToSQL() string {
 		conditions = append(conditions, fmt.Sprintf("`labels.%s`='%s'", k, v))
 	}
 
+	if where := rg.Filter.ToSQL(); where != "" {
+		conditions = append(conditions, where)
+	}
+
 	if len(conditions) > 0 {
 		return "SELECT * from resources WHERE " + strings.Join(conditions, " AND ")
 	} else {
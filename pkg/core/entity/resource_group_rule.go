@@ -16,6 +16,9 @@ package entity
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +32,12 @@ type ResourceGroupRule struct {
 	// Description is a human-readable description of the resourceGroupRule.
 	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
 	Fields      []string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	// Filter restricts the resources grouped by the resourceGroupRule, all
+	// resources are grouped if it is nil.
+	Filter *ResourceGroupFilter `yaml:"filter,omitempty" json:"filter,omitempty"`
+	// Metadata describes the groups of the resourceGroupRule, such as their
+	// owners and links.
+	Metadata []ResourceGroupMetadata `yaml:"metadata,omitempty" json:"metadata,omitempty"`
 	// CreatedAt is the timestamp of the created for the resourceGroupRule.
 	CreatedAt *metav1.Time `yaml:"createdAt,omitempty" json:"createdAt,omitempty"`
 	// CreatedAt is the timestamp of the updated for the resourceGroupRule.
@@ -37,6 +46,67 @@ type ResourceGroupRule struct {
 	DeletedAt *metav1.Time `yaml:"deletedAt,omitempty" json:"deletedAt,omitempty"`
 }
 
+// ResourceGroupFilter selects the resources grouped by a resourceGroupRule,
+// the resources must match both the terms and the query.
+type ResourceGroupFilter struct {
+	// Terms match the resources whose field equals any of the values, such
+	// as {"kind": ["Deployment"], "labels.env": ["prod"]}.
+	Terms map[string][]string `yaml:"terms,omitempty" json:"terms,omitempty"`
+	// Query is a SQL where clause, such as "cluster LIKE 'prod-%'".
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+}
+
+// IsEmpty reports whether the filter selects all resources.
+func (f *ResourceGroupFilter) IsEmpty() bool {
+	return f == nil || (len(f.Terms) == 0 && strings.TrimSpace(f.Query) == "")
+}
+
+// ToSQL generates the SQL conditions of the filter, which are joined with
+// AND. It returns an empty string if the filter selects all resources.
+func (f *ResourceGroupFilter) ToSQL() string {
+	if f.IsEmpty() {
+		return ""
+	}
+
+	conditions := []string{}
+	fields := make([]string, 0, len(f.Terms))
+	for field := range f.Terms {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		values := make([]string, 0, len(f.Terms[field]))
+		for _, v := range f.Terms[field] {
+			values = append(values, fmt.Sprintf("'%s'", v))
+		}
+		if strings.Contains(field, ".") {
+			field = "`" + field + "`"
+		}
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", field, strings.Join(values, ",")))
+	}
+	if where := strings.TrimSpace(f.Query); where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// ResourceGroupMetadata describes the groups of a resourceGroupRule.
+type ResourceGroupMetadata struct {
+	// Match selects the groups by the values of their fields, such as
+	// {"labels.app": "payments"}, all groups are selected if it is empty.
+	Match map[string]string `yaml:"match,omitempty" json:"match,omitempty"`
+	// Owners are the users or teams who own the groups.
+	Owners []string `yaml:"owners,omitempty" json:"owners,omitempty"`
+	// Links are the links of the groups, such as dashboards and runbooks.
+	Links []ResourceGroupLink `yaml:"links,omitempty" json:"links,omitempty"`
+}
+
+// ResourceGroupLink is a link of resource groups.
+type ResourceGroupLink struct {
+	Title string `yaml:"title" json:"title"`
+	URL   string `yaml:"url" json:"url"`
+}
+
 // Validate checks if the resourceGroupRule is valid.
 // It returns an error if the resourceGroupRule is not valid.
 func (e *ResourceGroupRule) Validate() error {
@@ -48,9 +118,66 @@ func (e *ResourceGroupRule) Validate() error {
 		return fmt.Errorf("resource group rule must have a name")
 	}
 
+	if e.Filter != nil {
+		for field, values := range e.Filter.Terms {
+			if !IsResourceGroupField(field) {
+				return fmt.Errorf("unsupported filter field %q of resource group rule", field)
+			}
+			if len(values) == 0 {
+				return fmt.Errorf("filter field %q of resource group rule must have values", field)
+			}
+		}
+	}
+
+	for _, metadata := range e.Metadata {
+		for field := range metadata.Match {
+			if !IsResourceGroupField(field) {
+				return fmt.Errorf("unsupported metadata match field %q of resource group rule", field)
+			}
+		}
+		for _, link := range metadata.Links {
+			u, err := url.Parse(link.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("link %q of resource group rule must have a http or https url", link.Title)
+			}
+		}
+	}
+
 	return nil
 }
 
+// MetadataOf returns the metadata of the resource group, which merges the
+// owners and links of all metadata matching the group. It returns nil if no
+// metadata matches the group.
+func (e *ResourceGroupRule) MetadataOf(rg *ResourceGroup) *ResourceGroupMetadata {
+	var merged *ResourceGroupMetadata
+	seen := map[string]bool{}
+	for _, metadata := range e.Metadata {
+		matched := true
+		for field, value := range metadata.Match {
+			if rg.FieldValue(field) != value {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		if merged == nil {
+			merged = &ResourceGroupMetadata{}
+		}
+		for _, owner := range metadata.Owners {
+			if !seen[owner] {
+				seen[owner] = true
+				merged.Owners = append(merged.Owners, owner)
+			}
+		}
+		merged.Links = append(merged.Links, metadata.Links...)
+	}
+	return merged
+}
+
 // UUID() returns a randomly generated UUID string.
 func UUID() string {
 	uuid := uuid.New()
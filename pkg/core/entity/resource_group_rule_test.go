@@ -44,6 +44,55 @@ func TestResourceGroupRule_Validate(t *testing.T) {
 			rule:    &ResourceGroupRule{},
 			wantErr: fmt.Errorf("resource group rule must have a name"),
 		},
+		{
+			name: "ValidFilterAndMetadata",
+			rule: &ResourceGroupRule{
+				Name:   "app",
+				Fields: []string{"labels.app"},
+				Filter: &ResourceGroupFilter{
+					Terms: map[string][]string{"kind": {"Deployment"}, "labels.env": {"prod"}},
+					Query: "cluster LIKE 'prod-%'",
+				},
+				Metadata: []ResourceGroupMetadata{{
+					Match:  map[string]string{"labels.app": "payments"},
+					Owners: []string{"team-payments"},
+					Links:  []ResourceGroupLink{{Title: "Dashboard", URL: "https://grafana.example.com/d/payments"}},
+				}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "UnsupportedFilterField",
+			rule: &ResourceGroupRule{
+				Name:   "app",
+				Filter: &ResourceGroupFilter{Terms: map[string][]string{"labels.": {"prod"}}},
+			},
+			wantErr: fmt.Errorf("unsupported filter field \"labels.\" of resource group rule"),
+		},
+		{
+			name: "FilterFieldWithoutValues",
+			rule: &ResourceGroupRule{
+				Name:   "app",
+				Filter: &ResourceGroupFilter{Terms: map[string][]string{"kind": {}}},
+			},
+			wantErr: fmt.Errorf("filter field \"kind\" of resource group rule must have values"),
+		},
+		{
+			name: "UnsupportedMetadataMatchField",
+			rule: &ResourceGroupRule{
+				Name:     "app",
+				Metadata: []ResourceGroupMetadata{{Match: map[string]string{"status": "Ready"}}},
+			},
+			wantErr: fmt.Errorf("unsupported metadata match field \"status\" of resource group rule"),
+		},
+		{
+			name: "InvalidLink",
+			rule: &ResourceGroupRule{
+				Name:     "app",
+				Metadata: []ResourceGroupMetadata{{Links: []ResourceGroupLink{{Title: "Runbook", URL: "runbook"}}}},
+			},
+			wantErr: fmt.Errorf("link \"Runbook\" of resource group rule must have a http or https url"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -58,6 +107,46 @@ func TestResourceGroupRule_Validate(t *testing.T) {
 	}
 }
 
+func TestResourceGroupRule_MetadataOf(t *testing.T) {
+	rule := &ResourceGroupRule{
+		Name:   "app",
+		Fields: []string{"cluster", "labels.app"},
+		Metadata: []ResourceGroupMetadata{
+			{Owners: []string{"sre"}, Links: []ResourceGroupLink{{Title: "Runbook", URL: "https://runbook.example.com"}}},
+			{Match: map[string]string{"labels.app": "payments"}, Owners: []string{"team-payments", "sre"}},
+			{Match: map[string]string{"labels.app": "payments", "cluster": "prod-1"}, Links: []ResourceGroupLink{{Title: "Dashboard", URL: "https://grafana.example.com"}}},
+		},
+	}
+
+	require.Equal(t, &ResourceGroupMetadata{
+		Owners: []string{"sre", "team-payments"},
+		Links: []ResourceGroupLink{
+			{Title: "Runbook", URL: "https://runbook.example.com"},
+			{Title: "Dashboard", URL: "https://grafana.example.com"},
+		},
+	}, rule.MetadataOf(&ResourceGroup{Cluster: "prod-1", Labels: map[string]string{"app": "payments"}}))
+	require.Equal(t, &ResourceGroupMetadata{
+		Owners: []string{"sre"},
+		Links:  []ResourceGroupLink{{Title: "Runbook", URL: "https://runbook.example.com"}},
+	}, rule.MetadataOf(&ResourceGroup{Cluster: "prod-2", Labels: map[string]string{"app": "orders"}}))
+	require.Nil(t, (&ResourceGroupRule{}).MetadataOf(&ResourceGroup{Cluster: "prod-1"}))
+}
+
+func TestResourceGroupFilter_IsEmpty(t *testing.T) {
+	require.True(t, (*ResourceGroupFilter)(nil).IsEmpty())
+	require.True(t, (&ResourceGroupFilter{Query: " "}).IsEmpty())
+	require.False(t, (&ResourceGroupFilter{Terms: map[string][]string{"kind": {"Pod"}}}).IsEmpty())
+	require.False(t, (&ResourceGroupFilter{Query: "kind = 'Pod'"}).IsEmpty())
+}
+
+func TestResourceGroupFilter_ToSQL(t *testing.T) {
+	require.Equal(t, "", (*ResourceGroupFilter)(nil).ToSQL())
+	require.Equal(t, "kind IN ('Deployment') AND `labels.env` IN ('prod','staging') AND (cluster LIKE 'prod-%')", (&ResourceGroupFilter{
+		Terms: map[string][]string{"labels.env": {"prod", "staging"}, "kind": {"Deployment"}},
+		Query: " cluster LIKE 'prod-%' ",
+	}).ToSQL())
+}
+
 func TestUUID(t *testing.T) {
 	uuid := UUID()
 	require.NotEmpty(t, uuid)
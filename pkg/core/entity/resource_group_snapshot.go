@@ -0,0 +1,44 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceGroupSnapshot is the membership of a resource group of a resource
+// group rule recorded at a point in time.
+type ResourceGroupSnapshot struct {
+	// ID is the id of the resource group snapshot.
+	ID string `yaml:"id" json:"id"`
+	// Rule is the name of the resource group rule of the resource group.
+	Rule string `yaml:"rule" json:"rule"`
+	// ResourceGroup is the resource group whose membership is recorded.
+	ResourceGroup ResourceGroup `yaml:"resourceGroup" json:"resourceGroup"`
+	// Members are the resources in the resource group, identified by their
+	// cluster, apiVersion, kind, namespace and name. They are omitted when
+	// the snapshots are listed.
+	Members []ResourceGroup `yaml:"members,omitempty" json:"members,omitempty"`
+	// MembersTruncated reports whether only the first of the resources in
+	// the resource group are recorded as the members, as the resource group
+	// has too many resources. The total and the counts are still complete.
+	MembersTruncated bool `yaml:"membersTruncated,omitempty" json:"membersTruncated,omitempty"`
+	// ResourceTotal is the number of resources in the resource group.
+	ResourceTotal int `yaml:"resourceTotal" json:"resourceTotal"`
+	// CountByGVK is the number of resources in the resource group by GVK.
+	CountByGVK map[string]int `yaml:"countByGVK,omitempty" json:"countByGVK,omitempty"`
+	// Timestamp is the time when the membership is recorded.
+	Timestamp *metav1.Time `yaml:"timestamp,omitempty" json:"timestamp,omitempty"`
+}
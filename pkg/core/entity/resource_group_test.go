@@ -78,6 +78,23 @@ func TestResourceGroupHash(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ResourceGroupWithFilter",
+			rg: ResourceGroup{
+				Cluster: "test-cluster",
+				Filter:  &ResourceGroupFilter{Terms: map[string][]string{"kind": {"Pod"}}},
+			},
+			wantHash: "test-cluster-----filter:kind IN ('Pod')",
+			wantEqual: []ResourceGroup{
+				{
+					Cluster: "test-cluster",
+					Filter:  &ResourceGroupFilter{Terms: map[string][]string{"kind": {"Pod"}}},
+				},
+			},
+			wantNotEqual: []ResourceGroup{
+				{Cluster: "test-cluster"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +114,36 @@ func TestResourceGroupHash(t *testing.T) {
 	}
 }
 
+func TestResourceGroupFieldValue(t *testing.T) {
+	rg := &ResourceGroup{
+		Cluster:     "prod-1",
+		APIVersion:  "apps/v1",
+		Kind:        "Deployment",
+		Namespace:   "payments",
+		Name:        "api",
+		Labels:      map[string]string{"app": "payments"},
+		Annotations: map[string]string{"owner": "sre"},
+	}
+	for field, want := range map[string]string{
+		"cluster":           "prod-1",
+		"apiVersion":        "apps/v1",
+		"kind":              "Deployment",
+		"namespace":         "payments",
+		"name":              "api",
+		"labels.app":        "payments",
+		"labels.env":        "",
+		"annotations.owner": "sre",
+		"status":            "",
+	} {
+		require.Equal(t, want, rg.FieldValue(field), field)
+	}
+
+	require.True(t, IsResourceGroupField("labels.app"))
+	require.True(t, IsResourceGroupField("apiVersion"))
+	require.False(t, IsResourceGroupField("labels."))
+	require.False(t, IsResourceGroupField("status"))
+}
+
 func TestResourceGroupToSQL(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -132,6 +179,17 @@ func TestResourceGroupToSQL(t *testing.T) {
 			},
 			wantSQL: "SELECT * from resources WHERE cluster='test-cluster' AND apiVersion='v1' AND kind='Pod' AND namespace='default' AND name='test-pod'",
 		},
+		{
+			name: "ResourceGroupWithFilter",
+			rg: ResourceGroup{
+				Cluster: "test-cluster",
+				Filter: &ResourceGroupFilter{
+					Terms: map[string][]string{"kind": {"Pod", "Service"}},
+					Query: "namespace LIKE 'app-%'",
+				},
+			},
+			wantSQL: "SELECT * from resources WHERE cluster='test-cluster' AND kind IN ('Pod','Service') AND (namespace LIKE 'app-%')",
+		},
 		{
 			name:    "EmptyResourceGroup",
 			rg:      ResourceGroup{},
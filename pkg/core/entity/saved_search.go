@@ -0,0 +1,125 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Visibilities of saved searches.
+const (
+	// SavedSearchVisibilityPrivate saved searches are only visible to their
+	// owners.
+	SavedSearchVisibilityPrivate = "private"
+	// SavedSearchVisibilityShared saved searches are visible to all users.
+	SavedSearchVisibilityShared = "shared"
+)
+
+// Formats of the results of saved searches.
+const (
+	SavedSearchFormatOrigin        = "origin"
+	SavedSearchFormatCustomColumns = "custom-columns"
+)
+
+// SavedSearch is a named search query which can be run again and shared
+// with other users.
+type SavedSearch struct {
+	// ID is the unique id of the saved search.
+	ID string `yaml:"id" json:"id"`
+	// Name is the unique name of the saved search.
+	Name string `yaml:"name" json:"name"`
+	// Description is a human-readable description of the saved search.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Owner is the user who created the saved search.
+	Owner string `yaml:"owner" json:"owner"`
+	// Pattern is the pattern of the query, either sql, dsl or nl.
+	Pattern string `yaml:"pattern" json:"pattern"`
+	// Query is the search query.
+	Query string `yaml:"query" json:"query"`
+	// Format is the format of the results, either origin or custom-columns.
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+	// Columns are the <header>:<json-path-expr> pairs of the custom-columns
+	// format.
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	// Visibility is either private or shared.
+	Visibility string `yaml:"visibility" json:"visibility"`
+	// FavoritedBy are the users who marked the saved search as a favorite.
+	FavoritedBy []string `yaml:"favoritedBy,omitempty" json:"favoritedBy,omitempty"`
+	// CreatedAt is the timestamp of the creation of the saved search.
+	CreatedAt *metav1.Time `yaml:"createdAt,omitempty" json:"createdAt,omitempty"`
+	// UpdatedAt is the timestamp of the last update of the saved search.
+	UpdatedAt *metav1.Time `yaml:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
+
+// Validate checks if the saved search is valid.
+func (e *SavedSearch) Validate() error {
+	if e == nil {
+		return fmt.Errorf("saved search is nil")
+	}
+	if e.Name == "" {
+		return fmt.Errorf("saved search must have a name")
+	}
+	if strings.TrimSpace(e.Query) == "" {
+		return fmt.Errorf("saved search must have a query")
+	}
+	switch e.Pattern {
+	case "sql", "dsl", "nl":
+	default:
+		return fmt.Errorf("unsupported pattern %q of saved search, expected sql, dsl or nl", e.Pattern)
+	}
+	switch e.Format {
+	case "", SavedSearchFormatOrigin:
+	case SavedSearchFormatCustomColumns:
+		if len(e.Columns) == 0 {
+			return fmt.Errorf("saved search with custom-columns format must have columns")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q of saved search, expected origin or custom-columns", e.Format)
+	}
+	switch e.Visibility {
+	case SavedSearchVisibilityPrivate, SavedSearchVisibilityShared:
+	default:
+		return fmt.Errorf("unsupported visibility %q of saved search, expected private or shared", e.Visibility)
+	}
+	return nil
+}
+
+// FormatSpec returns the format of the results in the syntax of the format
+// parameter of the search API, e.g. custom-columns=NAME:metadata.name.
+func (e *SavedSearch) FormatSpec() string {
+	if e.Format == SavedSearchFormatCustomColumns {
+		return e.Format + "=" + strings.Join(e.Columns, ",")
+	}
+	return e.Format
+}
+
+// VisibleTo reports whether the saved search is visible to the user.
+func (e *SavedSearch) VisibleTo(user string) bool {
+	return e.Visibility == SavedSearchVisibilityShared || e.Owner == user
+}
+
+// IsFavoriteOf reports whether the user marked the saved search as a
+// favorite.
+func (e *SavedSearch) IsFavoriteOf(user string) bool {
+	for _, u := range e.FavoritedBy {
+		if u == user {
+			return true
+		}
+	}
+	return false
+}
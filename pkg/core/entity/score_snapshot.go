@@ -0,0 +1,40 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScoreSnapshot is the security score of a resource group recorded by the
+// background audit at a point in time.
+type ScoreSnapshot struct {
+	// ID is the id of the score snapshot.
+	ID string `yaml:"id" json:"id"`
+	// ResourceGroup is the resource group which the score is calculated for.
+	ResourceGroup ResourceGroup `yaml:"resourceGroup" json:"resourceGroup"`
+	// Score is the score of the resource group at the time.
+	Score float64 `yaml:"score" json:"score"`
+	// ResourceTotal is the number of resources in the resource group.
+	ResourceTotal int `yaml:"resourceTotal" json:"resourceTotal"`
+	// IssuesTotal is the number of open issues in the resource group.
+	IssuesTotal int `yaml:"issuesTotal" json:"issuesTotal"`
+	// SuppressedTotal is the number of open issues suppressed by exceptions.
+	SuppressedTotal int `yaml:"suppressedTotal" json:"suppressedTotal"`
+	// SeverityStatistic is the number of open issues by severity level.
+	SeverityStatistic map[string]int `yaml:"severityStatistic,omitempty" json:"severityStatistic,omitempty"`
+	// Timestamp is the time when the score is recorded.
+	Timestamp *metav1.Time `yaml:"timestamp,omitempty" json:"timestamp,omitempty"`
+}
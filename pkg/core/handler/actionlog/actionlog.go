@@ -0,0 +1,102 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionlog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+)
+
+// List returns an HTTP handler function that lists the action logs of the
+// users, the latest first. It is served under the admin APIs since the logs
+// reveal the activity of all users.
+//
+// @Summary      List the action logs of users.
+// @Description  This endpoint returns the recorded actions of users, filtered by user, action, cluster, outcome and time.
+// @Tags         actionlog
+// @Produce      json
+// @Param        user      query     string                   false  "The user who performed the actions"
+// @Param        action    query     string                   false  "The name of the action, e.g. cluster.create"
+// @Param        cluster   query     string                   false  "The cluster the actions are performed on"
+// @Param        outcome   query     string                   false  "The outcome of the actions, either success or failure"
+// @Param        from      query     string                   false  "The start time in RFC3339 format"
+// @Param        to        query     string                   false  "The end time in RFC3339 format"
+// @Param        page      query     string                   false  "The current page to fetch. Default to 1"
+// @Param        pageSize  query     string                   false  "The size of the page. Default to 20"
+// @Success      200       {object}  storage.ActionLogResult  "The action logs"
+// @Failure      400       {string}  string                   "Bad Request"
+// @Failure      401       {string}  string                   "Unauthorized"
+// @Failure      403       {string}  string                   "Forbidden"
+// @Failure      500       {string}  string                   "Internal Server Error"
+// @Router       /admin-api/v1/action-logs [get]
+func List(actionLogStorage storage.ActionLogStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+		query := r.URL.Query()
+
+		filter := &storage.ActionLogFilter{
+			User:    query.Get("user"),
+			Action:  query.Get("action"),
+			Cluster: query.Get("cluster"),
+			Outcome: query.Get("outcome"),
+		}
+		var err error
+		if filter.From, err = parseTime(query.Get("from")); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if filter.To, err = parseTime(query.Get("to")); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+		if page < 1 {
+			page = 1
+		}
+		if pageSize < 1 {
+			pageSize = 20
+		}
+
+		logger.Info("Listing action logs...", "filter", filter, "page", page, "pageSize", pageSize)
+		result, err := actionLogStorage.ListActionLogs(ctx, filter, &storage.Pagination{Page: page, PageSize: pageSize})
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, result)
+	}
+}
+
+// parseTime parses an optional time in RFC3339 format.
+func parseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 format: %w", v, err)
+	}
+	return t, nil
+}
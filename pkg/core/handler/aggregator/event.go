@@ -27,6 +27,8 @@ import (
 	"k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/manager/ai"
 	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
@@ -76,6 +78,10 @@ func GetEvents(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) ht
 			writeEventSSEError(w, "missing required parameters")
 			return
 		}
+		if err := authz.Authorize(ctx, entity.ResourceGroup{Cluster: cluster, Namespace: namespace, Kind: kind}); err != nil {
+			writeEventSSEError(w, err.Error())
+			return
+		}
 
 		// Build multi-cluster client
 		client, err := multicluster.BuildMultiClusterClient(ctx, c.LoopbackClientConfig, cluster)
@@ -24,6 +24,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/manager/ai"
 	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
@@ -83,6 +85,10 @@ func GetPodLogs(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) h
 			writeLogSSEError(w, "cluster, namespace and name are required")
 			return
 		}
+		if err := authz.Authorize(ctx, entity.ResourceGroup{Cluster: cluster, Namespace: namespace, Kind: "Pod"}); err != nil {
+			writeLogSSEError(w, err.Error())
+			return
+		}
 
 		// Build multi-cluster client
 		client, err := multicluster.BuildMultiClusterClient(ctx, c.LoopbackClientConfig, cluster)
@@ -0,0 +1,160 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/logs"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"k8s.io/utils/pointer"
+)
+
+// GetWorkloadLogs returns an HTTP handler function that streams the
+// interleaved logs of multiple pods using Server-Sent Events
+//
+// @Summary      Stream the logs of multiple pods using Server-Sent Events
+// @Description  This endpoint streams the logs of the pods of a workload, of the pods selected by a label selector, or of the pods in a resource group, prefixed by pod and container. New replicas and restarted containers are followed.
+// @Tags         insight
+// @Produce      text/event-stream
+// @Param        cluster        query     string  false  "The cluster name, required for workloads and label selectors"
+// @Param        namespace      query     string  false  "The namespace name, required for workloads and label selectors"
+// @Param        kind           query     string  false  "The kind of the workload, one of Pod, Deployment, StatefulSet, DaemonSet, ReplicaSet and Job"
+// @Param        name           query     string  false  "The name of the workload, or of the pods in the resource group"
+// @Param        labelSelector  query     string  false  "The label selector of the pods, such as 'app=foo'"
+// @Param        labels         query     string  false  "The labels of the pods in the resource group, such as 'app=foo'"
+// @Param        annotations    query     string  false  "The annotations of the pods in the resource group"
+// @Param        container      query     string  false  "Only stream the containers of the name"
+// @Param        filter         query     string  false  "Only stream the lines matching the regular expression"
+// @Param        since          query     string  false  "Only return logs newer than a relative duration like 5s, 2m, or 3h"
+// @Param        tailLines      query     int     false  "Number of lines from the end of the logs of every container to show"
+// @Param        follow         query     bool    false  "Follow the logs, new pods and restarted containers. Default to true"
+// @Success      200            {object}  logs.Line
+// @Failure      400            {string}  string  "Bad Request"
+// @Failure      401            {string}  string  "Unauthorized"
+// @Failure      404            {string}  string  "Not Found"
+// @Router       /insight/aggregator/log/pods [get]
+func GetWorkloadLogs(aggregator *logs.Aggregator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+		query := r.URL.Query()
+
+		// Workloads and label selectors are resolved in the clusters, and
+		// the other pods are looked up in the resource group in the index.
+		target := &logs.Target{
+			Cluster:   query.Get("cluster"),
+			Namespace: query.Get("namespace"),
+			Kind:      query.Get("kind"),
+			Selector:  query.Get("labelSelector"),
+		}
+		if target.Kind != "" || target.Selector != "" {
+			if target.Kind != "" {
+				target.Name = query.Get("name")
+			}
+			if err := authz.Authorize(ctx, entity.ResourceGroup{Cluster: target.Cluster, Namespace: target.Namespace, Kind: "Pod"}); err != nil {
+				writeLogSSEError(w, err.Error())
+				return
+			}
+		} else {
+			resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+			if err != nil {
+				writeLogSSEError(w, err.Error())
+				return
+			}
+			resourceGroup.Kind = "Pod"
+			target = &logs.Target{ResourceGroup: &resourceGroup}
+		}
+
+		opts := &logs.Options{
+			Container: query.Get("container"),
+			Follow:    query.Get("follow") != "false",
+			// Default to the last 100 lines of every container
+			TailLines: pointer.Int64(100),
+		}
+		if filter := query.Get("filter"); filter != "" {
+			re, err := regexp.Compile(filter)
+			if err != nil {
+				writeLogSSEError(w, fmt.Sprintf("invalid filter: %v", err))
+				return
+			}
+			opts.Filter = re
+		}
+		if since := query.Get("since"); since != "" {
+			duration, err := time.ParseDuration(since)
+			if err != nil {
+				writeLogSSEError(w, fmt.Sprintf("invalid since: %v", err))
+				return
+			}
+			opts.SinceSeconds = pointer.Int64(int64(duration.Seconds()))
+		}
+		if tailLines := query.Get("tailLines"); tailLines != "" {
+			lines, err := strconv.ParseInt(tailLines, 10, 64)
+			if err != nil {
+				writeLogSSEError(w, fmt.Sprintf("invalid tailLines: %v", err))
+				return
+			}
+			opts.TailLines = pointer.Int64(lines)
+		}
+		if err := target.Validate(); err != nil {
+			writeLogSSEError(w, err.Error())
+			return
+		}
+
+		logger.Info("Getting workload logs...", "target", target, "container", opts.Container, "follow", opts.Follow)
+
+		// Set SSE headers for streaming
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		lines := make(chan *logs.Line, 100)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- aggregator.Stream(ctx, target, opts, lines)
+		}()
+
+		// Send logs as SSE events
+		for line := range lines {
+			data, err := json.Marshal(line)
+			if err != nil {
+				logger.Error(err, "Failed to marshal log line")
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+
+		if err := <-errCh; err != nil {
+			writeLogSSEError(w, fmt.Sprintf("failed to get logs: %v", err))
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/alert"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+// Get returns an HTTP handler function that reads an alert rule with its
+// status. It utilizes an AlertManager to execute the logic.
+//
+// @Summary      Get returns an AlertRule by name.
+// @Description  This endpoint returns an AlertRule with the status of its last evaluation by name. The channel destinations are only shown to the owner, and the matching objects are restricted to the data access of the user.
+// @Tags         alert
+// @Produce      json
+// @Param        alertRuleName  path      string            true  "The name of the alert rule"
+// @Success      200            {object}  entity.AlertRule  "AlertRule object"
+// @Failure      400            {string}  string            "Bad Request"
+// @Failure      401            {string}  string            "Unauthorized"
+// @Failure      404            {string}  string            "Not Found"
+// @Failure      405            {string}  string            "Method Not Allowed"
+// @Failure      429            {string}  string            "Too Many Requests"
+// @Failure      500            {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName} [get]
+func Get(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+		logger.Info("Getting alertRule...", "alertRule", name)
+
+		data, err := alertMgr.GetAlertRule(ctx, name)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, alert.Redacted(ctx, handler.UserName(ctx), data))
+	}
+}
+
+// List returns an HTTP handler function that lists all alert rules. It
+// utilizes an AlertManager to execute the logic.
+//
+// @Summary      List lists all AlertRules.
+// @Description  This endpoint lists all AlertRules with the status of their last evaluation. The channel destinations are only shown to the owners, and the matching objects are restricted to the data access of the user.
+// @Tags         alert
+// @Produce      json
+// @Success      200  {array}   entity.AlertRule  "List of AlertRule objects"
+// @Failure      400  {string}  string            "Bad Request"
+// @Failure      401  {string}  string            "Unauthorized"
+// @Failure      404  {string}  string            "Not Found"
+// @Failure      405  {string}  string            "Method Not Allowed"
+// @Failure      429  {string}  string            "Too Many Requests"
+// @Failure      500  {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rules [get]
+func List(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		logger.Info("Listing alertRules...")
+
+		rules, err := alertMgr.ListAlertRules(ctx)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		user := handler.UserName(ctx)
+		for i, rule := range rules {
+			rules[i] = alert.Redacted(ctx, user, rule)
+		}
+
+		handler.SuccessRender(ctx, w, r, rules)
+	}
+}
+
+// Create returns an HTTP handler function that creates an alert rule. It
+// utilizes an AlertManager to execute the logic.
+//
+// @Summary      Create creates an AlertRule.
+// @Description  This endpoint creates a new AlertRule using the payload.
+// @Tags         alert
+// @Accept       json
+// @Produce      json
+// @Param        request  body      AlertRulePayload  true  "alertRule to create"
+// @Success      200      {object}  entity.AlertRule  "AlertRule object"
+// @Failure      400      {string}  string            "Bad Request"
+// @Failure      401      {string}  string            "Unauthorized"
+// @Failure      404      {string}  string            "Not Found"
+// @Failure      405      {string}  string            "Method Not Allowed"
+// @Failure      409      {string}  string            "Conflict"
+// @Failure      429      {string}  string            "Too Many Requests"
+// @Failure      500      {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule [post]
+func Create(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		// Decode the request body into the payload.
+		var payload AlertRulePayload
+		if err := payload.Decode(r); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		logger.Info("Creating alertRule...", "alertRule", payload.Name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "AlertRule", Name: payload.Name})
+
+		rule := payload.ToEntity()
+		if err := alertMgr.CreateAlertRule(ctx, handler.UserName(ctx), rule); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, rule)
+	}
+}
+
+// Update returns an HTTP handler function that updates an alert rule. It
+// utilizes an AlertManager to execute the logic.
+//
+// @Summary      Update updates an AlertRule by name.
+// @Description  This endpoint updates an AlertRule by name, the status of the AlertRule is reset.
+// @Tags         alert
+// @Accept       json
+// @Produce      json
+// @Param        alertRuleName  path      string            true  "The name of the alert rule"
+// @Param        request        body      AlertRulePayload  true  "alertRule to update"
+// @Success      200            {object}  entity.AlertRule  "AlertRule object"
+// @Failure      400            {string}  string            "Bad Request"
+// @Failure      401            {string}  string            "Unauthorized"
+// @Failure      404            {string}  string            "Not Found"
+// @Failure      405            {string}  string            "Method Not Allowed"
+// @Failure      429            {string}  string            "Too Many Requests"
+// @Failure      500            {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName} [put]
+func Update(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+
+		// Decode the request body into the payload.
+		var payload AlertRulePayload
+		if err := payload.Decode(r); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if payload.Name == "" {
+			payload.Name = name
+		}
+
+		logger.Info("Updating alertRule...", "alertRule", name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "AlertRule", Name: name})
+
+		rule := payload.ToEntity()
+		if err := alertMgr.UpdateAlertRule(ctx, name, rule); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, alert.Redacted(ctx, handler.UserName(ctx), rule))
+	}
+}
+
+// Delete returns an HTTP handler function that deletes an alert rule. It
+// utilizes an AlertManager to execute the logic.
+//
+// @Summary      Delete removes an AlertRule by name.
+// @Description  This endpoint deletes an AlertRule by name.
+// @Tags         alert
+// @Produce      json
+// @Param        alertRuleName  path      string  true  "The name of the alert rule"
+// @Success      200            {string}  string  "Operation status"
+// @Failure      400            {string}  string  "Bad Request"
+// @Failure      401            {string}  string  "Unauthorized"
+// @Failure      404            {string}  string  "Not Found"
+// @Failure      405            {string}  string  "Method Not Allowed"
+// @Failure      429            {string}  string  "Too Many Requests"
+// @Failure      500            {string}  string  "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName} [delete]
+func Delete(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+		logger.Info("Deleting alertRule...", "alertRule", name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "AlertRule", Name: name})
+
+		if err := alertMgr.DeleteAlertRule(ctx, name); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, "AlertRule deleted successfully")
+	}
+}
+
+// Silence returns an HTTP handler function that suppresses the
+// notifications of an alert rule for a duration. It utilizes an
+// AlertManager to execute the logic.
+//
+// @Summary      Silence silences an AlertRule.
+// @Description  This endpoint suppresses the notifications of an AlertRule for the duration, the AlertRule is still evaluated.
+// @Tags         alert
+// @Produce      json
+// @Param        alertRuleName  path      string            true  "The name of the alert rule"
+// @Param        duration       query     string            true  "How long the AlertRule is silenced, such as '2h'"
+// @Success      200            {object}  entity.AlertRule  "AlertRule object"
+// @Failure      400            {string}  string            "Bad Request"
+// @Failure      401            {string}  string            "Unauthorized"
+// @Failure      404            {string}  string            "Not Found"
+// @Failure      405            {string}  string            "Method Not Allowed"
+// @Failure      429            {string}  string            "Too Many Requests"
+// @Failure      500            {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName}/silence [put]
+func Silence(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+		duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+		if err != nil || duration <= 0 {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("invalid silence duration %q, expected a positive duration such as 2h", r.URL.Query().Get("duration")), http.StatusBadRequest)
+			return
+		}
+
+		logger.Info("Silencing alertRule...", "alertRule", name, "duration", duration)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "AlertRule", Name: name})
+
+		until := time.Now().Add(duration)
+		rule, err := alertMgr.SilenceAlertRule(ctx, name, &until)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, alert.Redacted(ctx, handler.UserName(ctx), rule))
+	}
+}
+
+// Unsilence returns an HTTP handler function that lifts the silence of an
+// alert rule. It utilizes an AlertManager to execute the logic.
+//
+// @Summary      Unsilence lifts the silence of an AlertRule.
+// @Description  This endpoint lifts the silence of an AlertRule, so that its notifications are sent again.
+// @Tags         alert
+// @Produce      json
+// @Param        alertRuleName  path      string            true  "The name of the alert rule"
+// @Success      200            {object}  entity.AlertRule  "AlertRule object"
+// @Failure      400            {string}  string            "Bad Request"
+// @Failure      401            {string}  string            "Unauthorized"
+// @Failure      404            {string}  string            "Not Found"
+// @Failure      405            {string}  string            "Method Not Allowed"
+// @Failure      429            {string}  string            "Too Many Requests"
+// @Failure      500            {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName}/silence [delete]
+func Unsilence(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+		logger.Info("Unsilencing alertRule...", "alertRule", name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "AlertRule", Name: name})
+
+		rule, err := alertMgr.SilenceAlertRule(ctx, name, nil)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, alert.Redacted(ctx, handler.UserName(ctx), rule))
+	}
+}
+
+// Test returns an HTTP handler function that evaluates an alert rule
+// without notifying its channels. It utilizes an AlertManager to execute the
+// logic.
+//
+// @Summary      Test evaluates an AlertRule.
+// @Description  This endpoint evaluates an AlertRule and returns whether it fires, without notifying its channels or updating its status.
+// @Tags         alert
+// @Produce      json
+// @Param        alertRuleName  path      string            true  "The name of the alert rule"
+// @Success      200            {object}  alert.Evaluation  "Evaluation of the AlertRule"
+// @Failure      400            {string}  string            "Bad Request"
+// @Failure      401            {string}  string            "Unauthorized"
+// @Failure      404            {string}  string            "Not Found"
+// @Failure      405            {string}  string            "Method Not Allowed"
+// @Failure      429            {string}  string            "Too Many Requests"
+// @Failure      500            {string}  string            "Internal Server Error"
+// @Router       /rest-api/v1/alert-rule/{alertRuleName}/test [post]
+func Test(alertMgr *alert.AlertManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "alertRuleName")
+		logger.Info("Testing alertRule...", "alertRule", name)
+
+		evaluation, err := alertMgr.TestAlertRule(ctx, name)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, evaluation)
+	}
+}
+
+// renderError renders the error of the AlertManager with the matching
+// status code.
+func renderError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, alert.ErrAlertRuleNotFound):
+		handler.NotFoundRender(ctx, w, r, err)
+	case errors.Is(err, alert.ErrAlertRuleAlreadyExists):
+		handler.FailureWithCodeRender(ctx, w, r, err, http.StatusConflict)
+	case errors.Is(err, alert.ErrNilAlertRule),
+		errors.Is(err, alert.ErrMissingAlertRuleName),
+		errors.Is(err, alert.ErrInvalidAlertRule),
+		errors.Is(err, alert.ErrAlertRuleNameCannotModify):
+		handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+	default:
+		handler.FailureRender(ctx, w, r, err)
+	}
+}
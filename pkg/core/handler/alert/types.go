@@ -0,0 +1,72 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Ensure that AlertRulePayload implements the handler.Payload interface.
+var _ handler.Payload = &AlertRulePayload{}
+
+type AlertRulePayload struct {
+	Name          string                `json:"name"`
+	Description   string                `json:"description"`
+	SavedSearch   string                `json:"savedSearch"`
+	Query         string                `json:"query"`
+	ResourceGroup *entity.ResourceGroup `json:"resourceGroup"`
+	Trigger       entity.AlertTrigger   `json:"trigger"`
+	Condition     entity.AlertCondition `json:"condition"`
+	Channels      []entity.AlertChannel `json:"channels"`
+	DedupeWindow  *metav1.Duration      `json:"dedupeWindow" swaggertype:"string"`
+	Disabled      bool                  `json:"disabled"`
+}
+
+// Decode detects the correct decoder for use on an HTTP request and
+// marshals into a given interface.
+func (p *AlertRulePayload) Decode(r *http.Request) error {
+	switch render.GetRequestContentType(r) {
+	case render.ContentTypeJSON:
+		if err := render.DecodeJSON(r.Body, p); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported media type")
+	}
+
+	return nil
+}
+
+// ToEntity converts the payload struct to the corresponding entity struct.
+func (p *AlertRulePayload) ToEntity() *entity.AlertRule {
+	return &entity.AlertRule{
+		Name:          p.Name,
+		Description:   p.Description,
+		SavedSearch:   p.SavedSearch,
+		Query:         p.Query,
+		ResourceGroup: p.ResourceGroup,
+		Trigger:       p.Trigger,
+		Condition:     p.Condition,
+		Channels:      p.Channels,
+		DedupeWindow:  p.DedupeWindow,
+		Disabled:      p.Disabled,
+	}
+}
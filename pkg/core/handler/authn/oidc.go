@@ -0,0 +1,146 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/authn/oidc"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// UserInfo is the user of the request.
+type UserInfo struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Login returns an HTTP handler that redirects the user to the OpenID
+// provider to sign in.
+//
+// @Summary      Login redirects to the OpenID provider.
+// @Description  This endpoint starts the OpenID Connect authorization code flow with PKCE and redirects to the OpenID provider.
+// @Tags         authn
+// @Param        redirect  query  string  false  "The local path to return to after login"
+// @Success      302  {string}  string  "Found"
+// @Failure      500  {string}  string  "Internal Server Error"
+// @Router       /authn/oidc/login [get]
+func Login(provider *oidc.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		authURL, state, err := provider.LoginURL(ctx, r.URL.Query().Get("redirect"))
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		http.SetCookie(w, provider.StateCookie(state, oidc.LoginPath))
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// Callback returns an HTTP handler that completes the login redirected back
+// by the OpenID provider, it starts a session and sets the session cookie.
+//
+// @Summary      Callback completes the OpenID Connect login.
+// @Description  This endpoint exchanges the authorization code, starts a session and redirects to the page the login started from.
+// @Tags         authn
+// @Param        state  query  string  true  "The state of the login"
+// @Param        code   query  string  true  "The authorization code"
+// @Success      302  {string}  string  "Found"
+// @Failure      400  {string}  string  "Bad Request"
+// @Failure      401  {string}  string  "Unauthorized"
+// @Failure      500  {string}  string  "Internal Server Error"
+// @Router       /authn/oidc/callback [get]
+func Callback(provider *oidc.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+		query := r.URL.Query()
+
+		http.SetCookie(w, provider.ClearStateCookie(oidc.LoginPath))
+		if errCode := query.Get("error"); errCode != "" {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("login failed: %s %s", errCode, query.Get("error_description")), http.StatusUnauthorized)
+			return
+		}
+
+		// The state must belong to the browser which started the login,
+		// otherwise an attacker could sign the user in to their account.
+		state := query.Get("state")
+		cookie, err := r.Cookie(oidc.StateCookieName)
+		if err != nil || state == "" || cookie.Value != state {
+			handler.FailureWithCodeRender(ctx, w, r, oidc.ErrInvalidState, http.StatusBadRequest)
+			return
+		}
+
+		session, returnTo, err := provider.Callback(ctx, state, query.Get("code"))
+		if errors.Is(err, oidc.ErrInvalidState) {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		} else if err != nil {
+			logger.Error(err, "Failed to complete the OpenID Connect login")
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusUnauthorized)
+			return
+		}
+
+		logger.Info("User signed in with OpenID Connect", "user", session.Username, "groups", session.Groups)
+		http.SetCookie(w, provider.SessionCookie(session))
+		http.Redirect(w, r, returnTo, http.StatusFound)
+	}
+}
+
+// Logout returns an HTTP handler that ends the session of the user.
+//
+// @Summary      Logout ends the login session.
+// @Description  This endpoint ends the OpenID Connect login session and removes the session cookie.
+// @Tags         authn
+// @Produce      json
+// @Success      200  {string}  string  "OK"
+// @Router       /authn/oidc/logout [post]
+func Logout(provider *oidc.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(oidc.SessionCookieName); err == nil {
+			provider.Logout(cookie.Value)
+		}
+		http.SetCookie(w, provider.ClearSessionCookie())
+		handler.SuccessRender(r.Context(), w, r, "OK")
+	}
+}
+
+// GetUser returns an HTTP handler that returns the authenticated user of
+// the request, which is used by the dashboard to show who is signed in.
+//
+// @Summary      GetUser returns the authenticated user.
+// @Description  This endpoint returns the name and groups of the authenticated user.
+// @Tags         authn
+// @Produce      json
+// @Success      200  {object}  UserInfo  "The authenticated user"
+// @Failure      401  {string}  string    "Unauthorized"
+// @Router       /authn/user [get]
+func GetUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		u, ok := request.UserFrom(ctx)
+		if !ok {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("no authenticated user"), http.StatusUnauthorized)
+			return
+		}
+		handler.SuccessRender(ctx, w, r, &UserInfo{Name: u.GetName(), Groups: u.GetGroups()})
+	}
+}
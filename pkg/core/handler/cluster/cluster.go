@@ -20,6 +20,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
@@ -29,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 	_ "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/tools/clientcmd"
@@ -112,7 +114,7 @@ func Create(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.
 		}
 
 		client, _ := multicluster.BuildMultiClusterClient(r.Context(), c.LoopbackClientConfig, "")
-		clusterCreated, err := clusterMgr.CreateCluster(r.Context(), client, cluster, payload.ClusterDisplayName, payload.ClusterDescription, payload.ClusterKubeConfig)
+		clusterCreated, err := clusterMgr.CreateCluster(r.Context(), client, cluster, payload.ClusterDisplayName, payload.ClusterDescription, payload.ClusterKubeConfig, payload.ClusterLabels)
 		handler.HandleResult(w, r, ctx, err, clusterCreated)
 	}
 }
@@ -121,7 +123,7 @@ func Create(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.
 // resource. It utilizes a ClusterManager to execute the logic.
 //
 // @Summary      Update updates the cluster metadata by name.
-// @Description  This endpoint updates the display name and description of an existing cluster resource.
+// @Description  This endpoint updates the display name, description and labels of an existing cluster resource.
 // @Tags         cluster
 // @Accept       plain
 // @Accept       json
@@ -156,7 +158,7 @@ func Update(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.
 			clusterUpdated, err := clusterMgr.UpdateCredential(r.Context(), client, cluster, payload.ClusterKubeConfig)
 			handler.HandleResult(w, r, ctx, err, clusterUpdated)
 		} else {
-			clusterUpdated, err := clusterMgr.UpdateMetadata(r.Context(), client, cluster, payload.ClusterDisplayName, payload.ClusterDescription)
+			clusterUpdated, err := clusterMgr.UpdateMetadata(r.Context(), client, cluster, payload.ClusterDisplayName, payload.ClusterDescription, payload.ClusterLabels)
 			handler.HandleResult(w, r, ctx, err, clusterUpdated)
 		}
 	}
@@ -166,19 +168,20 @@ func Update(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.
 // resources. It utilizes a ClusterManager to execute the logic.
 //
 // @Summary      List lists all cluster resources.
-// @Description  This endpoint lists all cluster resources.
+// @Description  This endpoint lists all cluster resources, optionally filtered by a label selector.
 // @Tags         cluster
 // @Produce      json
-// @Param        summary     query     bool                       false  "Whether to display summary or not. Default to false"
-// @Param        orderBy     query     string                     false  "The order to list the cluster. Default to order by name"
-// @Param        descending  query     bool                       false  "Whether to sort the list in descending order. Default to false"
-// @Success      200         {array}   unstructured.Unstructured  "List of cluster objects"
-// @Failure      400         {string}  string                     "Bad Request"
-// @Failure      401         {string}  string                     "Unauthorized"
-// @Failure      404         {string}  string                     "Not Found"
-// @Failure      405         {string}  string                     "Method Not Allowed"
-// @Failure      429         {string}  string                     "Too Many Requests"
-// @Failure      500         {string}  string                     "Internal Server Error"
+// @Param        summary        query     bool                       false  "Whether to display summary or not. Default to false"
+// @Param        orderBy        query     string                     false  "The order to list the cluster. Default to order by name"
+// @Param        descending     query     bool                       false  "Whether to sort the list in descending order. Default to false"
+// @Param        labelSelector  query     string                     false  "The label selector of the clusters to list, such as 'env=prod,region in (eu-west,eu-north)'"
+// @Success      200            {array}   unstructured.Unstructured  "List of cluster objects"
+// @Failure      400            {string}  string                     "Bad Request"
+// @Failure      401            {string}  string                     "Unauthorized"
+// @Failure      404            {string}  string                     "Not Found"
+// @Failure      405            {string}  string                     "Method Not Allowed"
+// @Failure      429            {string}  string                     "Too Many Requests"
+// @Failure      500            {string}  string                     "Internal Server Error"
 // @Router       /rest-api/v1/clusters [get]
 func List(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -190,6 +193,11 @@ func List(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.Ha
 		orderBy := r.URL.Query().Get("orderBy")
 		descending, _ := strconv.ParseBool(r.URL.Query().Get("descending"))
 		summary, _ := strconv.ParseBool(r.URL.Query().Get("summary"))
+		labelSelector := r.URL.Query().Get("labelSelector")
+		if _, err := labels.Parse(labelSelector); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
 
 		client, _ := multicluster.BuildMultiClusterClient(r.Context(), c.LoopbackClientConfig, "")
 
@@ -201,7 +209,7 @@ func List(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.Ha
 			if !ok {
 				criteria = cluster.ByName
 			}
-			clusterList, err := clusterMgr.ListCluster(r.Context(), client, criteria, descending)
+			clusterList, err := clusterMgr.ListCluster(r.Context(), client, labelSelector, criteria, descending)
 			handler.HandleResult(w, r, ctx, err, clusterList)
 		}
 	}
@@ -381,3 +389,56 @@ func ValidateKubeConfig(clusterMgr *cluster.ClusterManager) http.HandlerFunc {
 		}
 	}
 }
+
+// ImportKubeConfig returns an HTTP handler function to import the clusters
+// of all contexts of one or more kubeconfigs.
+//
+// @Summary      Import clusters from kubeconfigs
+// @Description  Imports a cluster for each context of the kubeconfigs. The clusters are validated in parallel and created or updated idempotently, with dryRun the resulting clusters are only previewed.
+// @Tags         cluster
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ImportPayload   true  "The kubeconfigs to import and the import options"
+// @Success      200      {object}  ImportResponse  "The results of the import of each context"
+// @Failure      400      {string}  string          "Bad Request"
+// @Failure      401      {string}  string          "Unauthorized"
+// @Failure      404      {string}  string          "Not Found"
+// @Failure      405      {string}  string          "Method Not Allowed"
+// @Failure      429      {string}  string          "Too Many Requests"
+// @Failure      500      {string}  string          "Internal Server Error"
+// @Router       /rest-api/v1/cluster/config/import [post]
+func ImportKubeConfig(clusterMgr *cluster.ClusterManager, c *server.CompletedConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		// Decode the request body into the payload.
+		payload := &ImportPayload{}
+		if err := payload.Decode(r); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		if len(payload.KubeConfigs) == 0 {
+			handler.FailureWithCodeRender(ctx, w, r, errors.New("at least one kubeconfig is required"), http.StatusBadRequest)
+			return
+		}
+		logger.Info("Importing clusters from kubeconfigs...", "kubeConfigs", len(payload.KubeConfigs), "dryRun", payload.DryRun)
+		actionlog.Annotate(ctx, actionlog.ActionClusterImport, "dryRun", strconv.FormatBool(payload.DryRun))
+
+		client, err := multicluster.BuildMultiClusterClient(ctx, c.LoopbackClientConfig, "")
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		results, err := clusterMgr.ImportClusters(ctx, client, payload.KubeConfigs, payload.ImportOptions)
+		if err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		handler.SuccessRender(ctx, w, r, &ImportResponse{
+			Summary: cluster.SummarizeImport(results),
+			Results: results,
+		})
+	}
+}
@@ -29,13 +29,14 @@ var sortCriteriaMap = map[string]cluster.SortCriteria{
 }
 
 // ClusterPayload represents the structure for cluster request data. It includes
-// the name, display name, description and kubeconfig of a karpor-managed cluster
+// the name, display name, description, labels and kubeconfig of a karpor-managed cluster
 //
 //nolint:tagliatelle
 type ClusterPayload struct {
-	ClusterDisplayName string `json:"displayName"` // ClusterDisplayName is the display name of cluster to be created
-	ClusterDescription string `json:"description"` // ClusterDescription is the description of cluster to be created
-	ClusterKubeConfig  string `json:"kubeConfig"`  // ClusterKubeConfig is the kubeconfig of cluster to be created
+	ClusterDisplayName string            `json:"displayName"` // ClusterDisplayName is the display name of cluster to be created
+	ClusterDescription string            `json:"description"` // ClusterDescription is the description of cluster to be created
+	ClusterKubeConfig  string            `json:"kubeConfig"`  // ClusterKubeConfig is the kubeconfig of cluster to be created
+	ClusterLabels      map[string]string `json:"labels"`      // ClusterLabels are the labels of cluster, such as its environment, region and team
 }
 
 type UploadData struct {
@@ -48,3 +49,16 @@ type UploadData struct {
 type ValidatePayload struct {
 	KubeConfig string `json:"kubeConfig"`
 }
+
+// ImportPayload represents the kubeconfigs to import the clusters from,
+// along with the import options.
+type ImportPayload struct {
+	KubeConfigs []cluster.KubeConfigSource `json:"kubeConfigs"`
+	cluster.ImportOptions
+}
+
+// ImportResponse represents the results of importing the clusters.
+type ImportResponse struct {
+	Summary cluster.ImportSummary   `json:"summary"`
+	Results []*cluster.ImportResult `json:"results"`
+}
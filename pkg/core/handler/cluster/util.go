@@ -40,6 +40,22 @@ func (payload *ClusterPayload) Decode(r *http.Request) error {
 	return nil
 }
 
+// decode detects the correct decoder for use on an HTTP request and
+// marshals into a given interface.
+func (payload *ImportPayload) Decode(r *http.Request) error {
+	contentType := render.GetRequestContentType(r)
+	switch contentType {
+	case render.ContentTypeJSON:
+		if err := render.DecodeJSON(r.Body, payload); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported media type")
+	}
+
+	return nil
+}
+
 // decode detects the correct decoder for use on an HTTP request and
 // marshals into a given interface.
 func (payload *ValidatePayload) Decode(r *http.Request) error {
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
@@ -62,6 +63,10 @@ func GetDetail(clusterMgr *cluster.ClusterManager, insightMgr *insight.InsightMa
 			handler.FailureRender(ctx, w, r, err)
 			return
 		}
+		if err = authz.Authorize(ctx, resourceGroup); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
 		logger.Info("Getting resource detail for resourceGroup...", "resourceGroup", resourceGroup)
 
 		client, err := multicluster.BuildMultiClusterClient(r.Context(), c.LoopbackClientConfig, resourceGroup.Cluster)
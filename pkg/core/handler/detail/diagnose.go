@@ -0,0 +1,143 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/core/manager/insight"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"k8s.io/apiserver/pkg/server"
+)
+
+// DiagnoseResourceRequest represents the request body for resource diagnosis
+type DiagnoseResourceRequest struct {
+	Language string `json:"language"`
+	// TokenBudget limits the size of the context sent to the model, default
+	// to ai.DefaultDiagnosisTokenBudget
+	TokenBudget int `json:"tokenBudget"`
+}
+
+// DiagnoseResource returns an HTTP handler function that performs AI
+// diagnosis on a resource with its combined context
+//
+// @Summary      Diagnose a resource using AI
+// @Description  This endpoint assembles a bounded context of a resource, including its YAML, owner chain, related resources, recent events, logs of related pods and audit issues, and streams a root-cause analysis citing the evidence
+// @Tags         insight
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        cluster     query     string                   true   "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion  query     string                   true   "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded"
+// @Param        kind        query     string                   true   "The specified kind, such as 'Deployment'"
+// @Param        namespace   query     string                   false  "The specified namespace, such as 'default'"
+// @Param        name        query     string                   true   "The specified resource name, such as 'foo'"
+// @Param        request     body      DiagnoseResourceRequest  false  "The language and the token budget of the diagnosis"
+// @Success      200         {object}  ai.DiagnosisEvent
+// @Failure      400         {string}  string  "Bad Request"
+// @Failure      401         {string}  string  "Unauthorized"
+// @Failure      404         {string}  string  "Not Found"
+// @Failure      429         {string}  string  "Too Many Requests"
+// @Failure      500         {string}  string  "Internal Server Error"
+// @Router       /rest-api/v1/insight/resource/diagnosis/stream [post]
+func DiagnoseResource(aiMgr *ai.AIManager, insightMgr *insight.InsightManager, c *server.CompletedConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		if err := ai.CheckAIManager(aiMgr); err != nil {
+			logger.Error(err, "AI manager is not available")
+			http.Error(w, "AI service is not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		// Parse request body, which is optional
+		var req DiagnoseResourceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("invalid request format: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if resourceGroupType, ok := resourceGroup.GetType(); !ok || (resourceGroupType != entity.Resource && resourceGroupType != entity.NonNamespacedResource) {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("cluster, apiVersion, kind and name of the resource are required"), http.StatusBadRequest)
+			return
+		}
+		if err = authz.Authorize(ctx, resourceGroup); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		client, err := multicluster.BuildMultiClusterClient(ctx, c.LoopbackClientConfig, resourceGroup.Cluster)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		// Collect the context before streaming, so that a missing resource
+		// is reported as an error response
+		logger.Info("Collecting diagnosis context...", "resourceGroup", resourceGroup)
+		diagnosisContext, err := insightMgr.GetDiagnosisContext(ctx, client, &resourceGroup)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		// Set headers for SSE
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		// Create channel for diagnosis events
+		eventChan := make(chan *ai.DiagnosisEvent, 10)
+		go func() {
+			if err := aiMgr.DiagnoseResource(ctx, diagnosisContext, req.Language, req.TokenBudget, eventChan); err != nil {
+				logger.Error(err, "Failed to diagnose resource")
+				// Error will be sent through eventChan
+			}
+		}()
+
+		// Stream events to client
+		for event := range eventChan {
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Error(err, "Failed to marshal diagnosis event")
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
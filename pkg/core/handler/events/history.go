@@ -0,0 +1,113 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+)
+
+// GetEventHistory returns an HTTP handler function that returns the events
+// archived by the syncer, which are kept after the events expire in the
+// clusters or the clusters are deleted.
+//
+// @Summary      GetEventHistory returns the archived events.
+// @Description  This endpoint returns the archived events, the latest first, filtered by the involved object, type, reason and time across clusters.
+// @Tags         insight
+// @Produce      json
+// @Param        cluster     query     string               false  "The cluster of the events, all clusters if empty"
+// @Param        apiVersion  query     string               false  "The apiVersion of the involved objects, such as 'apps/v1'. Should be percent-encoded"
+// @Param        kind        query     string               false  "The kind of the involved objects, such as 'Deployment'"
+// @Param        namespace   query     string               false  "The namespace of the involved objects, such as 'default'"
+// @Param        name        query     string               false  "The name of the involved object, such as 'foo'"
+// @Param        type        query     string               false  "The type of the events, either Normal or Warning"
+// @Param        reason      query     string               false  "The reason of the events, such as 'BackOff'"
+// @Param        from        query     string               false  "The start time in RFC3339 format"
+// @Param        to          query     string               false  "The end time in RFC3339 format"
+// @Param        page        query     string               false  "The current page to fetch. Default to 1"
+// @Param        pageSize    query     string               false  "The size of the page. Default to 20"
+// @Success      200         {object}  storage.EventResult  "The archived events"
+// @Failure      400         {string}  string               "Bad Request"
+// @Failure      401         {string}  string               "Unauthorized"
+// @Failure      404         {string}  string               "Not Found"
+// @Failure      405         {string}  string               "Method Not Allowed"
+// @Failure      429         {string}  string               "Too Many Requests"
+// @Failure      500         {string}  string               "Internal Server Error"
+// @Router       /rest-api/v1/insight/events/history [get]
+func GetEventHistory(eventStorage storage.EventStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+		query := r.URL.Query()
+
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		filter := &storage.EventFilter{
+			ResourceGroup: resourceGroup,
+			Type:          query.Get("type"),
+			Reason:        query.Get("reason"),
+		}
+		if filter.From, err = parseTime(query.Get("from")); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if filter.To, err = parseTime(query.Get("to")); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+		if page < 1 {
+			page = 1
+		}
+		if pageSize < 1 {
+			pageSize = 20
+		}
+
+		logger.Info("Getting archived events...", "filter", filter, "page", page, "pageSize", pageSize)
+		result, err := eventStorage.ListArchivedEvents(ctx, filter, &storage.Pagination{Page: page, PageSize: pageSize})
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, result)
+	}
+}
+
+// parseTime parses an optional time in RFC3339 format.
+func parseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 format: %w", v, err)
+	}
+	return t, nil
+}
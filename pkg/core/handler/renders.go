@@ -16,9 +16,11 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	appmiddleware "github.com/KusionStack/karpor/pkg/core/middleware"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -70,9 +72,23 @@ func SuccessRender(ctx context.Context, w http.ResponseWriter, r *http.Request,
 	render.JSON(w, r, respRender)
 }
 
+// statusCoder is implemented by the errors which are rendered with their own
+// HTTP status code.
+type statusCoder interface {
+	StatusCode() int
+}
+
 // FailureRender renders a failed response and status code and respond to the
-// client request.
+// client request. Data access errors are rendered as forbidden, and the errors
+// implementing StatusCode() with their own status code.
 func FailureRender(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) error {
+	if errors.Is(err, authz.ErrForbidden) {
+		return FailureWithCodeRender(ctx, w, r, err, http.StatusForbidden)
+	}
+	var coder statusCoder
+	if errors.As(err, &coder) {
+		return FailureWithCodeRender(ctx, w, r, err, coder.StatusCode())
+	}
 	render.Status(r, http.StatusInternalServerError)
 	respRender := failureResponse(ctx, err)
 	return render.Render(w, r, respRender)
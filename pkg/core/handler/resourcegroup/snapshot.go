@@ -0,0 +1,216 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/resourcegroup"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+// defaultSnapshotRange is the time range of the listed snapshots when it is
+// not specified.
+const defaultSnapshotRange = 30 * 24 * time.Hour
+
+// ListSnapshots returns an HTTP handler function that lists the snapshots of
+// the membership of a resource group.
+//
+// @Summary      List the snapshots of a resource group.
+// @Description  This endpoint lists the membership snapshots of the specified resource group of the rule in a period of time, without their members.
+// @Tags         resourcegroup
+// @Produce      json
+// @Param        resourceGroupRuleName  path      string                          true   "The name of the resource group rule"
+// @Param        cluster                query     string                          false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion             query     string                          false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind                   query     string                          false  "The specified kind, such as 'Deployment'"
+// @Param        namespace              query     string                          false  "The specified namespace, such as 'default'"
+// @Param        name                   query     string                          false  "The specified resource name, such as 'foo'"
+// @Param        from                   query     string                          false  "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago"
+// @Param        to                     query     string                          false  "The end time in RFC3339 or YYYY-MM-DD format, default is now"
+// @Success      200                    {array}   entity.ResourceGroupSnapshot    "List of snapshots"
+// @Failure      400                    {string}  string                          "Bad Request"
+// @Failure      401                    {string}  string                          "Unauthorized"
+// @Failure      404                    {string}  string                          "Not Found"
+// @Failure      429                    {string}  string                          "Too Many Requests"
+// @Failure      500                    {string}  string                          "Internal Server Error"
+// @Router       /rest-api/v1/resource-groups/{resourceGroupRuleName}/snapshots [get]
+func ListSnapshots(resourceGroupMgr *resourcegroup.ResourceGroupManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "resourceGroupRuleName")
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		from, to, err := parseTimeRange(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		logger.Info("Listing resource group snapshots...", "resourceGroupRule", name, "resourceGroup", resourceGroup)
+
+		snapshots, err := resourceGroupMgr.ListResourceGroupSnapshots(ctx, name, resourceGroup, from, to)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, snapshots)
+	}
+}
+
+// CreateSnapshot returns an HTTP handler function that records a snapshot of
+// the current membership of a resource group.
+//
+// @Summary      Record a snapshot of a resource group.
+// @Description  This endpoint records the current members and the resource counts of the specified resource group of the rule.
+// @Tags         resourcegroup
+// @Produce      json
+// @Param        resourceGroupRuleName  path      string                        true   "The name of the resource group rule"
+// @Param        cluster                query     string                        false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion             query     string                        false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind                   query     string                        false  "The specified kind, such as 'Deployment'"
+// @Param        namespace              query     string                        false  "The specified namespace, such as 'default'"
+// @Param        name                   query     string                        false  "The specified resource name, such as 'foo'"
+// @Success      200                    {object}  entity.ResourceGroupSnapshot  "Recorded snapshot"
+// @Failure      400                    {string}  string                        "Bad Request"
+// @Failure      401                    {string}  string                        "Unauthorized"
+// @Failure      404                    {string}  string                        "Not Found"
+// @Failure      429                    {string}  string                        "Too Many Requests"
+// @Failure      500                    {string}  string                        "Internal Server Error"
+// @Router       /rest-api/v1/resource-groups/{resourceGroupRuleName}/snapshots [post]
+func CreateSnapshot(resourceGroupMgr *resourcegroup.ResourceGroupManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "resourceGroupRuleName")
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		logger.Info("Recording resource group snapshot...", "resourceGroupRule", name, "resourceGroup", resourceGroup)
+
+		snapshot, err := resourceGroupMgr.SnapshotResourceGroup(ctx, name, resourceGroup)
+		if err != nil {
+			if errors.Is(err, elasticsearch.ErrResourceGroupRuleNotFound) {
+				handler.NotFoundRender(ctx, w, r, err)
+			} else {
+				handler.FailureRender(ctx, w, r, err)
+			}
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, snapshot)
+	}
+}
+
+// DiffSnapshots returns an HTTP handler function that reports how a resource
+// group changed between two snapshots.
+//
+// @Summary      Compare two snapshots of a resource group.
+// @Description  This endpoint returns the resources added to and removed from the resource group between two snapshots, and the changes of the resource counts. The snapshot is compared with the current membership if 'to' is not specified.
+// @Tags         resourcegroup
+// @Produce      json
+// @Param        resourceGroupRuleName  path      string                             true   "The name of the resource group rule"
+// @Param        from                   query     string                             true   "The ID of the earlier snapshot"
+// @Param        to                     query     string                             false  "The ID of the later snapshot, default is the current membership"
+// @Success      200                    {object}  resourcegroup.ResourceGroupChange  "Changes between the snapshots"
+// @Failure      400                    {string}  string                             "Bad Request"
+// @Failure      401                    {string}  string                             "Unauthorized"
+// @Failure      404                    {string}  string                             "Not Found"
+// @Failure      429                    {string}  string                             "Too Many Requests"
+// @Failure      500                    {string}  string                             "Internal Server Error"
+// @Router       /rest-api/v1/resource-groups/{resourceGroupRuleName}/snapshots/diff [get]
+func DiffSnapshots(resourceGroupMgr *resourcegroup.ResourceGroupManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "resourceGroupRuleName")
+		fromID, toID := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+
+		logger.Info("Comparing resource group snapshots...", "resourceGroupRule", name, "from", fromID, "to", toID)
+
+		change, err := resourceGroupMgr.DiffResourceGroupSnapshots(ctx, name, fromID, toID)
+		if err != nil {
+			if errors.Is(err, elasticsearch.ErrResourceGroupSnapshotNotFound) ||
+				errors.Is(err, elasticsearch.ErrResourceGroupRuleNotFound) {
+				handler.NotFoundRender(ctx, w, r, err)
+			} else {
+				handler.FailureRender(ctx, w, r, err)
+			}
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, change)
+	}
+}
+
+// parseTimeRange parses the from and to query parameters of the request. A
+// date without time in the to parameter stands for the end of that day.
+func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now()
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, dateOnly, err := parseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if dateOnly {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultSnapshotRange)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, _, err := parseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = t
+	}
+
+	return from, to, nil
+}
+
+// parseTime parses the time in RFC3339 or YYYY-MM-DD format, and reports
+// whether it is a date only.
+func parseTime(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid time %q, expected RFC3339 or YYYY-MM-DD format", v)
+}
@@ -17,6 +17,8 @@ package resourcegrouprule
 import (
 	"net/http"
 
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/resourcegroup"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
@@ -104,6 +106,7 @@ func Create(resourceGroupMgr *resourcegroup.ResourceGroupManager) http.HandlerFu
 		}
 
 		logger.Info("Creating resourceGroupRule...", "resourceGroupRule", payload.Name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "ResourceGroupRule", Name: payload.Name})
 
 		// Use the ResourceGroupManager to create the resource group rule.
 		rgr := payload.ToEntity()
@@ -153,6 +156,7 @@ func Update(resourceGroupMgr *resourcegroup.ResourceGroupManager) http.HandlerFu
 		}
 
 		logger.Info("Updating resourceGroupRule metadata...", "resourceGroupRule", payload.Name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "ResourceGroupRule", Name: payload.Name})
 
 		// Use the ResourceGroupManager to update the resource group rule.
 		rgr := payload.ToEntity()
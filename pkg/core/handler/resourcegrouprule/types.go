@@ -29,9 +29,11 @@ import (
 var _ handler.Payload = &ResourceGroupRulePayload{}
 
 type ResourceGroupRulePayload struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Fields      []string `json:"fields"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Fields      []string                       `json:"fields"`
+	Filter      *entity.ResourceGroupFilter    `json:"filter"`
+	Metadata    []entity.ResourceGroupMetadata `json:"metadata"`
 }
 
 // Decode detects the correct decoder for use on an HTTP request and
@@ -59,6 +61,8 @@ func (p *ResourceGroupRulePayload) ToEntity() *entity.ResourceGroupRule {
 		Name:        p.Name,
 		Description: p.Description,
 		Fields:      p.Fields,
+		Filter:      p.Filter,
+		Metadata:    p.Metadata,
 		CreatedAt:   &t,
 		UpdatedAt:   &t,
 	}
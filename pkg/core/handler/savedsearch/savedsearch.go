@@ -0,0 +1,307 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/savedsearch"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+// Get returns an HTTP handler function that reads a saved search visible to
+// the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      Get returns a SavedSearch by name.
+// @Description  This endpoint returns a SavedSearch visible to the user by name.
+// @Tags         savedsearch
+// @Produce      json
+// @Param        savedSearchName  path      string              true  "The name of the saved search"
+// @Success      200              {object}  entity.SavedSearch  "SavedSearch object"
+// @Failure      400              {string}  string              "Bad Request"
+// @Failure      401              {string}  string              "Unauthorized"
+// @Failure      404              {string}  string              "Not Found"
+// @Failure      405              {string}  string              "Method Not Allowed"
+// @Failure      429              {string}  string              "Too Many Requests"
+// @Failure      500              {string}  string              "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName} [get]
+func Get(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "savedSearchName")
+		logger.Info("Getting savedSearch...", "savedSearch", name)
+
+		data, err := savedSearchMgr.GetSavedSearch(ctx, handler.UserName(ctx), name)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, data)
+	}
+}
+
+// List returns an HTTP handler function that lists the saved searches
+// visible to the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      List lists the SavedSearches visible to the user.
+// @Description  This endpoint lists the shared SavedSearches and the private ones of the user.
+// @Tags         savedsearch
+// @Produce      json
+// @Param        favorite  query     bool                  false  "Whether to only list the favorites of the user. Default to false"
+// @Success      200       {array}   entity.SavedSearch  "List of SavedSearch objects"
+// @Failure      400       {string}  string                "Bad Request"
+// @Failure      401       {string}  string                "Unauthorized"
+// @Failure      404       {string}  string                "Not Found"
+// @Failure      405       {string}  string                "Method Not Allowed"
+// @Failure      429       {string}  string                "Too Many Requests"
+// @Failure      500       {string}  string                "Internal Server Error"
+// @Router       /rest-api/v1/saved-searches [get]
+func List(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		favoritesOnly, _ := strconv.ParseBool(r.URL.Query().Get("favorite"))
+		logger.Info("Listing savedSearches...", "favorite", favoritesOnly)
+
+		savedSearches, err := savedSearchMgr.ListSavedSearches(ctx, handler.UserName(ctx), favoritesOnly)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, savedSearches)
+	}
+}
+
+// Create returns an HTTP handler function that creates a saved search owned
+// by the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      Create creates a SavedSearch.
+// @Description  This endpoint creates a new SavedSearch owned by the user using the payload.
+// @Tags         savedsearch
+// @Accept       json
+// @Produce      json
+// @Param        request  body      SavedSearchPayload  true  "savedSearch to create"
+// @Success      200      {object}  entity.SavedSearch  "SavedSearch object"
+// @Failure      400      {string}  string              "Bad Request"
+// @Failure      401      {string}  string              "Unauthorized"
+// @Failure      404      {string}  string              "Not Found"
+// @Failure      405      {string}  string              "Method Not Allowed"
+// @Failure      409      {string}  string              "Conflict"
+// @Failure      429      {string}  string              "Too Many Requests"
+// @Failure      500      {string}  string              "Internal Server Error"
+// @Router       /rest-api/v1/saved-search [post]
+func Create(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		// Decode the request body into the payload.
+		var payload SavedSearchPayload
+		if err := payload.Decode(r); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+
+		logger.Info("Creating savedSearch...", "savedSearch", payload.Name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "SavedSearch", Name: payload.Name})
+
+		savedSearch := payload.ToEntity()
+		if err := savedSearchMgr.CreateSavedSearch(ctx, handler.UserName(ctx), savedSearch); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, savedSearch)
+	}
+}
+
+// Update returns an HTTP handler function that updates a saved search owned
+// by the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      Update updates a SavedSearch by name.
+// @Description  This endpoint updates the query, format and visibility of a SavedSearch owned by the user.
+// @Tags         savedsearch
+// @Accept       json
+// @Produce      json
+// @Param        savedSearchName  path      string              true  "The name of the saved search"
+// @Param        request          body      SavedSearchPayload  true  "savedSearch to update"
+// @Success      200              {object}  entity.SavedSearch  "SavedSearch object"
+// @Failure      400              {string}  string              "Bad Request"
+// @Failure      401              {string}  string              "Unauthorized"
+// @Failure      403              {string}  string              "Forbidden"
+// @Failure      404              {string}  string              "Not Found"
+// @Failure      405              {string}  string              "Method Not Allowed"
+// @Failure      429              {string}  string              "Too Many Requests"
+// @Failure      500              {string}  string              "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName} [put]
+func Update(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "savedSearchName")
+
+		// Decode the request body into the payload.
+		var payload SavedSearchPayload
+		if err := payload.Decode(r); err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if payload.Name == "" {
+			payload.Name = name
+		}
+
+		logger.Info("Updating savedSearch...", "savedSearch", name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "SavedSearch", Name: name})
+
+		savedSearch := payload.ToEntity()
+		if err := savedSearchMgr.UpdateSavedSearch(ctx, handler.UserName(ctx), name, savedSearch); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, savedSearch)
+	}
+}
+
+// Delete returns an HTTP handler function that deletes a saved search owned
+// by the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      Delete removes a SavedSearch by name.
+// @Description  This endpoint deletes a SavedSearch owned by the user by name.
+// @Tags         savedsearch
+// @Produce      json
+// @Param        savedSearchName  path      string  true  "The name of the saved search"
+// @Success      200              {string}  string  "Operation status"
+// @Failure      400              {string}  string  "Bad Request"
+// @Failure      401              {string}  string  "Unauthorized"
+// @Failure      403              {string}  string  "Forbidden"
+// @Failure      404              {string}  string  "Not Found"
+// @Failure      405              {string}  string  "Method Not Allowed"
+// @Failure      429              {string}  string  "Too Many Requests"
+// @Failure      500              {string}  string  "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName} [delete]
+func Delete(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "savedSearchName")
+		logger.Info("Deleting savedSearch...", "savedSearch", name)
+		actionlog.SetTarget(ctx, entity.ActionTarget{Kind: "SavedSearch", Name: name})
+
+		if err := savedSearchMgr.DeleteSavedSearch(ctx, handler.UserName(ctx), name); err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, "SavedSearch deleted successfully")
+	}
+}
+
+// Favorite returns an HTTP handler function that marks a saved search as a
+// favorite of the user. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      Favorite marks a SavedSearch as a favorite of the user.
+// @Description  This endpoint adds a SavedSearch visible to the user to the favorites of the user.
+// @Tags         savedsearch
+// @Produce      json
+// @Param        savedSearchName  path      string              true  "The name of the saved search"
+// @Success      200              {object}  entity.SavedSearch  "SavedSearch object"
+// @Failure      400              {string}  string              "Bad Request"
+// @Failure      401              {string}  string              "Unauthorized"
+// @Failure      404              {string}  string              "Not Found"
+// @Failure      405              {string}  string              "Method Not Allowed"
+// @Failure      429              {string}  string              "Too Many Requests"
+// @Failure      500              {string}  string              "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName}/favorite [put]
+func Favorite(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return setFavorite(savedSearchMgr, true)
+}
+
+// Unfavorite returns an HTTP handler function that removes a saved search
+// from the favorites of the user. It utilizes a SavedSearchManager to execute
+// the logic.
+//
+// @Summary      Unfavorite removes a SavedSearch from the favorites of the user.
+// @Description  This endpoint removes a SavedSearch visible to the user from the favorites of the user.
+// @Tags         savedsearch
+// @Produce      json
+// @Param        savedSearchName  path      string              true  "The name of the saved search"
+// @Success      200              {object}  entity.SavedSearch  "SavedSearch object"
+// @Failure      400              {string}  string              "Bad Request"
+// @Failure      401              {string}  string              "Unauthorized"
+// @Failure      404              {string}  string              "Not Found"
+// @Failure      405              {string}  string              "Method Not Allowed"
+// @Failure      429              {string}  string              "Too Many Requests"
+// @Failure      500              {string}  string              "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName}/favorite [delete]
+func Unfavorite(savedSearchMgr *savedsearch.SavedSearchManager) http.HandlerFunc {
+	return setFavorite(savedSearchMgr, false)
+}
+
+func setFavorite(savedSearchMgr *savedsearch.SavedSearchManager, favorite bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		name := chi.URLParam(r, "savedSearchName")
+		logger.Info("Setting favorite of savedSearch...", "savedSearch", name, "favorite", favorite)
+
+		savedSearch, err := savedSearchMgr.SetFavorite(ctx, handler.UserName(ctx), name, favorite)
+		if err != nil {
+			renderError(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, savedSearch)
+	}
+}
+
+// renderError renders the error of the SavedSearchManager with the matching
+// status code.
+func renderError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, savedsearch.ErrSavedSearchNotFound):
+		handler.NotFoundRender(ctx, w, r, err)
+	case errors.Is(err, savedsearch.ErrSavedSearchAlreadyExists):
+		handler.FailureWithCodeRender(ctx, w, r, err, http.StatusConflict)
+	case errors.Is(err, savedsearch.ErrNilSavedSearch),
+		errors.Is(err, savedsearch.ErrMissingSavedSearchName),
+		errors.Is(err, savedsearch.ErrInvalidSavedSearch),
+		errors.Is(err, savedsearch.ErrSavedSearchNameCannotModify):
+		handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+	default:
+		handler.FailureRender(ctx, w, r, err)
+	}
+}
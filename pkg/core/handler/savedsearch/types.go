@@ -0,0 +1,65 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+)
+
+// Ensure that SavedSearchPayload implements the handler.Payload interface.
+var _ handler.Payload = &SavedSearchPayload{}
+
+type SavedSearchPayload struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Pattern     string   `json:"pattern"`
+	Query       string   `json:"query"`
+	Format      string   `json:"format"`
+	Columns     []string `json:"columns"`
+	Visibility  string   `json:"visibility"`
+}
+
+// Decode detects the correct decoder for use on an HTTP request and
+// marshals into a given interface.
+func (p *SavedSearchPayload) Decode(r *http.Request) error {
+	switch render.GetRequestContentType(r) {
+	case render.ContentTypeJSON:
+		if err := render.DecodeJSON(r.Body, p); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported media type")
+	}
+
+	return nil
+}
+
+// ToEntity converts the payload struct to the corresponding entity struct.
+func (p *SavedSearchPayload) ToEntity() *entity.SavedSearch {
+	return &entity.SavedSearch{
+		Name:        p.Name,
+		Description: p.Description,
+		Pattern:     p.Pattern,
+		Query:       p.Query,
+		Format:      p.Format,
+		Columns:     p.Columns,
+		Visibility:  p.Visibility,
+	}
+}
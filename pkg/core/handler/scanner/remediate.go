@@ -0,0 +1,178 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/core/manager/insight"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"k8s.io/apiserver/pkg/server"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// RemediateRequest represents the request body for issue remediation
+type RemediateRequest struct {
+	Language string `json:"language"`
+	// Issues are the issues to fix, default to the issues found by scanning
+	// the resource
+	Issues scanner.IssueList `json:"issues"`
+	// DryRun performs a server-side dry run of the patch against the cluster
+	DryRun bool `json:"dryRun"`
+}
+
+// RemediateIssues returns an HTTP handler function that suggests a patch
+// fixing the issues of a resource using AI and validates it
+//
+// @Summary      Suggest a patch fixing the issues of a resource using AI
+// @Description  This endpoint streams a patch suggested by AI for the issues of a resource, then validates it by applying it to a copy of the resource and scanning the result, and optionally by a server-side dry run. The patch is never applied to the cluster.
+// @Tags         insight
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        cluster     query     string            true   "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion  query     string            true   "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded"
+// @Param        kind        query     string            true   "The specified kind, such as 'Deployment'"
+// @Param        namespace   query     string            false  "The specified namespace, such as 'default'"
+// @Param        name        query     string            true   "The specified resource name, such as 'foo'"
+// @Param        request     body      RemediateRequest  false  "The language, the issues to fix and whether to dry run the patch"
+// @Success      200         {object}  ai.RemediationEvent
+// @Failure      400         {string}  string  "Bad Request"
+// @Failure      401         {string}  string  "Unauthorized"
+// @Failure      404         {string}  string  "Not Found"
+// @Failure      429         {string}  string  "Too Many Requests"
+// @Failure      500         {string}  string  "Internal Server Error"
+// @Router       /rest-api/v1/insight/issue/remediation/stream [post]
+func RemediateIssues(aiMgr *ai.AIManager, insightMgr *insight.InsightManager, c *server.CompletedConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request
+		ctx := r.Context()
+		logger := ctxutil.GetLogger(ctx)
+
+		if err := ai.CheckAIManager(aiMgr); err != nil {
+			logger.Error(err, "AI manager is not available")
+			http.Error(w, "AI service is not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		// Parse request body, which is optional
+		var req RemediateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("invalid request format: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			return
+		}
+		if resourceGroupType, ok := resourceGroup.GetType(); !ok || (resourceGroupType != entity.Resource && resourceGroupType != entity.NonNamespacedResource) {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("cluster, apiVersion, kind and name of the resource are required"), http.StatusBadRequest)
+			return
+		}
+		if err = authz.Authorize(ctx, resourceGroup); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		client, err := multicluster.BuildMultiClusterClient(ctx, c.LoopbackClientConfig, resourceGroup.Cluster)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		// Get the resource and its issues before streaming, so that a
+		// missing resource is reported as an error response
+		obj, err := insightMgr.GetResource(ctx, client, &resourceGroup)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		yaml, err := k8syaml.Marshal(obj.Object)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		issues := req.Issues
+		if len(issues) == 0 {
+			if issues, err = insightMgr.ScanObject(ctx, &resourceGroup, obj); err != nil {
+				handler.FailureRender(ctx, w, r, err)
+				return
+			}
+		}
+		if len(issues) == 0 {
+			handler.FailureWithCodeRender(ctx, w, r, fmt.Errorf("no issues to remediate"), http.StatusBadRequest)
+			return
+		}
+
+		// Set headers for SSE
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		// Create channel for remediation events, the suggested patch is
+		// validated before the remediation completes
+		eventChan := make(chan *ai.RemediationEvent, 10)
+		go func() {
+			defer close(eventChan)
+
+			patch, err := aiMgr.SuggestPatch(ctx, resourceGroup, string(yaml), issues, req.Language, eventChan)
+			if err != nil {
+				logger.Error(err, "Failed to suggest patch")
+				// Error has been sent through eventChan
+				return
+			}
+			result, err := insightMgr.ValidatePatch(ctx, client, &resourceGroup, obj, issues, patch, req.DryRun)
+			if err != nil {
+				logger.Error(err, "Failed to validate patch")
+				eventChan <- &ai.RemediationEvent{
+					Type:    "error",
+					Content: fmt.Sprintf("Failed to validate patch: %v", err),
+				}
+				return
+			}
+			eventChan <- &ai.RemediationEvent{Type: "result", Result: result}
+			eventChan <- &ai.RemediationEvent{Type: "complete"}
+		}()
+
+		// Stream events to client
+		for event := range eventChan {
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Error(err, "Failed to marshal remediation event")
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
@@ -15,34 +15,43 @@
 package scanner
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	_ "github.com/KusionStack/karpor/pkg/core/manager/ai"
 	"github.com/KusionStack/karpor/pkg/core/manager/insight"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/report"
 	"github.com/KusionStack/karpor/pkg/util/ctxutil"
 )
 
 // Audit handles the auditing process based on the specified resource group.
 //
 // @Summary      Audit based on resource group.
-// @Description  This endpoint audits based on the specified resource group.
+// @Description  This endpoint audits based on the specified resource group. The result is exported as a report file when the format is sarif, junit or csv.
 // @Tags         insight
 // @Produce      json
-// @Param        cluster     query     string        false  "The specified cluster name, such as 'example-cluster'"
-// @Param        apiVersion  query     string        false  "The specified apiVersion, such as 'apps/v1'"
-// @Param        kind        query     string        false  "The specified kind, such as 'Deployment'"
-// @Param        namespace   query     string        false  "The specified namespace, such as 'default'"
-// @Param        name        query     string        false  "The specified resource name, such as 'foo'"
-// @Param        forceNew    query     bool          false  "Switch for forced scanning, default is 'false'"
-// @Success      200         {object}  ai.AuditData  "Audit results"
-// @Failure      400         {string}  string        "Bad Request"
-// @Failure      401         {string}  string        "Unauthorized"
-// @Failure      429         {string}  string        "Too Many Requests"
-// @Failure      404         {string}  string        "Not Found"
-// @Failure      500         {string}  string        "Internal Server Error"
+// @Produce      application/sarif+json
+// @Produce      xml
+// @Produce      text/csv
+// @Param        cluster          query     string        false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion       query     string        false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind             query     string        false  "The specified kind, such as 'Deployment'"
+// @Param        namespace        query     string        false  "The specified namespace, such as 'default'"
+// @Param        name             query     string        false  "The specified resource name, such as 'foo'"
+// @Param        forceNew         query     bool          false  "Switch for forced scanning, default is 'false'"
+// @Param        format           query     string        false  "The format of the result, one of 'json', 'sarif', 'junit' or 'csv', default is 'json'"
+// @Param        clusterSelector  query     string        false  "The label selector of the clusters to cover, such as 'env=prod'"
+// @Success      200              {object}  ai.AuditData  "Audit results"
+// @Failure      400              {string}  string        "Bad Request"
+// @Failure      401              {string}  string        "Unauthorized"
+// @Failure      429              {string}  string        "Too Many Requests"
+// @Failure      404              {string}  string        "Not Found"
+// @Failure      500              {string}  string        "Internal Server Error"
 // @Router       /rest-api/v1/insight/audit [get]
 func Audit(insight *insight.InsightManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +70,16 @@ func Audit(insight *insight.InsightManager) http.HandlerFunc {
 		}
 		forceNew, _ := strconv.ParseBool(r.URL.Query().Get("forceNew"))
 
+		// Resolve the report format before auditing, so that an unsupported
+		// format fails fast.
+		var format *report.Format
+		if name := r.URL.Query().Get("format"); name != "" && !strings.EqualFold(name, "json") {
+			if format, err = report.Lookup(name); err != nil {
+				handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+				return
+			}
+		}
+
 		// Log successful decoding of the request body.
 		log.Info("Successfully decoded the query parameters to resourceGroup", "resourceGroup", resourceGroup)
 
@@ -71,6 +90,20 @@ func Audit(insight *insight.InsightManager) http.HandlerFunc {
 			return
 		}
 
+		if format != nil {
+			// Export the report into a buffer first, so that a failure can
+			// still be rendered as a regular error response.
+			buf := &bytes.Buffer{}
+			if err = format.Write(buf, scanResult); err != nil {
+				handler.FailureRender(ctx, w, r, err)
+				return
+			}
+			w.Header().Set("Content-Type", format.ContentType)
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=audit.%s", format.Extension))
+			w.Write(buf.Bytes())
+			return
+		}
+
 		data := convertScanResultToAuditData(scanResult)
 
 		handler.SuccessRender(ctx, w, r, data)
@@ -85,18 +118,19 @@ func Audit(insight *insight.InsightManager) http.HandlerFunc {
 // @Description  This endpoint calculates a score for the provided manifest based on the number and severity of issues detected during the audit.
 // @Tags         insight
 // @Produce      json
-// @Param        cluster     query     string             false  "The specified cluster name, such as 'example-cluster'"
-// @Param        apiVersion  query     string             false  "The specified apiVersion, such as 'apps/v1'"
-// @Param        kind        query     string             false  "The specified kind, such as 'Deployment'"
-// @Param        namespace   query     string             false  "The specified namespace, such as 'default'"
-// @Param        name        query     string             false  "The specified resource name, such as 'foo'"
-// @Param        forceNew    query     bool               false  "Switch for forced compute score, default is 'false'"
-// @Success      200         {object}  insight.ScoreData  "Score calculation result"
-// @Failure      400         {string}  string             "Bad Request"
-// @Failure      401         {string}  string             "Unauthorized"
-// @Failure      429         {string}  string             "Too Many Requests"
-// @Failure      404         {string}  string             "Not Found"
-// @Failure      500         {string}  string             "Internal Server Error"
+// @Param        cluster          query     string             false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion       query     string             false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind             query     string             false  "The specified kind, such as 'Deployment'"
+// @Param        namespace        query     string             false  "The specified namespace, such as 'default'"
+// @Param        name             query     string             false  "The specified resource name, such as 'foo'"
+// @Param        forceNew         query     bool               false  "Switch for forced compute score, default is 'false'"
+// @Param        clusterSelector  query     string             false  "The label selector of the clusters to cover, such as 'env=prod'"
+// @Success      200              {object}  insight.ScoreData  "Score calculation result"
+// @Failure      400              {string}  string             "Bad Request"
+// @Failure      401              {string}  string             "Unauthorized"
+// @Failure      429              {string}  string             "Too Many Requests"
+// @Failure      404              {string}  string             "Not Found"
+// @Failure      500              {string}  string             "Internal Server Error"
 // @Router       /rest-api/v1/insight/score [get]
 func Score(insightMgr *insight.InsightManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
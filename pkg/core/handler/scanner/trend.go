@@ -0,0 +1,175 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	"github.com/KusionStack/karpor/pkg/core/manager/insight"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+)
+
+// defaultTrendRange is the time range of the trend when it is not specified.
+const defaultTrendRange = 30 * 24 * time.Hour
+
+// ScoreTrend returns an HTTP handler function that returns the score of the
+// resource group over time.
+//
+// @Summary      Get the score trend of the resource group.
+// @Description  This endpoint returns the daily or weekly score of the specified resource group, recorded by the background audit.
+// @Tags         insight
+// @Produce      json
+// @Param        cluster     query     string                      false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion  query     string                      false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind        query     string                      false  "The specified kind, such as 'Deployment'"
+// @Param        namespace   query     string                      false  "The specified namespace, such as 'default'"
+// @Param        name        query     string                      false  "The specified resource name, such as 'foo'"
+// @Param        interval    query     string                      false  "The period of each point, 'day' or 'week', default is 'day'"
+// @Param        from        query     string                      false  "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago"
+// @Param        to          query     string                      false  "The end time in RFC3339 or YYYY-MM-DD format, default is now"
+// @Success      200         {array}   insight.ScoreTrendPoint     "Score trend"
+// @Failure      400         {string}  string                      "Bad Request"
+// @Failure      401         {string}  string                      "Unauthorized"
+// @Failure      429         {string}  string                      "Too Many Requests"
+// @Failure      404         {string}  string                      "Not Found"
+// @Failure      500         {string}  string                      "Internal Server Error"
+// @Router       /rest-api/v1/insight/score/trend [get]
+func ScoreTrend(insightMgr *insight.InsightManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		log := ctxutil.GetLogger(ctx)
+
+		log.Info("Starting get score trend with specified resourceGroup in handler...")
+
+		// Decode the query parameters into the resourceGroup.
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		from, to, err := parseTimeRange(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		interval := r.URL.Query().Get("interval")
+		if interval == "" {
+			interval = insight.TrendIntervalDay
+		}
+
+		data, err := insightMgr.ScoreTrend(ctx, resourceGroup, interval, from, to)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, data)
+	}
+}
+
+// IssueHistory returns an HTTP handler function that returns the issues
+// introduced and resolved in a period of time.
+//
+// @Summary      Get the issues introduced and resolved between two dates.
+// @Description  This endpoint returns the issues of the specified resource group which are introduced or resolved between two dates, except the ones suppressed by audit exceptions.
+// @Tags         insight
+// @Produce      json
+// @Param        cluster     query     string                false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion  query     string                false  "The specified apiVersion, such as 'apps/v1'"
+// @Param        kind        query     string                false  "The specified kind, such as 'Deployment'"
+// @Param        namespace   query     string                false  "The specified namespace, such as 'default'"
+// @Param        name        query     string                false  "The specified resource name, such as 'foo'"
+// @Param        from        query     string                false  "The start time in RFC3339 or YYYY-MM-DD format, default is 30 days ago"
+// @Param        to          query     string                false  "The end time in RFC3339 or YYYY-MM-DD format, default is now"
+// @Success      200         {object}  insight.IssueHistory  "Introduced and resolved issues"
+// @Failure      400         {string}  string                "Bad Request"
+// @Failure      401         {string}  string                "Unauthorized"
+// @Failure      429         {string}  string                "Too Many Requests"
+// @Failure      404         {string}  string                "Not Found"
+// @Failure      500         {string}  string                "Internal Server Error"
+// @Router       /rest-api/v1/insight/issues/history [get]
+func IssueHistory(insightMgr *insight.InsightManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the context and logger from the request.
+		ctx := r.Context()
+		log := ctxutil.GetLogger(ctx)
+
+		log.Info("Starting get issue history with specified resourceGroup in handler...")
+
+		// Decode the query parameters into the resourceGroup.
+		resourceGroup, err := entity.NewResourceGroupFromQuery(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+		from, to, err := parseTimeRange(r)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		data, err := insightMgr.IssueHistory(ctx, resourceGroup, from, to)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
+
+		handler.SuccessRender(ctx, w, r, data)
+	}
+}
+
+// parseTimeRange parses the from and to query parameters of the request. A
+// date without time in the to parameter stands for the end of that day.
+func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now()
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, dateOnly, err := parseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if dateOnly {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultTrendRange)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, _, err := parseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = t
+	}
+
+	return from, to, nil
+}
+
+// parseTime parses the time in RFC3339 or YYYY-MM-DD format, and reports
+// whether it is a date only.
+func parseTime(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid time %q, expected RFC3339 or YYYY-MM-DD format", v)
+}
@@ -27,6 +27,7 @@ import (
 func convertScanResultToAuditData(sr scanner.ScanResult) *ai.AuditData {
 	issueGroups := make([]*ai.IssueGroup, 0, len(sr.ByIssue()))
 	bySeverity := map[string]int{}
+	issueTotal, suppressedTotal := 0, 0
 
 	// Iterate through each issue in the ScanResult and create corresponding
 	// IssueGroup entries.
@@ -37,10 +38,15 @@ func convertScanResultToAuditData(sr scanner.ScanResult) *ai.AuditData {
 		}
 
 		// For each resource tied to the issue, create a ResourceGroup and increment
-		// severity count.
+		// severity count. Suppressed issues are listed but not counted.
 		for _, resource := range resources {
 			issueGroup.ResourceGroups = append(issueGroup.ResourceGroups, resource.ResourceGroup)
-			bySeverity[issue.Severity.String()]++
+			if issue.Suppressed {
+				suppressedTotal++
+			} else {
+				issueTotal++
+				bySeverity[issue.Severity.String()]++
+			}
 		}
 		issueGroups = append(issueGroups, issueGroup)
 	}
@@ -61,9 +67,10 @@ func convertScanResultToAuditData(sr scanner.ScanResult) *ai.AuditData {
 
 	// Construct the AuditData structure.
 	return &ai.AuditData{
-		IssueTotal:    sr.IssueTotal(),
-		ResourceTotal: len(sr.ByResource()),
-		BySeverity:    bySeverity,
-		IssueGroups:   issueGroups,
+		IssueTotal:      issueTotal,
+		SuppressedTotal: suppressedTotal,
+		ResourceTotal:   len(sr.ByResource()),
+		BySeverity:      bySeverity,
+		IssueGroups:     issueGroups,
 	}
 }
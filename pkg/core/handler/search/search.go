@@ -15,16 +15,20 @@
 package search
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/core/manager/savedsearch"
 	"github.com/KusionStack/karpor/pkg/core/manager/search"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/go-chi/chi/v5"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,118 +40,195 @@ import (
 // @Description  This endpoint returns an array of Kubernetes runtime Object matched using the query from context.
 // @Tags         search
 // @Produce      json
-// @Param        query     query     string          true   "The query to use for search. Required"
-// @Param        pattern   query     string          true   "The search pattern. Can be either sql, dsl or nl. Required"
-// @Param        pageSize  query     string          false  "The size of the page. Default to 10"
-// @Param        page      query     string          false  "The current page to fetch. Default to 1"
-// @Param        keyword   query     string          false  "The keyword to use for search. Optional"
-// @Success      200       {array}   runtime.Object  "Array of runtime.Object"
-// @Failure      400       {string}  string          "Bad Request"
-// @Failure      401       {string}  string          "Unauthorized"
-// @Failure      404       {string}  string          "Not Found"
-// @Failure      405       {string}  string          "Method Not Allowed"
-// @Failure      429       {string}  string          "Too Many Requests"
-// @Failure      500       {string}  string          "Internal Server Error"
+// @Param        query            query     string          true   "The query to use for search. Required"
+// @Param        pattern          query     string          true   "The search pattern. Can be either sql, dsl or nl. Required"
+// @Param        pageSize         query     string          false  "The size of the page. Default to 10"
+// @Param        page             query     string          false  "The current page to fetch. Default to 1"
+// @Param        keyword          query     string          false  "The keyword to use for search. Optional"
+// @Param        clusterSelector  query     string          false  "The label selector of the clusters to search, such as 'env=prod'"
+// @Success      200              {array}   runtime.Object  "Array of runtime.Object"
+// @Failure      400              {string}  string          "Bad Request"
+// @Failure      401              {string}  string          "Unauthorized"
+// @Failure      404              {string}  string          "Not Found"
+// @Failure      405              {string}  string          "Method Not Allowed"
+// @Failure      429              {string}  string          "Too Many Requests"
+// @Failure      500              {string}  string          "Internal Server Error"
 // @Router       /rest-api/v1/search [get]
 func SearchForResource(searchMgr *search.SearchManager, aiMgr *ai.AIManager, searchStorage storage.SearchStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract URL query parameters with default value
+		searchPageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		searchPage, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		runSearch(w, r, aiMgr, searchStorage, &searchParams{
+			query:    r.URL.Query().Get("query"),
+			pattern:  r.URL.Query().Get("pattern"),
+			keyword:  r.URL.Query().Get("keyword"),
+			format:   r.URL.Query().Get("format"),
+			page:     searchPage,
+			pageSize: searchPageSize,
+		})
+	}
+}
+
+// RunSavedSearch returns an HTTP handler function that runs a saved search
+// by its name. The URL of the endpoint is the shareable link of the saved
+// search. It utilizes a SavedSearchManager to execute the logic.
+//
+// @Summary      RunSavedSearch runs a saved search by name.
+// @Description  This endpoint returns the resources matched by the query of the saved search, in the format of the saved search.
+// @Tags         search
+// @Produce      json
+// @Param        savedSearchName  path      string          true   "The name of the saved search"
+// @Param        pageSize         query     string          false  "The size of the page. Default to 10"
+// @Param        page             query     string          false  "The current page to fetch. Default to 1"
+// @Param        clusterSelector  query     string          false  "The label selector of the clusters to search, such as 'env=prod'"
+// @Success      200              {array}   runtime.Object  "Array of runtime.Object"
+// @Failure      400              {string}  string          "Bad Request"
+// @Failure      401              {string}  string          "Unauthorized"
+// @Failure      404              {string}  string          "Not Found"
+// @Failure      405              {string}  string          "Method Not Allowed"
+// @Failure      429              {string}  string          "Too Many Requests"
+// @Failure      500              {string}  string          "Internal Server Error"
+// @Router       /rest-api/v1/saved-search/{savedSearchName}/run [get]
+func RunSavedSearch(savedSearchMgr *savedsearch.SavedSearchManager, aiMgr *ai.AIManager, searchStorage storage.SearchStorage) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Extract the context and logger from the request.
 		ctx := r.Context()
 		logger := ctxutil.GetLogger(ctx)
 
-		// Extract URL query parameters with default value
-		searchQuery := r.URL.Query().Get("query")
-		searchPattern := r.URL.Query().Get("pattern")
-		searchPageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
-		searchPage, _ := strconv.Atoi(r.URL.Query().Get("page"))
-		searchKeyword := r.URL.Query().Get("keyword")
+		name := chi.URLParam(r, "savedSearchName")
+		logger.Info("Running saved search...", "savedSearch", name)
 
-		format := r.URL.Query().Get("format")
-		formatter, err := ParseObjectFormatter(format)
+		savedSearch, err := savedSearchMgr.GetSavedSearch(ctx, handler.UserName(ctx), name)
 		if err != nil {
-			handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+			if errors.Is(err, savedsearch.ErrSavedSearchNotFound) {
+				handler.NotFoundRender(ctx, w, r, err)
+			} else {
+				handler.FailureRender(ctx, w, r, err)
+			}
 			return
 		}
 
-		if searchPageSize <= 1 {
-			searchPageSize = 10
+		searchPageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		searchPage, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		runSearch(w, r, aiMgr, searchStorage, &searchParams{
+			query:    savedSearch.Query,
+			pattern:  savedSearch.Pattern,
+			format:   savedSearch.FormatSpec(),
+			page:     searchPage,
+			pageSize: searchPageSize,
+		})
+	}
+}
+
+// searchParams are the parameters of a search for resources.
+type searchParams struct {
+	query    string
+	pattern  string
+	keyword  string
+	format   string
+	page     int
+	pageSize int
+}
+
+// runSearch searches for the resources with the given parameters and
+// renders the results.
+func runSearch(w http.ResponseWriter, r *http.Request, aiMgr *ai.AIManager, searchStorage storage.SearchStorage, params *searchParams) {
+	// Extract the context and logger from the request.
+	ctx := r.Context()
+	logger := ctxutil.GetLogger(ctx)
+
+	searchQuery := params.query
+	searchPattern := params.pattern
+	searchPageSize := params.pageSize
+	searchPage := params.page
+	searchKeyword := params.keyword
+
+	formatter, err := ParseObjectFormatter(params.format)
+	if err != nil {
+		handler.FailureWithCodeRender(ctx, w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if searchPageSize <= 1 {
+		searchPageSize = 10
+	}
+	if searchPage <= 1 {
+		searchPage = 1
+	}
+	if searchKeyword != "" && searchQuery != "" {
+		// TODO: Refactor to support keyword search in different search patterns (NL, DSL, etc.)
+		if searchPattern == storage.SQLPatternType {
+			searchKeyword = strings.ReplaceAll(searchKeyword, " ", "")
+			searchQuery = fmt.Sprintf("%s AND (name LIKE '%%%s%%' OR namespace LIKE '%%%s%%')",
+				searchQuery, searchKeyword, searchKeyword)
 		}
-		if searchPage <= 1 {
-			searchPage = 1
+	}
+
+	query := searchQuery
+
+	if searchPattern == storage.NLPatternType {
+		actionlog.Annotate(ctx, actionlog.ActionAICall, actionlog.DetailPromptType, string(ai.Text2sqlType))
+		if err := ai.CheckAIManager(aiMgr); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
 		}
-		if searchKeyword != "" && searchQuery != "" {
-			// TODO: Refactor to support keyword search in different search patterns (NL, DSL, etc.)
-			if searchPattern == storage.SQLPatternType {
-				searchKeyword = strings.ReplaceAll(searchKeyword, " ", "")
-				searchQuery = fmt.Sprintf("%s AND (name LIKE '%%%s%%' OR namespace LIKE '%%%s%%')",
-					searchQuery, searchKeyword, searchKeyword)
-			}
+		res, err := aiMgr.ConvertTextToSQL(ctx, searchQuery)
+		if err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
 		}
+		searchQuery = res
+	}
 
-		query := searchQuery
+	logger.Info("Searching for resources...", "page", searchPage, "pageSize", searchPageSize)
 
+	res, err := searchStorage.Search(ctx, searchQuery, searchPattern, &storage.Pagination{Page: searchPage, PageSize: searchPageSize})
+	if err != nil {
 		if searchPattern == storage.NLPatternType {
-			if err := ai.CheckAIManager(aiMgr); err != nil {
+			fixedQuery, fixErr := aiMgr.FixSQL(ctx, query, searchQuery, err.Error())
+			if fixErr != nil {
 				handler.FailureRender(ctx, w, r, err)
 				return
 			}
-			res, err := aiMgr.ConvertTextToSQL(searchQuery)
+			searchQuery = fixedQuery
+			res, err = searchStorage.Search(ctx, searchQuery, searchPattern, &storage.Pagination{Page: searchPage, PageSize: searchPageSize})
 			if err != nil {
 				handler.FailureRender(ctx, w, r, err)
 				return
 			}
-			searchQuery = res
+		} else {
+			handler.FailureRender(ctx, w, r, err)
+			return
 		}
+	}
 
-		logger.Info("Searching for resources...", "page", searchPage, "pageSize", searchPageSize)
+	if err != nil {
+		handler.FailureRender(ctx, w, r, err)
+		return
+	}
 
-		res, err := searchStorage.Search(ctx, searchQuery, searchPattern, &storage.Pagination{Page: searchPage, PageSize: searchPageSize})
-		if err != nil {
-			if searchPattern == storage.NLPatternType {
-				fixedQuery, fixErr := aiMgr.FixSQL(query, searchQuery, err.Error())
-				if fixErr != nil {
-					handler.FailureRender(ctx, w, r, err)
-					return
-				}
-				searchQuery = fixedQuery
-				res, err = searchStorage.Search(ctx, searchQuery, searchPattern, &storage.Pagination{Page: searchPage, PageSize: searchPageSize})
-				if err != nil {
-					handler.FailureRender(ctx, w, r, err)
-					return
-				}
-			} else {
-				handler.FailureRender(ctx, w, r, err)
-				return
-			}
-		}
+	rt := &search.UniResourceList{}
+	for _, res := range res.Resources {
+		unObj := &unstructured.Unstructured{}
+		unObj.SetUnstructuredContent(res.Object)
 
+		obj, err := formatter.Format(unObj)
 		if err != nil {
 			handler.FailureRender(ctx, w, r, err)
 			return
 		}
 
-		rt := &search.UniResourceList{}
-		for _, res := range res.Resources {
-			unObj := &unstructured.Unstructured{}
-			unObj.SetUnstructuredContent(res.Object)
-
-			obj, err := formatter.Format(unObj)
-			if err != nil {
-				handler.FailureRender(ctx, w, r, err)
-				return
-			}
-
-			rt.Items = append(rt.Items, search.UniResource{
-				Cluster: res.Cluster,
-				Object:  obj,
-				SyncAt:  res.SyncAt,
-				Deleted: res.Deleted,
-			})
-		}
-		rt.SQLQuery = searchQuery
-		rt.Total = res.Total
-		rt.CurrentPage = searchPage
-		rt.PageSize = searchPageSize
-		handler.SuccessRender(ctx, w, r, rt)
+		rt.Items = append(rt.Items, search.UniResource{
+			Cluster: res.Cluster,
+			Object:  obj,
+			SyncAt:  res.SyncAt,
+			Deleted: res.Deleted,
+		})
 	}
+	rt.SQLQuery = searchQuery
+	rt.Total = res.Total
+	rt.CurrentPage = searchPage
+	rt.PageSize = searchPageSize
+	handler.SuccessRender(ctx, w, r, rt)
 }
@@ -26,16 +26,17 @@ import (
 // info. It utilizes an InsightManager to execute the logic.
 //
 // @Summary      Get returns a global statistics info.
-// @Description  This endpoint returns a global statistics info.
+// @Description  This endpoint returns a global statistics info, optionally restricted to the clusters selected by labels.
 // @Tags         insight
 // @Produce      json
-// @Success      200  {object}  insight.Statistics  "Global statistics info"
-// @Failure      400  {string}  string              "Bad Request"
-// @Failure      401  {string}  string              "Unauthorized"
-// @Failure      404  {string}  string              "Not Found"
-// @Failure      405  {string}  string              "Method Not Allowed"
-// @Failure      429  {string}  string              "Too Many Requests"
-// @Failure      500  {string}  string              "Internal Server Error"
+// @Param        clusterSelector  query     string              false  "The label selector of the clusters to cover, such as 'env=prod'"
+// @Success      200              {object}  insight.Statistics  "Global statistics info"
+// @Failure      400              {string}  string              "Bad Request"
+// @Failure      401              {string}  string              "Unauthorized"
+// @Failure      404              {string}  string              "Not Found"
+// @Failure      405              {string}  string              "Method Not Allowed"
+// @Failure      429              {string}  string              "Too Many Requests"
+// @Failure      500              {string}  string              "Internal Server Error"
 // @Router       /rest-api/v1/insight/stats [get]
 func GetStatistics(insightMgr *insight.InsightManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
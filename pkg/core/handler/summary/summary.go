@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/insight"
@@ -57,6 +58,10 @@ func GetSummary(insightMgr *insight.InsightManager, c *server.CompletedConfig) h
 			handler.FailureRender(ctx, w, r, err)
 			return
 		}
+		if err = authz.Authorize(ctx, resourceGroup); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
 		logger.Info("Getting summary for resourceGroup...", "resourceGroup", resourceGroup)
 
 		client, err := multicluster.BuildMultiClusterClient(r.Context(), c.LoopbackClientConfig, resourceGroup.Cluster)
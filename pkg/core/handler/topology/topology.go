@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/core/handler"
 	"github.com/KusionStack/karpor/pkg/core/manager/cluster"
@@ -35,19 +36,20 @@ import (
 // @Description  This endpoint returns a topology map for a Kubernetes resource by name, namespace, cluster, apiVersion and kind.
 // @Tags         insight
 // @Produce      json
-// @Param        cluster     query     string                                          false  "The specified cluster name, such as 'example-cluster'"
-// @Param        apiVersion  query     string                                          false  "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded"
-// @Param        kind        query     string                                          false  "The specified kind, such as 'Deployment'"
-// @Param        namespace   query     string                                          false  "The specified namespace, such as 'default'"
-// @Param        name        query     string                                          false  "The specified resource name, such as 'foo'"
-// @Param        forceNew    query     bool                                            false  "Force re-generating the topology, default is 'false'"
-// @Success      200         {object}  map[string]map[string]insight.ResourceTopology  "map from string to resource.ResourceTopology"
-// @Failure      400         {string}  string                                          "Bad Request"
-// @Failure      401         {string}  string                                          "Unauthorized"
-// @Failure      404         {string}  string                                          "Not Found"
-// @Failure      405         {string}  string                                          "Method Not Allowed"
-// @Failure      429         {string}  string                                          "Too Many Requests"
-// @Failure      500         {string}  string                                          "Internal Server Error"
+// @Param        cluster          query     string                                          false  "The specified cluster name, such as 'example-cluster'"
+// @Param        apiVersion       query     string                                          false  "The specified apiVersion, such as 'apps/v1'. Should be percent-encoded"
+// @Param        kind             query     string                                          false  "The specified kind, such as 'Deployment'"
+// @Param        namespace        query     string                                          false  "The specified namespace, such as 'default'"
+// @Param        name             query     string                                          false  "The specified resource name, such as 'foo'"
+// @Param        forceNew         query     bool                                            false  "Force re-generating the topology, default is 'false'"
+// @Param        clusterSelector  query     string                                          false  "The label selector of the clusters to cover, such as 'env=prod'"
+// @Success      200              {object}  map[string]map[string]insight.ResourceTopology  "map from string to resource.ResourceTopology"
+// @Failure      400              {string}  string                                          "Bad Request"
+// @Failure      401              {string}  string                                          "Unauthorized"
+// @Failure      404              {string}  string                                          "Not Found"
+// @Failure      405              {string}  string                                          "Method Not Allowed"
+// @Failure      429              {string}  string                                          "Too Many Requests"
+// @Failure      500              {string}  string                                          "Internal Server Error"
 // @Router       /rest-api/v1/insight/topology [get]
 func GetTopology(clusterMgr *cluster.ClusterManager, insightMgr *insight.InsightManager, c *server.CompletedConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +63,10 @@ func GetTopology(clusterMgr *cluster.ClusterManager, insightMgr *insight.Insight
 			handler.FailureRender(ctx, w, r, err)
 			return
 		}
+		if err = authz.Authorize(ctx, resourceGroup); err != nil {
+			handler.FailureRender(ctx, w, r, err)
+			return
+		}
 		logger.Info("Getting topology for resourceGroup...", "resourceGroup", resourceGroup)
 
 		clusterName := resourceGroup.Cluster
@@ -85,11 +91,19 @@ func GetTopology(clusterMgr *cluster.ClusterManager, insightMgr *insight.Insight
 			}
 			var clusterNames []string
 			if len(resourceGroup.Cluster) == 0 {
-				clusterNames, err = clusterMgr.ListClusterName(ctx, client, cluster.ByName, false)
+				allClusterNames, err := clusterMgr.ListClusterName(ctx, client, "", cluster.ByName, false)
 				if err != nil {
 					handler.FailureRender(ctx, w, r, err)
 					return
 				}
+				// Leave out the clusters not granted or not selected by the
+				// cluster selector.
+				filter := authz.FilterFrom(ctx)
+				for _, name := range allClusterNames {
+					if filter.AllowsCluster(name) {
+						clusterNames = append(clusterNames, name)
+					}
+				}
 			} else {
 				clusterNames = []string{resourceGroup.Cluster}
 			}
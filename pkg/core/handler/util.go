@@ -21,6 +21,8 @@ import (
 	"github.com/KusionStack/karpor/pkg/util/ctxutil"
 	"github.com/go-chi/render"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
 // HandleResult is a handler function that writes the response to the HTTP response writer based on the provided error and data.
@@ -47,3 +49,12 @@ func RemoveUnstructuredManagedFields(
 	}
 	return sanitized, nil
 }
+
+// UserName returns the name of the user of the request, or the anonymous
+// user if the request is not authenticated.
+func UserName(ctx context.Context) string {
+	if u, ok := request.UserFrom(ctx); ok {
+		return u.GetName()
+	}
+	return user.Anonymous
+}
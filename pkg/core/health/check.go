@@ -24,10 +24,14 @@ import (
 )
 
 // Register registers the livez and readyz handlers to the specified
-// router.
-func Register(r *chi.Mux, sg storage.Storage) {
+// router, and the credentialz handler if the credentials of the clusters
+// are tracked.
+func Register(r *chi.Mux, sg storage.Storage, cs CredentialSource) {
 	r.Get("/livez", NewLivezHandler())
 	r.Get("/readyz", NewReadyzHandler(sg))
+	if cs != nil {
+		r.Get("/credentialz", NewCredentialzHandler(cs))
+	}
 }
 
 // NewLivezHandler creates a new liveness check handler that can be
@@ -62,6 +66,22 @@ func NewReadyzHandler(sg storage.Storage) http.HandlerFunc {
 	return NewHandler(conf)
 }
 
+// NewCredentialzHandler creates a new credential check handler that can be
+// used to warn about the clusters whose credentials are expiring. It is
+// kept apart from the readiness check, since expiring credentials do not
+// prevent the application from serving traffic.
+func NewCredentialzHandler(cs CredentialSource) http.HandlerFunc {
+	conf := HandlerConfig{
+		Verbose: true,
+		Checks: []Check{
+			NewCredentialCheck(cs),
+		},
+		FailureNotification: FailureNotification{Threshold: 1},
+	}
+
+	return NewHandler(conf)
+}
+
 func NewStorageCheck(sg storage.Storage) Check {
 	return NewStorageCheckHandler(sg)
 }
@@ -0,0 +1,46 @@
+/*
+Copyright The Karpor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+)
+
+// CredentialSource reports the clusters whose credentials are expiring.
+type CredentialSource interface {
+	ExpiringClusters(ctx context.Context) []string
+}
+
+// CredentialCheck fails when the credential of any cluster has expired or
+// expires soon.
+type CredentialCheck struct {
+	source CredentialSource
+}
+
+func NewCredentialCheck(source CredentialSource) Check {
+	return &CredentialCheck{
+		source: source,
+	}
+}
+
+func (c *CredentialCheck) Pass(ctx context.Context) bool {
+	return len(c.source.ExpiringClusters(ctx)) == 0
+}
+
+func (c *CredentialCheck) Name() string {
+	return "CredentialCheck"
+}
@@ -44,15 +44,21 @@ func (a *AIManager) DiagnoseLogs(ctx context.Context, logs []string, language st
 		Content: "Starting log analysis...",
 	}
 
-	// Get prompt template and add language instruction
-	servicePrompt := ServicePromptMap[LogDiagnosisType]
+	// Render prompt template with language instruction
 	if language == "" {
 		language = "English"
 	}
-	prompt := fmt.Sprintf(servicePrompt, language, logsStr)
+	prompt, err := a.prompts.Render(LogDiagnosisType, PromptData{Language: language, Logs: logsStr})
+	if err != nil {
+		eventChan <- &DiagnosisEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to analyze logs: %v", err),
+		}
+		return err
+	}
 
 	// Generate diagnosis using LLM with streaming
-	stream, err := a.client.GenerateStream(ctx, prompt)
+	stream, err := a.generateStream(ctx, LogDiagnosisType, prompt)
 	if err != nil {
 		errEvent := &DiagnosisEvent{
 			Type:    "error",
@@ -142,15 +148,21 @@ func (a *AIManager) DiagnoseEvents(ctx context.Context, events []Event, language
 		Content: "Starting event analysis...",
 	}
 
-	// Get prompt template and add language instruction
-	servicePrompt := ServicePromptMap[EventDiagnosisType]
+	// Render prompt template with language instruction
 	if language == "" {
 		language = "English"
 	}
-	prompt := fmt.Sprintf(servicePrompt, language, eventsText.String())
+	prompt, err := a.prompts.Render(EventDiagnosisType, PromptData{Language: language, Events: eventsText.String()})
+	if err != nil {
+		eventChan <- &DiagnosisEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to analyze events: %v", err),
+		}
+		return err
+	}
 
 	// Generate diagnosis using LLM with streaming
-	stream, err := a.client.GenerateStream(ctx, prompt)
+	stream, err := a.generateStream(ctx, EventDiagnosisType, prompt)
 	if err != nil {
 		errEvent := &DiagnosisEvent{
 			Type:    "error",
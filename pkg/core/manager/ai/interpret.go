@@ -18,6 +18,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 // InterpretEvent represents a single event in the YAML interpretation stream
@@ -42,8 +45,20 @@ func (a *AIManager) InterpretYAML(ctx context.Context, yaml, language string, ev
 		return fmt.Errorf("YAML content cannot be empty")
 	}
 
-	// Build prompt from template
-	prompt := fmt.Sprintf(ServicePromptMap[YAMLInterpretType], language, yaml)
+	// Build prompt from template, the resource is located by its metadata
+	data := PromptData{Language: language, YAML: yaml}
+	obj := &unstructured.Unstructured{}
+	if err := sigsyaml.Unmarshal([]byte(yaml), &obj.Object); err == nil {
+		data.Kind, data.Namespace, data.Name = obj.GetKind(), obj.GetNamespace(), obj.GetName()
+	}
+	prompt, err := a.prompts.Render(YAMLInterpretType, data)
+	if err != nil {
+		eventChan <- &InterpretEvent{
+			Type:    "error",
+			Content: err.Error(),
+		}
+		return err
+	}
 
 	// Get AI service client
 	if a.client == nil {
@@ -55,7 +70,7 @@ func (a *AIManager) InterpretYAML(ctx context.Context, yaml, language string, ev
 	}
 
 	// Stream completion from AI service
-	stream, err := a.client.GenerateStream(ctx, prompt)
+	stream, err := a.generateStream(ctx, YAMLInterpretType, prompt)
 	if err != nil {
 		eventChan <- &InterpretEvent{
 			Type:    "error",
@@ -26,10 +26,11 @@ import (
 // AuditData represents the aggregated data of scanner issues, including the
 // original list of issues and their aggregated count based on title.
 type AuditData struct {
-	IssueTotal    int            `json:"issueTotal"`
-	ResourceTotal int            `json:"resourceTotal"`
-	BySeverity    map[string]int `json:"bySeverity"`
-	IssueGroups   []*IssueGroup  `json:"issueGroups"`
+	IssueTotal      int            `json:"issueTotal"`
+	SuppressedTotal int            `json:"suppressedTotal"`
+	ResourceTotal   int            `json:"resourceTotal"`
+	BySeverity      map[string]int `json:"bySeverity"`
+	IssueGroups     []*IssueGroup  `json:"issueGroups"`
 }
 
 // IssueGroup represents a group of resourceGroups tied to a specific issue.
@@ -73,6 +74,9 @@ func (a *AIManager) InterpretIssues(ctx context.Context, auditData *AuditData, l
 		if group.Issue.Message != "" {
 			summary.WriteString(fmt.Sprintf("Message: %s\n", group.Issue.Message))
 		}
+		if group.Issue.Suppressed {
+			summary.WriteString(fmt.Sprintf("Suppressed: accepted risk by exception %s\n", group.Issue.Exception))
+		}
 
 		summary.WriteString("\nAffected Resources:\n")
 		for _, rg := range group.ResourceGroups {
@@ -81,7 +85,14 @@ func (a *AIManager) InterpretIssues(ctx context.Context, auditData *AuditData, l
 	}
 
 	// Build prompt from template
-	prompt := fmt.Sprintf(ServicePromptMap[IssueInterpretType], language, summary.String())
+	prompt, err := a.prompts.Render(IssueInterpretType, PromptData{Language: language, Issues: summary.String()})
+	if err != nil {
+		eventChan <- &InterpretEvent{
+			Type:    "error",
+			Content: err.Error(),
+		}
+		return err
+	}
 
 	// Get AI service client
 	if a.client == nil {
@@ -93,7 +104,7 @@ func (a *AIManager) InterpretIssues(ctx context.Context, auditData *AuditData, l
 	}
 
 	// Stream completion from AI service
-	stream, err := a.client.GenerateStream(ctx, prompt)
+	stream, err := a.generateStream(ctx, IssueInterpretType, prompt)
 	if err != nil {
 		eventChan <- &InterpretEvent{
 			Type:    "error",
@@ -15,12 +15,22 @@
 package ai
 
 import (
+	"context"
+	"time"
+
 	"github.com/KusionStack/karpor/pkg/infra/ai"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/KusionStack/karpor/pkg/util/cache"
 )
 
 type AIManager struct {
 	client ai.AIProvider
+	// cache caches the responses by prompt type and prompt, it is nil if
+	// caching is disabled.
+	cache   *cache.Cache[string, string]
+	quota   *quota
+	usage   *usageTracker
+	prompts *PromptStore
 }
 
 // NewAIManager returns a new AIManager object
@@ -32,10 +42,45 @@ func NewAIManager(c registry.ExtraConfig) (*AIManager, error) {
 	if err := aiClient.Configure(ai.ConvertToAIConfig(c)); err != nil {
 		return nil, err
 	}
+	prompts, err := NewPromptStore(c.AIPromptDir)
+	if err != nil {
+		return nil, err
+	}
+
+	a := newAIManager(aiClient, c)
+	a.prompts = prompts
+	return a, nil
+}
+
+// newAIManager returns a new AIManager calling the AI provider with the
+// cache and the limits of the config and the built-in prompt templates.
+func newAIManager(client ai.AIProvider, c registry.ExtraConfig) *AIManager {
+	provider := c.AIBackend
+	if provider == "" {
+		provider = ai.OpenAIProvider
+	}
+
+	a := &AIManager{
+		client: client,
+		quota: newQuota(Limits{
+			UserRequestsPerMinute:   c.AIUserRequestsPerMinute,
+			UserTokensPerDay:        c.AIUserTokensPerDay,
+			GlobalRequestsPerMinute: c.AIGlobalRequestsPerMinute,
+			GlobalTokensPerDay:      c.AIGlobalTokensPerDay,
+		}),
+		usage:   newUsageTracker(provider),
+		prompts: &PromptStore{},
+	}
+	if c.AICacheTTL > 0 {
+		a.cache = cache.NewCache[string, string](c.AICacheTTL)
+	}
+	return a
+}
 
-	return &AIManager{
-		client: aiClient,
-	}, nil
+// RunPromptReloader reloads the prompt templates in each interval until the
+// context is done.
+func (a *AIManager) RunPromptReloader(ctx context.Context, interval time.Duration) {
+	a.prompts.Run(ctx, interval)
 }
 
 // CheckAIManager check if the AI manager is created
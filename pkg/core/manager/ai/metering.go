@@ -0,0 +1,175 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// cacheSweepSize is the number of cached responses beyond which the expired
+// responses are removed when a response is cached.
+const cacheSweepSize = 1000
+
+// CheckQuota returns an ErrQuotaExceeded error if the user of the context
+// has exhausted a budget of the AI calls.
+func (a *AIManager) CheckQuota(ctx context.Context) error {
+	return a.quota.check(userFrom(ctx))
+}
+
+// Usage returns the usage of the AI provider since the server started.
+func (a *AIManager) Usage() *Usage {
+	return a.usage.snapshot()
+}
+
+// generate generates a response to the prompt, which is served from the
+// cache if the same prompt of the type was answered within the TTL. The
+// calls to the provider are limited by the quota and recorded in the usage.
+func (a *AIManager) generate(ctx context.Context, promptType PromptType, prompt string) (string, error) {
+	user := userFrom(ctx)
+	key := cacheKey(promptType, prompt)
+	if a.cache != nil {
+		if res, ok := a.cache.Get(key); ok {
+			a.usage.record(usageRecord{promptType: promptType, user: user, cacheHit: true})
+			return res, nil
+		}
+	}
+	if err := a.quota.take(user); err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	res, tokens, err := a.client.Generate(ctx, prompt)
+	record := usageRecord{
+		promptType: promptType,
+		user:       user,
+		failed:     err != nil,
+		latency:    time.Since(start),
+	}
+	record.countTokens(tokens, prompt, res)
+	a.account(record)
+	if err != nil {
+		return "", err
+	}
+	a.store(key, res)
+	return res, nil
+}
+
+// generateStream generates a streaming response to the prompt like
+// generate. A cached response is sent as a single chunk, and a streamed
+// response is cached once it completes without error.
+func (a *AIManager) generateStream(ctx context.Context, promptType PromptType, prompt string) (<-chan string, error) {
+	user := userFrom(ctx)
+	key := cacheKey(promptType, prompt)
+	if a.cache != nil {
+		if res, ok := a.cache.Get(key); ok {
+			a.usage.record(usageRecord{promptType: promptType, user: user, cacheHit: true})
+			cached := make(chan string, 1)
+			cached <- res
+			close(cached)
+			return cached, nil
+		}
+	}
+	if err := a.quota.take(user); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	record := usageRecord{
+		promptType: promptType,
+		user:       user,
+	}
+	stream, tokens, err := a.client.GenerateStream(ctx, prompt)
+	if err != nil {
+		record.failed = true
+		record.latency = time.Since(start)
+		record.countTokens(nil, prompt, "")
+		a.account(record)
+		return nil, err
+	}
+
+	out := make(chan string, cap(stream))
+	go func() {
+		defer close(out)
+
+		var content strings.Builder
+		canceled := false
+		for chunk := range stream {
+			if strings.HasPrefix(chunk, "ERROR:") {
+				record.failed = true
+			} else {
+				content.WriteString(chunk)
+			}
+			if canceled {
+				// Drain the stream so that the provider is not blocked.
+				continue
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				canceled = true
+			}
+		}
+
+		// The usage is filled in by the client once the stream is closed.
+		record.countTokens(tokens, prompt, content.String())
+		record.latency = time.Since(start)
+		a.account(record)
+		if !record.failed && !canceled {
+			a.store(key, content.String())
+		}
+	}()
+	return out, nil
+}
+
+// account records the AI call in the usage and counts its tokens in the
+// quota.
+func (a *AIManager) account(record usageRecord) {
+	a.usage.record(record)
+	a.quota.addTokens(record.user, record.inputTokens+record.outputTokens)
+}
+
+// store caches the response of the key.
+func (a *AIManager) store(key, res string) {
+	if a.cache == nil || res == "" {
+		return
+	}
+	if a.cache.Len() >= cacheSweepSize {
+		a.cache.DeleteExpired()
+	}
+	a.cache.Set(key, res)
+}
+
+// cacheKey returns the cache key of the prompt of the type, the prompts which
+// only differ in whitespaces share the key.
+func cacheKey(promptType PromptType, prompt string) string {
+	normalized := strings.Join(strings.Fields(prompt), " ")
+	sum := sha256.Sum256([]byte(string(promptType) + "\x00" + normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// userFrom returns the name of the user of the context.
+func userFrom(ctx context.Context) string {
+	if u, ok := request.UserFrom(ctx); ok {
+		return u.GetName()
+	}
+	return user.Anonymous
+}
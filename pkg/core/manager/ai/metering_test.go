@@ -0,0 +1,204 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/ai"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// fakeProvider answers every prompt with the same response, and reports the
+// token usage if it is set.
+type fakeProvider struct {
+	ai.AIProvider
+	response string
+	tokens   ai.TokenUsage
+	err      error
+	calls    int
+}
+
+func (p *fakeProvider) Generate(_ context.Context, _ string) (string, *ai.TokenUsage, error) {
+	p.calls++
+	if p.err != nil {
+		return "", nil, p.err
+	}
+	tokens := p.tokens
+	return p.response, &tokens, nil
+}
+
+func (p *fakeProvider) GenerateStream(_ context.Context, _ string) (<-chan string, *ai.TokenUsage, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+	stream := make(chan string, 10)
+	for _, chunk := range strings.SplitAfter(p.response, " ") {
+		stream <- chunk
+	}
+	close(stream)
+	tokens := p.tokens
+	return stream, &tokens, nil
+}
+
+func withUser(name string) context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{Name: name})
+}
+
+func readStream(t *testing.T, stream <-chan string) string {
+	t.Helper()
+	var sb strings.Builder
+	for chunk := range stream {
+		sb.WriteString(chunk)
+	}
+	return sb.String()
+}
+
+func TestGenerateCache(t *testing.T) {
+	provider := &fakeProvider{response: "SELECT * FROM resources"}
+	a := newAIManager(provider, registry.ExtraConfig{AIBackend: "deepseek", AICacheTTL: time.Minute})
+
+	res, err := a.generate(withUser("alice"), Text2sqlType, "all  pods\n")
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM resources", res)
+
+	// The prompts which only differ in whitespaces hit the cache.
+	res, err = a.generate(withUser("bob"), Text2sqlType, "all pods")
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM resources", res)
+	require.Equal(t, 1, provider.calls)
+
+	// The same prompt of another type misses the cache.
+	_, err = a.generate(withUser("bob"), SQLFixType, "all pods")
+	require.NoError(t, err)
+	require.Equal(t, 2, provider.calls)
+
+	usage := a.Usage()
+	require.Equal(t, "deepseek", usage.Provider)
+	require.Equal(t, int64(2), usage.Total.Requests)
+	require.Equal(t, int64(1), usage.Total.CacheHits)
+	// The provider doesn't report the tokens, so they are estimated.
+	require.Equal(t, int64(0), usage.Total.InputTokens)
+	require.Equal(t, int64(0), usage.Total.OutputTokens)
+	require.Equal(t, int64(5), usage.Total.EstimatedInputTokens)
+	require.Equal(t, int64(12), usage.Total.EstimatedOutputTokens)
+	require.Equal(t, int64(1), usage.ByPromptType[Text2sqlType].Requests)
+	require.Equal(t, int64(1), usage.ByPromptType[Text2sqlType].CacheHits)
+	require.Equal(t, int64(1), usage.ByUser["bob"].Requests)
+}
+
+func TestGenerateStreamCache(t *testing.T) {
+	provider := &fakeProvider{response: "the pod is crashing"}
+	a := newAIManager(provider, registry.ExtraConfig{AICacheTTL: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		stream, err := a.generateStream(withUser("alice"), LogDiagnosisType, "logs")
+		require.NoError(t, err)
+		require.Equal(t, "the pod is crashing", readStream(t, stream))
+	}
+	require.Equal(t, 1, provider.calls)
+	require.Equal(t, ai.OpenAIProvider, a.Usage().Provider)
+}
+
+func TestGenerateReportedTokens(t *testing.T) {
+	provider := &fakeProvider{response: "the pod is crashing", tokens: ai.TokenUsage{PromptTokens: 120, CompletionTokens: 30}}
+	a := newAIManager(provider, registry.ExtraConfig{})
+
+	_, err := a.generate(withUser("alice"), Text2sqlType, "all pods")
+	require.NoError(t, err)
+	stream, err := a.generateStream(withUser("alice"), LogDiagnosisType, "logs")
+	require.NoError(t, err)
+	readStream(t, stream)
+
+	// The stream is accounted once it is drained.
+	require.Eventually(t, func() bool {
+		return a.Usage().Total.Requests == 2
+	}, time.Second, 10*time.Millisecond)
+	usage := a.Usage()
+	require.Equal(t, int64(240), usage.Total.InputTokens)
+	require.Equal(t, int64(60), usage.Total.OutputTokens)
+	require.Equal(t, int64(0), usage.Total.EstimatedInputTokens)
+	require.Equal(t, int64(0), usage.Total.EstimatedOutputTokens)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("unavailable")}
+	a := newAIManager(provider, registry.ExtraConfig{AICacheTTL: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		_, err := a.generate(withUser("alice"), Text2sqlType, "all pods")
+		require.ErrorContains(t, err, "unavailable")
+	}
+	// Errors are not cached.
+	require.Equal(t, 2, provider.calls)
+	require.Equal(t, int64(2), a.Usage().Total.Errors)
+}
+
+func TestGenerateQuota(t *testing.T) {
+	provider := &fakeProvider{response: "ok"}
+	a := newAIManager(provider, registry.ExtraConfig{AIUserRequestsPerMinute: 1})
+
+	_, err := a.generate(withUser("alice"), Text2sqlType, "all pods")
+	require.NoError(t, err)
+	require.ErrorIs(t, a.CheckQuota(withUser("alice")), ErrQuotaExceeded)
+	_, err = a.generateStream(withUser("alice"), LogDiagnosisType, "logs")
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// Other users have their own budgets.
+	require.NoError(t, a.CheckQuota(withUser("bob")))
+	require.Equal(t, 1, provider.calls)
+}
+
+func TestQuota(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	q := newQuota(Limits{GlobalRequestsPerMinute: 2, UserTokensPerDay: 100})
+	q.now = func() time.Time { return now }
+
+	require.NoError(t, q.take("alice"))
+	require.NoError(t, q.take("bob"))
+	err := q.take("carol")
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	// The exceeded quotas are rendered as too many requests.
+	var coder interface{ StatusCode() int }
+	require.ErrorAs(t, err, &coder)
+	require.Equal(t, http.StatusTooManyRequests, coder.StatusCode())
+
+	// The requests are counted again in the next minute.
+	now = now.Add(time.Minute)
+	require.NoError(t, q.take("carol"))
+
+	q.addTokens("alice", 100)
+	require.ErrorIs(t, q.check("alice"), ErrQuotaExceeded)
+	require.NoError(t, q.check("bob"))
+
+	// The tokens are counted again in the next day.
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, q.check("alice"))
+}
+
+func TestCacheKey(t *testing.T) {
+	require.Equal(t, cacheKey(Text2sqlType, " all\tpods "), cacheKey(Text2sqlType, "all pods"))
+	require.NotEqual(t, cacheKey(Text2sqlType, "all pods"), cacheKey(Text2sqlType, "all nodes"))
+	require.NotEqual(t, cacheKey(Text2sqlType, "all pods"), cacheKey(SQLFixType, "all pods"))
+}
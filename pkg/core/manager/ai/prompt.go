@@ -32,13 +32,23 @@ const (
 	YAMLInterpretType PromptType = "yaml_interpret"
 	// IssueInterpretType represents the prompt type for issue interpretation
 	IssueInterpretType PromptType = "issue_interpret"
+	// ResourceDiagnosisType represents the prompt type for resource diagnosis
+	ResourceDiagnosisType PromptType = "resource_diagnosis"
+	// RemediationType represents the prompt type for issue remediation
+	RemediationType PromptType = "remediation"
+	// StateSummaryType represents the prompt type for cluster and namespace
+	// state summary
+	StateSummaryType PromptType = "state_summary"
 )
 
+// ServicePromptMap contains the built-in Go templates of the prompts of each
+// type, which are executed with a PromptData. They can be overridden or
+// extended by the templates in the prompt directory.
 var ServicePromptMap = map[PromptType]string{
 	DefaultType: "You are a helpful assistant.",
 
 	Text2sqlType: `You are an AI specialized in writing SQL queries.
-    Please convert the text: "%s" to sql.
+    Please convert the text: "{{.Query}}" to sql.
     If the text is not accurate enough, please output "Error".
     The output tokens only need to give the SQL first, the other thought process please do not give.
     The SQL should begin with "select * from" and end with ";".
@@ -101,7 +111,7 @@ var ServicePromptMap = map[PromptType]string{
     Please convert the text to sql.`,
 
 	SQLFixType: `You are an AI specialized in writing SQL queries.
-    Please convert the text: "%s" to sql.
+    Please convert the text: "{{.Query}}" to sql.
     The SQL should begin with "select * from".
 
     The database now only supports one table resources.
@@ -110,13 +120,13 @@ var ServicePromptMap = map[PromptType]string{
     namespace, name, creationTimestamp, deletionTimestamp, ownerReferences,
     resourceVersion, labels.[key], annotations.[key], content]
 
-    After we executed SQL: "%s",  we observed the following error "%s".
+    After we executed SQL: "{{.SQL}}",  we observed the following error "{{.Error}}".
     Please fix the SQL.`,
 
-	LogDiagnosisType: `You are a Kubernetes log analysis expert. Your task is to analyze pod logs and provide a diagnosis in %s.
+	LogDiagnosisType: `You are a Kubernetes log analysis expert. Your task is to analyze pod logs and provide a diagnosis in {{.Language}}.
 
 Logs to analyze:
-%s
+{{.Logs}}
 
 Please provide:
 1. A brief summary of any errors or issues found
@@ -127,10 +137,10 @@ Please provide:
 Note: Format your response with clear sections using markdown headings (##) and bullet points. Do NOT wrap your entire response in a markdown code block.`,
 
 	EventDiagnosisType: `You are a Kubernetes expert specialized in diagnosing system and application issues through event analysis.
-Please analyze the following Kubernetes events and provide your diagnosis in %s.
+Please analyze the following Kubernetes events and provide your diagnosis in {{.Language}}.
 
 Events:
-%s
+{{.Events}}
 
 Focus on:
 1. Identify any issues or potential problems
@@ -146,10 +156,10 @@ Please structure your response with clear sections:
 
 Note: Format your response with clear sections using markdown headings (##) and bullet points. Be specific and include technical details when relevant. Do NOT wrap your entire response in a markdown code block.`,
 
-	YAMLInterpretType: `You are a Kubernetes YAML expert. Your task is to analyze and interpret the following YAML configuration and provide explanation in %s.
+	YAMLInterpretType: `You are a Kubernetes YAML expert. Your task is to analyze and interpret the following YAML configuration and provide explanation in {{.Language}}.
 
 YAML to interpret:
-%s
+{{.YAML}}
 
 Please provide a detailed analysis including:
 1. Resource Type and Purpose
@@ -185,10 +195,10 @@ Note:
 - Use code blocks only for YAML examples or specific configuration snippets`,
 
 	IssueInterpretType: `You are a Kubernetes expert specialized in analyzing security issues and providing solutions.
-Please analyze the following issues and provide your insights in %s.
+Please analyze the following issues and provide your insights in {{.Language}}.
 
 Issues Summary:
-%s
+{{.Issues}}
 
 Please provide a concise analysis focusing on:
 1. Brief summary of the most critical issues (1-2 sentences)
@@ -199,4 +209,56 @@ Please provide a concise analysis focusing on:
 3. Best practices and preventive measures
 
 Note: Format your response with clear sections using markdown headings (##) and bullet points. Do NOT wrap your entire response in a markdown code block.`,
+
+	ResourceDiagnosisType: `You are a Kubernetes expert specialized in finding the root causes of incidents.
+Please analyze the following context of a Kubernetes resource and provide your diagnosis in {{.Language}}.
+
+The context combines the resource, its owners and related resources, audit issues, recent events, its YAML and recent logs of the related pods.
+Every piece of evidence is labeled with an ID in brackets, such as [E1] for an event, [Y12] for a YAML line and [L1.5] for a log line.
+Parts of the context may be omitted to fit the size limit.
+
+Context:
+{{.Context}}
+
+Please structure your response with clear sections:
+1. Summary: the state of the resource in 1-2 sentences
+2. Root Cause: the most likely root cause, citing the evidence IDs which support it, such as [E2] [L1.40]
+3. Evidence: the key evidence and what it shows, citing their IDs
+4. Recommendations: specific fixes, with exact configuration changes when relevant
+5. Confidence: how confident you are, and what additional information would confirm the root cause
+
+Note: Only cite evidence IDs which appear in the context, and say so if the context is not enough to determine the root cause. Format your response with clear sections using markdown headings (##) and bullet points. Do NOT wrap your entire response in a markdown code block.`,
+
+	RemediationType: `You are a Kubernetes security expert specialized in fixing misconfigurations.
+Please write a patch which fixes the following audit issues of the {{.Kind}} {{.Name}} and explain it in {{.Language}}.
+
+Issues:
+{{.Issues}}
+
+YAML:
+{{.YAML}}
+
+Requirements:
+1. The patch must only change what is needed to fix the issues, and must not change the apiVersion, kind, name or namespace
+2. Prefer a strategic merge patch as a JSON object, such as {"spec":{"template":{"spec":{"containers":[{"name":"app","securityContext":{"runAsNonRoot":true}}]}}}}
+3. Use a JSON patch as a JSON array of operations only if a strategic merge patch cannot express the change
+4. Skip the issues which cannot be fixed without more information, and explain why
+
+Please structure your response as:
+1. A brief explanation of the changes, one bullet point per issue
+2. The patch in exactly one markdown code block marked as json, placed at the end of the response`,
+
+	StateSummaryType: `You are a Kubernetes site reliability engineer writing a handover note.
+Please summarize the following state of a Kubernetes {{if .Namespace}}namespace{{else}}cluster{{end}} in {{.Language}}.
+
+State:
+{{.Context}}
+
+Please write a short operational report which can be pasted into a daily standup or a handover note:
+1. Start with a one-line headline of the overall health
+2. List the problems which need attention as bullet points, the most severe first, with concrete numbers and names, such as "3 nodes NotReady", "12 pods in CrashLoopBackOff in payments" or "audit score dropped by 8 points"
+3. Mention notable changes, such as a changed audit score, and the parts of the state which could not be collected
+4. Say so briefly if nothing needs attention
+
+Note: Keep the report under 150 words, only use facts from the state, and do not give generic advice. Do NOT wrap your entire response in a markdown code block.`,
 }
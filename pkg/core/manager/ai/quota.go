@@ -0,0 +1,155 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// requestWindow is the window the requests are limited in.
+	requestWindow = time.Minute
+	// tokenWindow is the window the tokens are limited in.
+	tokenWindow = 24 * time.Hour
+	// globalQuotaKey is the key of the quota shared by all users.
+	globalQuotaKey = "\x00"
+)
+
+// Limits are the budgets of the AI calls, zero means unlimited.
+type Limits struct {
+	// UserRequestsPerMinute is the maximum number of calls of a user in a
+	// minute.
+	UserRequestsPerMinute int
+	// UserTokensPerDay is the maximum number of tokens of a user in a day.
+	UserTokensPerDay int
+	// GlobalRequestsPerMinute is the maximum number of calls of all users in
+	// a minute.
+	GlobalRequestsPerMinute int
+	// GlobalTokensPerDay is the maximum number of tokens of all users in a
+	// day.
+	GlobalTokensPerDay int
+}
+
+// window counts in a fixed time window.
+type window struct {
+	start time.Time
+	count int
+}
+
+// current returns the count of the window which contains now, the window
+// restarts once it passes.
+func (w *window) current(now time.Time, length time.Duration) int {
+	if now.Sub(w.start) >= length {
+		w.start = now.Truncate(length)
+		w.count = 0
+	}
+	return w.count
+}
+
+// quota enforces the limits of the requests and the tokens per user and for
+// all users.
+type quota struct {
+	limits Limits
+	now    func() time.Time
+
+	lock     sync.Mutex
+	requests map[string]*window
+	tokens   map[string]*window
+}
+
+// newQuota returns a quota enforcing the limits.
+func newQuota(limits Limits) *quota {
+	return &quota{
+		limits:   limits,
+		now:      time.Now,
+		requests: map[string]*window{},
+		tokens:   map[string]*window{},
+	}
+}
+
+// check returns an ErrQuotaExceeded error if the user has exhausted a budget.
+func (q *quota) check(user string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.checkLocked(user)
+}
+
+// take checks the budgets of the user and counts a request if they are not
+// exhausted.
+func (q *quota) take(user string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if err := q.checkLocked(user); err != nil {
+		return err
+	}
+	now := q.now()
+	for _, key := range []string{user, globalQuotaKey} {
+		w := q.window(q.requests, key)
+		w.current(now, requestWindow)
+		w.count++
+	}
+	return nil
+}
+
+// addTokens counts the tokens used by a request of the user.
+func (q *quota) addTokens(user string, tokens int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+	for _, key := range []string{user, globalQuotaKey} {
+		w := q.window(q.tokens, key)
+		w.current(now, tokenWindow)
+		w.count += tokens
+	}
+}
+
+func (q *quota) checkLocked(user string) error {
+	now := q.now()
+	checks := []struct {
+		counters map[string]*window
+		key      string
+		length   time.Duration
+		limit    int
+		what     string
+	}{
+		{q.requests, user, requestWindow, q.limits.UserRequestsPerMinute, "requests per minute of the user"},
+		{q.tokens, user, tokenWindow, q.limits.UserTokensPerDay, "tokens per day of the user"},
+		{q.requests, globalQuotaKey, requestWindow, q.limits.GlobalRequestsPerMinute, "requests per minute"},
+		{q.tokens, globalQuotaKey, tokenWindow, q.limits.GlobalTokensPerDay, "tokens per day"},
+	}
+	for _, c := range checks {
+		if c.limit <= 0 {
+			continue
+		}
+		if q.window(c.counters, c.key).current(now, c.length) >= c.limit {
+			return fmt.Errorf("%w: the limit of %d %s is reached", ErrQuotaExceeded, c.limit, c.what)
+		}
+	}
+	return nil
+}
+
+// window returns the window of the key, which is created if missing.
+func (q *quota) window(counters map[string]*window, key string) *window {
+	w, ok := counters[key]
+	if !ok {
+		w = &window{}
+		counters[key] = w
+	}
+	return w
+}
@@ -0,0 +1,170 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// codeBlockRegex matches the fenced code blocks of a markdown response.
+var codeBlockRegex = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*\\n(.*?)```")
+
+// RemediationEvent represents a single event in the remediation stream
+type RemediationEvent struct {
+	Type    string             `json:"type"`              // Event type: start, chunk, result, error, complete
+	Content string             `json:"content,omitempty"` // Event content or error message
+	Result  *RemediationResult `json:"result,omitempty"`  // The validated patch of the result event
+}
+
+// Patch is a patch of a Kubernetes resource suggested by the model.
+type Patch struct {
+	// Type is the type of the patch, a strategic merge patch or a JSON patch.
+	// A strategic merge patch of a resource without a registered schema,
+	// such as a custom resource, is applied as a JSON merge patch.
+	Type types.PatchType `json:"type"`
+	// Content is the patch in JSON.
+	Content string `json:"content"`
+}
+
+// RemediationResult is the result of validating a suggested patch, which is
+// never applied to the cluster.
+type RemediationResult struct {
+	Patch Patch `json:"patch"`
+	// Diff is the unified diff of the YAML of the resource after the patch.
+	Diff string `json:"diff"`
+	// Issues are the issues the patch is suggested for.
+	Issues scanner.IssueList `json:"issues"`
+	// RemainingIssues are the issues found by scanning the patched resource
+	// again.
+	RemainingIssues scanner.IssueList `json:"remainingIssues"`
+	// ResolvedIssues are the titles of the issues fixed by the patch.
+	ResolvedIssues []string `json:"resolvedIssues"`
+	// IntroducedIssues are the titles of the issues caused by the patch.
+	IntroducedIssues []string `json:"introducedIssues"`
+	// DryRun is the result of the server-side dry run, nil if it is not
+	// requested.
+	DryRun *DryRunResult `json:"dryRun,omitempty"`
+}
+
+// DryRunResult is the result of the server-side dry run of a patch.
+type DryRunResult struct {
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SuggestPatch asks the model for a patch fixing the issues of the resource
+// and streams the response through the channel. Unlike the other streaming
+// methods, the channel is not closed, as the patch is validated before the
+// remediation completes.
+func (a *AIManager) SuggestPatch(
+	ctx context.Context, resourceGroup entity.ResourceGroup, yaml string, issues scanner.IssueList, language string, eventChan chan<- *RemediationEvent,
+) (*Patch, error) {
+	// Send start event
+	eventChan <- &RemediationEvent{
+		Type:    "start",
+		Content: "Starting remediation...",
+	}
+
+	sendError := func(err error) error {
+		eventChan <- &RemediationEvent{
+			Type:    "error",
+			Content: err.Error(),
+		}
+		return err
+	}
+	if len(issues) == 0 {
+		return nil, sendError(fmt.Errorf("no issues to remediate"))
+	}
+
+	// Render prompt template with language instruction
+	if language == "" {
+		language = "English"
+	}
+	var issuesText strings.Builder
+	for _, issue := range issues {
+		fmt.Fprintf(&issuesText, "- [%s] %s: %s\n", issue.Severity, issue.Title, issue.Message)
+	}
+	prompt, err := a.prompts.Render(RemediationType, PromptData{
+		Language:  language,
+		Cluster:   resourceGroup.Cluster,
+		Kind:      resourceGroup.Kind,
+		Namespace: resourceGroup.Namespace,
+		Name:      resourceGroup.Name,
+		YAML:      yaml,
+		Issues:    issuesText.String(),
+	})
+	if err != nil {
+		return nil, sendError(err)
+	}
+
+	// Generate remediation using LLM with streaming
+	stream, err := a.generateStream(ctx, RemediationType, prompt)
+	if err != nil {
+		return nil, sendError(fmt.Errorf("failed to start AI service: %w", err))
+	}
+
+	var fullContent strings.Builder
+	for chunk := range stream {
+		if strings.HasPrefix(chunk, "ERROR:") {
+			return nil, sendError(fmt.Errorf("AI service error: %s", strings.TrimPrefix(chunk, "ERROR: ")))
+		}
+		fullContent.WriteString(chunk)
+		select {
+		case eventChan <- &RemediationEvent{Type: "chunk", Content: chunk}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	patch, err := ParsePatch(fullContent.String())
+	if err != nil {
+		return nil, sendError(err)
+	}
+	return patch, nil
+}
+
+// ParsePatch extracts the patch from the response of the model, which is the
+// last fenced code block or the whole response. A JSON object is a strategic
+// merge patch and a JSON array is a JSON patch.
+func ParsePatch(response string) (*Patch, error) {
+	content := strings.TrimSpace(response)
+	if blocks := codeBlockRegex.FindAllStringSubmatch(response, -1); len(blocks) > 0 {
+		content = strings.TrimSpace(blocks[len(blocks)-1][1])
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(content)); err != nil {
+		return nil, fmt.Errorf("no valid JSON patch in the response: %w", err)
+	}
+	patch := &Patch{Content: compacted.String()}
+	switch {
+	case strings.HasPrefix(patch.Content, "{"):
+		patch.Type = types.StrategicMergePatchType
+	case strings.HasPrefix(patch.Content, "["):
+		patch.Type = types.JSONPatchType
+	default:
+		return nil, fmt.Errorf("the patch must be a JSON object or a JSON array")
+	}
+	return patch, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParsePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *Patch
+		wantErr  string
+	}{
+		{
+			name:     "strategic merge patch in code block",
+			response: "- Run as non-root.\n\n```json\n{\n  \"spec\": {\"securityContext\": {\"runAsNonRoot\": true}}\n}\n```\n",
+			want:     &Patch{Type: types.StrategicMergePatchType, Content: `{"spec":{"securityContext":{"runAsNonRoot":true}}}`},
+		},
+		{
+			name:     "JSON patch in the last code block",
+			response: "Before:\n```yaml\nspec: {}\n```\nPatch:\n```json\n[{\"op\": \"remove\", \"path\": \"/spec/hostNetwork\"}]\n```",
+			want:     &Patch{Type: types.JSONPatchType, Content: `[{"op":"remove","path":"/spec/hostNetwork"}]`},
+		},
+		{
+			name:     "patch without code block",
+			response: ` {"spec": {"replicas": 2}} `,
+			want:     &Patch{Type: types.StrategicMergePatchType, Content: `{"spec":{"replicas":2}}`},
+		},
+		{
+			name:     "invalid JSON",
+			response: "```json\n{\"spec\": \n```",
+			wantErr:  "no valid JSON patch in the response",
+		},
+		{
+			name:     "JSON string",
+			response: `"spec"`,
+			wantErr:  "the patch must be a JSON object or a JSON array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePatch(tt.response)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSuggestPatch(t *testing.T) {
+	provider := &fakeProvider{response: "Set runAsNonRoot.\n```json\n{\"spec\": {\"securityContext\": {\"runAsNonRoot\": true}}}\n```"}
+	a := newAIManager(provider, registry.ExtraConfig{})
+	resourceGroup := entity.ResourceGroup{Cluster: "c1", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web"}
+	issues := scanner.IssueList{{Scanner: "kubeaudit", Severity: scanner.High, Title: "RunAsNonRoot", Message: "runAsNonRoot is not set"}}
+
+	eventChan := make(chan *RemediationEvent, 20)
+	patch, err := a.SuggestPatch(context.Background(), resourceGroup, "kind: Pod", issues, "", eventChan)
+	require.NoError(t, err)
+	require.Equal(t, &Patch{Type: types.StrategicMergePatchType, Content: `{"spec":{"securityContext":{"runAsNonRoot":true}}}`}, patch)
+
+	// The channel is left open for the result of the validation.
+	require.Equal(t, "start", (<-eventChan).Type)
+	var content string
+	for len(eventChan) > 0 {
+		event := <-eventChan
+		require.Equal(t, "chunk", event.Type)
+		content += event.Content
+	}
+	require.Equal(t, provider.response, content)
+
+	_, err = a.SuggestPatch(context.Background(), resourceGroup, "kind: Pod", nil, "", eventChan)
+	require.ErrorContains(t, err, "no issues to remediate")
+	require.Equal(t, "start", (<-eventChan).Type)
+	require.Equal(t, &RemediationEvent{Type: "error", Content: "no issues to remediate"}, <-eventChan)
+}
@@ -0,0 +1,335 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+)
+
+const (
+	// DefaultDiagnosisTokenBudget is the default number of tokens the
+	// context of a resource diagnosis is limited to.
+	DefaultDiagnosisTokenBudget = 8000
+	// charsPerToken is the approximate number of characters of a token.
+	charsPerToken = 4
+	// maxContextLineChars is the maximum number of characters of a line in
+	// the context, longer lines are cut.
+	maxContextLineChars = 500
+	// omittedMarkerChars is the maximum number of characters of the marker
+	// of omitted lines.
+	omittedMarkerChars = 40
+)
+
+// DiagnosisContext is the context bundle of a resource to diagnose, which
+// combines the artifacts related to the resource.
+type DiagnosisContext struct {
+	Resource entity.ResourceGroup `json:"resource"`
+	// YAML is the sanitized YAML of the resource.
+	YAML string `json:"yaml"`
+	// OwnerChain is the owners of the resource, from the direct owner up.
+	OwnerChain []entity.ResourceGroup `json:"ownerChain,omitempty"`
+	// Related is the resources related to the resource in the topology.
+	Related []entity.ResourceGroup `json:"related,omitempty"`
+	// Events is the recent events of the resource and its related pods,
+	// the latest first.
+	Events []Event `json:"events,omitempty"`
+	// Logs is the recent logs of the related pods.
+	Logs []ContainerLogs `json:"logs,omitempty"`
+	// Issues is the audit issues of the resource.
+	Issues []scanner.Issue `json:"issues,omitempty"`
+	// Notes record the parts of the context which could not be collected.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// ContainerLogs is the recent logs of a container.
+type ContainerLogs struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// Previous reports whether the logs are of the previous terminated
+	// instance of the container.
+	Previous bool     `json:"previous,omitempty"`
+	Lines    []string `json:"lines"`
+}
+
+// contextSection is a section of the rendered context, which is rendered
+// within a share of the token budget by its weight.
+type contextSection struct {
+	weight int
+	render func(budget int) string
+}
+
+// Render renders the context as the evidence of a diagnosis within the
+// token budget. Every piece of evidence is labeled with an ID, such as [E1]
+// for an event, so that it can be cited. The sections which use less than
+// their shares leave the rest of the budget to the following sections, and
+// the logs, which are usually the longest, come last.
+func (c *DiagnosisContext) Render(budget int) string {
+	if budget <= 0 {
+		budget = DefaultDiagnosisTokenBudget
+	}
+
+	sections := []contextSection{
+		{weight: 1, render: c.renderResource},
+		{weight: 1, render: c.renderIssues},
+		{weight: 2, render: c.renderEvents},
+		{weight: 3, render: c.renderYAML},
+		{weight: 4, render: c.renderLogs},
+	}
+	totalWeight := 0
+	for _, section := range sections {
+		totalWeight += section.weight
+	}
+
+	var sb strings.Builder
+	remaining := budget
+	for _, section := range sections {
+		text := section.render(remaining * section.weight / totalWeight)
+		remaining -= estimateTokens(text)
+		totalWeight -= section.weight
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// renderResource renders the resource, its owners, the related resources and
+// the notes.
+func (c *DiagnosisContext) renderResource(budget int) string {
+	lines := []string{fmt.Sprintf("[R] %s", describeResourceGroup(c.Resource))}
+	for i, owner := range c.OwnerChain {
+		lines = append(lines, fmt.Sprintf("[O%d] owned by %s", i+1, describeResourceGroup(owner)))
+	}
+	for _, note := range c.Notes {
+		lines = append(lines, "Note: "+note)
+	}
+	for i, related := range c.Related {
+		lines = append(lines, fmt.Sprintf("[T%d] related %s", i+1, describeResourceGroup(related)))
+	}
+	return fitLines("## Resource", lines, budget, false)
+}
+
+// renderIssues renders the audit issues.
+func (c *DiagnosisContext) renderIssues(budget int) string {
+	if len(c.Issues) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(c.Issues))
+	for i, issue := range c.Issues {
+		line := fmt.Sprintf("[I%d] %s %s", i+1, issue.Severity, issue.Title)
+		if issue.Message != "" {
+			line += ": " + issue.Message
+		}
+		lines = append(lines, line)
+	}
+	return fitLines("## Audit Issues", lines, budget, false)
+}
+
+// renderEvents renders the events, the latest first.
+func (c *DiagnosisContext) renderEvents(budget int) string {
+	if len(c.Events) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(c.Events))
+	for i, event := range c.Events {
+		line := fmt.Sprintf("[E%d] [%s] %s: %s (Count: %d, First: %s, Last: %s)",
+			i+1, event.Type, event.Reason, event.Message, event.Count,
+			event.FirstTimestamp, event.LastTimestamp)
+		if event.Object != "" {
+			line += " on " + event.Object
+		}
+		lines = append(lines, line)
+	}
+	return fitLines("## Events", lines, budget, false)
+}
+
+// renderYAML renders the YAML with the line numbers.
+func (c *DiagnosisContext) renderYAML(budget int) string {
+	if c.YAML == "" {
+		return ""
+	}
+	yamlLines := strings.Split(strings.TrimRight(c.YAML, "\n"), "\n")
+	lines := make([]string, 0, len(yamlLines))
+	for i, line := range yamlLines {
+		lines = append(lines, fmt.Sprintf("[Y%d] %s", i+1, line))
+	}
+	return fitLines("## YAML", lines, budget, false)
+}
+
+// renderLogs renders the logs of the containers, the budget is shared
+// equally by the containers and the latest lines of each are kept.
+func (c *DiagnosisContext) renderLogs(budget int) string {
+	if len(c.Logs) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Logs\n")
+	remaining := budget - estimateTokens("## Logs\n")
+	for i, logs := range c.Logs {
+		header := fmt.Sprintf("### %s/%s", logs.Pod, logs.Container)
+		if logs.Previous {
+			header += " (previous instance)"
+		}
+		lines := make([]string, 0, len(logs.Lines))
+		for j, line := range logs.Lines {
+			lines = append(lines, fmt.Sprintf("[L%d.%d] %s", i+1, j+1, line))
+		}
+
+		text := fitLines(header, lines, remaining/(len(c.Logs)-i), true)
+		remaining -= estimateTokens(text)
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// fitLines renders the header and as many lines as fit in the budget, with
+// a marker of the omitted lines. The last lines are kept if keepTail is
+// true, and the first lines otherwise.
+func fitLines(header string, lines []string, budget int, keepTail bool) string {
+	header += "\n"
+	// Reserve the space of the marker of the omitted lines and the trailing
+	// blank line.
+	remaining := budget*charsPerToken - len(header) - omittedMarkerChars - 1
+
+	for i, line := range lines {
+		if len(line) > maxContextLineChars {
+			lines[i] = strings.ToValidUTF8(line[:maxContextLineChars], "") + "...(cut)"
+		}
+	}
+
+	kept := 0
+	for kept < len(lines) {
+		line := lines[kept]
+		if keepTail {
+			line = lines[len(lines)-1-kept]
+		}
+		if remaining < len(line)+1 {
+			break
+		}
+		remaining -= len(line) + 1
+		kept++
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	omitted := len(lines) - kept
+	if keepTail {
+		if omitted > 0 {
+			fmt.Fprintf(&sb, "... %d earlier lines omitted ...\n", omitted)
+		}
+		for _, line := range lines[omitted:] {
+			sb.WriteString(line + "\n")
+		}
+	} else {
+		for _, line := range lines[:kept] {
+			sb.WriteString(line + "\n")
+		}
+		if omitted > 0 {
+			fmt.Fprintf(&sb, "... %d more lines omitted ...\n", omitted)
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// estimateTokens estimates the number of tokens of the text.
+func estimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// describeResourceGroup describes the resource located by the resource
+// group.
+func describeResourceGroup(rg entity.ResourceGroup) string {
+	name := rg.Name
+	if rg.Namespace != "" {
+		name = rg.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s (%s) in cluster %s", rg.Kind, name, rg.APIVersion, rg.Cluster)
+}
+
+// DiagnoseResource analyzes the context bundle of a resource using LLM and
+// returns a root-cause analysis citing the evidence through a streaming
+// channel
+func (a *AIManager) DiagnoseResource(ctx context.Context, diagnosisContext *DiagnosisContext, language string, tokenBudget int, eventChan chan<- *DiagnosisEvent) error {
+	defer close(eventChan)
+
+	// Send start event
+	eventChan <- &DiagnosisEvent{
+		Type:    "start",
+		Content: "Starting resource analysis...",
+	}
+
+	// Render prompt template with language instruction
+	if language == "" {
+		language = "English"
+	}
+	resource := diagnosisContext.Resource
+	prompt, err := a.prompts.Render(ResourceDiagnosisType, PromptData{
+		Language:  language,
+		Cluster:   resource.Cluster,
+		Kind:      resource.Kind,
+		Namespace: resource.Namespace,
+		Name:      resource.Name,
+		Context:   diagnosisContext.Render(tokenBudget),
+	})
+	if err != nil {
+		eventChan <- &DiagnosisEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to analyze resource: %v", err),
+		}
+		return err
+	}
+
+	// Generate diagnosis using LLM with streaming
+	stream, err := a.generateStream(ctx, ResourceDiagnosisType, prompt)
+	if err != nil {
+		errEvent := &DiagnosisEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to analyze resource: %v", err),
+		}
+		eventChan <- errEvent
+		return fmt.Errorf("failed to generate resource diagnosis: %v", err)
+	}
+
+	var fullContent strings.Builder
+	for chunk := range stream {
+		if strings.HasPrefix(chunk, "ERROR:") {
+			errEvent := &DiagnosisEvent{
+				Type:    "error",
+				Content: fmt.Sprintf("Failed to receive diagnosis: %v", strings.TrimPrefix(chunk, "ERROR: ")),
+			}
+			eventChan <- errEvent
+			return fmt.Errorf("failed to receive diagnosis chunk: %v", chunk)
+		}
+
+		fullContent.WriteString(chunk)
+		eventChan <- &DiagnosisEvent{
+			Type:    "chunk",
+			Content: chunk,
+		}
+	}
+
+	// Send complete event
+	eventChan <- &DiagnosisEvent{
+		Type:    "complete",
+		Content: fullContent.String(),
+	}
+
+	return nil
+}
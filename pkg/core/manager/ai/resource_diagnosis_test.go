@@ -0,0 +1,87 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/stretchr/testify/require"
+)
+
+func newDiagnosisContext(logLines int) *DiagnosisContext {
+	lines := make([]string, 0, logLines)
+	for i := 0; i < logLines; i++ {
+		lines = append(lines, fmt.Sprintf("log line %d", i+1))
+	}
+	return &DiagnosisContext{
+		Resource:   entity.ResourceGroup{Cluster: "cluster1", APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"},
+		YAML:       "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n",
+		OwnerChain: []entity.ResourceGroup{{Cluster: "cluster1", APIVersion: "apps.kusionstack.io/v1", Kind: "App", Namespace: "default", Name: "web"}},
+		Events: []Event{
+			{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 5, Object: "Pod default/web-1"},
+		},
+		Logs:   []ContainerLogs{{Pod: "web-1", Container: "app", Lines: lines}},
+		Issues: []scanner.Issue{{Severity: scanner.High, Title: "Missing liveness probe"}},
+		Notes:  []string{"failed to get topology: forbidden"},
+	}
+}
+
+func TestDiagnosisContextRender(t *testing.T) {
+	rendered := newDiagnosisContext(3).Render(0)
+
+	for _, want := range []string{
+		"[R] Deployment default/web (apps/v1) in cluster cluster1",
+		"[O1] owned by App default/web",
+		"Note: failed to get topology: forbidden",
+		"[I1] High Missing liveness probe",
+		"[E1] [Warning] BackOff: Back-off restarting failed container (Count: 5, First: , Last: ) on Pod default/web-1",
+		"[Y2] kind: Deployment",
+		"### web-1/app",
+		"[L1.3] log line 3",
+	} {
+		require.Contains(t, rendered, want)
+	}
+	require.NotContains(t, rendered, "omitted")
+}
+
+func TestDiagnosisContextRenderWithinBudget(t *testing.T) {
+	budget := 500
+	rendered := newDiagnosisContext(10000).Render(budget)
+
+	require.LessOrEqual(t, estimateTokens(rendered), budget)
+	// The other sections are kept, and the latest logs are kept.
+	require.Contains(t, rendered, "[E1]")
+	require.Contains(t, rendered, "[Y4]")
+	require.Contains(t, rendered, "[L1.10000] log line 10000")
+	require.NotContains(t, rendered, "[L1.1] ")
+	require.Contains(t, rendered, "earlier lines omitted")
+}
+
+func TestFitLines(t *testing.T) {
+	lines := []string{"line 1", "line 2", "line 3", strings.Repeat("x", 1000)}
+
+	head := fitLines("## Head", append([]string{}, lines[:3]...), 16, false)
+	require.Equal(t, "## Head\nline 1\nline 2\n... 1 more lines omitted ...\n\n", head)
+
+	tail := fitLines("## Tail", append([]string{}, lines[:3]...), 16, true)
+	require.Equal(t, "## Tail\n... 1 earlier lines omitted ...\nline 2\nline 3\n\n", tail)
+
+	long := fitLines("## Long", append([]string{}, lines[3]), 1000, false)
+	require.Contains(t, long, strings.Repeat("x", maxContextLineChars)+"...(cut)\n")
+}
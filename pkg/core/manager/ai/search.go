@@ -16,14 +16,15 @@ package ai
 
 import (
 	"context"
-	"fmt"
 )
 
 // ConvertTextToSQL converts natural language text to an SQL query
-func (a *AIManager) ConvertTextToSQL(query string) (string, error) {
-	servicePrompt := ServicePromptMap[Text2sqlType]
-	prompt := fmt.Sprintf(servicePrompt, query)
-	res, err := a.client.Generate(context.Background(), prompt)
+func (a *AIManager) ConvertTextToSQL(ctx context.Context, query string) (string, error) {
+	prompt, err := a.prompts.Render(Text2sqlType, PromptData{Query: query})
+	if err != nil {
+		return "", err
+	}
+	res, err := a.generate(ctx, Text2sqlType, prompt)
 	if err != nil {
 		return "", err
 	}
@@ -34,10 +35,12 @@ func (a *AIManager) ConvertTextToSQL(query string) (string, error) {
 }
 
 // FixSQL fix the error SQL
-func (a *AIManager) FixSQL(sql string, query string, sqlErr string) (string, error) {
-	servicePrompt := ServicePromptMap[SQLFixType]
-	prompt := fmt.Sprintf(servicePrompt, query, sql, sqlErr)
-	res, err := a.client.Generate(context.Background(), prompt)
+func (a *AIManager) FixSQL(ctx context.Context, sql string, query string, sqlErr string) (string, error) {
+	prompt, err := a.prompts.Render(SQLFixType, PromptData{Query: query, SQL: sql, Error: sqlErr})
+	if err != nil {
+		return "", err
+	}
+	res, err := a.generate(ctx, SQLFixType, prompt)
 	if err != nil {
 		return "", err
 	}
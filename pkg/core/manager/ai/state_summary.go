@@ -0,0 +1,304 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultSummaryTokenBudget is the default number of tokens the state
+	// of a state summary is limited to.
+	DefaultSummaryTokenBudget = 4000
+	// summaryExamplePods is the number of pods named for each group of
+	// unhealthy pods.
+	summaryExamplePods = 3
+)
+
+// StateContext is the state of a cluster or a namespace to summarize.
+type StateContext struct {
+	Cluster string `json:"cluster"`
+	// Namespace is empty for the state of a cluster.
+	Namespace string `json:"namespace,omitempty"`
+	// ServerVersion, Nodes and Usage are only collected for a cluster.
+	ServerVersion string     `json:"serverVersion,omitempty"`
+	Nodes         *NodeState `json:"nodes,omitempty"`
+	// Usage is the usage of the capacity of the cluster, such as
+	// "CPU: 40% of 8 cores".
+	Usage []string `json:"usage,omitempty"`
+	// CountByGVK is the number of the resources of each GVK.
+	CountByGVK map[string]int `json:"countByGVK,omitempty"`
+	Score      *ScoreState    `json:"score,omitempty"`
+	// UnhealthyPods is the pods which are not running or ready.
+	UnhealthyPods []UnhealthyPod `json:"unhealthyPods,omitempty"`
+	// WarningEvents is the recent warning events, the latest first.
+	WarningEvents []Event `json:"warningEvents,omitempty"`
+	// Notes record the parts of the state which could not be collected.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// NodeState is the number of the nodes by readiness.
+type NodeState struct {
+	Total    int `json:"total"`
+	Ready    int `json:"ready"`
+	NotReady int `json:"notReady"`
+}
+
+// ScoreState is the audit score and its change.
+type ScoreState struct {
+	Score             float64        `json:"score"`
+	IssuesTotal       int            `json:"issuesTotal"`
+	SeverityStatistic map[string]int `json:"severityStatistic,omitempty"`
+	// PreviousScore is the score recorded at PreviousTimestamp, nil if no
+	// score was recorded.
+	PreviousScore     *float64 `json:"previousScore,omitempty"`
+	PreviousTimestamp string   `json:"previousTimestamp,omitempty"`
+}
+
+// UnhealthyPod is a pod which is not running or ready.
+type UnhealthyPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Reason is why the pod is unhealthy, such as CrashLoopBackOff.
+	Reason   string `json:"reason"`
+	Restarts int32  `json:"restarts"`
+}
+
+// Render renders the state within the token budget, the overview first,
+// followed by the unhealthy pods, the warning events and the resource
+// counts.
+func (c *StateContext) Render(budget int) string {
+	if budget <= 0 {
+		budget = DefaultSummaryTokenBudget
+	}
+
+	sections := []contextSection{
+		{weight: 1, render: c.renderOverview},
+		{weight: 2, render: c.renderUnhealthyPods},
+		{weight: 2, render: c.renderWarningEvents},
+		{weight: 1, render: c.renderResourceCounts},
+	}
+	totalWeight := 0
+	for _, section := range sections {
+		totalWeight += section.weight
+	}
+
+	var sb strings.Builder
+	remaining := budget
+	for _, section := range sections {
+		text := section.render(remaining * section.weight / totalWeight)
+		remaining -= estimateTokens(text)
+		totalWeight -= section.weight
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// scope describes the cluster or the namespace.
+func (c *StateContext) scope() string {
+	if c.Namespace != "" {
+		return fmt.Sprintf("namespace %s in cluster %s", c.Namespace, c.Cluster)
+	}
+	return "cluster " + c.Cluster
+}
+
+// renderOverview renders the scope, the nodes, the usage, the score and the
+// notes.
+func (c *StateContext) renderOverview(budget int) string {
+	lines := []string{"Scope: " + c.scope()}
+	if c.ServerVersion != "" {
+		lines = append(lines, "Kubernetes version: "+c.ServerVersion)
+	}
+	if c.Nodes != nil {
+		lines = append(lines, fmt.Sprintf("Nodes: %d total, %d ready, %d not ready", c.Nodes.Total, c.Nodes.Ready, c.Nodes.NotReady))
+	}
+	for _, usage := range c.Usage {
+		lines = append(lines, "Usage: "+usage)
+	}
+	if score := c.Score; score != nil {
+		line := fmt.Sprintf("Audit score: %.1f with %d issues", score.Score, score.IssuesTotal)
+		if len(score.SeverityStatistic) > 0 {
+			line += " (" + formatCounts(score.SeverityStatistic, ", ") + ")"
+		}
+		if score.PreviousScore != nil {
+			line += fmt.Sprintf(", changed by %+.1f since %s", score.Score-*score.PreviousScore, score.PreviousTimestamp)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("Unhealthy pods: %d", len(c.UnhealthyPods)))
+	for _, note := range c.Notes {
+		lines = append(lines, "Note: "+note)
+	}
+	return fitLines("## Overview", lines, budget, false)
+}
+
+// renderUnhealthyPods renders the unhealthy pods grouped by namespace and
+// reason, the largest groups first.
+func (c *StateContext) renderUnhealthyPods(budget int) string {
+	if len(c.UnhealthyPods) == 0 {
+		return ""
+	}
+
+	type podGroup struct {
+		namespace, reason string
+		pods              []UnhealthyPod
+	}
+	groups := map[string]*podGroup{}
+	var order []*podGroup
+	for _, pod := range c.UnhealthyPods {
+		key := pod.Namespace + "/" + pod.Reason
+		group, ok := groups[key]
+		if !ok {
+			group = &podGroup{namespace: pod.Namespace, reason: pod.Reason}
+			groups[key] = group
+			order = append(order, group)
+		}
+		group.pods = append(group.pods, pod)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(order[a].pods) > len(order[b].pods)
+	})
+
+	lines := make([]string, 0, len(order))
+	for _, group := range order {
+		examples := make([]string, 0, summaryExamplePods)
+		for _, pod := range group.pods[:min(len(group.pods), summaryExamplePods)] {
+			example := pod.Name
+			if pod.Restarts > 0 {
+				example += fmt.Sprintf(" with %d restarts", pod.Restarts)
+			}
+			examples = append(examples, example)
+		}
+		if more := len(group.pods) - len(examples); more > 0 {
+			examples = append(examples, fmt.Sprintf("%d more", more))
+		}
+		lines = append(lines, fmt.Sprintf("%d pods %s in namespace %s: %s",
+			len(group.pods), group.reason, group.namespace, strings.Join(examples, ", ")))
+	}
+	return fitLines("## Unhealthy Pods", lines, budget, false)
+}
+
+// renderWarningEvents renders the warning events, the latest first.
+func (c *StateContext) renderWarningEvents(budget int) string {
+	if len(c.WarningEvents) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(c.WarningEvents))
+	for _, event := range c.WarningEvents {
+		line := fmt.Sprintf("%s: %s (Count: %d, Last: %s)", event.Reason, event.Message, event.Count, event.LastTimestamp)
+		if event.Object != "" {
+			line += " on " + event.Object
+		}
+		lines = append(lines, line)
+	}
+	return fitLines("## Warning Events", lines, budget, false)
+}
+
+// renderResourceCounts renders the number of the resources of each GVK, the
+// most first.
+func (c *StateContext) renderResourceCounts(budget int) string {
+	if len(c.CountByGVK) == 0 {
+		return ""
+	}
+	return fitLines("## Resources", strings.Split(formatCounts(c.CountByGVK, "\n"), "\n"), budget, false)
+}
+
+// formatCounts formats the counts by the keys, the largest first.
+func formatCounts(counts map[string]int, sep string) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if counts[keys[a]] != counts[keys[b]] {
+			return counts[keys[a]] > counts[keys[b]]
+		}
+		return keys[a] < keys[b]
+	})
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s: %d", key, counts[key]))
+	}
+	return strings.Join(parts, sep)
+}
+
+// SummarizeState writes a short operational report of the state of a
+// cluster or a namespace using LLM and returns it through a streaming
+// channel
+func (a *AIManager) SummarizeState(ctx context.Context, stateContext *StateContext, language string, tokenBudget int, eventChan chan<- *InterpretEvent) error {
+	defer close(eventChan)
+
+	// Send start event
+	eventChan <- &InterpretEvent{
+		Type:    "start",
+		Content: "Starting state summary...",
+	}
+
+	// Render prompt template with language instruction
+	if language == "" {
+		language = "English"
+	}
+	prompt, err := a.prompts.Render(StateSummaryType, PromptData{
+		Language:  language,
+		Cluster:   stateContext.Cluster,
+		Namespace: stateContext.Namespace,
+		Context:   stateContext.Render(tokenBudget),
+	})
+	if err != nil {
+		eventChan <- &InterpretEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to summarize state: %v", err),
+		}
+		return err
+	}
+
+	// Generate summary using LLM with streaming
+	stream, err := a.generateStream(ctx, StateSummaryType, prompt)
+	if err != nil {
+		eventChan <- &InterpretEvent{
+			Type:    "error",
+			Content: fmt.Sprintf("Failed to summarize state: %v", err),
+		}
+		return fmt.Errorf("failed to generate state summary: %v", err)
+	}
+
+	var fullContent strings.Builder
+	for chunk := range stream {
+		if strings.HasPrefix(chunk, "ERROR:") {
+			eventChan <- &InterpretEvent{
+				Type:    "error",
+				Content: fmt.Sprintf("Failed to receive summary: %v", strings.TrimPrefix(chunk, "ERROR: ")),
+			}
+			return fmt.Errorf("failed to receive summary chunk: %v", chunk)
+		}
+
+		fullContent.WriteString(chunk)
+		eventChan <- &InterpretEvent{
+			Type:    "chunk",
+			Content: chunk,
+		}
+	}
+
+	// Send complete event
+	eventChan <- &InterpretEvent{
+		Type:    "complete",
+		Content: fullContent.String(),
+	}
+
+	return nil
+}
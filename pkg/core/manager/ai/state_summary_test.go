@@ -0,0 +1,93 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateContextRender(t *testing.T) {
+	previous := 80.0
+	stateContext := &StateContext{
+		Cluster:       "c1",
+		ServerVersion: "v1.28.3",
+		Nodes:         &NodeState{Total: 5, Ready: 2, NotReady: 3},
+		Usage:         []string{"CPU: 40% of 8 cores"},
+		CountByGVK:    map[string]int{"v1/Pod": 30, "apps/v1/Deployment": 10},
+		Score: &ScoreState{
+			Score:             72,
+			IssuesTotal:       4,
+			SeverityStatistic: map[string]int{"High": 3, "Low": 1},
+			PreviousScore:     &previous,
+			PreviousTimestamp: "2024-05-01",
+		},
+		WarningEvents: []Event{{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 12, LastTimestamp: "2024-05-02T10:00:00Z", Object: "Pod payments/api-1"}},
+		Notes:         []string{"failed to list pods: forbidden"},
+	}
+	for i := 0; i < 5; i++ {
+		stateContext.UnhealthyPods = append(stateContext.UnhealthyPods, UnhealthyPod{Namespace: "payments", Name: fmt.Sprintf("api-%d", i), Reason: "CrashLoopBackOff", Restarts: int32(i)})
+	}
+	stateContext.UnhealthyPods = append(stateContext.UnhealthyPods, UnhealthyPod{Namespace: "default", Name: "web", Reason: "ImagePullBackOff"})
+
+	rendered := stateContext.Render(0)
+	require.Contains(t, rendered, "Scope: cluster c1\n")
+	require.Contains(t, rendered, "Nodes: 5 total, 2 ready, 3 not ready\n")
+	require.Contains(t, rendered, "Usage: CPU: 40% of 8 cores\n")
+	require.Contains(t, rendered, "Audit score: 72.0 with 4 issues (High: 3, Low: 1), changed by -8.0 since 2024-05-01\n")
+	require.Contains(t, rendered, "Note: failed to list pods: forbidden\n")
+	// The largest group of unhealthy pods comes first with a few examples.
+	require.Contains(t, rendered, "## Unhealthy Pods\n"+
+		"5 pods CrashLoopBackOff in namespace payments: api-0, api-1 with 1 restarts, api-2 with 2 restarts, 2 more\n"+
+		"1 pods ImagePullBackOff in namespace default: web\n")
+	require.Contains(t, rendered, "BackOff: Back-off restarting failed container (Count: 12, Last: 2024-05-02T10:00:00Z) on Pod payments/api-1\n")
+	require.Contains(t, rendered, "## Resources\nv1/Pod: 30\napps/v1/Deployment: 10\n")
+
+	// The namespace state has no nodes.
+	rendered = (&StateContext{Cluster: "c1", Namespace: "payments"}).Render(0)
+	require.Contains(t, rendered, "Scope: namespace payments in cluster c1\n")
+	require.NotContains(t, rendered, "Nodes:")
+	require.NotContains(t, rendered, "## Unhealthy Pods")
+
+	// The state is limited by the token budget.
+	stateContext.WarningEvents = nil
+	for i := 0; i < 200; i++ {
+		stateContext.WarningEvents = append(stateContext.WarningEvents, Event{Reason: "FailedMount", Message: strings.Repeat("x", 100)})
+	}
+	rendered = stateContext.Render(500)
+	require.LessOrEqual(t, estimateTokens(rendered), 500)
+	require.Contains(t, rendered, "lines omitted")
+}
+
+func TestSummarizeState(t *testing.T) {
+	provider := &fakeProvider{response: "3 nodes NotReady."}
+	a := newAIManager(provider, registry.ExtraConfig{})
+
+	eventChan := make(chan *InterpretEvent, 20)
+	err := a.SummarizeState(context.Background(), &StateContext{Cluster: "c1", Nodes: &NodeState{Total: 3, NotReady: 3}}, "", 0, eventChan)
+	require.NoError(t, err)
+
+	var events []*InterpretEvent
+	for event := range eventChan {
+		events = append(events, event)
+	}
+	require.Equal(t, "start", events[0].Type)
+	require.Equal(t, &InterpretEvent{Type: "complete", Content: provider.response}, events[len(events)-1])
+}
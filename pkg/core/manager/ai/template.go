@@ -0,0 +1,316 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// promptFileExt is the extension of the prompt template files.
+	promptFileExt = ".tmpl"
+	// builtinTemplateName is the name the built-in template of the prompt
+	// type is available as in the overriding templates, so that
+	// `{{template "builtin" .}}` extends the built-in prompt.
+	builtinTemplateName = "builtin"
+)
+
+// PromptData contains the variables of the prompt templates. The variables
+// which are unknown for a prompt are empty.
+type PromptData struct {
+	// Language is the language the response is expected in.
+	Language string
+	// Cluster, Kind, Namespace and Name locate the resource the prompt is
+	// about.
+	Cluster   string
+	Kind      string
+	Namespace string
+	Name      string
+	// YAML is the YAML of the resource to interpret.
+	YAML string
+	// Query is the natural language search query.
+	Query string
+	// SQL and Error are the SQL which failed and its error.
+	SQL   string
+	Error string
+	// Logs, Events and Issues are the texts to diagnose or interpret.
+	Logs   string
+	Events string
+	Issues string
+	// Context is the rendered context bundle of a resource diagnosis.
+	Context string
+}
+
+// requiredPromptVars are the variables each template of the prompt type
+// must reference, as the prompt is meaningless without them.
+var requiredPromptVars = map[PromptType][]string{
+	Text2sqlType:          {"Query"},
+	SQLFixType:            {"Query", "SQL", "Error"},
+	LogDiagnosisType:      {"Logs"},
+	EventDiagnosisType:    {"Events"},
+	YAMLInterpretType:     {"YAML"},
+	IssueInterpretType:    {"Issues"},
+	ResourceDiagnosisType: {"Context"},
+	RemediationType:       {"YAML", "Issues"},
+	StateSummaryType:      {"Context"},
+}
+
+// builtinPrompts are the parsed templates of ServicePromptMap.
+var builtinPrompts = func() map[PromptType]*template.Template {
+	prompts := make(map[PromptType]*template.Template, len(ServicePromptMap))
+	for promptType, text := range ServicePromptMap {
+		prompts[promptType] = template.Must(template.New(string(promptType)).Parse(text))
+	}
+	return prompts
+}()
+
+// promptKey is the key of a prompt template, an empty language matches all
+// languages.
+type promptKey struct {
+	promptType PromptType
+	language   string
+}
+
+// PromptStore renders the prompts from the templates loaded from a directory,
+// falling back to the built-in templates. A template file is named
+// `<prompt type>.tmpl` to apply to all languages, or
+// `<prompt type>.<language>.tmpl` to apply to a language only, such as
+// `yaml_interpret.chinese.tmpl`. The directory can be a mounted ConfigMap.
+type PromptStore struct {
+	dir string
+
+	lock        sync.RWMutex
+	overrides   map[promptKey]*template.Template
+	fingerprint string
+}
+
+// NewPromptStore returns a store of the templates in the directory, which are
+// loaded before it returns. An empty directory means only the built-in
+// templates are used.
+func NewPromptStore(dir string) (*PromptStore, error) {
+	s := &PromptStore{dir: dir, overrides: map[promptKey]*template.Template{}}
+	if dir == "" {
+		return s, nil
+	}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload loads the templates again if the files in the directory changed and
+// returns whether they are reloaded. The templates in use are kept if any of
+// the files is invalid.
+func (s *PromptStore) Reload() (bool, error) {
+	if s.dir == "" {
+		return false, nil
+	}
+	fingerprint, err := promptDirFingerprint(s.dir)
+	if err != nil {
+		return false, err
+	}
+	s.lock.RLock()
+	unchanged := fingerprint == s.fingerprint
+	s.lock.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	overrides, err := loadPrompts(s.dir)
+	if err != nil {
+		return false, err
+	}
+	s.lock.Lock()
+	s.overrides = overrides
+	s.fingerprint = fingerprint
+	s.lock.Unlock()
+	return true, nil
+}
+
+// Run reloads the templates in each interval until the context is done.
+func (s *PromptStore) Run(ctx context.Context, interval time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting prompt template reloader", "dir", s.dir, "interval", interval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		reloaded, err := s.Reload()
+		if err != nil {
+			log.Error(err, "Failed to reload prompt templates, keeping the templates in use")
+			return
+		}
+		if reloaded {
+			log.Info("Reloaded prompt templates", "dir", s.dir)
+		}
+	}, interval)
+}
+
+// Render renders the prompt of the type with the data. The template of the
+// language is preferred to the template of all languages, which is preferred
+// to the built-in template.
+func (s *PromptStore) Render(promptType PromptType, data PromptData) (string, error) {
+	tmpl := s.lookup(promptType, data.Language)
+	if tmpl == nil {
+		return "", fmt.Errorf("no prompt template of type %s", promptType)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render the prompt of type %s: %w", promptType, err)
+	}
+	return sb.String(), nil
+}
+
+func (s *PromptStore) lookup(promptType PromptType, language string) *template.Template {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if tmpl, ok := s.overrides[promptKey{promptType, strings.ToLower(language)}]; ok {
+		return tmpl
+	}
+	if tmpl, ok := s.overrides[promptKey{promptType, ""}]; ok {
+		return tmpl
+	}
+	return builtinPrompts[promptType]
+}
+
+// loadPrompts parses and validates the template files in the directory.
+func loadPrompts(dir string) (map[promptKey]*template.Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+promptFileExt))
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[promptKey]*template.Template, len(files))
+	for _, file := range files {
+		key, err := parsePromptFileName(filepath.Base(file))
+		if err != nil {
+			return nil, err
+		}
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", file, err)
+		}
+		tmpl, err := ParsePromptTemplate(key.promptType, string(text))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", file, err)
+		}
+		overrides[key] = tmpl
+	}
+	return overrides, nil
+}
+
+// parsePromptFileName returns the prompt type and the language of the
+// template file.
+func parsePromptFileName(name string) (promptKey, error) {
+	parts := strings.SplitN(strings.TrimSuffix(name, promptFileExt), ".", 2)
+	key := promptKey{promptType: PromptType(parts[0])}
+	if len(parts) == 2 {
+		key.language = strings.ToLower(parts[1])
+	}
+	if _, ok := ServicePromptMap[key.promptType]; !ok {
+		return promptKey{}, fmt.Errorf("unknown prompt type %q of prompt template %s", key.promptType, name)
+	}
+	return key, nil
+}
+
+// ParsePromptTemplate parses the template of the prompt type and validates
+// that it renders and references the required variables of the type. The
+// built-in template of the type is available as "builtin".
+func ParsePromptTemplate(promptType PromptType, text string) (*template.Template, error) {
+	builtin, ok := builtinPrompts[promptType]
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt type %q", promptType)
+	}
+	tmpl, err := template.New(string(promptType)).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmpl.AddParseTree(builtinTemplateName, builtin.Tree); err != nil {
+		return nil, err
+	}
+
+	// Render the template with a marker in each variable to find out the
+	// variables which are missing.
+	data := PromptData{}
+	markers := map[string]string{}
+	setPromptMarkers(&data, markers)
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range requiredPromptVars[promptType] {
+		if !strings.Contains(sb.String(), markers[name]) {
+			missing = append(missing, "{{."+name+"}}")
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required placeholders %s", strings.Join(missing, ", "))
+	}
+	return tmpl, nil
+}
+
+// setPromptMarkers sets each variable of the data to a unique marker and
+// records the markers by the variable names.
+func setPromptMarkers(data *PromptData, markers map[string]string) {
+	fields := map[string]*string{
+		"Language":  &data.Language,
+		"Cluster":   &data.Cluster,
+		"Kind":      &data.Kind,
+		"Namespace": &data.Namespace,
+		"Name":      &data.Name,
+		"YAML":      &data.YAML,
+		"Query":     &data.Query,
+		"SQL":       &data.SQL,
+		"Error":     &data.Error,
+		"Logs":      &data.Logs,
+		"Events":    &data.Events,
+		"Issues":    &data.Issues,
+		"Context":   &data.Context,
+	}
+	for name, field := range fields {
+		markers[name] = "\x00" + name + "\x00"
+		*field = markers[name]
+	}
+}
+
+// promptDirFingerprint returns a fingerprint of the names, the sizes and the
+// modification times of the template files in the directory. The files of a
+// mounted ConfigMap are symbolic links, which are followed.
+func promptDirFingerprint(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+promptFileExt))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	var sb strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat prompt template %s: %w", file, err)
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", filepath.Base(file), info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,159 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writePrompt(t *testing.T, dir, name, text string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(text), 0o600))
+}
+
+func TestBuiltinPrompts(t *testing.T) {
+	for promptType, text := range ServicePromptMap {
+		_, err := ParsePromptTemplate(promptType, text)
+		require.NoError(t, err, promptType)
+	}
+
+	s, err := NewPromptStore("")
+	require.NoError(t, err)
+	prompt, err := s.Render(SQLFixType, PromptData{Query: "all pods", SQL: "select", Error: "syntax error"})
+	require.NoError(t, err)
+	require.Contains(t, prompt, `Please convert the text: "all pods" to sql.`)
+	require.Contains(t, prompt, `After we executed SQL: "select",  we observed the following error "syntax error".`)
+}
+
+func TestParsePromptTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		promptType PromptType
+		text       string
+		wantErr    string
+	}{
+		{
+			name:       "valid",
+			promptType: YAMLInterpretType,
+			text:       "Explain the {{.Kind}} {{.Name}} in {{.Language}}:\n{{.YAML}}",
+		},
+		{
+			name:       "extends built-in",
+			promptType: YAMLInterpretType,
+			text:       `{{template "builtin" .}} Follow the conventions at https://wiki.example.com.`,
+		},
+		{
+			name:       "missing placeholders",
+			promptType: SQLFixType,
+			text:       "Fix {{.SQL}}",
+			wantErr:    "missing required placeholders {{.Query}}, {{.Error}}",
+		},
+		{
+			name:       "unknown variable",
+			promptType: YAMLInterpretType,
+			text:       "{{.YAML}} {{.Manifest}}",
+			wantErr:    "can't evaluate field Manifest",
+		},
+		{
+			name:       "syntax error",
+			promptType: YAMLInterpretType,
+			text:       "{{.YAML",
+			wantErr:    "unclosed action",
+		},
+		{
+			name:       "unknown prompt type",
+			promptType: "summary",
+			text:       "{{.YAML}}",
+			wantErr:    `unknown prompt type "summary"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePromptTemplate(tt.promptType, tt.text)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPromptStore(t *testing.T) {
+	dir := t.TempDir()
+	writePrompt(t, dir, "yaml_interpret.tmpl", "Explain {{.Kind}} in {{.Language}}: {{.YAML}}")
+	writePrompt(t, dir, "yaml_interpret.Chinese.tmpl", "用中文解释 {{.YAML}}")
+	writePrompt(t, dir, "README.md", "not a template")
+
+	s, err := NewPromptStore(dir)
+	require.NoError(t, err)
+
+	prompt, err := s.Render(YAMLInterpretType, PromptData{Language: "English", Kind: "Pod", YAML: "kind: Pod"})
+	require.NoError(t, err)
+	require.Equal(t, "Explain Pod in English: kind: Pod", prompt)
+
+	prompt, err = s.Render(YAMLInterpretType, PromptData{Language: "chinese", YAML: "kind: Pod"})
+	require.NoError(t, err)
+	require.Equal(t, "用中文解释 kind: Pod", prompt)
+
+	// The prompt types which are not overridden use the built-in templates.
+	prompt, err = s.Render(Text2sqlType, PromptData{Query: "all pods"})
+	require.NoError(t, err)
+	require.Contains(t, prompt, `Please convert the text: "all pods" to sql.`)
+
+	reloaded, err := s.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	// An invalid template keeps the templates in use.
+	writePrompt(t, dir, "yaml_interpret.tmpl", "Explain {{.Kind}}")
+	touch(t, filepath.Join(dir, "yaml_interpret.tmpl"))
+	_, err = s.Reload()
+	require.ErrorContains(t, err, "missing required placeholders {{.YAML}}")
+	prompt, err = s.Render(YAMLInterpretType, PromptData{Language: "English", Kind: "Pod", YAML: "kind: Pod"})
+	require.NoError(t, err)
+	require.Equal(t, "Explain Pod in English: kind: Pod", prompt)
+
+	writePrompt(t, dir, "yaml_interpret.tmpl", "Explain {{.YAML}}")
+	touch(t, filepath.Join(dir, "yaml_interpret.tmpl"))
+	reloaded, err = s.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	prompt, err = s.Render(YAMLInterpretType, PromptData{Language: "English", YAML: "kind: Pod"})
+	require.NoError(t, err)
+	require.Equal(t, "Explain kind: Pod", prompt)
+}
+
+func TestNewPromptStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	writePrompt(t, dir, "summary.tmpl", "{{.YAML}}")
+	_, err := NewPromptStore(dir)
+	require.ErrorContains(t, err, `unknown prompt type "summary" of prompt template summary.tmpl`)
+}
+
+// touch moves the modification time of the file forward, so that a rewrite
+// is detected even if the file system has a coarse time resolution.
+func touch(t *testing.T, file string) {
+	t.Helper()
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	modTime := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+}
@@ -14,13 +14,33 @@
 
 package ai
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
 
 var (
 	ErrMissingAuthToken = errors.New("auth token is required")
 	ErrInvalidQuery     = errors.New("query is invalid")
+	// ErrQuotaExceeded is rendered as too many requests.
+	ErrQuotaExceeded error = &statusError{msg: "AI quota exceeded", code: http.StatusTooManyRequests}
 )
 
+// statusError is an error carrying the HTTP status code it is rendered with.
+type statusError struct {
+	msg  string
+	code int
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// StatusCode returns the HTTP status code of the error.
+func (e *statusError) StatusCode() int {
+	return e.code
+}
+
 // Event represents a Kubernetes event for diagnosis
 type Event struct {
 	Type           string `json:"type"`
@@ -29,4 +49,7 @@ type Event struct {
 	Count          int32  `json:"count"`
 	LastTimestamp  string `json:"lastTimestamp"`
 	FirstTimestamp string `json:"firstTimestamp"`
+	// Object is the object the event is about, if it is not the diagnosed
+	// resource itself.
+	Object string `json:"object,omitempty"`
 }
@@ -0,0 +1,147 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"sync"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/ai"
+)
+
+// UsageStats are the accumulated statistics of AI calls. The tokens are the
+// ones reported by the provider, and the tokens of the calls the provider
+// doesn't report them for are estimated from the lengths of the prompts and
+// the responses and counted separately.
+type UsageStats struct {
+	Requests              int64 `json:"requests"`
+	Errors                int64 `json:"errors"`
+	CacheHits             int64 `json:"cacheHits"`
+	InputTokens           int64 `json:"inputTokens"`
+	OutputTokens          int64 `json:"outputTokens"`
+	EstimatedInputTokens  int64 `json:"estimatedInputTokens"`
+	EstimatedOutputTokens int64 `json:"estimatedOutputTokens"`
+	LatencyMillis         int64 `json:"latencyMillis"`
+	// AvgLatencyMillis is the average latency of the requests to the
+	// provider, which excludes the cache hits.
+	AvgLatencyMillis int64 `json:"avgLatencyMillis"`
+}
+
+// Usage is the usage of the AI provider since the server started.
+type Usage struct {
+	Provider     string                     `json:"provider"`
+	Total        UsageStats                 `json:"total"`
+	ByPromptType map[PromptType]*UsageStats `json:"byPromptType"`
+	ByUser       map[string]*UsageStats     `json:"byUser"`
+}
+
+// usageRecord is the record of an AI call.
+type usageRecord struct {
+	promptType   PromptType
+	user         string
+	cacheHit     bool
+	failed       bool
+	inputTokens  int
+	outputTokens int
+	// estimated is whether the tokens are estimated as the provider
+	// doesn't report them.
+	estimated bool
+	latency   time.Duration
+}
+
+// countTokens sets the tokens of the record to the ones reported by the
+// provider, or estimates them from the prompt and the response if they are
+// not reported.
+func (r *usageRecord) countTokens(tokens *ai.TokenUsage, prompt, response string) {
+	if tokens.Reported() {
+		r.inputTokens = tokens.PromptTokens
+		r.outputTokens = tokens.CompletionTokens
+		return
+	}
+	r.inputTokens = estimateTokens(prompt)
+	r.outputTokens = estimateTokens(response)
+	r.estimated = true
+}
+
+// usageTracker accumulates the usage of the AI calls.
+type usageTracker struct {
+	lock  sync.Mutex
+	usage Usage
+}
+
+// newUsageTracker returns a tracker of the usage of the provider.
+func newUsageTracker(provider string) *usageTracker {
+	return &usageTracker{
+		usage: Usage{
+			Provider:     provider,
+			ByPromptType: map[PromptType]*UsageStats{},
+			ByUser:       map[string]*UsageStats{},
+		},
+	}
+}
+
+// record adds the AI call to the usage.
+func (t *usageTracker) record(r usageRecord) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.usage.ByPromptType[r.promptType] == nil {
+		t.usage.ByPromptType[r.promptType] = &UsageStats{}
+	}
+	if t.usage.ByUser[r.user] == nil {
+		t.usage.ByUser[r.user] = &UsageStats{}
+	}
+	for _, stats := range []*UsageStats{&t.usage.Total, t.usage.ByPromptType[r.promptType], t.usage.ByUser[r.user]} {
+		if r.cacheHit {
+			stats.CacheHits++
+			continue
+		}
+		stats.Requests++
+		if r.failed {
+			stats.Errors++
+		}
+		if r.estimated {
+			stats.EstimatedInputTokens += int64(r.inputTokens)
+			stats.EstimatedOutputTokens += int64(r.outputTokens)
+		} else {
+			stats.InputTokens += int64(r.inputTokens)
+			stats.OutputTokens += int64(r.outputTokens)
+		}
+		stats.LatencyMillis += r.latency.Milliseconds()
+		stats.AvgLatencyMillis = stats.LatencyMillis / stats.Requests
+	}
+}
+
+// snapshot returns a copy of the usage.
+func (t *usageTracker) snapshot() *Usage {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	usage := &Usage{
+		Provider:     t.usage.Provider,
+		Total:        t.usage.Total,
+		ByPromptType: make(map[PromptType]*UsageStats, len(t.usage.ByPromptType)),
+		ByUser:       make(map[string]*UsageStats, len(t.usage.ByUser)),
+	}
+	for promptType, stats := range t.usage.ByPromptType {
+		s := *stats
+		usage.ByPromptType[promptType] = &s
+	}
+	for user, stats := range t.usage.ByUser {
+		s := *stats
+		usage.ByUser[user] = &s
+	}
+	return usage
+}
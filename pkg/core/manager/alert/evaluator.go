@@ -0,0 +1,396 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultDedupeWindow is the dedupe window of the alert rules which do
+	// not set one.
+	DefaultDedupeWindow = time.Hour
+
+	// alertPageSize is the page size of the queries of alert rules.
+	alertPageSize = 500
+	// maxAlertMatches is the maximum number of matching objects tracked for
+	// an alert rule. Beyond it, the rules with the appear, disappear and
+	// change conditions fire on the changes of the number of matching
+	// objects rather than the objects themselves.
+	maxAlertMatches = 5000
+)
+
+// RunEvaluator evaluates the alert rules which are due at every interval and
+// whenever the resources are synced, until the context is done. Rules with
+// the schedule trigger are due when their interval elapsed, and rules with
+// the sync trigger when resources were synced since their last evaluation.
+func (m *AlertManager) RunEvaluator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var synced <-chan struct{}
+	if m.syncs != nil {
+		synced = m.syncs.Synced()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-synced:
+		}
+		if err := m.EvaluateDue(ctx, time.Now()); err != nil {
+			klog.ErrorS(err, "Failed to evaluate alert rules")
+		}
+	}
+}
+
+// EvaluateDue evaluates the alert rules which are due at the time, and
+// notifies the channels of the rules which fire.
+func (m *AlertManager) EvaluateDue(ctx context.Context, now time.Time) error {
+	rules, err := m.storage.ListAlertRules(ctx)
+	if err != nil {
+		return err
+	}
+	var syncAt time.Time
+	if m.syncs != nil {
+		syncAt = m.syncs.LatestSyncTime()
+	}
+
+	for _, rule := range rules {
+		if !due(rule, now, syncAt) {
+			continue
+		}
+		if err := m.evaluateAndNotify(ctx, rule.Name, now, syncAt); err != nil {
+			klog.ErrorS(err, "Failed to evaluate alert rule", "alertRule", rule.Name)
+		}
+	}
+	return nil
+}
+
+// due reports whether the alert rule is evaluated at the time, given the
+// time the resources were last synced.
+func due(rule *entity.AlertRule, now, syncAt time.Time) bool {
+	if rule.Disabled {
+		return false
+	}
+	last := rule.Status.LastEvaluatedAt
+	switch rule.Trigger.Type {
+	case entity.AlertTriggerSchedule:
+		return last == nil || rule.Trigger.Interval == nil || now.Sub(last.Time) >= rule.Trigger.Interval.Duration
+	case entity.AlertTriggerSync:
+		lastSyncAt := rule.Status.LastSyncAt
+		return last == nil || (!syncAt.IsZero() && (lastSyncAt == nil || syncAt.Truncate(time.Microsecond).After(lastSyncAt.Time)))
+	}
+	return false
+}
+
+// evaluateAndNotify evaluates the alert rule, saves its status, and notifies
+// its channels if it fires. The channels are notified without holding the
+// lock, so that a slow channel does not block the other rules and the
+// updates of the alert rules.
+func (m *AlertManager) evaluateAndNotify(ctx context.Context, name string, now, syncAt time.Time) error {
+	rule, evaluation, err := m.evaluateAndSave(ctx, name, now, syncAt)
+	if err != nil || evaluation == nil || !evaluation.ShouldNotify() {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range rule.Channels {
+		if err := m.notifier.Send(ctx, channel, evaluation.Notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify %s channel: %w", channel.Type, err))
+		}
+	}
+	klog.InfoS("Alert rule fired", "alertRule", rule.Name, "count", evaluation.Count)
+	if err := errors.Join(errs...); err != nil {
+		klog.ErrorS(err, "Failed to notify the channels of alert rule", "alertRule", rule.Name)
+		return m.saveNotifyError(ctx, rule, err)
+	}
+	return nil
+}
+
+// evaluateAndSave evaluates the alert rule and saves its status. It returns
+// the evaluated rule and the evaluation, which are nil if the rule no longer
+// exists.
+func (m *AlertManager) evaluateAndSave(ctx context.Context, name string, now, syncAt time.Time) (*entity.AlertRule, *Evaluation, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Get the rule again in case it was updated since it was listed.
+	rule, err := m.GetAlertRule(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrAlertRuleNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	// The rule only matches the objects its owner can access, rather than
+	// all objects visible to the background evaluation.
+	evaluation, err := m.Evaluate(authz.WithFilter(ctx, filterOf(rule)), rule, now)
+
+	evaluatedAt := metav1.NewTime(now.UTC().Truncate(time.Second))
+	rule.Status.LastEvaluatedAt = &evaluatedAt
+	if !syncAt.IsZero() {
+		lastSyncAt := metav1.NewMicroTime(syncAt.Truncate(time.Microsecond))
+		rule.Status.LastSyncAt = &lastSyncAt
+	}
+	if err != nil {
+		rule.Status.LastError = err.Error()
+		if saveErr := m.storage.SaveAlertRule(ctx, rule); saveErr != nil {
+			return nil, nil, saveErr
+		}
+		return nil, nil, err
+	}
+
+	rule.Status.Count = evaluation.Count
+	rule.Status.Matches = evaluation.Matches
+	rule.Status.Firing = evaluation.Firing
+	rule.Status.LastError = ""
+	if evaluation.ShouldNotify() {
+		// The notification is deduplicated even if some channels fail, so
+		// that the channels which succeed are not notified repeatedly.
+		rule.Status.LastFiredAt = &evaluatedAt
+		rule.Status.LastFingerprint = evaluation.Fingerprint
+	}
+	if err := m.storage.SaveAlertRule(ctx, rule); err != nil {
+		return nil, nil, err
+	}
+	return rule, evaluation, nil
+}
+
+// saveNotifyError records the error of notifying the channels in the status
+// of the alert rule, unless the rule was updated or evaluated again since.
+func (m *AlertManager) saveNotifyError(ctx context.Context, evaluated *entity.AlertRule, notifyErr error) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	rule, err := m.GetAlertRule(ctx, evaluated.Name)
+	if err != nil {
+		if errors.Is(err, ErrAlertRuleNotFound) {
+			return nil
+		}
+		return err
+	}
+	if rule.Status.LastEvaluatedAt == nil || !rule.Status.LastEvaluatedAt.Equal(evaluated.Status.LastEvaluatedAt) {
+		return nil
+	}
+	rule.Status.LastError = notifyErr.Error()
+	return m.storage.SaveAlertRule(ctx, rule)
+}
+
+// Evaluate runs the query of the alert rule and checks its condition
+// against the status of the last evaluation, without notifying the channels
+// or updating the status.
+func (m *AlertManager) Evaluate(ctx context.Context, rule *entity.AlertRule, now time.Time) (*Evaluation, error) {
+	count, matches, err := m.query(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	evaluation := &Evaluation{Count: count, Matches: matches}
+
+	notification := &entity.AlertNotification{
+		Rule:        rule.Name,
+		Description: rule.Description,
+		Count:       count,
+		FiredAt:     now.UTC(),
+	}
+	switch rule.Condition.Type {
+	case entity.AlertConditionThreshold:
+		evaluation.Firing = rule.Condition.Matches(count)
+		notification.Summary = fmt.Sprintf("%d objects match, which is %s %d", count, rule.Condition.Operator, rule.Condition.Threshold)
+		evaluation.Fingerprint = fingerprint(entity.AlertConditionThreshold)
+	default:
+		// The objects appearing and disappearing are only known after the
+		// first evaluation, which records the matching objects.
+		if rule.Status.LastEvaluatedAt == nil {
+			return evaluation, nil
+		}
+		if count > maxAlertMatches || rule.Status.Count > maxAlertMatches {
+			evaluation.Firing = countChanged(rule.Condition.Type, rule.Status.Count, count)
+			notification.Summary = fmt.Sprintf("%d objects match, %d matched before, the objects are not tracked beyond %d matches",
+				count, rule.Status.Count, maxAlertMatches)
+			evaluation.Fingerprint = fingerprint("count", strconv.Itoa(count))
+			break
+		}
+		previous := sets.NewString(rule.Status.Matches...)
+		current := sets.NewString(matches...)
+		if rule.Condition.Type != entity.AlertConditionDisappear {
+			notification.Appeared = current.Difference(previous).List()
+		}
+		if rule.Condition.Type != entity.AlertConditionAppear {
+			notification.Disappeared = previous.Difference(current).List()
+		}
+		evaluation.Firing = len(notification.Appeared) > 0 || len(notification.Disappeared) > 0
+		notification.Summary = fmt.Sprintf("%d objects appeared and %d objects disappeared, %d objects match",
+			len(notification.Appeared), len(notification.Disappeared), count)
+		evaluation.Fingerprint = fingerprint(append(prefixed("+", notification.Appeared), prefixed("-", notification.Disappeared)...)...)
+	}
+	if !evaluation.Firing {
+		return evaluation, nil
+	}
+
+	evaluation.Notification = notification
+	evaluation.Silenced = rule.Silenced(now)
+	dedupeWindow := DefaultDedupeWindow
+	if rule.DedupeWindow != nil {
+		dedupeWindow = rule.DedupeWindow.Duration
+	}
+	evaluation.Deduplicated = rule.Status.LastFiredAt != nil &&
+		rule.Status.LastFingerprint == evaluation.Fingerprint &&
+		now.Sub(rule.Status.LastFiredAt.Time) < dedupeWindow
+	return evaluation, nil
+}
+
+// query runs the query of the alert rule, and returns the number of matching
+// objects and their sorted keys. The keys are nil if more than
+// maxAlertMatches objects match, as the objects beyond the cap would be
+// chosen in no particular order. The deleted objects are not counted, the
+// queries exclude them unless they filter by deletion themselves.
+func (m *AlertManager) query(ctx context.Context, rule *entity.AlertRule) (int, []string, error) {
+	queryString, pattern, err := m.resolveQuery(ctx, rule)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keys := []string{}
+	for page := 1; ; page++ {
+		res, err := m.search.Search(ctx, queryString, pattern, &storage.Pagination{Page: page, PageSize: alertPageSize})
+		if err != nil {
+			return 0, nil, err
+		}
+		if res.Total > maxAlertMatches {
+			return res.Total, nil, nil
+		}
+		for _, r := range res.Resources {
+			if !r.Deleted {
+				keys = append(keys, objectKey(r))
+			}
+		}
+		if len(res.Resources) < alertPageSize || page*alertPageSize >= res.Total {
+			break
+		}
+	}
+	sort.Strings(keys)
+	return len(keys), keys, nil
+}
+
+// resolveQuery returns the query and pattern evaluated for the alert rule.
+func (m *AlertManager) resolveQuery(ctx context.Context, rule *entity.AlertRule) (string, string, error) {
+	switch {
+	case rule.SavedSearch != "":
+		// The saved search is resolved as the owner of the rule, so that
+		// the private saved searches of other users are not used.
+		savedSearch, err := m.savedSearches.GetSavedSearch(ctx, rule.Owner, rule.SavedSearch)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get saved search %s: %w", rule.SavedSearch, err)
+		}
+		if savedSearch.Pattern == storage.NLPatternType {
+			return "", "", fmt.Errorf("saved search %s with the nl pattern cannot be evaluated", rule.SavedSearch)
+		}
+		if savedSearch.Pattern == storage.DSLPatternType {
+			return withoutDeleted(savedSearch.Query), savedSearch.Pattern, nil
+		}
+		// The SQL queries exclude the deleted objects by default.
+		return savedSearch.Query, savedSearch.Pattern, nil
+	case rule.ResourceGroup != nil:
+		return rule.ResourceGroup.ToSQL(), storage.SQLPatternType, nil
+	default:
+		return rule.Query, storage.SQLPatternType, nil
+	}
+}
+
+// withoutDeleted adds a term excluding the deleted objects to the DSL query,
+// unless it already has a term of the deletion.
+func withoutDeleted(dsl string) string {
+	for _, term := range strings.Split(dsl, ",") {
+		if strings.HasPrefix(strings.TrimSpace(term), "deleted=") {
+			return dsl
+		}
+	}
+	if strings.TrimSpace(dsl) == "" {
+		return "deleted=false"
+	}
+	return dsl + ",deleted=false"
+}
+
+// countChanged reports whether the change of the number of matching objects
+// fires the condition, which is used when the objects are not tracked.
+func countChanged(condition string, previous, current int) bool {
+	switch condition {
+	case entity.AlertConditionAppear:
+		return current > previous
+	case entity.AlertConditionDisappear:
+		return current < previous
+	default:
+		return current != previous
+	}
+}
+
+// objectKey identifies the object of the resource in the notifications,
+// e.g. "prod: apps/v1 Deployment default/web".
+func objectKey(r *storage.Resource) string {
+	name := r.Name
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + r.Name
+	}
+	return fmt.Sprintf("%s: %s %s %s", r.Cluster, r.APIVersion, r.Kind, name)
+}
+
+// parseObjectKey parses the key of an object returned by objectKey.
+func parseObjectKey(key string) (entity.ResourceGroup, bool) {
+	cluster, rest, ok := strings.Cut(key, ": ")
+	if !ok {
+		return entity.ResourceGroup{}, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 3 {
+		return entity.ResourceGroup{}, false
+	}
+	rg := entity.ResourceGroup{Cluster: cluster, APIVersion: fields[0], Kind: fields[1], Name: fields[2]}
+	if namespace, name, ok := strings.Cut(fields[2], "/"); ok {
+		rg.Namespace, rg.Name = namespace, name
+	}
+	return rg, true
+}
+
+// fingerprint returns a short hash identifying the values.
+func fingerprint(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func prefixed(prefix string, values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, prefix+v)
+	}
+	return out
+}
@@ -0,0 +1,296 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/savedsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AlertManager manages the alert rules, and evaluates them in the
+// background to notify their channels when they fire.
+type AlertManager struct {
+	storage       storage.AlertRuleStorage
+	savedSearches *savedsearch.SavedSearchManager
+	search        storage.SearchStorage
+	syncs         SyncSource
+	notifier      Notifier
+
+	// lock serializes the evaluations and the updates of the alert rules,
+	// so that the status written by an evaluation is not lost.
+	lock sync.Mutex
+}
+
+// NewAlertManager creates a new instance of AlertManager with the given
+// storages, saved search manager, sync source and notifier. The alert rules
+// with the sync trigger are only evaluated once if the sync source is nil.
+func NewAlertManager(
+	alertRuleStorage storage.AlertRuleStorage,
+	savedSearchMgr *savedsearch.SavedSearchManager,
+	searchStorage storage.SearchStorage,
+	syncSource SyncSource,
+	notifier Notifier,
+) (*AlertManager, error) {
+	return &AlertManager{
+		storage:       alertRuleStorage,
+		savedSearches: savedSearchMgr,
+		search:        searchStorage,
+		syncs:         syncSource,
+		notifier:      notifier,
+	}, nil
+}
+
+// GetAlertRule retrieves the alert rule by its name.
+func (m *AlertManager) GetAlertRule(ctx context.Context, name string) (*entity.AlertRule, error) {
+	if len(name) == 0 {
+		return nil, ErrMissingAlertRuleName
+	}
+	rule, err := m.storage.GetAlertRule(ctx, name)
+	if err != nil {
+		if errors.Is(err, elasticsearch.ErrAlertRuleNotFound) {
+			return nil, ErrAlertRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// ListAlertRules returns all alert rules.
+func (m *AlertManager) ListAlertRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	return m.storage.ListAlertRules(ctx)
+}
+
+// CreateAlertRule creates a new alert rule owned by the user, which only
+// matches the objects the user can access.
+func (m *AlertManager) CreateAlertRule(ctx context.Context, user string, rule *entity.AlertRule) error {
+	if rule == nil {
+		return ErrNilAlertRule
+	}
+	if len(rule.Name) == 0 {
+		return ErrMissingAlertRuleName
+	}
+	if err := m.validate(ctx, user, rule); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Check if the alert rule already exists to prevent duplicates.
+	_, err := m.storage.GetAlertRule(ctx, rule.Name)
+	if err == nil {
+		return ErrAlertRuleAlreadyExists
+	}
+	if !errors.Is(err, elasticsearch.ErrAlertRuleNotFound) {
+		return err
+	}
+
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	rule.ID = ""
+	rule.Owner = user
+	rule.Access = accessOf(authz.FilterFrom(ctx))
+	rule.Status = entity.AlertRuleStatus{}
+	rule.CreatedAt = &now
+	rule.UpdatedAt = &now
+	return m.storage.SaveAlertRule(ctx, rule)
+}
+
+// UpdateAlertRule updates the alert rule. The status is reset, so that the
+// updated rule starts over from its first evaluation, and the rule only
+// matches the objects the updating user can access.
+func (m *AlertManager) UpdateAlertRule(ctx context.Context, name string, rule *entity.AlertRule) error {
+	if rule == nil {
+		return ErrNilAlertRule
+	}
+	if name != rule.Name {
+		return ErrAlertRuleNameCannotModify
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	current, err := m.GetAlertRule(ctx, name)
+	if err != nil {
+		return err
+	}
+	// The channels redacted for the users other than the owner keep their
+	// destinations.
+	restoreChannels(rule, current)
+	if err := m.validate(ctx, current.Owner, rule); err != nil {
+		return err
+	}
+
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	rule.ID = current.ID
+	rule.Owner = current.Owner
+	rule.Access = accessOf(authz.FilterFrom(ctx))
+	rule.Status = entity.AlertRuleStatus{}
+	rule.CreatedAt = current.CreatedAt
+	rule.UpdatedAt = &now
+	return m.storage.SaveAlertRule(ctx, rule)
+}
+
+// DeleteAlertRule deletes the alert rule by its name.
+func (m *AlertManager) DeleteAlertRule(ctx context.Context, name string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, err := m.GetAlertRule(ctx, name); err != nil {
+		return err
+	}
+	return m.storage.DeleteAlertRule(ctx, name)
+}
+
+// SilenceAlertRule suppresses the notifications of the alert rule until the
+// given time, or lifts the silence if it is nil.
+func (m *AlertManager) SilenceAlertRule(ctx context.Context, name string, until *time.Time) (*entity.AlertRule, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	rule, err := m.GetAlertRule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	rule.SilencedUntil = nil
+	if until != nil {
+		silencedUntil := metav1.NewTime(until.UTC().Truncate(time.Second))
+		rule.SilencedUntil = &silencedUntil
+	}
+	if err := m.storage.SaveAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// TestAlertRule evaluates the alert rule without notifying its channels or
+// updating its status.
+func (m *AlertManager) TestAlertRule(ctx context.Context, name string) (*Evaluation, error) {
+	rule, err := m.GetAlertRule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Evaluate(ctx, rule, time.Now())
+}
+
+// validate checks the alert rule and the saved search it refers to, which
+// must be visible to the owner of the rule.
+func (m *AlertManager) validate(ctx context.Context, owner string, rule *entity.AlertRule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAlertRule, err)
+	}
+	if rule.SavedSearch != "" {
+		savedSearch, err := m.savedSearches.GetSavedSearch(ctx, owner, rule.SavedSearch)
+		if err != nil {
+			if errors.Is(err, savedsearch.ErrSavedSearchNotFound) {
+				return fmt.Errorf("%w: saved search %s not found", ErrInvalidAlertRule, rule.SavedSearch)
+			}
+			return err
+		}
+		if savedSearch.Pattern == storage.NLPatternType {
+			return fmt.Errorf("%w: saved search %s with the nl pattern cannot be evaluated, use the sql or dsl pattern", ErrInvalidAlertRule, rule.SavedSearch)
+		}
+	}
+	return nil
+}
+
+// accessOf returns the data access recorded on the alert rules created with
+// the filter, which is nil if the filter does not restrict the access.
+func accessOf(filter *authz.Filter) *entity.AlertRuleAccess {
+	if filter == nil {
+		return nil
+	}
+	access := &entity.AlertRuleAccess{Scopes: []entity.AlertRuleAccessScope{}}
+	for _, scope := range filter.Scopes {
+		access.Scopes = append(access.Scopes, entity.AlertRuleAccessScope{
+			Clusters:   scope.Clusters,
+			Namespaces: scope.Namespaces,
+			Kinds:      scope.Kinds,
+		})
+	}
+	return access
+}
+
+// filterOf returns the data access filter the alert rule is evaluated with.
+func filterOf(rule *entity.AlertRule) *authz.Filter {
+	if rule.Access == nil {
+		return nil
+	}
+	filter := &authz.Filter{Scopes: []authz.Scope{}}
+	for _, scope := range rule.Access.Scopes {
+		filter.Scopes = append(filter.Scopes, authz.Scope{
+			Clusters:   scope.Clusters,
+			Namespaces: scope.Namespaces,
+			Kinds:      scope.Kinds,
+		})
+	}
+	return filter
+}
+
+// Redacted returns a copy of the alert rule with the parts the user must not
+// see hidden. The destinations of the channels are secrets only shown to the
+// owner, and the matching objects are restricted to the data access filter
+// of the context for the other users.
+func Redacted(ctx context.Context, user string, rule *entity.AlertRule) *entity.AlertRule {
+	if rule.Owner == user {
+		return rule
+	}
+	redacted := *rule
+	redacted.Channels = make([]entity.AlertChannel, 0, len(rule.Channels))
+	for _, channel := range rule.Channels {
+		if channel.URL != "" {
+			channel.URL = redactedValue
+		}
+		if len(channel.To) > 0 {
+			channel.To = []string{redactedValue}
+		}
+		redacted.Channels = append(redacted.Channels, channel)
+	}
+	if filter := authz.FilterFrom(ctx); filter != nil && rule.Status.Matches != nil {
+		redacted.Status.Matches = []string{}
+		for _, key := range rule.Status.Matches {
+			if rg, ok := parseObjectKey(key); ok && filter.Allows(rg) {
+				redacted.Status.Matches = append(redacted.Status.Matches, key)
+			}
+		}
+	}
+	return &redacted
+}
+
+// restoreChannels restores the redacted destinations of the channels of the
+// rule from the channels of the same type at the same position of the
+// current rule.
+func restoreChannels(rule, current *entity.AlertRule) {
+	for i := range rule.Channels {
+		if i >= len(current.Channels) || rule.Channels[i].Type != current.Channels[i].Type {
+			continue
+		}
+		if rule.Channels[i].URL == redactedValue {
+			rule.Channels[i].URL = current.Channels[i].URL
+		}
+		if len(rule.Channels[i].To) == 1 && rule.Channels[i].To[0] == redactedValue {
+			rule.Channels[i].To = current.Channels[i].To
+		}
+	}
+}
@@ -0,0 +1,610 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/savedsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mockAlertRuleStorage is a mock implementation of storage.AlertRuleStorage
+type mockAlertRuleStorage struct {
+	rules map[string]*entity.AlertRule
+}
+
+func (m *mockAlertRuleStorage) GetAlertRule(ctx context.Context, name string) (*entity.AlertRule, error) {
+	if rule, exists := m.rules[name]; exists {
+		copied := *rule
+		return &copied, nil
+	}
+	return nil, elasticsearch.ErrAlertRuleNotFound
+}
+
+func (m *mockAlertRuleStorage) SaveAlertRule(ctx context.Context, rule *entity.AlertRule) error {
+	if rule.ID == "" {
+		rule.ID = entity.UUID()
+	}
+	copied := *rule
+	m.rules[rule.Name] = &copied
+	return nil
+}
+
+func (m *mockAlertRuleStorage) DeleteAlertRule(ctx context.Context, name string) error {
+	if _, exists := m.rules[name]; !exists {
+		return elasticsearch.ErrAlertRuleNotFound
+	}
+	delete(m.rules, name)
+	return nil
+}
+
+func (m *mockAlertRuleStorage) ListAlertRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	rules := make([]*entity.AlertRule, 0, len(m.rules))
+	for _, rule := range m.rules {
+		copied := *rule
+		rules = append(rules, &copied)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// mockSavedSearchStorage is a mock implementation of storage.SavedSearchStorage
+type mockSavedSearchStorage struct {
+	storage.SavedSearchStorage
+	savedSearches map[string]*entity.SavedSearch
+}
+
+func (m *mockSavedSearchStorage) GetSavedSearch(ctx context.Context, name string) (*entity.SavedSearch, error) {
+	if savedSearch, exists := m.savedSearches[name]; exists {
+		return savedSearch, nil
+	}
+	return nil, elasticsearch.ErrSavedSearchNotFound
+}
+
+// mockSearchStorage returns the configured resources for every query and
+// records the last query.
+type mockSearchStorage struct {
+	storage.SearchStorage
+	resources []*storage.Resource
+	query     string
+	pattern   string
+	filter    *authz.Filter
+}
+
+func (m *mockSearchStorage) Search(ctx context.Context, queryString, patternType string, pagination *storage.Pagination) (*storage.SearchResult, error) {
+	m.query, m.pattern = queryString, patternType
+	m.filter = authz.FilterFrom(ctx)
+	start := (pagination.Page - 1) * pagination.PageSize
+	end := start + pagination.PageSize
+	if start > len(m.resources) {
+		start = len(m.resources)
+	}
+	if end > len(m.resources) {
+		end = len(m.resources)
+	}
+	return &storage.SearchResult{Total: len(m.resources), Resources: m.resources[start:end]}, nil
+}
+
+// mockSyncSource reports the configured latest sync time.
+type mockSyncSource struct {
+	syncAt time.Time
+	synced chan struct{}
+}
+
+func (m *mockSyncSource) LatestSyncTime() time.Time {
+	return m.syncAt
+}
+
+func (m *mockSyncSource) Synced() <-chan struct{} {
+	return m.synced
+}
+
+// mockNotifier records the sent notifications.
+type mockNotifier struct {
+	sent   []*entity.AlertNotification
+	err    error
+	onSend func()
+}
+
+func (m *mockNotifier) Send(ctx context.Context, channel entity.AlertChannel, n *entity.AlertNotification) error {
+	if m.onSend != nil {
+		m.onSend()
+	}
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+type testEnv struct {
+	manager  *AlertManager
+	rules    *mockAlertRuleStorage
+	search   *mockSearchStorage
+	syncs    *mockSyncSource
+	notifier *mockNotifier
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	env := &testEnv{
+		rules:    &mockAlertRuleStorage{rules: map[string]*entity.AlertRule{}},
+		search:   &mockSearchStorage{},
+		syncs:    &mockSyncSource{synced: make(chan struct{}, 1)},
+		notifier: &mockNotifier{},
+	}
+	savedSearches, err := savedsearch.NewSavedSearchManager(&mockSavedSearchStorage{savedSearches: map[string]*entity.SavedSearch{
+		"pods":     {Name: "pods", Pattern: "sql", Query: "select * from resources where kind = 'Pod'", Visibility: entity.SavedSearchVisibilityShared},
+		"nl-pods":  {Name: "nl-pods", Pattern: "nl", Query: "all pods", Visibility: entity.SavedSearchVisibilityShared},
+		"bob-pods": {Name: "bob-pods", Pattern: "sql", Query: "select * from resources where kind = 'Pod'", Owner: "bob", Visibility: entity.SavedSearchVisibilityPrivate},
+	}})
+	require.NoError(t, err)
+	manager, err := NewAlertManager(env.rules, savedSearches, env.search, env.syncs, env.notifier)
+	require.NoError(t, err)
+	env.manager = manager
+	return env
+}
+
+func newResource(name string) *storage.Resource {
+	return &storage.Resource{ResourceGroup: entity.ResourceGroup{
+		Cluster: "prod", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: name,
+	}}
+}
+
+func newTestAlertRule(name string, condition entity.AlertCondition) *entity.AlertRule {
+	return &entity.AlertRule{
+		Name:      name,
+		Query:     "select * from resources where kind = 'Pod'",
+		Trigger:   entity.AlertTrigger{Type: entity.AlertTriggerSync},
+		Condition: condition,
+		Channels:  []entity.AlertChannel{{Type: entity.AlertChannelWebhook, URL: "https://example.com/hook"}},
+	}
+}
+
+func TestAlertManager_CreateAlertRule(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	threshold := entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0}
+
+	fromSavedSearch := newTestAlertRule("saved", threshold)
+	fromSavedSearch.Query = ""
+	fromSavedSearch.SavedSearch = "pods"
+	fromNLSavedSearch := newTestAlertRule("nl", threshold)
+	fromNLSavedSearch.Query = ""
+	fromNLSavedSearch.SavedSearch = "nl-pods"
+	fromPrivateSavedSearch := newTestAlertRule("private", threshold)
+	fromPrivateSavedSearch.Query = ""
+	fromPrivateSavedSearch.SavedSearch = "bob-pods"
+	fromMissingSavedSearch := newTestAlertRule("missing", threshold)
+	fromMissingSavedSearch.Query = ""
+	fromMissingSavedSearch.SavedSearch = "missing"
+	twoSources := newTestAlertRule("two", threshold)
+	twoSources.ResourceGroup = &entity.ResourceGroup{Kind: "Pod"}
+	noInterval := newTestAlertRule("schedule", threshold)
+	noInterval.Trigger.Type = entity.AlertTriggerSchedule
+	badChannel := newTestAlertRule("channel", threshold)
+	badChannel.Channels = []entity.AlertChannel{{Type: entity.AlertChannelSlack, URL: "not a url"}}
+
+	tests := []struct {
+		name        string
+		rule        *entity.AlertRule
+		expectError error
+	}{
+		{name: "Success", rule: newTestAlertRule("pods", threshold)},
+		{name: "Success with saved search", rule: fromSavedSearch},
+		{name: "Nil rule", rule: nil, expectError: ErrNilAlertRule},
+		{name: "Empty name", rule: newTestAlertRule("", threshold), expectError: ErrMissingAlertRuleName},
+		{name: "Already exists", rule: newTestAlertRule("pods", threshold), expectError: ErrAlertRuleAlreadyExists},
+		{name: "Saved search with nl pattern", rule: fromNLSavedSearch, expectError: ErrInvalidAlertRule},
+		{name: "Saved search not found", rule: fromMissingSavedSearch, expectError: ErrInvalidAlertRule},
+		{name: "Private saved search of another user", rule: fromPrivateSavedSearch, expectError: ErrInvalidAlertRule},
+		{name: "Two sources", rule: twoSources, expectError: ErrInvalidAlertRule},
+		{name: "Schedule without interval", rule: noInterval, expectError: ErrInvalidAlertRule},
+		{name: "Invalid operator", rule: newTestAlertRule("operator", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: "~"}), expectError: ErrInvalidAlertRule},
+		{name: "Invalid channel", rule: badChannel, expectError: ErrInvalidAlertRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.manager.CreateAlertRule(ctx, "alice", tt.rule)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, tt.rule.ID)
+			require.Equal(t, "alice", tt.rule.Owner)
+			require.NotNil(t, tt.rule.CreatedAt)
+		})
+	}
+}
+
+func TestAlertManager_UpdateDeleteAlertRule(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	threshold := entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0}
+
+	original := newTestAlertRule("pods", threshold)
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", original))
+	env.rules.rules["pods"].Status.Firing = true
+
+	updated := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 5})
+	require.NoError(t, env.manager.UpdateAlertRule(ctx, "pods", updated))
+	require.Equal(t, original.ID, updated.ID)
+	require.Equal(t, "alice", updated.Owner)
+	require.False(t, env.rules.rules["pods"].Status.Firing)
+	require.Equal(t, 5, env.rules.rules["pods"].Condition.Threshold)
+
+	require.ErrorIs(t, env.manager.UpdateAlertRule(ctx, "pods", newTestAlertRule("renamed", threshold)), ErrAlertRuleNameCannotModify)
+	require.ErrorIs(t, env.manager.UpdateAlertRule(ctx, "missing", newTestAlertRule("missing", threshold)), ErrAlertRuleNotFound)
+
+	require.NoError(t, env.manager.DeleteAlertRule(ctx, "pods"))
+	require.ErrorIs(t, env.manager.DeleteAlertRule(ctx, "pods"), ErrAlertRuleNotFound)
+}
+
+func TestRedacted(t *testing.T) {
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionAppear})
+	rule.Owner = "alice"
+	rule.Channels = append(rule.Channels, entity.AlertChannel{Type: entity.AlertChannelEmail, To: []string{"oncall@example.com"}})
+	rule.Status.Matches = []string{"dev: v1 Pod default/web", "prod: v1 Pod default/web", "prod: v1 Namespace default"}
+
+	// The owner sees the whole rule.
+	require.Same(t, rule, Redacted(context.Background(), "alice", rule))
+
+	// Other users do not see the channel destinations, and only see the
+	// matching objects they can access.
+	ctx := authz.WithFilter(context.Background(), &authz.Filter{Scopes: []authz.Scope{{Clusters: []string{"dev"}}}})
+	redacted := Redacted(ctx, "bob", rule)
+	require.Equal(t, []entity.AlertChannel{
+		{Type: entity.AlertChannelWebhook, URL: redactedValue},
+		{Type: entity.AlertChannelEmail, To: []string{redactedValue}},
+	}, redacted.Channels)
+	require.Equal(t, []string{"dev: v1 Pod default/web"}, redacted.Status.Matches)
+	require.Equal(t, "https://example.com/hook", rule.Channels[0].URL)
+	require.Len(t, rule.Status.Matches, 3)
+
+	// The users with unrestricted access see all matching objects.
+	require.Equal(t, rule.Status.Matches, Redacted(context.Background(), "bob", rule).Status.Matches)
+}
+
+func TestAlertManager_UpdateRedactedChannels(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	threshold := entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0}
+
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", newTestAlertRule("pods", threshold)))
+	rule, err := env.manager.GetAlertRule(ctx, "pods")
+	require.NoError(t, err)
+
+	// The redacted channels keep their destinations when updated.
+	updated := Redacted(ctx, "bob", rule)
+	updated.Condition.Threshold = 3
+	require.NoError(t, env.manager.UpdateAlertRule(ctx, "pods", updated))
+	require.Equal(t, "https://example.com/hook", env.rules.rules["pods"].Channels[0].URL)
+	require.Equal(t, 3, env.rules.rules["pods"].Condition.Threshold)
+}
+
+func TestAlertManager_Threshold(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	rule := newTestAlertRule("crashloop", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">=", Threshold: 2})
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+
+	// Not firing below the threshold.
+	env.search.resources = []*storage.Resource{newResource("a")}
+	require.NoError(t, env.manager.EvaluateDue(ctx, now))
+	require.Empty(t, env.notifier.sent)
+	require.Equal(t, 1, env.rules.rules["crashloop"].Status.Count)
+
+	// Firing at the threshold, after the resources are synced.
+	env.search.resources = []*storage.Resource{newResource("a"), newResource("b")}
+	env.syncs.syncAt = now.Add(time.Second)
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(2*time.Second)))
+	require.Len(t, env.notifier.sent, 1)
+	require.Equal(t, 2, env.notifier.sent[0].Count)
+	require.True(t, env.rules.rules["crashloop"].Status.Firing)
+
+	// Not evaluated again without a sync.
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(3*time.Second)))
+	require.Len(t, env.notifier.sent, 1)
+
+	// Still firing within the dedupe window.
+	env.syncs.syncAt = now.Add(4 * time.Second)
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(5*time.Second)))
+	require.Len(t, env.notifier.sent, 1)
+
+	// Notified again after the dedupe window.
+	env.syncs.syncAt = now.Add(DefaultDedupeWindow)
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(DefaultDedupeWindow+3*time.Second)))
+	require.Len(t, env.notifier.sent, 2)
+}
+
+func TestAlertManager_OwnerAccess(t *testing.T) {
+	env := newTestEnv(t)
+	now := time.Now()
+
+	// The rule created by a restricted user only matches the objects the
+	// user can access, even though it is evaluated in the background.
+	filter := &authz.Filter{Scopes: []authz.Scope{{Clusters: []string{"dev"}, Namespaces: []string{"team-a"}}}}
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0})
+	require.NoError(t, env.manager.CreateAlertRule(authz.WithFilter(context.Background(), filter), "alice", rule))
+	require.Equal(t, &entity.AlertRuleAccess{Scopes: []entity.AlertRuleAccessScope{
+		{Clusters: []string{"dev"}, Namespaces: []string{"team-a"}},
+	}}, env.rules.rules["pods"].Access)
+
+	require.NoError(t, env.manager.EvaluateDue(context.Background(), now))
+	require.Equal(t, filter, env.search.filter)
+
+	// The rule updated by an unrestricted user matches all objects.
+	update := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0})
+	require.NoError(t, env.manager.UpdateAlertRule(context.Background(), "pods", update))
+	require.Nil(t, env.rules.rules["pods"].Access)
+
+	require.NoError(t, env.manager.EvaluateDue(context.Background(), now))
+	require.Nil(t, env.search.filter)
+
+	// The rule created by a user without access matches no object.
+	rule = newTestAlertRule("none", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0})
+	require.NoError(t, env.manager.CreateAlertRule(authz.WithFilter(context.Background(), &authz.Filter{}), "bob", rule))
+	require.True(t, filterOf(env.rules.rules["none"]).AllowsNone())
+}
+
+func TestAlertManager_AppearDisappear(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	rule := newTestAlertRule("bindings", entity.AlertCondition{Type: entity.AlertConditionChange})
+	rule.ResourceGroup = &entity.ResourceGroup{Kind: "ClusterRoleBinding"}
+	rule.Query = ""
+	rule.Trigger = entity.AlertTrigger{Type: entity.AlertTriggerSchedule, Interval: &metav1.Duration{Duration: time.Minute}}
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+
+	// The first evaluation records the matching objects.
+	env.search.resources = []*storage.Resource{newResource("a")}
+	require.NoError(t, env.manager.EvaluateDue(ctx, now))
+	require.Empty(t, env.notifier.sent)
+	require.Equal(t, "SELECT * from resources WHERE kind='ClusterRoleBinding'", env.search.query)
+	require.Equal(t, []string{"prod: v1 Pod default/a"}, env.rules.rules["bindings"].Status.Matches)
+
+	// Not due before the interval elapsed.
+	env.search.resources = []*storage.Resource{newResource("b")}
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(30*time.Second)))
+	require.Empty(t, env.notifier.sent)
+
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(time.Minute)))
+	require.Len(t, env.notifier.sent, 1)
+	require.Equal(t, []string{"prod: v1 Pod default/b"}, env.notifier.sent[0].Appeared)
+	require.Equal(t, []string{"prod: v1 Pod default/a"}, env.notifier.sent[0].Disappeared)
+
+	// Nothing changed.
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(2*time.Minute)))
+	require.Len(t, env.notifier.sent, 1)
+	require.False(t, env.rules.rules["bindings"].Status.Firing)
+}
+
+func TestAlertManager_DeletedObjects(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	deleted := newResource("deleted")
+	deleted.Deleted = true
+	env.search.resources = []*storage.Resource{newResource("web"), deleted}
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 1})
+
+	// The deleted objects are neither counted nor matched.
+	evaluation, err := env.manager.Evaluate(ctx, rule, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, evaluation.Count)
+	require.Equal(t, []string{"prod: v1 Pod default/web"}, evaluation.Matches)
+	require.False(t, evaluation.Firing)
+}
+
+func TestWithoutDeleted(t *testing.T) {
+	require.Equal(t, "kind=Pod,deleted=false", withoutDeleted("kind=Pod"))
+	require.Equal(t, "deleted=false", withoutDeleted(""))
+	require.Equal(t, "kind=Pod,deleted=true", withoutDeleted("kind=Pod,deleted=true"))
+}
+
+func TestAlertManager_TooManyMatches(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionAppear})
+	rule.Trigger = entity.AlertTrigger{Type: entity.AlertTriggerSchedule, Interval: &metav1.Duration{Duration: time.Minute}}
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+
+	env.search.resources = []*storage.Resource{newResource("a")}
+	require.NoError(t, env.manager.EvaluateDue(ctx, now))
+
+	// Beyond the cap, the objects are not tracked and the rule fires on the
+	// increase of the number of matching objects.
+	env.search.resources = make([]*storage.Resource, 0, maxAlertMatches+1)
+	for i := 0; i <= maxAlertMatches; i++ {
+		env.search.resources = append(env.search.resources, newResource(fmt.Sprintf("pod-%d", i)))
+	}
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(time.Minute)))
+	require.Len(t, env.notifier.sent, 1)
+	require.Equal(t, maxAlertMatches+1, env.notifier.sent[0].Count)
+	require.Empty(t, env.notifier.sent[0].Appeared)
+	require.Nil(t, env.rules.rules["pods"].Status.Matches)
+
+	// Not firing when the number of matching objects decreases.
+	env.search.resources = env.search.resources[:maxAlertMatches]
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(2*time.Minute)))
+	require.Len(t, env.notifier.sent, 1)
+	require.False(t, env.rules.rules["pods"].Status.Firing)
+	require.Len(t, env.rules.rules["pods"].Status.Matches, maxAlertMatches)
+}
+
+func TestAlertManager_Silence(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionAppear})
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+	require.NoError(t, env.manager.EvaluateDue(ctx, now))
+
+	until := now.Add(time.Hour)
+	silenced, err := env.manager.SilenceAlertRule(ctx, "pods", &until)
+	require.NoError(t, err)
+	require.NotNil(t, silenced.SilencedUntil)
+
+	env.search.resources = []*storage.Resource{newResource("a")}
+	env.syncs.syncAt = now.Add(time.Second)
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(2*time.Second)))
+	require.Empty(t, env.notifier.sent)
+	require.True(t, env.rules.rules["pods"].Status.Firing)
+
+	// The silenced objects are not reported after the silence is lifted.
+	_, err = env.manager.SilenceAlertRule(ctx, "pods", nil)
+	require.NoError(t, err)
+	env.search.resources = []*storage.Resource{newResource("a"), newResource("b")}
+	env.syncs.syncAt = now.Add(3 * time.Second)
+	require.NoError(t, env.manager.EvaluateDue(ctx, now.Add(4*time.Second)))
+	require.Len(t, env.notifier.sent, 1)
+	require.Equal(t, []string{"prod: v1 Pod default/b"}, env.notifier.sent[0].Appeared)
+}
+
+func TestAlertManager_NotifyFailure(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.notifier.err = errors.New("connection refused")
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: "==", Threshold: 0})
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+	require.NoError(t, env.manager.EvaluateDue(ctx, time.Now()))
+	require.Contains(t, env.rules.rules["pods"].Status.LastError, "connection refused")
+	require.NotNil(t, env.rules.rules["pods"].Status.LastFiredAt)
+}
+
+func TestAlertManager_NotifyWithoutLock(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	// The alert rules can be updated while the channels are notified.
+	locked := true
+	env.notifier.onSend = func() {
+		if env.manager.lock.TryLock() {
+			locked = false
+			env.manager.lock.Unlock()
+		}
+	}
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: "==", Threshold: 0})
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+	require.NoError(t, env.manager.EvaluateDue(ctx, time.Now()))
+	require.Len(t, env.notifier.sent, 1)
+	require.False(t, locked)
+}
+
+func TestAlertManager_TestAlertRule(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0})
+	rule.Query = ""
+	rule.SavedSearch = "pods"
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+
+	env.search.resources = []*storage.Resource{newResource("a")}
+	evaluation, err := env.manager.TestAlertRule(ctx, "pods")
+	require.NoError(t, err)
+	require.True(t, evaluation.Firing)
+	require.True(t, evaluation.ShouldNotify())
+	require.Equal(t, "select * from resources where kind = 'Pod'", env.search.query)
+	require.Empty(t, env.notifier.sent)
+	require.Nil(t, env.rules.rules["pods"].Status.LastEvaluatedAt)
+}
+
+func TestDue(t *testing.T) {
+	now := time.Now()
+	lastEvaluatedAt := metav1.NewTime(now.Add(-time.Minute))
+	lastSyncAt := metav1.NewMicroTime(now.Add(-2 * time.Minute))
+
+	tests := []struct {
+		name   string
+		rule   *entity.AlertRule
+		syncAt time.Time
+		due    bool
+	}{
+		{
+			name: "disabled",
+			rule: &entity.AlertRule{Disabled: true, Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSync}},
+		},
+		{
+			name: "never evaluated",
+			rule: &entity.AlertRule{Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSync}},
+			due:  true,
+		},
+		{
+			name:   "synced since last evaluation",
+			rule:   &entity.AlertRule{Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSync}, Status: entity.AlertRuleStatus{LastEvaluatedAt: &lastEvaluatedAt, LastSyncAt: &lastSyncAt}},
+			syncAt: now,
+			due:    true,
+		},
+		{
+			name:   "not synced since last evaluation",
+			rule:   &entity.AlertRule{Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSync}, Status: entity.AlertRuleStatus{LastEvaluatedAt: &lastEvaluatedAt, LastSyncAt: &lastSyncAt}},
+			syncAt: lastSyncAt.Time,
+		},
+		{
+			name: "interval elapsed",
+			rule: &entity.AlertRule{Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSchedule, Interval: &metav1.Duration{Duration: time.Minute}}, Status: entity.AlertRuleStatus{LastEvaluatedAt: &lastEvaluatedAt}},
+			due:  true,
+		},
+		{
+			name: "interval not elapsed",
+			rule: &entity.AlertRule{Trigger: entity.AlertTrigger{Type: entity.AlertTriggerSchedule, Interval: &metav1.Duration{Duration: time.Hour}}, Status: entity.AlertRuleStatus{LastEvaluatedAt: &lastEvaluatedAt}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.due, due(tt.rule, now, tt.syncAt))
+		})
+	}
+}
+
+func TestRunEvaluatorOnSync(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rule := newTestAlertRule("pods", entity.AlertCondition{Type: entity.AlertConditionThreshold, Operator: ">", Threshold: 0})
+	require.NoError(t, env.manager.CreateAlertRule(ctx, "alice", rule))
+
+	// The rule is evaluated on the sync rather than at the interval.
+	go env.manager.RunEvaluator(ctx, time.Hour)
+	env.syncs.synced <- struct{}{}
+	require.Eventually(t, func() bool {
+		env.manager.lock.Lock()
+		defer env.manager.lock.Unlock()
+		return env.rules.rules["pods"].Status.LastEvaluatedAt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
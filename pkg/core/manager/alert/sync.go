@@ -0,0 +1,109 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
+	clusterinformers "github.com/KusionStack/karpor/pkg/kubernetes/generated/informers/externalversions/cluster/v1beta1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SyncSource tells the evaluator when the resources are synced, which the
+// alert rules with the sync trigger are evaluated on.
+type SyncSource interface {
+	// LatestSyncTime returns the last time the resources of any cluster
+	// were synced, it is zero if no resource is synced.
+	LatestSyncTime() time.Time
+	// Synced returns a channel receiving a value whenever the resources are
+	// synced.
+	Synced() <-chan struct{}
+}
+
+// ClusterSyncSource is a SyncSource watching the last sync time the syncer
+// records in the status of the clusters.
+type ClusterSyncSource struct {
+	informer cache.SharedIndexInformer
+	synced   chan struct{}
+}
+
+var _ SyncSource = &ClusterSyncSource{}
+
+// NewClusterSyncSource creates a new ClusterSyncSource watching the clusters
+// with the client.
+func NewClusterSyncSource(client versioned.Interface) (*ClusterSyncSource, error) {
+	s := &ClusterSyncSource{
+		informer: clusterinformers.NewClusterInformer(client, 0, cache.Indexers{}),
+		// The notifications are coalesced, as the evaluator only needs to
+		// know whether the resources were synced since its last evaluation.
+		synced: make(chan struct{}, 1),
+	}
+	_, err := s.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cluster, ok := obj.(*clusterv1beta1.Cluster); ok && cluster.Status.LastSyncTime != nil {
+				s.notify()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCluster, okOld := oldObj.(*clusterv1beta1.Cluster)
+			newCluster, okNew := newObj.(*clusterv1beta1.Cluster)
+			if !okOld || !okNew || newCluster.Status.LastSyncTime == nil {
+				return
+			}
+			if oldCluster.Status.LastSyncTime == nil || oldCluster.Status.LastSyncTime.Before(newCluster.Status.LastSyncTime) {
+				s.notify()
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Run watches the clusters until the context is done.
+func (s *ClusterSyncSource) Run(ctx context.Context) {
+	s.informer.Run(ctx.Done())
+}
+
+// LatestSyncTime returns the last time the resources of any cluster were
+// synced.
+func (s *ClusterSyncSource) LatestSyncTime() time.Time {
+	var latest time.Time
+	for _, obj := range s.informer.GetStore().List() {
+		cluster, ok := obj.(*clusterv1beta1.Cluster)
+		if ok && cluster.Status.LastSyncTime != nil && cluster.Status.LastSyncTime.After(latest) {
+			latest = cluster.Status.LastSyncTime.Time
+		}
+	}
+	return latest
+}
+
+// Synced returns a channel receiving a value whenever the resources of any
+// cluster are synced.
+func (s *ClusterSyncSource) Synced() <-chan struct{} {
+	return s.synced
+}
+
+// notify sends a value to the synced channel unless one is pending.
+func (s *ClusterSyncSource) notify() {
+	select {
+	case s.synced <- struct{}{}:
+	default:
+	}
+}
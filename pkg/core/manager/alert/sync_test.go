@@ -0,0 +1,65 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterSyncSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	syncedAt := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	client := fake.NewSimpleClientset(
+		&clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "synced"}, Status: clusterv1beta1.ClusterStatus{LastSyncTime: &syncedAt}},
+		&clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "idle"}},
+	)
+	source, err := NewClusterSyncSource(client)
+	require.NoError(t, err)
+	go source.Run(ctx)
+
+	// The synced clusters are reported when they are listed.
+	waitSynced(t, source)
+	require.Equal(t, syncedAt.Time, source.LatestSyncTime().UTC())
+
+	// A later sync of any cluster is reported.
+	later := metav1.NewTime(syncedAt.Add(time.Minute))
+	idle, err := client.ClusterV1beta1().Clusters().Get(ctx, "idle", metav1.GetOptions{})
+	require.NoError(t, err)
+	idle.Status.LastSyncTime = &later
+	_, err = client.ClusterV1beta1().Clusters().UpdateStatus(ctx, idle, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	waitSynced(t, source)
+	require.Eventually(t, func() bool {
+		return source.LatestSyncTime().Equal(later.Time)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func waitSynced(t *testing.T, source SyncSource) {
+	t.Helper()
+	select {
+	case <-source.Synced():
+	case <-time.After(5 * time.Second):
+		t.Fatal("resources are not reported synced")
+	}
+}
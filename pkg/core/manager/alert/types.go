@@ -0,0 +1,67 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
+
+var (
+	ErrNilAlertRule              = errors.New("alert rule cannot be nil")
+	ErrMissingAlertRuleName      = errors.New("alert rule name is required")
+	ErrInvalidAlertRule          = errors.New("invalid alert rule")
+	ErrAlertRuleAlreadyExists    = errors.New("alert rule already exists")
+	ErrAlertRuleNotFound         = errors.New("alert rule not found")
+	ErrAlertRuleNameCannotModify = errors.New("alert rule name cannot be modified")
+)
+
+// redactedValue replaces the destinations of the channels in the alert rules
+// shown to the users other than their owners.
+const redactedValue = "[redacted]"
+
+// Notifier delivers the notifications of alert rules to their channels.
+type Notifier interface {
+	Send(ctx context.Context, channel entity.AlertChannel, n *entity.AlertNotification) error
+}
+
+// Evaluation is the result of evaluating an alert rule.
+type Evaluation struct {
+	// Count is the number of matching objects.
+	Count int `json:"count"`
+	// Matches are the keys of the matching objects, which are not tracked
+	// if more than maxAlertMatches objects match.
+	Matches []string `json:"matches,omitempty"`
+	// Firing reports whether the condition of the alert rule fired.
+	Firing bool `json:"firing"`
+	// Notification is sent to the channels of the alert rule, it is nil if
+	// the condition did not fire.
+	Notification *entity.AlertNotification `json:"notification,omitempty"`
+	// Fingerprint identifies the notification for dedupe.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Silenced reports whether the notification is suppressed because the
+	// alert rule is silenced.
+	Silenced bool `json:"silenced,omitempty"`
+	// Deduplicated reports whether the notification is suppressed because
+	// the same notification was sent within the dedupe window.
+	Deduplicated bool `json:"deduplicated,omitempty"`
+}
+
+// ShouldNotify reports whether the notification of the evaluation is sent.
+func (e *Evaluation) ShouldNotify() bool {
+	return e.Notification != nil && !e.Silenced && !e.Deduplicated
+}
@@ -0,0 +1,260 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
+	"github.com/KusionStack/karpor/pkg/util/credentialutil"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+)
+
+// TokenRequester requests a new token of the service account in the
+// namespace from the target cluster, valid for the given duration.
+type TokenRequester func(ctx context.Context, cluster, namespace, name string, ttl time.Duration) (string, error)
+
+// NewTokenRequester returns a TokenRequester which requests the tokens with
+// the TokenRequest API of the target clusters, through the cluster proxy of
+// the hub.
+func NewTokenRequester(hubConfig *rest.Config) TokenRequester {
+	return func(ctx context.Context, cluster, namespace, name string, ttl time.Duration) (string, error) {
+		client, err := multicluster.BuildMultiClusterClient(ctx, hubConfig, cluster)
+		if err != nil {
+			return "", err
+		}
+		expirationSeconds := int64(ttl.Seconds())
+		tokenRequest, err := client.ClientSet.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return "", err
+		}
+		return tokenRequest.Status.Token, nil
+	}
+}
+
+// CredentialTrackerOptions configures the CredentialTracker.
+type CredentialTrackerOptions struct {
+	// WarningWindow is how long before the expiry a credential is reported
+	// as expiring.
+	WarningWindow time.Duration
+	// RotateServiceAccountTokens enables requesting new service account
+	// tokens for the clusters whose tokens are expiring.
+	RotateServiceAccountTokens bool
+	// RotatedTokenTTL is the validity of the rotated tokens.
+	RotatedTokenTTL time.Duration
+}
+
+// CredentialTracker tracks the expiry of the credentials of the clusters,
+// keeps it in the status of the clusters and optionally rotates expiring
+// service account tokens.
+type CredentialTracker struct {
+	client    versioned.Interface
+	requester TokenRequester
+	options   CredentialTrackerOptions
+	now       func() time.Time
+
+	lock     sync.RWMutex
+	expiring map[string]time.Time
+}
+
+// NewCredentialTracker creates a credential tracker managing the clusters
+// through the given client of the hub.
+func NewCredentialTracker(client versioned.Interface, requester TokenRequester, options CredentialTrackerOptions) *CredentialTracker {
+	return &CredentialTracker{
+		client:    client,
+		requester: requester,
+		options:   options,
+		now:       time.Now,
+		expiring:  map[string]time.Time{},
+	}
+}
+
+// credentialRetryInterval is the interval of retrying a failed check of the
+// credentials, such as when the apiserver is not serving yet.
+const credentialRetryInterval = time.Minute
+
+// Run checks the credentials of all clusters every interval in the
+// background. It blocks until the context is done.
+func (t *CredentialTracker) Run(ctx context.Context, interval time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting cluster credential tracker", "interval", interval)
+
+	for {
+		next := interval
+		if err := t.CheckAll(ctx); err != nil {
+			log.Error(err, "Cluster credential check finished with errors")
+			next = min(interval, credentialRetryInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next):
+		}
+	}
+}
+
+// CheckAll checks the credentials of all clusters, updates their expiry in
+// the status of the clusters and rotates the expiring service account
+// tokens if enabled.
+func (t *CredentialTracker) CheckAll(ctx context.Context) error {
+	clusters, err := t.client.ClusterV1beta1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list clusters")
+	}
+
+	var errs []error
+	expiring := map[string]time.Time{}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		expiration, err := t.check(ctx, cluster)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "cluster %s", cluster.Name))
+		}
+		if expiration != nil && t.isExpiring(*expiration) {
+			expiring[cluster.Name] = *expiration
+		}
+	}
+
+	t.lock.Lock()
+	t.expiring = expiring
+	t.lock.Unlock()
+	return utilerrors.NewAggregate(errs)
+}
+
+// ExpiringClusters returns the names of the clusters whose credentials have
+// expired or expire within the warning window, as of the last check.
+func (t *CredentialTracker) ExpiringClusters(ctx context.Context) []string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	names := make([]string, 0, len(t.expiring))
+	for name := range t.expiring {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// check checks the credential of the cluster and returns its expiry, which
+// is nil if the credential does not expire or its expiry is unknown.
+func (t *CredentialTracker) check(ctx context.Context, cluster *clusterv1beta1.Cluster) (*time.Time, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	expiration, err := credentialExpiration(cluster.Spec.Access.Credential)
+	if err != nil {
+		return nil, err
+	}
+	if expiration == nil {
+		return nil, nil
+	}
+
+	if t.isExpiring(*expiration) && t.canRotate(cluster) {
+		rotated, err := t.rotate(ctx, cluster)
+		if err != nil {
+			log.Error(err, "Failed to rotate the service account token of cluster", "cluster", cluster.Name)
+		} else {
+			log.Info("Rotated the service account token of cluster", "cluster", cluster.Name)
+			cluster = rotated
+			if expiration, err = credentialExpiration(cluster.Spec.Access.Credential); err != nil || expiration == nil {
+				return nil, err
+			}
+		}
+	}
+
+	if t.isExpiring(*expiration) {
+		log.Info("The credential of cluster expires soon", "cluster", cluster.Name, "expiration", expiration.UTC())
+	}
+
+	// The status is normally kept up to date by the apiserver, it is only
+	// updated here for the clusters created before it was tracked.
+	if status := cluster.Status.CredentialExpiration; status == nil || !status.Time.Equal(*expiration) {
+		cluster.Status.CredentialExpiration = &metav1.Time{Time: *expiration}
+		if _, err := t.client.ClusterV1beta1().Clusters().UpdateStatus(ctx, cluster, metav1.UpdateOptions{}); err != nil {
+			return expiration, errors.Wrap(err, "failed to update the credential expiration")
+		}
+	}
+	return expiration, nil
+}
+
+// rotate requests a new token of the service account of the cluster and
+// stores it as the credential of the cluster.
+func (t *CredentialTracker) rotate(ctx context.Context, cluster *clusterv1beta1.Cluster) (*clusterv1beta1.Cluster, error) {
+	namespace, name, err := credentialutil.ServiceAccountFromToken(cluster.Spec.Access.Credential.ServiceAccountToken)
+	if err != nil {
+		return nil, err
+	}
+	token, err := t.requester(ctx, cluster.Name, namespace, name, t.options.RotatedTokenTTL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request a service account token")
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Spec.Access.Credential.ServiceAccountToken = token
+	updated, err := t.client.ClusterV1beta1().Clusters().Update(ctx, cluster, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update the cluster credential")
+	}
+
+	updated.Status.CredentialRotationTime = &metav1.Time{Time: t.now()}
+	return t.client.ClusterV1beta1().Clusters().UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+}
+
+// isExpiring returns true if the expiration is within the warning window.
+func (t *CredentialTracker) isExpiring(expiration time.Time) bool {
+	return !t.now().Add(t.options.WarningWindow).Before(expiration)
+}
+
+// canRotate returns true if the credential of the cluster is a service
+// account token and the rotation is enabled.
+func (t *CredentialTracker) canRotate(cluster *clusterv1beta1.Cluster) bool {
+	return t.options.RotateServiceAccountTokens && t.requester != nil &&
+		cluster.Spec.Access.Credential.Type == clusterv1beta1.CredentialTypeServiceAccountToken
+}
+
+// credentialExpiration returns the expiry of the credential, or nil if the
+// credential does not expire or its expiry is unknown, such as for exec
+// credentials.
+func credentialExpiration(credential *clusterv1beta1.ClusterAccessCredential) (*time.Time, error) {
+	if credential == nil {
+		return nil, nil
+	}
+	switch credential.Type {
+	case clusterv1beta1.CredentialTypeServiceAccountToken:
+		expiration, err := credentialutil.TokenExpiration(credential.ServiceAccountToken)
+		if errors.Is(err, credentialutil.ErrMalformedJWT) {
+			// Opaque tokens carry no expiry.
+			return nil, nil
+		}
+		return expiration, err
+	case clusterv1beta1.CredentialTypeX509Certificate:
+		if credential.X509 == nil {
+			return nil, nil
+		}
+		return credentialutil.CertificateExpiration(credential.X509.Certificate)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newServiceAccountToken returns an unsigned service account token expiring
+// at the given time.
+func newServiceAccountToken(t *testing.T, expiration time.Time) string {
+	payload, err := json.Marshal(map[string]interface{}{
+		"sub": "system:serviceaccount:karpor:reader",
+		"exp": expiration.Unix(),
+	})
+	require.NoError(t, err)
+	return "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func newTokenCluster(name, token string) *clusterv1beta1.Cluster {
+	return &clusterv1beta1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clusterv1beta1.ClusterSpec{
+			Access: clusterv1beta1.ClusterAccess{
+				Credential: &clusterv1beta1.ClusterAccessCredential{
+					Type:                clusterv1beta1.CredentialTypeServiceAccountToken,
+					ServiceAccountToken: token,
+				},
+			},
+		},
+	}
+}
+
+func TestCredentialTracker(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiring := now.Add(24 * time.Hour)
+	valid := now.Add(90 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		rotate           bool
+		requestErr       error
+		expectExpiring   []string
+		expectExpiration time.Time
+		expectRotated    bool
+	}{
+		{
+			name:             "warn about expiring token",
+			expectExpiring:   []string{"expiring"},
+			expectExpiration: expiring,
+		},
+		{
+			name:             "rotate expiring token",
+			rotate:           true,
+			expectExpiring:   []string{},
+			expectExpiration: valid,
+			expectRotated:    true,
+		},
+		{
+			name:             "warn when rotation fails",
+			rotate:           true,
+			requestErr:       errors.New("forbidden"),
+			expectExpiring:   []string{"expiring"},
+			expectExpiration: expiring,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(
+				newTokenCluster("expiring", newServiceAccountToken(t, expiring)),
+				newTokenCluster("valid", newServiceAccountToken(t, valid)),
+				newTokenCluster("opaque", "opaque-token"),
+			)
+			var requested []string
+			requester := func(ctx context.Context, cluster, namespace, name string, ttl time.Duration) (string, error) {
+				requested = append(requested, cluster+"/"+namespace+"/"+name)
+				return newServiceAccountToken(t, now.Add(ttl)), tt.requestErr
+			}
+			tracker := NewCredentialTracker(client, requester, CredentialTrackerOptions{
+				WarningWindow:              7 * 24 * time.Hour,
+				RotateServiceAccountTokens: tt.rotate,
+				RotatedTokenTTL:            90 * 24 * time.Hour,
+			})
+			tracker.now = func() time.Time { return now }
+
+			require.NoError(t, tracker.CheckAll(context.Background()))
+			require.Equal(t, tt.expectExpiring, tracker.ExpiringClusters(context.Background()))
+			if tt.rotate {
+				require.Equal(t, []string{"expiring/karpor/reader"}, requested)
+			}
+
+			cluster, err := client.ClusterV1beta1().Clusters().Get(context.Background(), "expiring", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, cluster.Status.CredentialExpiration)
+			require.True(t, tt.expectExpiration.Equal(cluster.Status.CredentialExpiration.Time))
+			require.Equal(t, tt.expectRotated, cluster.Status.CredentialRotationTime != nil)
+
+			cluster, err = client.ClusterV1beta1().Clusters().Get(context.Background(), "opaque", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Nil(t, cluster.Status.CredentialExpiration)
+		})
+	}
+}
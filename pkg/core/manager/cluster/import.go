@@ -0,0 +1,409 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/util/clusterinstall"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	errors2 "github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ContextLabelKey is the label of the imported clusters holding the name of
+// the kubeconfig context they were imported from.
+const ContextLabelKey = "cluster.karpor.io/context"
+
+const (
+	// defaultImportConcurrency is the default number of clusters validated
+	// and imported in parallel.
+	defaultImportConcurrency = 8
+	// maxImportConcurrency is the maximum number of clusters validated and
+	// imported in parallel.
+	maxImportConcurrency = 32
+	// importValidationTimeout is the timeout of validating a single cluster.
+	importValidationTimeout = 10 * time.Second
+)
+
+// ImportAction is the action taken for a cluster during the import.
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionUpdate    ImportAction = "update"
+	ImportActionUnchanged ImportAction = "unchanged"
+	ImportActionFailed    ImportAction = "failed"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// KubeConfigSource is a kubeconfig to import the clusters from.
+type KubeConfigSource struct {
+	// Name identifies the kubeconfig in the results, such as its file name.
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// ImportOptions configures the import of clusters from kubeconfigs.
+type ImportOptions struct {
+	// Contexts limits the import to the given contexts, all contexts are
+	// imported if it is empty.
+	Contexts []string `json:"contexts,omitempty"`
+	// LabelPattern is a regular expression matched against the context
+	// names, its named groups become labels of the imported clusters.
+	LabelPattern string `json:"labelPattern,omitempty"`
+	// DryRun previews the clusters without creating or updating them.
+	DryRun bool `json:"dryRun,omitempty"`
+	// SkipValidation skips checking that the clusters are reachable.
+	SkipValidation bool `json:"skipValidation,omitempty"`
+	// Concurrency is the number of clusters imported in parallel, it is at
+	// most 32.
+	Concurrency int `json:"concurrency,omitempty"`
+	// AllowLocalCredentials allows the kubeconfigs to read the credentials
+	// from local files and to run credential plugins, which is only allowed
+	// for the kubeconfigs imported by the local command line.
+	AllowLocalCredentials bool `json:"-"`
+}
+
+// ImportResult is the outcome of importing the cluster of a context.
+type ImportResult struct {
+	Source        string                     `json:"source,omitempty"`
+	Context       string                     `json:"context"`
+	ClusterName   string                     `json:"clusterName"`
+	Action        ImportAction               `json:"action"`
+	ServerVersion string                     `json:"serverVersion,omitempty"`
+	Cluster       *unstructured.Unstructured `json:"cluster,omitempty"`
+	Error         string                     `json:"error,omitempty"`
+}
+
+// ImportSummary counts the clusters of the import by action.
+type ImportSummary struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// SummarizeImport counts the results of the import by action.
+func SummarizeImport(results []*ImportResult) ImportSummary {
+	summary := ImportSummary{Total: len(results)}
+	for _, result := range results {
+		switch result.Action {
+		case ImportActionCreate:
+			summary.Created++
+		case ImportActionUpdate:
+			summary.Updated++
+		case ImportActionUnchanged:
+			summary.Unchanged++
+		case ImportActionFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// importCandidate is a cluster converted from a kubeconfig context.
+type importCandidate struct {
+	result  *ImportResult
+	config  *rest.Config
+	cluster *clusterv1beta1.Cluster
+}
+
+// ImportClusters imports a cluster for each context of the kubeconfigs. The
+// clusters are validated in parallel and created or updated idempotently,
+// the clusters whose access is unchanged are left untouched. With DryRun,
+// the results preview the clusters without changing them.
+func (c *ClusterManager) ImportClusters(
+	ctx context.Context,
+	client *multicluster.MultiClusterClient,
+	sources []KubeConfigSource,
+	opts ImportOptions,
+) ([]*ImportResult, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	var labelPattern *regexp.Regexp
+	if opts.LabelPattern != "" {
+		var err error
+		if labelPattern, err = regexp.Compile(opts.LabelPattern); err != nil {
+			return nil, errors2.Wrap(err, "invalid label pattern")
+		}
+	}
+	candidates, err := importCandidates(sources, opts.Contexts, labelPattern, opts.AllowLocalCredentials)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Importing clusters from kubeconfigs", "clusters", len(candidates), "dryRun", opts.DryRun)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultImportConcurrency
+	}
+	if concurrency > maxImportConcurrency {
+		concurrency = maxImportConcurrency
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for _, candidate := range candidates {
+		if candidate.result.Action == ImportActionFailed {
+			continue
+		}
+		candidate := candidate
+		g.Go(func() error {
+			if err := c.importCluster(gctx, client, candidate, opts); err != nil {
+				candidate.result.Action = ImportActionFailed
+				candidate.result.Error = err.Error()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	results := make([]*ImportResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		results = append(results, candidate.result)
+	}
+	return results, nil
+}
+
+// importCluster validates the candidate and creates or updates its cluster.
+func (c *ClusterManager) importCluster(
+	ctx context.Context,
+	client *multicluster.MultiClusterClient,
+	candidate *importCandidate,
+	opts ImportOptions,
+) error {
+	if !opts.SkipValidation {
+		version, err := validateRESTConfig(ctx, candidate.config)
+		if err != nil {
+			return err
+		}
+		candidate.result.ServerVersion = version
+	}
+
+	clusterGVR := clusterv1beta1.SchemeGroupVersion.WithResource("clusters")
+	resource := client.DynamicClient.Resource(clusterGVR)
+	desired := candidate.cluster
+
+	var current *clusterv1beta1.Cluster
+	currentObj, err := resource.Get(ctx, desired.Name, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		candidate.result.Action = ImportActionCreate
+	case err != nil:
+		return err
+	default:
+		current = &clusterv1beta1.Cluster{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(currentObj.Object, current); err != nil {
+			return err
+		}
+		// Keep the metadata edited by users, only the access and the
+		// derived labels are owned by the import.
+		updated := current.DeepCopy()
+		updated.Spec.Access = desired.Spec.Access
+		if updated.Labels == nil {
+			updated.Labels = map[string]string{}
+		}
+		for k, v := range desired.Labels {
+			updated.Labels[k] = v
+		}
+		candidate.result.Action = ImportActionUnchanged
+		if !reflect.DeepEqual(current.Spec.Access, updated.Spec.Access) || !reflect.DeepEqual(current.Labels, updated.Labels) {
+			candidate.result.Action = ImportActionUpdate
+		}
+		desired = updated
+	}
+
+	unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: unstructuredMap}
+	if !opts.DryRun {
+		switch candidate.result.Action {
+		case ImportActionCreate:
+			obj, err = resource.Create(ctx, obj, metav1.CreateOptions{})
+		case ImportActionUpdate:
+			obj, err = resource.Update(ctx, obj, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	candidate.result.Cluster, err = SanitizeUnstructuredCluster(ctx, obj)
+	return err
+}
+
+// importCandidates converts the contexts of the kubeconfigs to clusters, the
+// contexts which cannot be converted are reported as failed. Unless local
+// credentials are allowed, the contexts must carry their credentials inline.
+func importCandidates(sources []KubeConfigSource, contexts []string, labelPattern *regexp.Regexp, allowLocalCredentials bool) ([]*importCandidate, error) {
+	selected := map[string]bool{}
+	for _, name := range contexts {
+		selected[name] = true
+	}
+
+	var candidates []*importCandidate
+	names := map[string]string{}
+	for _, source := range sources {
+		config, err := clientcmd.Load([]byte(source.Content))
+		if err != nil {
+			return nil, errors2.Wrapf(err, "failed to parse kubeconfig %s", source.Name)
+		}
+
+		contextNames := make([]string, 0, len(config.Contexts))
+		for name := range config.Contexts {
+			if len(selected) == 0 || selected[name] {
+				contextNames = append(contextNames, name)
+			}
+		}
+		sort.Strings(contextNames)
+
+		for _, contextName := range contextNames {
+			candidate := &importCandidate{result: &ImportResult{
+				Source:      source.Name,
+				Context:     contextName,
+				ClusterName: ClusterNameFromContext(contextName),
+			}}
+			candidates = append(candidates, candidate)
+
+			if previous, ok := names[candidate.result.ClusterName]; ok {
+				candidate.result.Action = ImportActionFailed
+				candidate.result.Error = fmt.Sprintf("cluster name %s is already imported from context %s", candidate.result.ClusterName, previous)
+				continue
+			}
+			names[candidate.result.ClusterName] = contextName
+
+			if !allowLocalCredentials {
+				if err := checkInlineCredentials(config, contextName); err != nil {
+					candidate.result.Action = ImportActionFailed
+					candidate.result.Error = err.Error()
+					continue
+				}
+			}
+			if err := candidate.convert(config, labelPattern); err != nil {
+				candidate.result.Action = ImportActionFailed
+				candidate.result.Error = err.Error()
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrMissingContexts
+	}
+	return candidates, nil
+}
+
+// convert builds the rest config and the cluster of the context.
+func (i *importCandidate) convert(config *clientcmdapi.Config, labelPattern *regexp.Regexp) error {
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, i.result.Context, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return errors2.Wrap(err, "failed to build client config")
+	}
+	cluster, err := clusterinstall.ConvertKubeconfigToCluster(i.result.ClusterName, i.result.Context, "", restConfig)
+	if err != nil {
+		return err
+	}
+	cluster.Labels = ContextLabels(i.result.Context, labelPattern)
+	i.config = restConfig
+	i.cluster = cluster
+	return nil
+}
+
+// checkInlineCredentials checks that the cluster and the user of the context
+// only carry inline certificates and tokens, so that importing the context
+// neither reads local files nor runs credential plugins.
+func checkInlineCredentials(config *clientcmdapi.Config, contextName string) error {
+	context := config.Contexts[contextName]
+	if context == nil {
+		return nil
+	}
+	if cluster := config.Clusters[context.Cluster]; cluster != nil && cluster.CertificateAuthority != "" {
+		return ErrLocalCredentials
+	}
+	if authInfo := config.AuthInfos[context.AuthInfo]; authInfo != nil {
+		if authInfo.Exec != nil || authInfo.AuthProvider != nil || authInfo.TokenFile != "" ||
+			authInfo.ClientCertificate != "" || authInfo.ClientKey != "" {
+			return ErrLocalCredentials
+		}
+	}
+	return nil
+}
+
+// validateRESTConfig checks that the cluster is reachable with the config
+// and returns the version of its API server.
+func validateRESTConfig(ctx context.Context, config *rest.Config) (string, error) {
+	config = rest.CopyConfig(config)
+	config.Timeout = importValidationTimeout
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", ErrCreateClientSet
+	}
+	info, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", errors2.Wrap(ErrGetServerVersion, err.Error())
+	}
+	return info.String(), nil
+}
+
+// ClusterNameFromContext derives a valid cluster name from the name of a
+// kubeconfig context, such as "prod-us-east-1" for "prod/us-east-1".
+func ClusterNameFromContext(contextName string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(contextName), "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(name, "-")
+}
+
+// ContextLabels derives the labels of the cluster imported from the context.
+// The context name is kept in ContextLabelKey, and the named groups of the
+// label pattern matching the context name become labels as well.
+func ContextLabels(contextName string, labelPattern *regexp.Regexp) map[string]string {
+	labels := map[string]string{ContextLabelKey: ClusterNameFromContext(contextName)}
+	if labelPattern == nil {
+		return labels
+	}
+	match := labelPattern.FindStringSubmatch(contextName)
+	if match == nil {
+		return labels
+	}
+	for i, key := range labelPattern.SubexpNames() {
+		if key == "" || match[i] == "" {
+			continue
+		}
+		if value := ClusterNameFromContext(match[i]); len(validation.IsQualifiedName(key)) == 0 && len(validation.IsValidLabelValue(value)) == 0 {
+			labels[key] = value
+		}
+	}
+	return labels
+}
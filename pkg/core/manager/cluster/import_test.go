@@ -0,0 +1,221 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const multiContextKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: east
+  cluster:
+    server: https://east.example.com
+- name: west
+  cluster:
+    server: https://west.example.com
+users:
+- name: admin
+  user:
+    token: secret-token
+contexts:
+- name: prod/us-east-1
+  context:
+    cluster: east
+    user: admin
+- name: prod/us-west-2
+  context:
+    cluster: west
+    user: admin
+- name: PROD_us-west-2
+  context:
+    cluster: west
+    user: admin
+current-context: prod/us-east-1
+`
+
+func TestClusterNameFromContext(t *testing.T) {
+	require.Equal(t, "prod-us-east-1", ClusterNameFromContext("prod/us-east-1"))
+	require.Equal(t, "arn-aws-eks-us-east-1-123-cluster-prod", ClusterNameFromContext("arn:aws:eks:us-east-1:123:cluster/prod"))
+	require.Equal(t, "kind-dev", ClusterNameFromContext("_Kind_Dev_"))
+}
+
+func TestContextLabels(t *testing.T) {
+	pattern := regexp.MustCompile(`^(?P<env>[a-z]+)/(?P<region>[a-z0-9-]+)$`)
+	require.Equal(t, map[string]string{
+		ContextLabelKey: "prod-us-east-1",
+		"env":           "prod",
+		"region":        "us-east-1",
+	}, ContextLabels("prod/us-east-1", pattern))
+	require.Equal(t, map[string]string{ContextLabelKey: "minikube"}, ContextLabels("minikube", pattern))
+	require.Equal(t, map[string]string{ContextLabelKey: "minikube"}, ContextLabels("minikube", nil))
+}
+
+func TestImportCandidates(t *testing.T) {
+	sources := []KubeConfigSource{{Name: "config", Content: multiContextKubeConfig}}
+
+	candidates, err := importCandidates(sources, nil, nil, false)
+	require.NoError(t, err)
+	require.Len(t, candidates, 3)
+	// The contexts are sorted, the later context with the same derived
+	// cluster name fails.
+	require.Equal(t, "PROD_us-west-2", candidates[0].result.Context)
+	require.Empty(t, candidates[0].result.Error)
+	require.Equal(t, "prod-us-east-1", candidates[1].cluster.Name)
+	require.Equal(t, "https://east.example.com", candidates[1].cluster.Spec.Access.Endpoint)
+	require.Equal(t, ImportActionFailed, candidates[2].result.Action)
+	require.Contains(t, candidates[2].result.Error, "already imported")
+
+	candidates, err = importCandidates(sources, []string{"prod/us-west-2"}, nil, false)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.Equal(t, "prod-us-west-2", candidates[0].cluster.Name)
+
+	_, err = importCandidates(sources, []string{"missing"}, nil, false)
+	require.ErrorIs(t, err, ErrMissingContexts)
+
+	_, err = importCandidates([]KubeConfigSource{{Name: "invalid", Content: "{"}}, nil, nil, false)
+	require.Error(t, err)
+}
+
+const localCredentialsKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: inline
+  cluster:
+    server: https://inline.example.com
+- name: ca-file
+  cluster:
+    server: https://ca-file.example.com
+    certificate-authority: /etc/kubernetes/pki/ca.crt
+users:
+- name: token
+  user:
+    token: secret-token
+- name: exec
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: /bin/sh
+- name: token-file
+  user:
+    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token
+- name: cert-file
+  user:
+    client-certificate: /etc/kubernetes/pki/admin.crt
+    client-key: /etc/kubernetes/pki/admin.key
+contexts:
+- name: ca-file
+  context:
+    cluster: ca-file
+    user: token
+- name: cert-file
+  context:
+    cluster: inline
+    user: cert-file
+- name: exec
+  context:
+    cluster: inline
+    user: exec
+- name: inline
+  context:
+    cluster: inline
+    user: token
+- name: token-file
+  context:
+    cluster: inline
+    user: token-file
+`
+
+func TestImportCandidatesLocalCredentials(t *testing.T) {
+	sources := []KubeConfigSource{{Name: "config", Content: localCredentialsKubeConfig}}
+
+	// Only the context with inline credentials is converted.
+	candidates, err := importCandidates(sources, nil, nil, false)
+	require.NoError(t, err)
+	require.Len(t, candidates, 5)
+	for _, candidate := range candidates {
+		if candidate.result.Context == "inline" {
+			require.Empty(t, candidate.result.Error)
+			require.NotNil(t, candidate.cluster)
+			continue
+		}
+		require.Equal(t, ImportActionFailed, candidate.result.Action, candidate.result.Context)
+		require.Equal(t, ErrLocalCredentials.Error(), candidate.result.Error)
+		require.Nil(t, candidate.config)
+	}
+
+	// The local credentials are allowed for the command line.
+	candidates, err = importCandidates(sources, []string{"token-file"}, nil, true)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.NotEqual(t, ErrLocalCredentials.Error(), candidates[0].result.Error)
+}
+
+func TestImportClusters(t *testing.T) {
+	existing := &clusterv1beta1.Cluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1beta1.SchemeGroupVersion.String(), Kind: "Cluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-us-east-1", Labels: map[string]string{"team": "infra"}},
+	}
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, existing)
+	mockey.Mock((*dynamic.DynamicClient).Resource).To(fakeClient.Resource).Build()
+	defer mockey.UnPatchAll()
+
+	client := &multicluster.MultiClusterClient{DynamicClient: &dynamic.DynamicClient{}}
+	sources := []KubeConfigSource{{Name: "config", Content: multiContextKubeConfig}}
+	opts := ImportOptions{
+		Contexts:       []string{"prod/us-east-1", "prod/us-west-2"},
+		LabelPattern:   `^(?P<env>[a-z]+)/`,
+		SkipValidation: true,
+	}
+	manager := NewClusterManager()
+
+	opts.DryRun = true
+	results, err := manager.ImportClusters(context.Background(), client, sources, opts)
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Total: 2, Created: 1, Updated: 1}, SummarizeImport(results))
+	_, err = fakeClient.Resource(clusterv1beta1.SchemeGroupVersion.WithResource("clusters")).
+		Get(context.Background(), "prod-us-west-2", metav1.GetOptions{})
+	require.Error(t, err)
+
+	opts.DryRun = false
+	results, err = manager.ImportClusters(context.Background(), client, sources, opts)
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Total: 2, Created: 1, Updated: 1}, SummarizeImport(results))
+	updated, err := fakeClient.Resource(clusterv1beta1.SchemeGroupVersion.WithResource("clusters")).
+		Get(context.Background(), "prod-us-east-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"team":          "infra",
+		"env":           "prod",
+		ContextLabelKey: "prod-us-east-1",
+	}, updated.GetLabels())
+
+	results, err = manager.ImportClusters(context.Background(), client, sources, opts)
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Total: 2, Unchanged: 2}, SummarizeImport(results))
+}
@@ -63,12 +63,13 @@ func (c *ClusterManager) GetCluster(
 	return SanitizeUnstructuredCluster(ctx, obj)
 }
 
-// CreateCluster creates a new Cluster resource in the hub cluster and returns the created
-// unstructured Cluster object
+// CreateCluster creates a new Cluster resource with the given labels in the hub cluster and
+// returns the created unstructured Cluster object
 func (c *ClusterManager) CreateCluster(
 	ctx context.Context,
 	client *multicluster.MultiClusterClient,
 	name, displayName, description, kubeconfig string,
+	labels map[string]string,
 ) (*unstructured.Unstructured, error) {
 	clusterGVR := clusterv1beta1.SchemeGroupVersion.WithResource("clusters")
 	// Make sure the cluster does not exist first
@@ -96,6 +97,7 @@ func (c *ClusterManager) CreateCluster(
 	if err != nil {
 		return nil, err
 	}
+	clusterObj.Labels = labels
 	unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(clusterObj)
 	if err != nil {
 		return nil, err
@@ -105,11 +107,13 @@ func (c *ClusterManager) CreateCluster(
 		Create(ctx, unstructuredCluster, metav1.CreateOptions{})
 }
 
-// UpdateMetadata updates cluster by name with a full payload
+// UpdateMetadata updates cluster by name with a full payload. The labels of the cluster are
+// replaced with the given labels unless they are nil.
 func (c *ClusterManager) UpdateMetadata(
 	ctx context.Context,
 	client *multicluster.MultiClusterClient,
 	name, displayName, description string,
+	labels map[string]string,
 ) (*unstructured.Unstructured, error) {
 	clusterGVR := clusterv1beta1.SchemeGroupVersion.WithResource("clusters")
 	// Make sure the cluster exists first
@@ -125,6 +129,9 @@ func (c *ClusterManager) UpdateMetadata(
 	// method.
 	currentObj.Object["spec"].(map[string]interface{})["displayName"] = displayName
 	currentObj.Object["spec"].(map[string]interface{})["description"] = description
+	if labels != nil {
+		currentObj.SetLabels(labels)
+	}
 	return client.DynamicClient.Resource(clusterGVR).Update(ctx, currentObj, metav1.UpdateOptions{})
 }
 
@@ -165,6 +172,8 @@ func (c *ClusterManager) UpdateCredential(
 	}
 	unstructuredMap["metadata"].(map[string]interface{})["resourceVersion"] = currentObj.Object["metadata"].(map[string]interface{})["resourceVersion"]
 	unstructuredCluster := &unstructured.Unstructured{Object: unstructuredMap}
+	// Keep the labels of the cluster, only the credential is updated.
+	unstructuredCluster.SetLabels(currentObj.GetLabels())
 	return client.DynamicClient.Resource(clusterGVR).
 		Update(ctx, unstructuredCluster, metav1.UpdateOptions{})
 }
@@ -179,15 +188,17 @@ func (c *ClusterManager) DeleteCluster(
 	return client.DynamicClient.Resource(clusterGVR).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
-// ListCluster returns the full list of clusters in a specific order
+// ListCluster returns the list of clusters matching the label selector in a specific order,
+// all clusters are listed if the label selector is empty
 func (c *ClusterManager) ListCluster(
 	ctx context.Context,
 	client *multicluster.MultiClusterClient,
+	labelSelector string,
 	orderBy SortCriteria,
 	descending bool,
 ) (*unstructured.UnstructuredList, error) {
 	clusterGVR := clusterv1beta1.SchemeGroupVersion.WithResource("clusters")
-	clusterList, err := client.DynamicClient.Resource(clusterGVR).List(ctx, metav1.ListOptions{})
+	clusterList, err := client.DynamicClient.Resource(clusterGVR).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +210,10 @@ func (c *ClusterManager) ListCluster(
 	return SortUnstructuredList(sanitizedClusterList, orderBy, descending)
 }
 
-// ListClusterName retrieves a list of cluster names based on the specified sorting criteria and order.
-func (c *ClusterManager) ListClusterName(ctx context.Context, client *multicluster.MultiClusterClient, orderBy SortCriteria, descending bool) ([]string, error) {
-	unList, err := c.ListCluster(ctx, client, orderBy, descending)
+// ListClusterName retrieves a list of cluster names matching the label selector based on the
+// specified sorting criteria and order.
+func (c *ClusterManager) ListClusterName(ctx context.Context, client *multicluster.MultiClusterClient, labelSelector string, orderBy SortCriteria, descending bool) ([]string, error) {
+	unList, err := c.ListCluster(ctx, client, labelSelector, orderBy, descending)
 	if err != nil {
 		return nil, err
 	}
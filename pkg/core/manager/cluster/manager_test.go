@@ -22,12 +22,14 @@ import (
 
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
 	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	k8syaml "sigs.k8s.io/yaml"
 )
 
@@ -133,6 +135,7 @@ func TestCreateCluster(t *testing.T) {
 		displayName          string
 		description          string
 		kubeConfig           string
+		labels               map[string]string
 		expectError          bool
 		expectedErrorMessage string
 	}{
@@ -142,6 +145,7 @@ func TestCreateCluster(t *testing.T) {
 			displayName: "New Cluster",
 			description: "This is a new cluster.",
 			kubeConfig:  newMockKubeConfig(),
+			labels:      map[string]string{"env": "prod"},
 			expectError: false,
 		},
 		{
@@ -173,6 +177,7 @@ func TestCreateCluster(t *testing.T) {
 				tc.displayName,
 				tc.description,
 				tc.kubeConfig,
+				tc.labels,
 			)
 
 			if tc.expectError {
@@ -187,6 +192,7 @@ func TestCreateCluster(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, cluster, "Expected a non-nil cluster object.")
 				require.Equal(t, tc.clusterName, cluster.GetName(), "Cluster name mismatch.")
+				require.Equal(t, tc.labels, cluster.GetLabels(), "Labels mismatch.")
 			}
 		})
 	}
@@ -204,6 +210,7 @@ func TestUpdateMetadata(t *testing.T) {
 		clusterName   string
 		displayName   string
 		description   string
+		labels        map[string]string
 		expectError   bool
 		expectedError string
 	}{
@@ -214,6 +221,13 @@ func TestUpdateMetadata(t *testing.T) {
 			description: "This cluster has been updated.",
 			expectError: false,
 		},
+		{
+			name:        "Update labels successfully",
+			clusterName: "existing-cluster",
+			displayName: "Updated Cluster",
+			labels:      map[string]string{"env": "prod", "region": "eu"},
+			expectError: false,
+		},
 		{
 			name:          "Attempt to update non-existing cluster",
 			clusterName:   "non-existing-cluster",
@@ -239,6 +253,7 @@ func TestUpdateMetadata(t *testing.T) {
 				tc.clusterName,
 				tc.displayName,
 				tc.description,
+				tc.labels,
 			)
 			if tc.expectError {
 				require.Error(t, err)
@@ -257,6 +272,9 @@ func TestUpdateMetadata(t *testing.T) {
 					require.Equal(t, tc.displayName, updatedCluster.Object["spec"].(map[string]interface{})["displayName"].(string), "Display name mismatch.")
 				}
 				require.Equal(t, tc.description, updatedCluster.Object["spec"].(map[string]interface{})["description"], "Description mismatch.")
+				if tc.labels != nil {
+					require.Equal(t, tc.labels, updatedCluster.GetLabels(), "Labels mismatch.")
+				}
 			}
 		})
 	}
@@ -394,6 +412,7 @@ func TestListCluster(t *testing.T) {
 			result, err := manager.ListCluster(
 				context.TODO(),
 				&multicluster.MultiClusterClient{},
+				"",
 				tc.orderBy,
 				tc.descending,
 			)
@@ -439,6 +458,7 @@ func TestListClusterName(t *testing.T) {
 			names, err := manager.ListClusterName(
 				context.TODO(),
 				&multicluster.MultiClusterClient{},
+				"",
 				tc.orderBy,
 				tc.descending,
 			)
@@ -452,6 +472,42 @@ func TestListClusterName(t *testing.T) {
 	}
 }
 
+// TestListClusterNameWithLabelSelector tests that ListClusterName only
+// returns the clusters matching the label selector.
+func TestListClusterNameWithLabelSelector(t *testing.T) {
+	newCluster := func(name string, labels map[string]string) *clusterv1beta1.Cluster {
+		return &clusterv1beta1.Cluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1beta1.SchemeGroupVersion.String(), Kind: "Cluster"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: clusterv1beta1.ClusterSpec{
+				Access: clusterv1beta1.ClusterAccess{
+					Credential: &clusterv1beta1.ClusterAccessCredential{
+						Type:                clusterv1beta1.CredentialTypeServiceAccountToken,
+						ServiceAccountToken: "token",
+					},
+				},
+			},
+		}
+	}
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		newCluster("prod-eu", map[string]string{"env": "prod", "region": "eu"}),
+		newCluster("prod-us", map[string]string{"env": "prod", "region": "us"}),
+		newCluster("dev-eu", map[string]string{"env": "dev", "region": "eu"}),
+	)
+	mockey.Mock((*dynamic.DynamicClient).Resource).To(fakeClient.Resource).Build()
+	defer mockey.UnPatchAll()
+
+	manager := NewClusterManager()
+	client := &multicluster.MultiClusterClient{DynamicClient: &dynamic.DynamicClient{}}
+	names, err := manager.ListClusterName(context.TODO(), client, "env=prod,region in (eu)", ByName, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod-eu"}, names)
+
+	names, err = manager.ListClusterName(context.TODO(), client, "", ByName, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dev-eu", "prod-eu", "prod-us"}, names)
+}
+
 // TestGetYAMLForCluster tests the GetYAMLForCluster method.
 func TestGetYAMLForCluster(t *testing.T) {
 	manager := NewClusterManager()
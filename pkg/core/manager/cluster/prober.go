@@ -0,0 +1,217 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+// probeTimeout is the timeout of probing a single cluster.
+const probeTimeout = 10 * time.Second
+
+// Reasons of the conditions set by the health prober.
+const (
+	ReasonProbeSucceeded    = "ProbeSucceeded"
+	ReasonProbeFailed       = "ProbeFailed"
+	ReasonUnauthorized      = "Unauthorized"
+	ReasonCredentialExpired = "CredentialExpired"
+	ReasonNotProbed         = "NotProbed"
+)
+
+// ErrCredentialExpired is reported instead of probing clusters whose
+// credentials have expired.
+var ErrCredentialExpired = errors.New("the credential of the cluster has expired")
+
+// ProbeResult is the result of probing the API server of a cluster.
+type ProbeResult struct {
+	// Version is the version of the API server.
+	Version string
+	// Latency is the latency of getting the version of the API server.
+	Latency time.Duration
+	// ReachErr is the error reaching the API server, if any.
+	ReachErr error
+	// AuthErr is the error authenticating to the API server, if any.
+	AuthErr error
+}
+
+// Prober probes the API server of the cluster.
+type Prober func(ctx context.Context, cluster string) ProbeResult
+
+// NewProber returns a Prober which probes the clusters through the cluster
+// proxy of the hub. The credential is verified with a self subject access
+// review, since the version endpoint usually allows anonymous access.
+func NewProber(hubConfig *rest.Config) Prober {
+	return func(ctx context.Context, cluster string) ProbeResult {
+		client, err := multicluster.BuildMultiClusterClient(ctx, hubConfig, cluster)
+		if err != nil {
+			return ProbeResult{ReachErr: err}
+		}
+
+		start := time.Now()
+		serverVersion, err := client.ClientSet.Discovery().ServerVersion()
+		result := ProbeResult{Latency: time.Since(start)}
+		if err != nil {
+			if apierrors.IsUnauthorized(err) {
+				result.AuthErr = err
+			} else {
+				result.ReachErr = err
+			}
+			return result
+		}
+		result.Version = serverVersion.String()
+
+		_, err = client.ClientSet.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "list", Resource: "namespaces"},
+			},
+		}, metav1.CreateOptions{})
+		if apierrors.IsUnauthorized(err) {
+			result.AuthErr = err
+		}
+		return result
+	}
+}
+
+// HealthProber periodically probes the clusters and reports their health
+// as conditions in the status of the clusters.
+type HealthProber struct {
+	client versioned.Interface
+	probe  Prober
+	now    func() time.Time
+}
+
+// NewHealthProber creates a health prober updating the clusters through the
+// given client of the hub.
+func NewHealthProber(client versioned.Interface, probe Prober) *HealthProber {
+	return &HealthProber{
+		client: client,
+		probe:  probe,
+		now:    time.Now,
+	}
+}
+
+// Run probes all clusters every interval in the background. It blocks until
+// the context is done.
+func (p *HealthProber) Run(ctx context.Context, interval time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting cluster health prober", "interval", interval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.ProbeAll(ctx); err != nil {
+			log.Error(err, "Cluster health probe finished with errors")
+		}
+	}, interval)
+}
+
+// ProbeAll probes all clusters and updates their status.
+func (p *HealthProber) ProbeAll(ctx context.Context) error {
+	clusters, err := p.client.ClusterV1beta1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list clusters")
+	}
+
+	var errs []error
+	for i := range clusters.Items {
+		if err := p.ProbeCluster(ctx, &clusters.Items[i]); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cluster %s", clusters.Items[i].Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ProbeCluster probes the cluster and writes the result into its status.
+func (p *HealthProber) ProbeCluster(ctx context.Context, cluster *clusterv1beta1.Cluster) error {
+	var result ProbeResult
+	if expiration := cluster.Status.CredentialExpiration; expiration != nil && !p.now().Before(expiration.Time) {
+		// There is no point in probing with an expired credential.
+		result.AuthErr = errors.Wrapf(ErrCredentialExpired, "expired at %s", expiration.UTC().Format(time.RFC3339))
+	} else {
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		result = p.probe(probeCtx, cluster.Name)
+		cancel()
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := p.client.ClusterV1beta1().Clusters().Get(ctx, cluster.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		p.applyResult(current, result)
+		_, err = p.client.ClusterV1beta1().Clusters().UpdateStatus(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// applyResult writes the probe result into the status of the cluster.
+func (p *HealthProber) applyResult(cluster *clusterv1beta1.Cluster, result ProbeResult) {
+	now := metav1.NewTime(p.now())
+	status := &cluster.Status
+	status.LastProbeTime = &now
+
+	reachable := metav1.Condition{
+		Type:    clusterv1beta1.ClusterConditionReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonProbeSucceeded,
+		Message: "The API server of the cluster is reachable",
+	}
+	authenticated := metav1.Condition{
+		Type:    clusterv1beta1.ClusterConditionAuthenticated,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonProbeSucceeded,
+		Message: "The credential of the cluster is accepted",
+	}
+	switch {
+	case result.ReachErr != nil:
+		reachable.Status = metav1.ConditionFalse
+		reachable.Reason = ReasonProbeFailed
+		reachable.Message = result.ReachErr.Error()
+		authenticated.Status = metav1.ConditionUnknown
+		authenticated.Reason = ReasonNotProbed
+		authenticated.Message = "The API server of the cluster is not reachable"
+	case result.AuthErr != nil:
+		authenticated.Status = metav1.ConditionFalse
+		authenticated.Reason = ReasonUnauthorized
+		authenticated.Message = result.AuthErr.Error()
+		if errors.Is(result.AuthErr, ErrCredentialExpired) {
+			authenticated.Reason = ReasonCredentialExpired
+			reachable.Status = metav1.ConditionUnknown
+			reachable.Reason = ReasonNotProbed
+			reachable.Message = "The cluster is not probed with an expired credential"
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, reachable)
+	meta.SetStatusCondition(&status.Conditions, authenticated)
+
+	status.Healthy = reachable.Status == metav1.ConditionTrue && authenticated.Status == metav1.ConditionTrue
+	if status.Healthy {
+		status.Version = result.Version
+		status.LatencyMillis = result.Latency.Milliseconds()
+	}
+}
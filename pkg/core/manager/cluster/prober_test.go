@@ -0,0 +1,114 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHealthProber(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name                string
+		expiration          *metav1.Time
+		result              ProbeResult
+		expectProbed        bool
+		expectHealthy       bool
+		expectReachable     metav1.ConditionStatus
+		expectAuthenticated metav1.ConditionStatus
+		expectReason        string
+	}{
+		{
+			name:                "healthy cluster",
+			result:              ProbeResult{Version: "v1.28.0", Latency: 15 * time.Millisecond},
+			expectProbed:        true,
+			expectHealthy:       true,
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionTrue,
+			expectReason:        ReasonProbeSucceeded,
+		},
+		{
+			name:                "unreachable cluster",
+			result:              ProbeResult{ReachErr: errors.New("connection refused")},
+			expectProbed:        true,
+			expectReachable:     metav1.ConditionFalse,
+			expectAuthenticated: metav1.ConditionUnknown,
+			expectReason:        ReasonNotProbed,
+		},
+		{
+			name:                "rejected credential",
+			result:              ProbeResult{Version: "v1.28.0", AuthErr: errors.New("Unauthorized")},
+			expectProbed:        true,
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionFalse,
+			expectReason:        ReasonUnauthorized,
+		},
+		{
+			name:                "expired credential",
+			expiration:          &expired,
+			expectReachable:     metav1.ConditionUnknown,
+			expectAuthenticated: metav1.ConditionFalse,
+			expectReason:        ReasonCredentialExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&clusterv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+				Status:     clusterv1beta1.ClusterStatus{CredentialExpiration: tt.expiration},
+			})
+			probed := false
+			prober := NewHealthProber(client, func(ctx context.Context, cluster string) ProbeResult {
+				probed = true
+				require.Equal(t, "cluster1", cluster)
+				return tt.result
+			})
+			prober.now = func() time.Time { return now }
+
+			require.NoError(t, prober.ProbeAll(context.Background()))
+			require.Equal(t, tt.expectProbed, probed)
+
+			cluster, err := client.ClusterV1beta1().Clusters().Get(context.Background(), "cluster1", metav1.GetOptions{})
+			require.NoError(t, err)
+			status := cluster.Status
+			require.Equal(t, tt.expectHealthy, status.Healthy)
+			require.True(t, now.Equal(status.LastProbeTime.Time))
+			if tt.expectHealthy {
+				require.Equal(t, tt.result.Version, status.Version)
+				require.Equal(t, tt.result.Latency.Milliseconds(), status.LatencyMillis)
+			}
+
+			reachable := meta.FindStatusCondition(status.Conditions, clusterv1beta1.ClusterConditionReachable)
+			require.NotNil(t, reachable)
+			require.Equal(t, tt.expectReachable, reachable.Status)
+			authenticated := meta.FindStatusCondition(status.Conditions, clusterv1beta1.ClusterConditionAuthenticated)
+			require.NotNil(t, authenticated)
+			require.Equal(t, tt.expectAuthenticated, authenticated.Status)
+			require.Contains(t, []string{reachable.Reason, authenticated.Reason}, tt.expectReason)
+		})
+	}
+}
@@ -32,6 +32,10 @@ var (
 	)
 	ErrCreateClientSet  = errors.New("failed to create clientset")
 	ErrGetServerVersion = errors.New("failed to connect to the cluster")
+	ErrMissingContexts  = errors.New("no contexts to import found in the kubeconfigs")
+	ErrLocalCredentials = errors.New(
+		"credential files and plugins are not allowed, only inline certificates and tokens are",
+	)
 )
 
 type SortCriteria int
@@ -135,22 +135,26 @@ func SanitizeUnstructuredCluster(
 	// Inform that the unmarshaling process has started.
 	log.Info("Sanitizing unstructured cluster...")
 	sanitized := cluster
-	if token, ok := sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["credential"].(map[string]interface{})["serviceAccountToken"]; ok {
-		sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["credential"].(map[string]interface{})["serviceAccountToken"] = maskContent(
+	// The clusters in Agent access mode may have no credential.
+	spec, _ := sanitized.Object["spec"].(map[string]interface{})
+	access, _ := spec["access"].(map[string]interface{})
+	credential, _ := access["credential"].(map[string]interface{})
+	if token, ok := credential["serviceAccountToken"]; ok {
+		credential["serviceAccountToken"] = maskContent(
 			token.(string),
 		)
 	}
-	if x509, ok := sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["credential"].(map[string]interface{})["x509"]; ok &&
+	if x509, ok := credential["x509"]; ok &&
 		x509 != nil {
-		sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["credential"].(map[string]interface{})["x509"].(map[string]interface{})["certificate"] = []byte(
+		x509.(map[string]interface{})["certificate"] = []byte(
 			maskContent(x509.(map[string]interface{})["certificate"].(string)),
 		)
-		sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["credential"].(map[string]interface{})["x509"].(map[string]interface{})["privateKey"] = []byte(
+		x509.(map[string]interface{})["privateKey"] = []byte(
 			maskContent(x509.(map[string]interface{})["privateKey"].(string)),
 		)
 	}
-	if caBundle, ok := sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["caBundle"]; ok {
-		sanitized.Object["spec"].(map[string]interface{})["access"].(map[string]interface{})["caBundle"] = []byte(
+	if caBundle, ok := access["caBundle"]; ok {
+		access["caBundle"] = []byte(
 			maskContent(caBundle.(string)),
 		)
 	}
@@ -0,0 +1,199 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// auditPageSize is the number of resources scanned at once by the background
+// auditor.
+const auditPageSize = 100
+
+// RunAuditor audits all clusters every interval in the background and
+// persists the issues found into the audit storage. It blocks until the
+// context is done.
+func (i *InsightManager) RunAuditor(ctx context.Context, interval time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting background auditor", "interval", interval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := i.AuditAll(ctx); err != nil {
+			log.Error(err, "Background audit finished with errors")
+		}
+	}, interval)
+}
+
+// AuditAll audits the resources of each cluster and namespace, persists the
+// issues found and resolves the issues which are no longer found. The
+// cluster-scoped resources are audited as a namespace with an empty name.
+// The scores of the clusters, namespaces and resource groups are recorded
+// after each round to track their trends.
+func (i *InsightManager) AuditAll(ctx context.Context) error {
+	log := ctxutil.GetLogger(ctx)
+	startedAt := time.Now().Truncate(time.Second)
+
+	scopes, err := i.search.AggregateByTerms(ctx, []string{"cluster", "namespace"})
+	if err != nil {
+		return errors.Wrap(err, "failed to list namespaces to audit")
+	}
+
+	var errs []error
+	audited := make([]entity.ResourceGroup, 0, len(scopes.Buckets))
+	for _, bucket := range scopes.Buckets {
+		if len(bucket.Keys) != 2 {
+			continue
+		}
+		cluster, namespace := bucket.Keys[0], bucket.Keys[1]
+		if err := i.auditScope(ctx, cluster, namespace); err != nil {
+			log.Error(err, "Failed to audit namespace", "cluster", cluster, "namespace", namespace)
+			errs = append(errs, err)
+			continue
+		}
+		audited = append(audited, entity.ResourceGroup{Cluster: cluster, Namespace: namespace})
+	}
+
+	// Serve the audits from the storage after the first round, even if some
+	// namespaces failed, since their issues are kept from previous rounds.
+	i.auditCompleted.Store(true)
+	log.Info("Finished background audit", "namespaces", len(scopes.Buckets), "failed", len(errs))
+
+	if err := i.recordScores(ctx, i.scoreScopes(ctx, audited), startedAt); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// auditScope scans all resources in the given cluster and namespace and
+// persists the issues found.
+func (i *InsightManager) auditScope(ctx context.Context, cluster, namespace string) error {
+	// The audit storage stores timestamps in seconds, truncate it so that the
+	// issues found in this round are not resolved right away.
+	seenAt := time.Now().Truncate(time.Second)
+	terms := map[string]any{"cluster": cluster, "namespace": namespace}
+
+	err := i.search.ScanByTerms(ctx, terms, auditPageSize, func(resources []*storage.Resource) error {
+		result, err := i.scanner.Scan(ctx, true, resources...)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan resources")
+		}
+		if err = i.audit.SaveAuditIssues(ctx, toAuditIssues(result), seenAt); err != nil {
+			return errors.Wrap(err, "failed to save audit issues")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return i.audit.ResolveAuditIssues(ctx, cluster, namespace, seenAt)
+}
+
+// servedFromStore reports whether the audit of the resource group is served
+// from the audit storage. Issues are not stored with labels and annotations,
+// so resource groups selecting them are always scanned.
+func (i *InsightManager) servedFromStore(resourceGroup entity.ResourceGroup) bool {
+	return i.auditCompleted.Load() && len(resourceGroup.Labels) == 0 && len(resourceGroup.Annotations) == 0
+}
+
+// loadFromStore builds the scan result of the resource group from the open
+// issues in the audit storage.
+func (i *InsightManager) loadFromStore(ctx context.Context, resourceGroup entity.ResourceGroup) (scanner.ScanResult, error) {
+	auditIssues, err := i.audit.ListAuditIssues(ctx, resourceGroup, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[entity.ResourceGroupHash]*storage.Resource{}
+	issuesByResource := map[entity.ResourceGroupHash]scanner.IssueList{}
+	for _, ai := range auditIssues {
+		severity, err := scanner.ParseSeverity(ai.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit issue %s: %w", ai.ID, err)
+		}
+		hash := ai.Resource.Hash()
+		if _, ok := resources[hash]; !ok {
+			resources[hash] = &storage.Resource{ResourceGroup: ai.Resource, Object: labeledObject(ai.Labels)}
+		}
+		issuesByResource[hash] = append(issuesByResource[hash], &scanner.Issue{
+			Scanner:  ai.Scanner,
+			Severity: severity,
+			Title:    ai.Title,
+			Message:  ai.Message,
+		})
+	}
+
+	result := scanner.NewScanResult()
+	for hash, resource := range resources {
+		result.Add(resource, issuesByResource[hash])
+	}
+	return result, nil
+}
+
+// countResources returns the number of resources located by the resource
+// group in the search storage.
+func (i *InsightManager) countResources(ctx context.Context, resourceGroup entity.ResourceGroup) (int, error) {
+	res, err := i.search.Search(ctx, resourceGroup.ToSQL(), storage.SQLPatternType, &storage.Pagination{Page: 1, PageSize: 1})
+	if err != nil {
+		return 0, err
+	}
+	return res.Total, nil
+}
+
+// toAuditIssues converts the scan result to the audit issues to be persisted.
+func toAuditIssues(result scanner.ScanResult) []*entity.AuditIssue {
+	auditIssues := []*entity.AuditIssue{}
+	for issue, resources := range result.ByIssue() {
+		for _, resource := range resources {
+			rg := entity.ResourceGroup{
+				Cluster:    resource.Cluster,
+				APIVersion: resource.APIVersion,
+				Kind:       resource.Kind,
+				Namespace:  resource.Namespace,
+				Name:       resource.Name,
+			}
+			auditIssues = append(auditIssues, &entity.AuditIssue{
+				ID:       entity.AuditIssueID(rg, issue.Scanner, issue.Title, issue.Message),
+				Resource: rg,
+				Labels:   (&unstructured.Unstructured{Object: resource.Object}).GetLabels(),
+				Scanner:  issue.Scanner,
+				Severity: issue.Severity.String(),
+				Title:    issue.Title,
+				Message:  issue.Message,
+			})
+		}
+	}
+	return auditIssues
+}
+
+// labeledObject returns an object carrying only the given labels, which is
+// all the audit storage keeps of a resource besides its location.
+func labeledObject(labels map[string]string) map[string]interface{} {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetLabels(labels)
+	return obj.Object
+}
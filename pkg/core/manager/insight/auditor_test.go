@@ -0,0 +1,69 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/stretchr/testify/require"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+func TestInsightManager_AuditAll(t *testing.T) {
+	auditStorage := &mockAuditStorage{}
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, auditStorage, &genericapiserver.CompletedConfig{})
+	require.NoError(t, err, "Unexpected error initializing InsightManager")
+
+	resourceGroup := entity.ResourceGroup{Cluster: "existing-cluster", Namespace: "default"}
+	require.False(t, manager.servedFromStore(resourceGroup))
+
+	// Run a round of the background audit.
+	require.NoError(t, manager.AuditAll(context.Background()))
+	require.Len(t, auditStorage.issues, 9)
+	require.Equal(t, []string{"existing-cluster/default"}, auditStorage.resolved)
+	for _, issue := range auditStorage.issues {
+		require.Equal(t, "existing-pod", issue.Resource.Name)
+		require.NotNil(t, issue.FirstSeen)
+		require.NotNil(t, issue.LastSeen)
+	}
+
+	// The audit is served from the storage after the first round.
+	require.True(t, manager.servedFromStore(resourceGroup))
+	require.False(t, manager.servedFromStore(entity.ResourceGroup{Labels: map[string]string{"app": "foo"}}))
+
+	result, err := manager.Audit(context.Background(), resourceGroup, false)
+	require.NoError(t, err)
+	require.Equal(t, 9, result.IssueTotal())
+	require.Len(t, result.ByResource(), 1)
+
+	score, err := manager.Score(context.Background(), resourceGroup, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, score.ResourceTotal)
+	require.Equal(t, 9, score.IssuesTotal)
+	require.Equal(t, 17, int(score.Score))
+
+	// A second round keeps the first seen timestamps.
+	firstSeen := map[string]int64{}
+	for id, issue := range auditStorage.issues {
+		firstSeen[id] = issue.FirstSeen.Unix()
+	}
+	require.NoError(t, manager.AuditAll(context.Background()))
+	require.Len(t, auditStorage.issues, 9)
+	for id, issue := range auditStorage.issues {
+		require.Equal(t, firstSeen[id], issue.FirstSeen.Unix())
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// diagnosisMaxOwners is the maximum depth of the owner chain collected.
+	diagnosisMaxOwners = 5
+	// diagnosisMaxPods is the maximum number of related pods whose logs are
+	// collected.
+	diagnosisMaxPods = 5
+	// diagnosisMaxEvents is the maximum number of events collected.
+	diagnosisMaxEvents = 50
+	// diagnosisLogTailLines is the number of the latest log lines collected
+	// from every container.
+	diagnosisLogTailLines = 100
+)
+
+// GetDiagnosisContext collects the context bundle of a resource for AI
+// diagnosis, which includes its sanitized YAML, owner chain, related
+// resources in the topology, recent events and logs of the related pods and
+// audit issues. Only the resource itself is required, the other parts which
+// fail to be collected are recorded as notes in the context.
+func (i *InsightManager) GetDiagnosisContext(
+	ctx context.Context, client *multicluster.MultiClusterClient, resourceGroup *entity.ResourceGroup,
+) (*ai.DiagnosisContext, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	obj, err := i.GetResource(ctx, client, resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	yaml, err := k8syaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	diagnosisContext := &ai.DiagnosisContext{
+		Resource: *resourceGroup,
+		YAML:     string(yaml),
+	}
+	note := func(format string, args ...any) {
+		message := fmt.Sprintf(format, args...)
+		log.Info("Incomplete diagnosis context", "resourceGroup", resourceGroup, "note", message)
+		diagnosisContext.Notes = append(diagnosisContext.Notes, message)
+	}
+
+	// Walk up the owner chain by the controller references.
+	owned := obj
+	for len(diagnosisContext.OwnerChain) < diagnosisMaxOwners {
+		ref := controllerRef(owned)
+		if ref == nil {
+			break
+		}
+		owner := entity.ResourceGroup{
+			Cluster:    resourceGroup.Cluster,
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  owned.GetNamespace(),
+			Name:       ref.Name,
+		}
+		diagnosisContext.OwnerChain = append(diagnosisContext.OwnerChain, owner)
+		if owned, err = i.getResource(ctx, client, &owner); err != nil {
+			note("failed to get owner %s %s: %v", owner.Kind, owner.Name, err)
+			break
+		}
+	}
+
+	// Collect the related resources in the topology, and the pods among
+	// them.
+	var pods []string
+	if strings.EqualFold(resourceGroup.Kind, "Pod") {
+		pods = append(pods, resourceGroup.Name)
+	}
+	if topologyMap, err := i.GetTopologyForResource(ctx, client, resourceGroup, false); err != nil {
+		note("failed to get topology: %v", err)
+	} else {
+		for _, node := range topologyMap {
+			related := node.ResourceGroup
+			if related.Kind == resourceGroup.Kind && related.Namespace == resourceGroup.Namespace && related.Name == resourceGroup.Name {
+				continue
+			}
+			diagnosisContext.Related = append(diagnosisContext.Related, related)
+			if related.Kind == "Pod" && related.Namespace == resourceGroup.Namespace {
+				pods = append(pods, related.Name)
+			}
+		}
+		sort.Slice(diagnosisContext.Related, func(a, b int) bool {
+			ra, rb := diagnosisContext.Related[a], diagnosisContext.Related[b]
+			if ra.Kind != rb.Kind {
+				return ra.Kind < rb.Kind
+			}
+			return ra.Name < rb.Name
+		})
+	}
+	sort.Strings(pods)
+	if len(pods) > diagnosisMaxPods {
+		note("only the logs of %d of the %d related pods are collected", diagnosisMaxPods, len(pods))
+		pods = pods[:diagnosisMaxPods]
+	}
+
+	// Collect the events of the resource and the related pods, which are
+	// listed object by object so that the other events of the namespace are
+	// not read.
+	eventGVR := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"}
+	var events []unstructured.Unstructured
+	self := *resourceGroup
+	if kind := obj.GetKind(); kind != "" {
+		// The field selector matches the kind exactly.
+		self.Kind = kind
+	}
+	objects := append([]entity.ResourceGroup{self}, podGroups(resourceGroup, pods)...)
+	for _, object := range objects {
+		selector := fields.Set{"involvedObject.kind": object.Kind, "involvedObject.name": object.Name}.AsSelector()
+		eventList, err := client.DynamicClient.Resource(eventGVR).Namespace(resourceGroup.Namespace).List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+		if err != nil {
+			note("failed to list events of %s %s: %v", object.Kind, object.Name, err)
+			continue
+		}
+		events = append(events, eventList.Items...)
+	}
+	diagnosisContext.Events = diagnosisEvents(events, resourceGroup, pods)
+
+	// Collect the logs of the related pods, if the pods are accessible.
+	if len(pods) > 0 {
+		podGroup := entity.ResourceGroup{Cluster: resourceGroup.Cluster, Namespace: resourceGroup.Namespace, Kind: "Pod"}
+		if err := authz.Authorize(ctx, podGroup); err != nil {
+			note("logs of the related pods are not collected: %v", err)
+		} else {
+			logs, errs := collectContainerLogs(ctx, client.ClientSet, resourceGroup.Namespace, pods)
+			diagnosisContext.Logs = logs
+			for _, err := range errs {
+				note("%v", err)
+			}
+		}
+	}
+
+	// Collect the audit issues of the resource.
+	if result, err := i.Audit(ctx, *resourceGroup, false); err != nil {
+		note("failed to audit: %v", err)
+	} else {
+		for issue := range result.ByIssue() {
+			diagnosisContext.Issues = append(diagnosisContext.Issues, issue)
+		}
+		sort.Slice(diagnosisContext.Issues, func(a, b int) bool {
+			ia, ib := diagnosisContext.Issues[a], diagnosisContext.Issues[b]
+			if ia.Severity != ib.Severity {
+				return ia.Severity > ib.Severity
+			}
+			return ia.Title < ib.Title
+		})
+	}
+
+	return diagnosisContext, nil
+}
+
+// controllerRef returns the controller reference of the object, or its
+// first owner reference if it has no controller.
+func controllerRef(obj *unstructured.Unstructured) *metav1.OwnerReference {
+	refs := obj.GetOwnerReferences()
+	if len(refs) == 0 {
+		return nil
+	}
+	for idx := range refs {
+		if refs[idx].Controller != nil && *refs[idx].Controller {
+			return &refs[idx]
+		}
+	}
+	return &refs[0]
+}
+
+// podGroups returns the pods of the names other than the resource itself.
+func podGroups(resourceGroup *entity.ResourceGroup, pods []string) []entity.ResourceGroup {
+	groups := make([]entity.ResourceGroup, 0, len(pods))
+	for _, pod := range pods {
+		if strings.EqualFold(resourceGroup.Kind, "Pod") && pod == resourceGroup.Name {
+			continue
+		}
+		groups = append(groups, entity.ResourceGroup{Kind: "Pod", Name: pod})
+	}
+	return groups
+}
+
+// diagnosisEvents returns the events of the resource and the pods, the
+// latest first.
+func diagnosisEvents(events []unstructured.Unstructured, resourceGroup *entity.ResourceGroup, pods []string) []ai.Event {
+	podSet := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		podSet[pod] = true
+	}
+
+	type timedEvent struct {
+		event ai.Event
+		last  string
+	}
+	var matched []timedEvent
+	for _, event := range events {
+		kind, _, _ := unstructured.NestedString(event.Object, "involvedObject", "kind")
+		name, _, _ := unstructured.NestedString(event.Object, "involvedObject", "name")
+
+		var object string
+		switch {
+		case strings.EqualFold(kind, resourceGroup.Kind) && name == resourceGroup.Name:
+		case kind == "Pod" && podSet[name]:
+			object = "Pod " + name
+		default:
+			continue
+		}
+
+		e := ai.Event{Object: object}
+		e.Type, _, _ = unstructured.NestedString(event.Object, "type")
+		e.Reason, _, _ = unstructured.NestedString(event.Object, "reason")
+		e.Message, _, _ = unstructured.NestedString(event.Object, "message")
+		count, _, _ := unstructured.NestedInt64(event.Object, "count")
+		e.Count = int32(count)
+		e.FirstTimestamp, _, _ = unstructured.NestedString(event.Object, "firstTimestamp")
+		e.LastTimestamp, _, _ = unstructured.NestedString(event.Object, "lastTimestamp")
+
+		// Fall back to the event time for the events without timestamps.
+		last := e.LastTimestamp
+		if last == "" {
+			last, _, _ = unstructured.NestedString(event.Object, "eventTime")
+		}
+		matched = append(matched, timedEvent{event: e, last: last})
+	}
+
+	// RFC3339 timestamps in UTC sort in time order.
+	sort.SliceStable(matched, func(a, b int) bool {
+		return matched[a].last > matched[b].last
+	})
+	if len(matched) > diagnosisMaxEvents {
+		matched = matched[:diagnosisMaxEvents]
+	}
+
+	result := make([]ai.Event, 0, len(matched))
+	for _, m := range matched {
+		result = append(result, m.event)
+	}
+	return result
+}
+
+// collectContainerLogs returns the latest logs of the containers of the
+// pods, including the logs of the previous instances of the containers which
+// terminated, which usually tell why they crashed.
+func collectContainerLogs(ctx context.Context, client kubernetes.Interface, namespace string, pods []string) ([]ai.ContainerLogs, []error) {
+	var logs []ai.ContainerLogs
+	var errs []error
+	for _, name := range pods {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get pod %s: %w", name, err))
+			continue
+		}
+
+		terminated := map[string]bool{}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.LastTerminationState.Terminated != nil {
+				terminated[status.Name] = true
+			}
+		}
+
+		for _, container := range pod.Spec.Containers {
+			for _, previous := range []bool{true, false} {
+				if previous && !terminated[container.Name] {
+					continue
+				}
+				lines, err := tailLogs(ctx, client, namespace, name, container.Name, previous)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to get logs of %s/%s: %w", name, container.Name, err))
+					continue
+				}
+				logs = append(logs, ai.ContainerLogs{
+					Pod:       name,
+					Container: container.Name,
+					Previous:  previous,
+					Lines:     lines,
+				})
+			}
+		}
+	}
+	return logs, errs
+}
+
+// tailLogs returns the latest log lines of the container.
+func tailLogs(ctx context.Context, client kubernetes.Interface, namespace, pod, container string, previous bool) ([]string, error) {
+	tailLines := int64(diagnosisLogTailLines)
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,103 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newEvent(kind, name, reason, lastTimestamp string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion":     "v1",
+		"kind":           "Event",
+		"involvedObject": map[string]interface{}{"kind": kind, "name": name},
+		"type":           "Warning",
+		"reason":         reason,
+		"count":          int64(2),
+		"lastTimestamp":  lastTimestamp,
+	}}
+}
+
+func TestDiagnosisEvents(t *testing.T) {
+	resourceGroup := &entity.ResourceGroup{Kind: "Deployment", Namespace: "default", Name: "web"}
+	events := []unstructured.Unstructured{
+		newEvent("Deployment", "web", "ScalingReplicaSet", "2024-05-01T10:00:00Z"),
+		newEvent("Pod", "web-1", "BackOff", "2024-05-01T11:00:00Z"),
+		newEvent("Pod", "db-1", "BackOff", "2024-05-01T12:00:00Z"),
+		newEvent("Deployment", "db", "ScalingReplicaSet", "2024-05-01T12:00:00Z"),
+	}
+
+	got := diagnosisEvents(events, resourceGroup, []string{"web-1"})
+	require.Equal(t, []ai.Event{
+		{Type: "Warning", Reason: "BackOff", Count: 2, LastTimestamp: "2024-05-01T11:00:00Z", Object: "Pod web-1"},
+		{Type: "Warning", Reason: "ScalingReplicaSet", Count: 2, LastTimestamp: "2024-05-01T10:00:00Z"},
+	}, got)
+}
+
+func TestPodGroups(t *testing.T) {
+	deployment := &entity.ResourceGroup{Kind: "Deployment", Namespace: "default", Name: "web"}
+	require.Equal(t, []entity.ResourceGroup{
+		{Kind: "Pod", Name: "web-1"},
+		{Kind: "Pod", Name: "web-2"},
+	}, podGroups(deployment, []string{"web-1", "web-2"}))
+
+	// The events of the pod itself are listed as the resource.
+	pod := &entity.ResourceGroup{Kind: "Pod", Namespace: "default", Name: "web-1"}
+	require.Equal(t, []entity.ResourceGroup{{Kind: "Pod", Name: "web-2"}}, podGroups(pod, []string{"web-1", "web-2"}))
+}
+
+func TestControllerRef(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	require.Nil(t, controllerRef(obj))
+
+	obj.SetOwnerReferences([]metav1.OwnerReference{
+		{Kind: "ConfigMap", Name: "foo"},
+		{Kind: "ReplicaSet", Name: "web-1", Controller: pointer.Bool(true)},
+	})
+	require.Equal(t, "web-1", controllerRef(obj).Name)
+
+	obj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ConfigMap", Name: "foo"}})
+	require.Equal(t, "foo", controllerRef(obj).Name)
+}
+
+func TestCollectContainerLogs(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+			{Name: "sidecar"},
+		}},
+	})
+
+	logs, errs := collectContainerLogs(context.TODO(), client, "default", []string{"web-1", "web-2"})
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "failed to get pod web-2")
+	require.Equal(t, []ai.ContainerLogs{
+		{Pod: "web-1", Container: "app", Previous: true, Lines: []string{"fake logs"}},
+		{Pod: "web-1", Container: "app", Lines: []string{"fake logs"}},
+		{Pod: "web-1", Container: "sidecar", Lines: []string{"fake logs"}},
+	}, logs)
+}
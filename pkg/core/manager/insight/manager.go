@@ -15,10 +15,12 @@
 package insight
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/exception"
 	"github.com/KusionStack/karpor/pkg/infra/scanner/kubeaudit"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 	"github.com/KusionStack/karpor/pkg/util/cache"
@@ -29,11 +31,17 @@ type InsightManager struct {
 	search                storage.SearchStorage
 	resource              storage.ResourceStorage
 	resourceGroupRule     storage.ResourceGroupRuleStorage
-	scanner               scanner.KubeScanner
+	audit                 storage.AuditStorage
+	scanner               *scanner.Registry
+	suppressor            *exception.Suppressor
 	scanCache             *cache.Cache[entity.ResourceGroupHash, scanner.ScanResult]
 	clusterTopologyCache  *cache.Cache[entity.ResourceGroupHash, map[string]ClusterTopology]
 	resourceTopologyCache *cache.Cache[entity.ResourceGroupHash, map[string]ResourceTopology]
 	genericConfig         *genericapiserver.CompletedConfig
+
+	// auditCompleted is set once the background auditor has audited all
+	// clusters, from then on audits are served from the audit storage.
+	auditCompleted atomic.Bool
 }
 
 // NewInsightManager returns a new InsightManager object
@@ -41,6 +49,7 @@ func NewInsightManager(
 	searchStorage storage.SearchStorage,
 	resourceStorage storage.ResourceStorage,
 	resourceGroupRuleStorage storage.ResourceGroupRuleStorage,
+	auditStorage storage.AuditStorage,
 	genericConfig *genericapiserver.CompletedConfig,
 ) (*InsightManager, error) {
 	const defaultExpiration = 10 * time.Minute
@@ -51,14 +60,34 @@ func NewInsightManager(
 		return nil, err
 	}
 
+	// Create the scanner registry with the built-in scanners, more scanners
+	// can be added later by RegisterScanner.
+	scannerRegistry, err := scanner.NewRegistry(kubeauditScanner)
+	if err != nil {
+		return nil, err
+	}
+
 	return &InsightManager{
 		search:                searchStorage,
 		resource:              resourceStorage,
 		resourceGroupRule:     resourceGroupRuleStorage,
-		scanner:               kubeauditScanner,
+		audit:                 auditStorage,
+		scanner:               scannerRegistry,
 		scanCache:             cache.NewCache[entity.ResourceGroupHash, scanner.ScanResult](defaultExpiration),
 		clusterTopologyCache:  cache.NewCache[entity.ResourceGroupHash, map[string]ClusterTopology](defaultExpiration),
 		resourceTopologyCache: cache.NewCache[entity.ResourceGroupHash, map[string]ResourceTopology](defaultExpiration),
 		genericConfig:         genericConfig,
 	}, nil
 }
+
+// RegisterScanner adds a scanner to the scanners which are run in parallel
+// on every audit.
+func (i *InsightManager) RegisterScanner(s scanner.KubeScanner) error {
+	return i.scanner.Register(s)
+}
+
+// SetExceptionSource sets the source of the audit exceptions which suppress
+// the accepted issues in every audit. No issue is suppressed until it is set.
+func (i *InsightManager) SetExceptionSource(source exception.Source) {
+	i.suppressor = exception.New(source)
+}
@@ -0,0 +1,193 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	topologyutil "github.com/KusionStack/karpor/pkg/util/topology"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// ScanObject scans the object of the resource without cache and returns the
+// issues found.
+func (i *InsightManager) ScanObject(ctx context.Context, resourceGroup *entity.ResourceGroup, obj *unstructured.Unstructured) (scanner.IssueList, error) {
+	result, err := i.scanner.Scan(ctx, true, &storage.Resource{ResourceGroup: *resourceGroup, Object: obj.Object})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scan resource")
+	}
+	return result.ByResource()[resourceGroup.Hash()], nil
+}
+
+// ValidatePatch applies the patch to a copy of the object locally, scans the
+// patched object again and diffs it with the object. The patch is dry run
+// against the cluster if requested, it is never applied to the cluster.
+func (i *InsightManager) ValidatePatch(
+	ctx context.Context,
+	client *multicluster.MultiClusterClient,
+	resourceGroup *entity.ResourceGroup,
+	obj *unstructured.Unstructured,
+	issues scanner.IssueList,
+	patch *ai.Patch,
+	dryRun bool,
+) (*ai.RemediationResult, error) {
+	patched, patchType, err := applyPatch(obj, patch)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := i.ScanObject(ctx, resourceGroup, patched)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := diffObjects(obj, patched)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ai.RemediationResult{
+		Patch:           ai.Patch{Type: patchType, Content: patch.Content},
+		Diff:            diff,
+		Issues:          issues,
+		RemainingIssues: remaining,
+	}
+	result.ResolvedIssues, result.IntroducedIssues = compareIssues(issues, remaining)
+
+	if dryRun {
+		result.DryRun = &ai.DryRunResult{Succeeded: true}
+		if err := dryRunPatch(ctx, client, resourceGroup, result.Patch); err != nil {
+			result.DryRun = &ai.DryRunResult{Error: err.Error()}
+		}
+	}
+	return result, nil
+}
+
+// applyPatch returns a copy of the object with the patch applied and the
+// type the patch is applied as. A strategic merge patch of an object without
+// a registered schema is applied as a JSON merge patch like kubectl does.
+func applyPatch(obj *unstructured.Unstructured, patch *ai.Patch) (*unstructured.Unstructured, types.PatchType, error) {
+	original, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var patchedJSON []byte
+	patchType := patch.Type
+	switch patch.Type {
+	case types.JSONPatchType:
+		operations, err := jsonpatch.DecodePatch([]byte(patch.Content))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "invalid JSON patch")
+		}
+		patchedJSON, err = operations.Apply(original)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to apply JSON patch")
+		}
+	case types.StrategicMergePatchType:
+		typed, err := scheme.Scheme.New(obj.GroupVersionKind())
+		if runtime.IsNotRegisteredError(err) {
+			patchType = types.MergePatchType
+			patchedJSON, err = jsonpatch.MergePatch(original, []byte(patch.Content))
+		} else if err == nil {
+			patchedJSON, err = strategicpatch.StrategicMergePatch(original, []byte(patch.Content), typed)
+		}
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to apply strategic merge patch")
+		}
+	default:
+		return nil, "", fmt.Errorf("unsupported patch type %q", patch.Type)
+	}
+
+	patched := &unstructured.Unstructured{}
+	if err := patched.UnmarshalJSON(patchedJSON); err != nil {
+		return nil, "", err
+	}
+	if patched.GetAPIVersion() != obj.GetAPIVersion() || patched.GetKind() != obj.GetKind() ||
+		patched.GetNamespace() != obj.GetNamespace() || patched.GetName() != obj.GetName() {
+		return nil, "", fmt.Errorf("the patch must not change the apiVersion, kind, namespace or name")
+	}
+	return patched, patchType, nil
+}
+
+// diffObjects returns the unified diff of the YAML of the objects.
+func diffObjects(before, after *unstructured.Unstructured) (string, error) {
+	beforeYAML, err := k8syaml.Marshal(before.Object)
+	if err != nil {
+		return "", err
+	}
+	afterYAML, err := k8syaml.Marshal(after.Object)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(beforeYAML)),
+		B:        difflib.SplitLines(string(afterYAML)),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
+}
+
+// compareIssues returns the titles of the issues which are resolved and
+// introduced, the issues are identified by their scanners and titles.
+func compareIssues(before, after scanner.IssueList) (resolved, introduced []string) {
+	key := func(issue *scanner.Issue) string {
+		return issue.Scanner + "/" + issue.Title
+	}
+	remaining := make(map[string]bool, len(after))
+	for _, issue := range after {
+		remaining[key(issue)] = true
+	}
+	existing := make(map[string]bool, len(before))
+	for _, issue := range before {
+		existing[key(issue)] = true
+		if !remaining[key(issue)] {
+			resolved = append(resolved, issue.Title)
+		}
+	}
+	for _, issue := range after {
+		if !existing[key(issue)] {
+			introduced = append(introduced, issue.Title)
+		}
+	}
+	return resolved, introduced
+}
+
+// dryRunPatch performs a server-side dry run of the patch against the
+// cluster, which validates it with the admission chain without persisting.
+func dryRunPatch(ctx context.Context, client *multicluster.MultiClusterClient, resourceGroup *entity.ResourceGroup, patch ai.Patch) error {
+	resourceGVR, err := topologyutil.GetGVRFromGVK(resourceGroup.APIVersion, resourceGroup.Kind)
+	if err != nil {
+		return err
+	}
+	_, err = client.DynamicClient.Resource(resourceGVR).Namespace(resourceGroup.Namespace).Patch(
+		ctx, resourceGroup.Name, patch.Type, []byte(patch.Content), metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}})
+	return err
+}
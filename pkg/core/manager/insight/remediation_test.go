@@ -0,0 +1,156 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newDeployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": "web"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "nginx"},
+						map[string]interface{}{"name": "sidecar", "image": "envoy"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		obj           *unstructured.Unstructured
+		patch         ai.Patch
+		wantPatchType types.PatchType
+		wantPath      []string
+		wantValue     interface{}
+		wantErr       string
+	}{
+		{
+			name:          "strategic merge patch merges containers by name",
+			obj:           newDeployment(),
+			patch:         ai.Patch{Type: types.StrategicMergePatchType, Content: `{"spec":{"template":{"spec":{"containers":[{"name":"sidecar","securityContext":{"runAsNonRoot":true}}]}}}}`},
+			wantPatchType: types.StrategicMergePatchType,
+			wantPath:      []string{"spec", "template", "spec", "containers"},
+			wantValue: []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy", "securityContext": map[string]interface{}{"runAsNonRoot": true}},
+			},
+		},
+		{
+			name: "strategic merge patch of custom resource is a merge patch",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Widget",
+				"metadata":   map[string]interface{}{"name": "foo"},
+				"spec":       map[string]interface{}{"replicas": int64(1), "privileged": true},
+			}},
+			patch:         ai.Patch{Type: types.StrategicMergePatchType, Content: `{"spec":{"privileged":null}}`},
+			wantPatchType: types.MergePatchType,
+			wantPath:      []string{"spec"},
+			wantValue:     map[string]interface{}{"replicas": int64(1)},
+		},
+		{
+			name:          "JSON patch",
+			obj:           newDeployment(),
+			patch:         ai.Patch{Type: types.JSONPatchType, Content: `[{"op":"replace","path":"/spec/template/spec/containers/0/image","value":"nginx:1.25"}]`},
+			wantPatchType: types.JSONPatchType,
+			wantPath:      []string{"spec", "template", "spec", "containers"},
+			wantValue: []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx:1.25"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy"},
+			},
+		},
+		{
+			name:    "JSON patch of missing path",
+			obj:     newDeployment(),
+			patch:   ai.Patch{Type: types.JSONPatchType, Content: `[{"op":"replace","path":"/spec/replicas/foo","value":1}]`},
+			wantErr: "failed to apply JSON patch",
+		},
+		{
+			name:    "patch renaming the resource",
+			obj:     newDeployment(),
+			patch:   ai.Patch{Type: types.StrategicMergePatchType, Content: `{"metadata":{"name":"web-2"}}`},
+			wantErr: "the patch must not change the apiVersion, kind, namespace or name",
+		},
+		{
+			name:    "unsupported patch type",
+			obj:     newDeployment(),
+			patch:   ai.Patch{Type: types.ApplyPatchType, Content: `{}`},
+			wantErr: "unsupported patch type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.obj.DeepCopy()
+			patched, patchType, err := applyPatch(tt.obj, &tt.patch)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPatchType, patchType)
+			value, found, err := unstructured.NestedFieldNoCopy(patched.Object, tt.wantPath...)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, tt.wantValue, value)
+			// The original object is left unchanged.
+			require.Equal(t, original, tt.obj)
+		})
+	}
+}
+
+func TestCompareIssues(t *testing.T) {
+	before := scanner.IssueList{
+		{Scanner: "kubeaudit", Title: "RunAsNonRoot"},
+		{Scanner: "kubeaudit", Title: "ImageTag"},
+	}
+	after := scanner.IssueList{
+		{Scanner: "kubeaudit", Title: "ImageTag"},
+		{Scanner: "kubeaudit", Title: "Privileged"},
+	}
+	resolved, introduced := compareIssues(before, after)
+	require.Equal(t, []string{"RunAsNonRoot"}, resolved)
+	require.Equal(t, []string{"Privileged"}, introduced)
+}
+
+func TestDiffObjects(t *testing.T) {
+	before := newDeployment()
+	after := before.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(after.Object, int64(2), "spec", "replicas"))
+
+	diff, err := diffObjects(before, after)
+	require.NoError(t, err)
+	require.Contains(t, diff, "--- before\n+++ after\n")
+	require.Contains(t, diff, "+  replicas: 2\n")
+
+	diff, err = diffObjects(before, before)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+}
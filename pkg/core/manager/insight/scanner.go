@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/scanner"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
@@ -26,24 +27,64 @@ import (
 )
 
 // Audit performs the audit on Kubernetes manifests with the specified resourceGroup
-// and returns the issues found during the audit.
+// and returns the issues found during the audit. Once the background auditor
+// has audited all clusters, the issues are served from the audit storage and
+// the result only contains the resources with open issues. The issues covered
+// by an active audit exception are flagged as suppressed, and the resources
+// not granted by the data access filter of the context are left out.
 func (i *InsightManager) Audit(ctx context.Context, resourceGroup entity.ResourceGroup, noCache bool) (scanner.ScanResult, error) {
 	// Retrieve logger from context and log the start of the audit.
 	log := ctxutil.GetLogger(ctx)
 	log.Info("Starting audit with specified condition in AuditManager ...")
 
+	// The scan results are cached and shared by all users, so the audit is
+	// performed without restriction and the result is filtered afterwards.
+	filter := authz.FilterFrom(ctx)
+	ctx = authz.WithFilter(ctx, nil)
+
+	var result scanner.ScanResult
+	var err error
 	if noCache {
 		log.Info("Scan without cache for resourceGroup", "resourceGroup", resourceGroup)
-		return i.scanFor(ctx, resourceGroup, true)
+		result, err = i.scanFor(ctx, resourceGroup, true)
+	} else if i.servedFromStore(resourceGroup) {
+		log.Info("Load audit issues from storage for resourceGroup", "resourceGroup", resourceGroup)
+		result, err = i.loadFromStore(ctx, resourceGroup)
 	} else {
 		if auditData, exist := i.scanCache.Get(resourceGroup.Hash()); exist {
 			log.Info("Cache hit for resourceGroup", "resourceGroup", resourceGroup)
-			return auditData, nil
+			result = auditData
 		} else {
 			log.Info("Cache miss for resourceGroup", "resourceGroup", resourceGroup)
-			return i.scanFor(ctx, resourceGroup, false)
+			result, err = i.scanFor(ctx, resourceGroup, false)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		result = restrictResult(result, filter)
+	}
+	if i.suppressor == nil {
+		return result, nil
+	}
+
+	// The exceptions are applied on every audit rather than cached, so that
+	// they take effect as soon as they are changed or expired.
+	return i.suppressor.Apply(ctx, result)
+}
+
+// restrictResult returns a copy of the scan result which only contains the
+// resources allowed by the data access filter.
+func restrictResult(result scanner.ScanResult, filter *authz.Filter) scanner.ScanResult {
+	restricted := scanner.NewScanResult()
+	byResource := result.ByResource()
+	for _, resource := range result.Resources() {
+		if filter.Allows(resource.ResourceGroup) {
+			restricted.Add(resource, byResource[resource.ResourceGroup.Hash()])
 		}
 	}
+	return restricted
 }
 
 // Score calculates a score based on the severity and total number of issues
@@ -59,18 +100,44 @@ func (i *InsightManager) Score(ctx context.Context, resourceGroup entity.Resourc
 		return nil, err
 	}
 
-	// Calculate the total score and severity statistics for each resource.
+	resourceTotal := len(scanResult.ByResource())
+	if !noCache && i.servedFromStore(resourceGroup) {
+		// The audit storage only contains the resources with issues, so the
+		// resources are counted from the search storage instead.
+		count, err := i.countResources(ctx, resourceGroup)
+		if err != nil {
+			return nil, err
+		}
+		resourceTotal = max(resourceTotal, count)
+	}
+
+	// Calculate the total score and severity statistics for each resource,
+	// the resources without any issue get the full score. Suppressed issues
+	// are accepted risks, so they are not counted.
 	var scoreTotal float64 = 0
+	issuesTotal, suppressedTotal := 0, 0
 	severityStats := map[string]int{}
 	for _, issues := range scanResult.ByResource() {
-		score, stats := CalculateResourceScore(issues)
+		counted := make(scanner.IssueList, 0, len(issues))
+		for _, issue := range issues {
+			if issue.Suppressed {
+				suppressedTotal++
+			} else {
+				counted = append(counted, issue)
+			}
+		}
+		issuesTotal += len(counted)
+
+		score, stats := CalculateResourceScore(counted)
 		scoreTotal += score
 		for k, v := range stats {
 			severityStats[k] += v
 		}
 	}
+	if cleanTotal := resourceTotal - len(scanResult.ByResource()); cleanTotal > 0 {
+		scoreTotal += 100 * float64(cleanTotal)
+	}
 
-	resourceTotal := len(scanResult.ByResource())
 	if resourceTotal == 0 {
 		scoreTotal = 100
 	} else if resourceTotal > 0 {
@@ -82,7 +149,8 @@ func (i *InsightManager) Score(ctx context.Context, resourceGroup entity.Resourc
 	return &ScoreData{
 		Score:             scoreTotal,
 		ResourceTotal:     resourceTotal,
-		IssuesTotal:       scanResult.IssueTotal(),
+		IssuesTotal:       issuesTotal,
+		SuppressedTotal:   suppressedTotal,
 		SeverityStatistic: severityStats,
 	}, nil
 }
@@ -18,14 +18,18 @@ import (
 	"context"
 	"testing"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/exception"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 )
 
 func TestInsightManager_Audit(t *testing.T) {
 	// Initialize InsightManager
-	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &genericapiserver.CompletedConfig{})
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &mockAuditStorage{}, &genericapiserver.CompletedConfig{})
 	require.NoError(t, err, "Unexpected error initializing InsightManager")
 
 	// Test cases
@@ -85,9 +89,33 @@ func TestInsightManager_Audit(t *testing.T) {
 	}
 }
 
+func TestInsightManager_AuditWithDataAccessFilter(t *testing.T) {
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &mockAuditStorage{}, &genericapiserver.CompletedConfig{})
+	require.NoError(t, err)
+
+	resourceGroup := entity.ResourceGroup{Cluster: "existing-cluster", Namespace: "default", APIVersion: "v1", Kind: "Pod", Name: "existing-pod"}
+
+	// The unrestricted audit fills the cache shared by all users.
+	result, err := manager.Audit(context.Background(), resourceGroup, false)
+	require.NoError(t, err)
+	require.Len(t, result.Resources(), 1)
+
+	denied := authz.WithFilter(context.Background(), &authz.Filter{Scopes: []authz.Scope{{Clusters: []string{"other-cluster"}}}})
+	result, err = manager.Audit(denied, resourceGroup, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Resources())
+	require.Zero(t, result.IssueTotal())
+
+	allowed := authz.WithFilter(context.Background(), &authz.Filter{Scopes: []authz.Scope{{Clusters: []string{"existing-cluster"}}}})
+	result, err = manager.Audit(allowed, resourceGroup, false)
+	require.NoError(t, err)
+	require.Len(t, result.Resources(), 1)
+	require.NotZero(t, result.IssueTotal())
+}
+
 func TestInsightManager_Score(t *testing.T) {
 	// Initialize InsightManager
-	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &genericapiserver.CompletedConfig{})
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &mockAuditStorage{}, &genericapiserver.CompletedConfig{})
 	require.NoError(t, err, "Unexpected error initializing InsightManager")
 
 	// Test cases
@@ -155,3 +183,38 @@ func TestInsightManager_Score(t *testing.T) {
 		})
 	}
 }
+
+func TestInsightManager_ScoreWithExceptions(t *testing.T) {
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, &mockAuditStorage{}, &genericapiserver.CompletedConfig{})
+	require.NoError(t, err, "Unexpected error initializing InsightManager")
+	manager.SetExceptionSource(exception.StaticSource{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-namespace"},
+			Spec: searchv1beta1.AuditExceptionSpec{
+				Match:         searchv1beta1.ScanPolicyMatch{Namespaces: []string{"default"}},
+				Justification: "accepted risk",
+				Owner:         "platform-team",
+			},
+		},
+	})
+
+	rg := entity.ResourceGroup{Cluster: "existing-cluster", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "existing-pod"}
+
+	// Suppressed issues are still reported with a flag.
+	result, err := manager.Audit(context.Background(), rg, true)
+	require.NoError(t, err)
+	require.Len(t, result.ByResource()[rg.Hash()], 9)
+	for _, issue := range result.ByResource()[rg.Hash()] {
+		require.True(t, issue.Suppressed)
+		require.Equal(t, "default-namespace", issue.Exception)
+	}
+
+	// But they are excluded from the score and the severity statistic.
+	scoreData, err := manager.Score(context.Background(), rg, true)
+	require.NoError(t, err)
+	require.Equal(t, float64(100), scoreData.Score)
+	require.Equal(t, 1, scoreData.ResourceTotal)
+	require.Equal(t, 0, scoreData.IssuesTotal)
+	require.Equal(t, 9, scoreData.SuppressedTotal)
+	require.Empty(t, scoreData.SeverityStatistic)
+}
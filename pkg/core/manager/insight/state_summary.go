@@ -0,0 +1,267 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// summaryEventWindow is how far back the warning events are collected.
+	summaryEventWindow = 24 * time.Hour
+	// summaryMaxEvents is the maximum number of warning events collected.
+	summaryMaxEvents = 50
+	// summaryScoreLookback is how far back the previous score is compared
+	// with.
+	summaryScoreLookback = 24 * time.Hour
+)
+
+// GetStateContext collects the state of a cluster or a namespace for an AI
+// summary, which includes the cluster detail, the resource counts, the
+// audit score and its change, the unhealthy pods and the recent warning
+// events. The parts which fail to be collected are recorded as notes in the
+// state.
+func (i *InsightManager) GetStateContext(
+	ctx context.Context, client *multicluster.MultiClusterClient, resourceGroup *entity.ResourceGroup,
+) (*ai.StateContext, error) {
+	log := ctxutil.GetLogger(ctx)
+	now := time.Now()
+
+	stateContext := &ai.StateContext{
+		Cluster:   resourceGroup.Cluster,
+		Namespace: resourceGroup.Namespace,
+	}
+	note := func(format string, args ...any) {
+		message := fmt.Sprintf(format, args...)
+		log.Info("Incomplete state context", "resourceGroup", resourceGroup, "note", message)
+		stateContext.Notes = append(stateContext.Notes, message)
+	}
+
+	// The nodes and the capacity only concern the cluster.
+	if resourceGroup.Namespace == "" {
+		detail, err := i.GetDetailsForCluster(ctx, client, resourceGroup.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		stateContext.ServerVersion = detail.ServerVersion
+		stateContext.Nodes = &ai.NodeState{
+			Total:    detail.NodeCount,
+			Ready:    detail.ReadyNodes,
+			NotReady: detail.NotReadyNodes,
+		}
+		stateContext.Usage = clusterUsage(detail)
+	}
+
+	if counts, err := i.CountByResourceGroup(ctx, client, resourceGroup); err != nil {
+		note("failed to count resources: %v", err)
+	} else {
+		stateContext.CountByGVK = counts
+	}
+
+	if score, err := i.Score(ctx, *resourceGroup, false); err != nil {
+		note("failed to get audit score: %v", err)
+	} else {
+		stateContext.Score = &ai.ScoreState{
+			Score:             score.Score,
+			IssuesTotal:       score.IssuesTotal,
+			SeverityStatistic: score.SeverityStatistic,
+		}
+		// Compare with the last score recorded before the lookback.
+		trend, err := i.ScoreTrend(ctx, *resourceGroup, TrendIntervalDay, now.Add(-7*summaryScoreLookback), now.Add(-summaryScoreLookback))
+		if err != nil {
+			note("failed to get audit score trend: %v", err)
+		} else if len(trend) > 0 {
+			previous := trend[len(trend)-1]
+			stateContext.Score.PreviousScore = &previous.Score
+			stateContext.Score.PreviousTimestamp = previous.Timestamp.Format(time.DateOnly)
+		}
+	}
+
+	pods, err := client.ClientSet.CoreV1().Pods(resourceGroup.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		note("failed to list pods: %v", err)
+	} else {
+		stateContext.UnhealthyPods = unhealthyPods(pods.Items)
+	}
+
+	var events []unstructured.Unstructured
+	if resourceGroup.Namespace == "" {
+		events, err = i.GetClusterEvents(ctx, client, resourceGroup)
+	} else {
+		events, err = i.GetNamespaceEvents(ctx, client, resourceGroup)
+	}
+	if err != nil {
+		note("failed to list events: %v", err)
+	} else {
+		stateContext.WarningEvents = warningEvents(events, now.Add(-summaryEventWindow))
+	}
+
+	return stateContext, nil
+}
+
+// clusterUsage describes the usage of the capacity of the cluster.
+func clusterUsage(detail *ClusterDetail) []string {
+	var usage []string
+	if detail.MetricsEnabled && detail.CPUCapacity > 0 {
+		usage = append(usage, fmt.Sprintf("CPU: %.0f%% of %s cores",
+			100*detail.CPUUsage/float64(detail.CPUCapacity), resource.NewMilliQuantity(detail.CPUCapacity, resource.DecimalSI)))
+	}
+	if detail.MetricsEnabled && detail.MemoryCapacity > 0 {
+		usage = append(usage, fmt.Sprintf("Memory: %.0f%% of %s",
+			100*detail.MemoryUsage/float64(detail.MemoryCapacity), resource.NewQuantity(detail.MemoryCapacity, resource.BinarySI)))
+	}
+	if detail.PodsCapacity > 0 {
+		usage = append(usage, fmt.Sprintf("Pods: %d of %d", detail.PodsUsage, detail.PodsCapacity))
+	}
+	return usage
+}
+
+// unhealthyPods returns the pods which are not running or ready with the
+// reasons, the pods which completed or are being deleted are left out.
+func unhealthyPods(pods []corev1.Pod) []ai.UnhealthyPod {
+	var result []ai.UnhealthyPod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		reason := unhealthyReason(&pod)
+		if reason == "" {
+			continue
+		}
+		var restarts int32
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+		result = append(result, ai.UnhealthyPod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Reason:    reason,
+			Restarts:  restarts,
+		})
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Namespace != result[b].Namespace {
+			return result[a].Namespace < result[b].Namespace
+		}
+		return result[a].Name < result[b].Name
+	})
+	return result
+}
+
+// unhealthyReason returns why the pod is unhealthy, or an empty string if
+// it is running and ready. The reasons of the containers, such as
+// CrashLoopBackOff, are preferred to the phase of the pod.
+func unhealthyReason(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" && waiting.Reason != "PodInitializing" && waiting.Reason != "ContainerCreating" {
+			return waiting.Reason
+		}
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 && terminated.Reason != "" {
+			return terminated.Reason
+		}
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodPending:
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason != "" {
+				return condition.Reason
+			}
+		}
+		return string(corev1.PodPending)
+	case corev1.PodFailed:
+		if pod.Status.Reason != "" {
+			return pod.Status.Reason
+		}
+		return string(corev1.PodFailed)
+	case corev1.PodRunning:
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+				return "NotReady"
+			}
+		}
+		return ""
+	default:
+		return string(corev1.PodUnknown)
+	}
+}
+
+// warningEvents returns the warning events which last occurred after since,
+// the latest first.
+func warningEvents(events []unstructured.Unstructured, since time.Time) []ai.Event {
+	type timedEvent struct {
+		event ai.Event
+		last  time.Time
+	}
+	var matched []timedEvent
+	for _, event := range events {
+		if eventType, _, _ := unstructured.NestedString(event.Object, "type"); eventType != corev1.EventTypeWarning {
+			continue
+		}
+		e := ai.Event{Type: corev1.EventTypeWarning}
+		e.Reason, _, _ = unstructured.NestedString(event.Object, "reason")
+		e.Message, _, _ = unstructured.NestedString(event.Object, "message")
+		count, _, _ := unstructured.NestedInt64(event.Object, "count")
+		e.Count = int32(count)
+		e.FirstTimestamp, _, _ = unstructured.NestedString(event.Object, "firstTimestamp")
+		e.LastTimestamp, _, _ = unstructured.NestedString(event.Object, "lastTimestamp")
+
+		// Fall back to the event time for the events without timestamps.
+		lastTimestamp := e.LastTimestamp
+		if lastTimestamp == "" {
+			lastTimestamp, _, _ = unstructured.NestedString(event.Object, "eventTime")
+			e.LastTimestamp = lastTimestamp
+		}
+		last, err := time.Parse(time.RFC3339, lastTimestamp)
+		if err != nil || last.Before(since) {
+			continue
+		}
+
+		kind, _, _ := unstructured.NestedString(event.Object, "involvedObject", "kind")
+		namespace, _, _ := unstructured.NestedString(event.Object, "involvedObject", "namespace")
+		name, _, _ := unstructured.NestedString(event.Object, "involvedObject", "name")
+		if namespace != "" {
+			name = namespace + "/" + name
+		}
+		e.Object = kind + " " + name
+		matched = append(matched, timedEvent{event: e, last: last})
+	}
+
+	sort.SliceStable(matched, func(a, b int) bool {
+		return matched[a].last.After(matched[b].last)
+	})
+	if len(matched) > summaryMaxEvents {
+		matched = matched[:summaryMaxEvents]
+	}
+
+	result := make([]ai.Event, 0, len(matched))
+	for _, m := range matched {
+		result = append(result, m.event)
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/manager/ai"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestUnhealthyPods(t *testing.T) {
+	pod := func(name string, status corev1.PodStatus) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}, Status: status}
+	}
+	ready := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	deleting := pod("deleting", corev1.PodStatus{Phase: corev1.PodFailed})
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	pods := []corev1.Pod{
+		pod("running", corev1.PodStatus{Phase: corev1.PodRunning, Conditions: ready}),
+		pod("completed", corev1.PodStatus{Phase: corev1.PodSucceeded}),
+		deleting,
+		pod("crashing", corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{RestartCount: 3, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{RestartCount: 9, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		}),
+		pod("creating", corev1.PodStatus{
+			Phase:             corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}}},
+		}),
+		pod("unschedulable", corev1.PodStatus{
+			Phase:      corev1.PodPending,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable"}},
+		}),
+		pod("not-ready", corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		}),
+		pod("evicted", corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"}),
+		pod("oom", corev1.PodStatus{
+			Phase:                 corev1.PodPending,
+			InitContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}}}},
+		}),
+	}
+	require.Equal(t, []ai.UnhealthyPod{
+		{Namespace: "default", Name: "crashing", Reason: "CrashLoopBackOff", Restarts: 12},
+		{Namespace: "default", Name: "creating", Reason: "Pending"},
+		{Namespace: "default", Name: "evicted", Reason: "Evicted"},
+		{Namespace: "default", Name: "not-ready", Reason: "NotReady"},
+		{Namespace: "default", Name: "oom", Reason: "OOMKilled"},
+		{Namespace: "default", Name: "unschedulable", Reason: "Unschedulable"},
+	}, unhealthyPods(pods))
+}
+
+func TestWarningEvents(t *testing.T) {
+	event := func(eventType, reason, lastTimestamp string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"type":           eventType,
+			"reason":         reason,
+			"message":        reason + " happened",
+			"count":          int64(2),
+			"firstTimestamp": "2024-05-01T00:00:00Z",
+			"lastTimestamp":  lastTimestamp,
+			"involvedObject": map[string]interface{}{"kind": "Pod", "namespace": "default", "name": "web"},
+		}}
+	}
+	newEvent := event("Warning", "", "")
+	delete(newEvent.Object, "lastTimestamp")
+	newEvent.Object["reason"] = "FailedMount"
+	newEvent.Object["eventTime"] = "2024-05-02T12:00:00Z"
+
+	events := []unstructured.Unstructured{
+		event("Normal", "Pulled", "2024-05-02T10:00:00Z"),
+		event("Warning", "Stale", "2024-04-30T10:00:00Z"),
+		event("Warning", "BackOff", "2024-05-02T10:00:00Z"),
+		newEvent,
+		event("Warning", "Unhealthy", "2024-05-02T11:00:00Z"),
+	}
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	got := warningEvents(events, since)
+	require.Len(t, got, 3)
+	require.Equal(t, ai.Event{
+		Type:           "Warning",
+		Reason:         "FailedMount",
+		Message:        " happened",
+		Count:          2,
+		FirstTimestamp: "2024-05-01T00:00:00Z",
+		LastTimestamp:  "2024-05-02T12:00:00Z",
+		Object:         "Pod default/web",
+	}, got[0])
+	require.Equal(t, "Unhealthy", got[1].Reason)
+	require.Equal(t, "BackOff", got[2].Reason)
+}
+
+func TestClusterUsage(t *testing.T) {
+	detail := &ClusterDetail{
+		CPUCapacity:    8000,
+		CPUUsage:       3200,
+		MemoryCapacity: 32 << 30,
+		MemoryUsage:    8 << 30,
+		PodsCapacity:   330,
+		PodsUsage:      120,
+	}
+	require.Equal(t, []string{"Pods: 120 of 330"}, clusterUsage(detail))
+
+	detail.MetricsEnabled = true
+	require.Equal(t, []string{"CPU: 40% of 8 cores", "Memory: 25% of 32Gi", "Pods: 120 of 330"}, clusterUsage(detail))
+}
@@ -17,6 +17,7 @@ package insight
 import (
 	"context"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	clustermanager "github.com/KusionStack/karpor/pkg/core/manager/cluster"
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
 )
@@ -52,9 +53,20 @@ func (i *InsightManager) Statistics(ctx context.Context) (*Statistics, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Only count the clusters allowed by the data access filter, which is
+	// also narrowed down by the cluster selector of the request.
+	clusterCount := summary.TotalCount
+	if filter := authz.FilterFrom(ctx); filter != nil {
+		clusterCount = 0
+		for _, name := range append(summary.HealthyClusters, summary.UnhealthyClusters...) {
+			if filter.AllowsCluster(name) {
+				clusterCount++
+			}
+		}
+	}
 
 	return &Statistics{
-		ClusterCount:           summary.TotalCount,
+		ClusterCount:           clusterCount,
 		ResourceCount:          resourceCount,
 		ResourceGroupRuleCount: rgrCount,
 	}, nil
@@ -16,6 +16,7 @@ package insight
 
 import (
 	"context"
+	"time"
 
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/multicluster"
@@ -111,6 +112,91 @@ type mockResourceGroupRuleStorage struct {
 	storage.ResourceGroupRuleStorage
 }
 
+// mockAuditStorage is an in-memory implementation of the AuditStorage
+// interface for testing purposes.
+type mockAuditStorage struct {
+	storage.AuditStorage
+	issues    map[string]*entity.AuditIssue
+	resolved  []string
+	snapshots []*entity.ScoreSnapshot
+}
+
+// SaveAuditIssues stores the audit issues in memory.
+func (m *mockAuditStorage) SaveAuditIssues(ctx context.Context, issues []*entity.AuditIssue, seenAt time.Time) error {
+	if m.issues == nil {
+		m.issues = map[string]*entity.AuditIssue{}
+	}
+	for _, issue := range issues {
+		if _, ok := m.issues[issue.ID]; !ok {
+			issue.FirstSeen = &metav1.Time{Time: seenAt}
+			m.issues[issue.ID] = issue
+		}
+		m.issues[issue.ID].LastSeen = &metav1.Time{Time: seenAt}
+	}
+	return nil
+}
+
+// ResolveAuditIssues records the resolved cluster and namespace.
+func (m *mockAuditStorage) ResolveAuditIssues(ctx context.Context, cluster, namespace string, seenBefore time.Time) error {
+	m.resolved = append(m.resolved, cluster+"/"+namespace)
+	return nil
+}
+
+// ListAuditIssues returns all audit issues stored in memory.
+func (m *mockAuditStorage) ListAuditIssues(ctx context.Context, resourceGroup entity.ResourceGroup, includeResolved bool) ([]*entity.AuditIssue, error) {
+	issues := make([]*entity.AuditIssue, 0, len(m.issues))
+	for _, issue := range m.issues {
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// ListAuditIssuesIntroduced returns the audit issues first seen in the range.
+func (m *mockAuditStorage) ListAuditIssuesIntroduced(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error) {
+	issues := []*entity.AuditIssue{}
+	for _, issue := range m.issues {
+		if issue.FirstSeen != nil && !issue.FirstSeen.Time.Before(from) && !issue.FirstSeen.Time.After(to) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// ListAuditIssuesResolved returns the audit issues resolved in the range.
+func (m *mockAuditStorage) ListAuditIssuesResolved(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error) {
+	issues := []*entity.AuditIssue{}
+	for _, issue := range m.issues {
+		if issue.ResolvedAt != nil && !issue.ResolvedAt.Time.Before(from) && !issue.ResolvedAt.Time.After(to) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// SaveScoreSnapshot stores the score snapshot in memory.
+func (m *mockAuditStorage) SaveScoreSnapshot(ctx context.Context, snapshot *entity.ScoreSnapshot) error {
+	m.snapshots = append(m.snapshots, snapshot)
+	return nil
+}
+
+// ListScoreSnapshots returns the score snapshots of the resource group in the
+// range.
+func (m *mockAuditStorage) ListScoreSnapshots(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ScoreSnapshot, error) {
+	snapshots := []*entity.ScoreSnapshot{}
+	for _, snapshot := range m.snapshots {
+		if snapshot.ResourceGroup.Hash() == resourceGroup.Hash() &&
+			!snapshot.Timestamp.Time.Before(from) && !snapshot.Timestamp.Time.After(to) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// ListResourceGroupRules returns no resource group rules.
+func (m *mockResourceGroupRuleStorage) ListResourceGroupRules(ctx context.Context) ([]*entity.ResourceGroupRule, error) {
+	return []*entity.ResourceGroupRule{}, nil
+}
+
 // Search implements the search operation returning a single mock resource.
 func (m *mockSearchStorage) Search(ctx context.Context, queryString, patternType string, pagination *storage.Pagination) (*storage.SearchResult, error) {
 	return &storage.SearchResult{
@@ -128,6 +214,29 @@ func (m *mockSearchStorage) Search(ctx context.Context, queryString, patternType
 	}, nil
 }
 
+// SearchByTerms returns the same mock resource as Search.
+func (m *mockSearchStorage) SearchByTerms(ctx context.Context, keysAndValues map[string]any, pagination *storage.Pagination) (*storage.SearchResult, error) {
+	return m.Search(ctx, "", storage.SQLPatternType, pagination)
+}
+
+// ScanByTerms calls fn with the same mock resource as Search.
+func (m *mockSearchStorage) ScanByTerms(ctx context.Context, keysAndValues map[string]any, pageSize int, fn func(resources []*storage.Resource) error) error {
+	res, err := m.Search(ctx, "", storage.SQLPatternType, nil)
+	if err != nil {
+		return err
+	}
+	return fn(res.Resources)
+}
+
+// AggregateByTerms returns a single bucket for the namespace of the mock
+// resource.
+func (m *mockSearchStorage) AggregateByTerms(ctx context.Context, keys []string) (*storage.AggregateResults, error) {
+	return &storage.AggregateResults{
+		Buckets: []storage.Bucket{{Keys: []string{"existing-cluster", "default"}, Count: 1}},
+		Total:   1,
+	}, nil
+}
+
 // newMockConfigmap creates a mock Unstructured object representing a ConfigMap resource.
 func newMockConfigmap(namespace, name string) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
@@ -0,0 +1,215 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Periods of the points in a score trend.
+const (
+	TrendIntervalDay  = "day"
+	TrendIntervalWeek = "week"
+)
+
+// ScoreTrend returns the score of the resource group for each day or week
+// between from and to, based on the scores recorded by the background audit.
+// Periods without any recorded score are omitted.
+func (i *InsightManager) ScoreTrend(ctx context.Context, resourceGroup entity.ResourceGroup, interval string, from, to time.Time) ([]*ScoreTrendPoint, error) {
+	if interval != TrendIntervalDay && interval != TrendIntervalWeek {
+		return nil, fmt.Errorf("unsupported trend interval: %s", interval)
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("the start time must be before the end time")
+	}
+	// The score snapshots cover the whole resource group, so they are only
+	// served to users granted access to all of its resources.
+	if err := authz.Authorize(ctx, resourceGroup); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := i.audit.ListScoreSnapshots(ctx, resourceGroup, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshots are ordered by time, keep the last one of each period.
+	points := []*ScoreTrendPoint{}
+	for _, snapshot := range snapshots {
+		if snapshot.Timestamp == nil {
+			continue
+		}
+		start := periodStart(snapshot.Timestamp.Time, interval)
+		point := &ScoreTrendPoint{
+			Timestamp: metav1.NewTime(start),
+			ScoreData: ScoreData{
+				Score:             snapshot.Score,
+				ResourceTotal:     snapshot.ResourceTotal,
+				IssuesTotal:       snapshot.IssuesTotal,
+				SuppressedTotal:   snapshot.SuppressedTotal,
+				SeverityStatistic: snapshot.SeverityStatistic,
+			},
+		}
+		if n := len(points); n > 0 && points[n-1].Timestamp.Equal(&point.Timestamp) {
+			points[n-1] = point
+		} else {
+			points = append(points, point)
+		}
+	}
+
+	return points, nil
+}
+
+// IssueHistory returns the issues of the resource group which are introduced
+// or resolved between from and to, except the ones suppressed by audit
+// exceptions.
+func (i *InsightManager) IssueHistory(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) (*IssueHistory, error) {
+	if from.After(to) {
+		return nil, fmt.Errorf("the start time must be before the end time")
+	}
+
+	introduced, err := i.audit.ListAuditIssuesIntroduced(ctx, resourceGroup, from, to)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := i.audit.ListAuditIssuesResolved(ctx, resourceGroup, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := authz.FilterFrom(ctx)
+	history := &IssueHistory{
+		Introduced: allowedIssues(introduced, filter),
+		Resolved:   allowedIssues(resolved, filter),
+	}
+	if i.suppressor == nil {
+		return history, nil
+	}
+
+	// The issues covered by an active audit exception are left out, as they
+	// are not counted in the audit and score either.
+	if history.Introduced, err = i.suppressor.Unsuppressed(ctx, history.Introduced); err != nil {
+		return nil, err
+	}
+	if history.Resolved, err = i.suppressor.Unsuppressed(ctx, history.Resolved); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// allowedIssues returns the audit issues on the resources allowed by the
+// data access filter.
+func allowedIssues(issues []*entity.AuditIssue, filter *authz.Filter) []*entity.AuditIssue {
+	if filter == nil {
+		return issues
+	}
+	allowed := make([]*entity.AuditIssue, 0, len(issues))
+	for _, issue := range issues {
+		if filter.Allows(issue.Resource) {
+			allowed = append(allowed, issue)
+		}
+	}
+	return allowed
+}
+
+// recordScores calculates the scores of the given resource groups from the
+// audit storage and records them at the given time.
+func (i *InsightManager) recordScores(ctx context.Context, resourceGroups []entity.ResourceGroup, at time.Time) error {
+	log := ctxutil.GetLogger(ctx)
+
+	var errs []error
+	for _, rg := range resourceGroups {
+		score, err := i.Score(ctx, rg, false)
+		if err == nil {
+			err = i.audit.SaveScoreSnapshot(ctx, &entity.ScoreSnapshot{
+				ResourceGroup:     rg,
+				Score:             score.Score,
+				ResourceTotal:     score.ResourceTotal,
+				IssuesTotal:       score.IssuesTotal,
+				SuppressedTotal:   score.SuppressedTotal,
+				SeverityStatistic: score.SeverityStatistic,
+				Timestamp:         &metav1.Time{Time: at},
+			})
+		}
+		if err != nil {
+			log.Error(err, "Failed to record score", "resourceGroup", rg)
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// scoreScopes returns the resource groups whose scores are recorded, which
+// are the given clusters and namespaces, and the resource groups of all
+// resource group rules.
+func (i *InsightManager) scoreScopes(ctx context.Context, scopes []entity.ResourceGroup) []entity.ResourceGroup {
+	log := ctxutil.GetLogger(ctx)
+
+	seen := map[entity.ResourceGroupHash]struct{}{}
+	result := []entity.ResourceGroup{}
+	add := func(rg entity.ResourceGroup) {
+		if _, ok := seen[rg.Hash()]; !ok {
+			seen[rg.Hash()] = struct{}{}
+			result = append(result, rg)
+		}
+	}
+
+	for _, scope := range scopes {
+		add(entity.ResourceGroup{Cluster: scope.Cluster})
+		// The cluster-scoped resources are already scored with the cluster.
+		if scope.Namespace != "" {
+			add(entity.ResourceGroup{Cluster: scope.Cluster, Namespace: scope.Namespace})
+		}
+	}
+
+	rules, err := i.resourceGroupRule.ListResourceGroupRules(ctx)
+	if err != nil {
+		log.Error(err, "Failed to list resource group rules, skip recording their scores")
+		return result
+	}
+	for _, rule := range rules {
+		groups, err := i.resourceGroupRule.ListResourceGroupsBy(ctx, rule.Name)
+		if err != nil {
+			log.Error(err, "Failed to list resource groups, skip recording their scores", "rule", rule.Name)
+			continue
+		}
+		for _, rg := range groups.Groups {
+			add(*rg)
+		}
+	}
+
+	return result
+}
+
+// periodStart returns the start of the day or the week (starting on Monday)
+// of the given time in UTC.
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == TrendIntervalWeek {
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+	return day
+}
@@ -0,0 +1,140 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/exception"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+func TestInsightManager_ScoreTrend(t *testing.T) {
+	rg := entity.ResourceGroup{Cluster: "existing-cluster"}
+	snapshot := func(ts string, score float64) *entity.ScoreSnapshot {
+		tm, err := time.Parse(time.RFC3339, ts)
+		require.NoError(t, err)
+		return &entity.ScoreSnapshot{ResourceGroup: rg, Score: score, Timestamp: &metav1.Time{Time: tm}}
+	}
+	auditStorage := &mockAuditStorage{
+		snapshots: []*entity.ScoreSnapshot{
+			snapshot("2024-06-03T01:00:00Z", 50), // Monday
+			snapshot("2024-06-03T13:00:00Z", 60),
+			snapshot("2024-06-05T01:00:00Z", 70),
+			snapshot("2024-06-10T01:00:00Z", 80), // Next Monday
+		},
+	}
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, auditStorage, &genericapiserver.CompletedConfig{})
+	require.NoError(t, err)
+
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		interval    string
+		from, to    time.Time
+		expectDays  []string
+		expectScore []float64
+		expectError bool
+	}{
+		{
+			name:        "daily trend keeps the last score of each day",
+			interval:    TrendIntervalDay,
+			from:        from,
+			to:          to,
+			expectDays:  []string{"2024-06-03", "2024-06-05", "2024-06-10"},
+			expectScore: []float64{60, 70, 80},
+		},
+		{
+			name:        "weekly trend starts on Monday",
+			interval:    TrendIntervalWeek,
+			from:        from,
+			to:          to,
+			expectDays:  []string{"2024-06-03", "2024-06-10"},
+			expectScore: []float64{70, 80},
+		},
+		{
+			name:        "unsupported interval",
+			interval:    "month",
+			from:        from,
+			to:          to,
+			expectError: true,
+		},
+		{
+			name:        "invalid range",
+			interval:    TrendIntervalDay,
+			from:        to,
+			to:          from,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := manager.ScoreTrend(context.Background(), rg, tt.interval, tt.from, tt.to)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, points, len(tt.expectDays))
+			for i, p := range points {
+				require.Equal(t, tt.expectDays[i], p.Timestamp.UTC().Format(time.DateOnly))
+				require.Equal(t, tt.expectScore[i], p.Score)
+			}
+		})
+	}
+}
+
+func TestInsightManager_RecordScores(t *testing.T) {
+	auditStorage := &mockAuditStorage{}
+	manager, err := NewInsightManager(&mockSearchStorage{}, &mockResourceStorage{}, &mockResourceGroupRuleStorage{}, auditStorage, &genericapiserver.CompletedConfig{})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.AuditAll(context.Background()))
+
+	// Scores are recorded for the cluster and the namespace.
+	require.Len(t, auditStorage.snapshots, 2)
+	require.Equal(t, entity.ResourceGroup{Cluster: "existing-cluster"}, auditStorage.snapshots[0].ResourceGroup)
+	require.Equal(t, entity.ResourceGroup{Cluster: "existing-cluster", Namespace: "default"}, auditStorage.snapshots[1].ResourceGroup)
+	require.Equal(t, 9, auditStorage.snapshots[0].IssuesTotal)
+
+	history, err := manager.IssueHistory(context.Background(), entity.ResourceGroup{Cluster: "existing-cluster"}, time.Now().Add(-time.Hour), time.Now())
+	require.NoError(t, err)
+	require.Len(t, history.Introduced, 9)
+	require.Empty(t, history.Resolved)
+
+	// The issues suppressed by audit exceptions are left out.
+	manager.SetExceptionSource(exception.StaticSource{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-namespace"},
+			Spec: searchv1beta1.AuditExceptionSpec{
+				Match:         searchv1beta1.ScanPolicyMatch{Namespaces: []string{"default"}},
+				Justification: "accepted risk",
+				Owner:         "platform-team",
+			},
+		},
+	})
+	history, err = manager.IssueHistory(context.Background(), entity.ResourceGroup{Cluster: "existing-cluster"}, time.Now().Add(-time.Hour), time.Now())
+	require.NoError(t, err)
+	require.Empty(t, history.Introduced)
+}
@@ -107,15 +107,36 @@ type ScoreData struct {
 
 	// IssuesTotal is the total count of all issues found during the audit.
 	// This count can be used to understand the overall number of problems
-	// that need to be addressed.
+	// that need to be addressed. Suppressed issues are not included.
 	IssuesTotal int `json:"issuesTotal"`
 
+	// SuppressedTotal is the count of issues suppressed by audit exceptions,
+	// which are excluded from the score and the severity statistic.
+	SuppressedTotal int `json:"suppressedTotal"`
+
 	// SeverityStatistic is a mapping of severity levels to their respective
 	// number of occurrences. It allows for a quick overview of the distribution
 	// of issues across different severity categories.
 	SeverityStatistic map[string]int `json:"severityStatistic"`
 }
 
+// ScoreTrendPoint is the score of a resource group in a period of time, taken
+// from the last score recorded in the period.
+type ScoreTrendPoint struct {
+	// Timestamp is the start of the period.
+	Timestamp metav1.Time `json:"timestamp"`
+	ScoreData `json:",inline"`
+}
+
+// IssueHistory contains the issues introduced and resolved in a period of
+// time.
+type IssueHistory struct {
+	// Introduced is the list of issues found for the first time in the period.
+	Introduced []*entity.AuditIssue `json:"introduced"`
+	// Resolved is the list of issues no longer found in the period.
+	Resolved []*entity.AuditIssue `json:"resolved"`
+}
+
 // GVK-related
 type GVKSummary struct {
 	Cluster string `json:"cluster"`
@@ -0,0 +1,328 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// podResyncInterval is the interval the pods of the target are resolved
+	// again while following, so that the new replicas and the restarted
+	// containers are followed.
+	podResyncInterval = 5 * time.Second
+	// maxStreams is the maximum number of containers streamed at once.
+	maxStreams = 100
+	// maxLineSize is the maximum size of a log line, longer lines end the
+	// stream of the container.
+	maxLineSize = 1024 * 1024
+)
+
+// Aggregator streams and interleaves the logs of the containers of multiple
+// pods, which are selected by a workload, a label selector or a resource
+// group.
+type Aggregator struct {
+	search  storage.SearchStorage
+	clients ClientGetter
+}
+
+// NewAggregator returns a new Aggregator which resolves the pods selected by
+// resource groups in the search storage, and reads the logs through the
+// clients of the clusters.
+func NewAggregator(searchStorage storage.SearchStorage, clients ClientGetter) *Aggregator {
+	return &Aggregator{
+		search:  searchStorage,
+		clients: clients,
+	}
+}
+
+// Stream streams the logs of the pods selected by the target into the
+// channel, the lines of the containers are interleaved as they arrive. It
+// returns when all logs are streamed, or when the context is done if
+// following. The channel is closed when Stream returns.
+func (a *Aggregator) Stream(ctx context.Context, target *Target, opts *Options, out chan<- *Line) error {
+	defer close(out)
+
+	if err := target.Validate(); err != nil {
+		return err
+	}
+
+	s := &session{
+		aggregator: a,
+		opts:       opts,
+		out:        out,
+		clients:    map[string]kubernetes.Interface{},
+		active:     map[string]bool{},
+		streamed:   map[string]bool{},
+		lastSeen:   map[string]time.Time{},
+		lastError:  map[string]string{},
+	}
+
+	pods, err := a.resolve(ctx, s, target)
+	if err != nil {
+		return err
+	}
+	s.start(ctx, pods)
+
+	if opts.Follow {
+		ticker := time.NewTicker(podResyncInterval)
+		defer ticker.Stop()
+	loop:
+		for {
+			select {
+			case <-ctx.Done():
+				break loop
+			case <-ticker.C:
+			}
+
+			pods, err := a.resolve(ctx, s, target)
+			if err != nil {
+				s.sendError(ctx, &podRef{Cluster: target.Cluster, Namespace: target.Namespace}, "", err)
+				continue
+			}
+			s.start(ctx, pods)
+		}
+	}
+
+	s.wg.Wait()
+	return nil
+}
+
+// podRef locates a pod whose logs are streamed.
+type podRef struct {
+	Cluster    string
+	Namespace  string
+	Name       string
+	Containers []string
+	// Finished pods have no more logs once streamed.
+	Finished bool
+}
+
+// session is the state of streaming the logs of a target.
+type session struct {
+	aggregator *Aggregator
+	opts       *Options
+	out        chan<- *Line
+	wg         sync.WaitGroup
+
+	lock    sync.Mutex
+	clients map[string]kubernetes.Interface
+	// active are the containers being streamed, streamed are the containers
+	// which have been streamed, by their keys.
+	active   map[string]bool
+	streamed map[string]bool
+	// lastSeen is the timestamp of the last line of the containers, from
+	// which the logs are streamed again after the containers restart.
+	lastSeen map[string]time.Time
+	// lastError is the last error of streaming the containers, the same
+	// error is only sent once.
+	lastError map[string]string
+	// limited reports whether some containers are not streamed because of
+	// the limit of streams.
+	limited bool
+}
+
+// client returns the client of the cluster.
+func (s *session) client(ctx context.Context, cluster string) (kubernetes.Interface, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if client, ok := s.clients[cluster]; ok {
+		return client, nil
+	}
+	client, err := s.aggregator.clients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[cluster] = client
+	return client, nil
+}
+
+// start starts streaming the containers of the pods which are not being
+// streamed.
+func (s *session) start(ctx context.Context, pods []*podRef) {
+	for _, pod := range pods {
+		for _, container := range pod.Containers {
+			if s.opts.Container != "" && container != s.opts.Container {
+				continue
+			}
+
+			key := strings.Join([]string{pod.Cluster, pod.Namespace, pod.Name, container}, "/")
+			s.lock.Lock()
+			if s.active[key] || (pod.Finished && s.streamed[key]) {
+				s.lock.Unlock()
+				continue
+			}
+			if len(s.active) >= maxStreams {
+				limited := s.limited
+				s.limited = true
+				s.lock.Unlock()
+				if !limited {
+					s.sendError(ctx, pod, container, fmt.Errorf("only the logs of %d containers are streamed at once", maxStreams))
+				}
+				continue
+			}
+			s.active[key] = true
+			s.streamed[key] = true
+			s.lock.Unlock()
+
+			s.wg.Add(1)
+			go func(pod *podRef, container, key string) {
+				defer s.wg.Done()
+				s.stream(ctx, pod, container, key)
+
+				s.lock.Lock()
+				delete(s.active, key)
+				s.lock.Unlock()
+			}(pod, container, key)
+		}
+	}
+}
+
+// stream streams the logs of a container until it ends.
+func (s *session) stream(ctx context.Context, pod *podRef, container, key string) {
+	client, err := s.client(ctx, pod.Cluster)
+	if err != nil {
+		s.sendError(ctx, pod, container, err)
+		return
+	}
+
+	logOpts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     s.opts.Follow,
+		Timestamps: true,
+	}
+	s.lock.Lock()
+	lastSeen, resumed := s.lastSeen[key]
+	s.lock.Unlock()
+	if resumed {
+		// Resume from the last line streamed before the container restarted
+		// or the stream broke, the lines already streamed are skipped below.
+		logOpts.SinceTime = &metav1.Time{Time: lastSeen}
+	} else {
+		logOpts.TailLines = s.opts.TailLines
+		logOpts.SinceSeconds = s.opts.SinceSeconds
+	}
+
+	stream, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOpts).Stream(ctx)
+	if err != nil {
+		s.sendError(ctx, pod, container, err)
+		return
+	}
+	defer stream.Close()
+
+	s.lock.Lock()
+	delete(s.lastError, key)
+	s.lock.Unlock()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		timestamp, content := splitTimestamp(scanner.Text())
+		if !timestamp.IsZero() {
+			if resumed && !timestamp.After(lastSeen) {
+				continue
+			}
+			s.lock.Lock()
+			s.lastSeen[key] = timestamp
+			s.lock.Unlock()
+		}
+		if s.opts.Filter != nil && !s.opts.Filter.MatchString(content) {
+			continue
+		}
+
+		line := newLine(pod, container)
+		if !timestamp.IsZero() {
+			line.Timestamp = timestamp.Format(time.RFC3339Nano)
+		}
+		line.Content = content
+		if !s.send(ctx, line) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		s.sendError(ctx, pod, container, err)
+	}
+}
+
+// send sends the line unless the context is done.
+func (s *session) send(ctx context.Context, line *Line) bool {
+	select {
+	case s.out <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sendError sends the error of streaming the container, unless it is the
+// same as the last error of the container.
+func (s *session) sendError(ctx context.Context, pod *podRef, container string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	key := strings.Join([]string{pod.Cluster, pod.Namespace, pod.Name, container}, "/")
+	s.lock.Lock()
+	if s.lastError[key] == err.Error() {
+		s.lock.Unlock()
+		return
+	}
+	s.lastError[key] = err.Error()
+	s.lock.Unlock()
+
+	line := newLine(pod, container)
+	line.Error = err.Error()
+	s.send(ctx, line)
+}
+
+// newLine returns a line of the container of the pod.
+func newLine(pod *podRef, container string) *Line {
+	prefix := pod.Name
+	if container != "" {
+		prefix += "/" + container
+	}
+	return &Line{
+		Cluster:   pod.Cluster,
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Prefix:    prefix,
+	}
+}
+
+// splitTimestamp splits the timestamp added by the kubelet from the log
+// line, the timestamp is zero if the line has none.
+func splitTimestamp(line string) (time.Time, string) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, line
+	}
+	t, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, line
+	}
+	return t, parts[1]
+}
@@ -0,0 +1,259 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// mockSearchStorage returns the pods which are not deleted as the search
+// results.
+type mockSearchStorage struct {
+	storage.SearchStorage
+	resources []*storage.Resource
+	terms     map[string]any
+}
+
+func (m *mockSearchStorage) ScanByTerms(_ context.Context, keysAndValues map[string]any, _ int, fn func(resources []*storage.Resource) error) error {
+	m.terms = keysAndValues
+	var resources []*storage.Resource
+	for _, r := range m.resources {
+		if !r.Deleted {
+			resources = append(resources, r)
+		}
+	}
+	return fn(resources)
+}
+
+func newPod(name string, labels map[string]string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func collect(t *testing.T, a *Aggregator, target *Target, opts *Options) ([]*Line, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out := make(chan *Line)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- a.Stream(ctx, target, opts, out)
+	}()
+
+	var lines []*Line
+	for line := range out {
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].Prefix < lines[j].Prefix
+	})
+	return lines, <-errCh
+}
+
+func TestAggregatorStream(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		},
+		newPod("web-1", map[string]string{"app": "web"}, "app", "sidecar"),
+		newPod("web-2", map[string]string{"app": "web"}, "app"),
+		newPod("db-1", map[string]string{"app": "db"}, "db"),
+	)
+	clients := func(_ context.Context, cluster string) (kubernetes.Interface, error) {
+		require.Equal(t, "cluster1", cluster)
+		return client, nil
+	}
+
+	tests := []struct {
+		name    string
+		target  *Target
+		opts    *Options
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "pods of deployment",
+			target: &Target{Cluster: "cluster1", Namespace: "default", Kind: "Deployment", Name: "web"},
+			opts:   &Options{},
+			want:   []string{"web-1/app", "web-1/sidecar", "web-2/app"},
+		},
+		{
+			name:   "container of pods selected by labels",
+			target: &Target{Cluster: "cluster1", Namespace: "default", Selector: "app=web"},
+			opts:   &Options{Container: "app"},
+			want:   []string{"web-1/app", "web-2/app"},
+		},
+		{
+			name:   "single pod",
+			target: &Target{Cluster: "cluster1", Namespace: "default", Kind: "Pod", Name: "db-1"},
+			opts:   &Options{},
+			want:   []string{"db-1/db"},
+		},
+		{
+			name:   "lines not matching the filter",
+			target: &Target{Cluster: "cluster1", Namespace: "default", Selector: "app=web"},
+			opts:   &Options{Filter: regexp.MustCompile("error")},
+			want:   nil,
+		},
+		{
+			name:    "unsupported workload",
+			target:  &Target{Cluster: "cluster1", Namespace: "default", Kind: "CronJob", Name: "web"},
+			opts:    &Options{},
+			wantErr: ErrUnsupportedWorkload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := collect(t, NewAggregator(nil, clients), tt.target, tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, line := range lines {
+				require.Empty(t, line.Error)
+				require.Equal(t, "cluster1", line.Cluster)
+				require.Equal(t, "fake logs", line.Content)
+				got = append(got, line.Prefix)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAggregatorStreamResourceGroup(t *testing.T) {
+	pod := func(name string, deleted bool) *storage.Resource {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newPod(name, nil, "app"))
+		require.NoError(t, err)
+		return &storage.Resource{
+			ResourceGroup: entity.ResourceGroup{Cluster: "cluster1", Kind: "Pod", Namespace: "default", Name: name},
+			Object:        obj,
+			Deleted:       deleted,
+		}
+	}
+	searchStorage := &mockSearchStorage{resources: []*storage.Resource{pod("web-1", false), pod("web-0", true)}}
+	clients := func(_ context.Context, _ string) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+
+	lines, err := collect(t, NewAggregator(searchStorage, clients), &Target{
+		ResourceGroup: &entity.ResourceGroup{Namespace: "default", Labels: map[string]string{"app": "web"}},
+	}, &Options{})
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, "web-1/app", lines[0].Prefix)
+	require.Equal(t, "Pod", searchStorage.terms["kind"])
+}
+
+func TestTargetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  *Target
+		wantErr error
+	}{
+		{
+			name:   "workload",
+			target: &Target{Cluster: "cluster1", Namespace: "default", Kind: "statefulset", Name: "db"},
+		},
+		{
+			name:   "resource group",
+			target: &Target{ResourceGroup: &entity.ResourceGroup{Cluster: "cluster1", Kind: "Pod"}},
+		},
+		{
+			name:    "no target",
+			target:  &Target{Cluster: "cluster1", Namespace: "default"},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "multiple targets",
+			target:  &Target{Cluster: "cluster1", Namespace: "default", Kind: "Deployment", Name: "web", Selector: "app=web"},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "workload without name",
+			target:  &Target{Cluster: "cluster1", Namespace: "default", Kind: "Deployment"},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "invalid selector",
+			target:  &Target{Cluster: "cluster1", Namespace: "default", Selector: "app in ("},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:   "resource group of labels",
+			target: &Target{ResourceGroup: &entity.ResourceGroup{Labels: map[string]string{"app": "web"}}},
+		},
+		{
+			name:    "resource group of everything",
+			target:  &Target{ResourceGroup: &entity.ResourceGroup{Namespace: "default"}},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "resource group of other kinds",
+			target:  &Target{ResourceGroup: &entity.ResourceGroup{Kind: "Deployment"}},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "no namespace",
+			target:  &Target{Cluster: "cluster1", Selector: "app=web"},
+			wantErr: ErrInvalidTarget,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitTimestamp(t *testing.T) {
+	ts, content := splitTimestamp("2024-05-01T10:00:00.123456789Z hello world")
+	require.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC), ts)
+	require.Equal(t, "hello world", content)
+
+	ts, content = splitTimestamp("hello world")
+	require.True(t, ts.IsZero())
+	require.Equal(t, "hello world", content)
+}
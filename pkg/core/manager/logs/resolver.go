@@ -0,0 +1,157 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// resolvePageSize is the page size the pods of resource groups are searched
+// with.
+const resolvePageSize = 100
+
+// selectorGetter returns the pod selector of a workload.
+type selectorGetter func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error)
+
+// workloadKinds are the kinds of the workloads whose pods are streamed, by
+// their lowercase kinds. The selector of Pod is nil as the pod is streamed
+// itself.
+var workloadKinds = map[string]selectorGetter{
+	"pod": nil,
+	"deployment": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error) {
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	},
+	"statefulset": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error) {
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	},
+	"daemonset": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error) {
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	},
+	"replicaset": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error) {
+		obj, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	},
+	"job": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (*metav1.LabelSelector, error) {
+		obj, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Selector, nil
+	},
+}
+
+// resolve returns the pods selected by the target.
+func (a *Aggregator) resolve(ctx context.Context, s *session, target *Target) ([]*podRef, error) {
+	if target.ResourceGroup != nil {
+		return a.resolveResourceGroup(ctx, target)
+	}
+
+	client, err := s.client(ctx, target.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := target.Selector
+	if target.Kind != "" {
+		getSelector := workloadKinds[strings.ToLower(target.Kind)]
+		if getSelector == nil {
+			pod, err := client.CoreV1().Pods(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return []*podRef{podRefFrom(target.Cluster, pod)}, nil
+		}
+
+		labelSelector, err := getSelector(ctx, client, target.Namespace, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selector of %s %s", target.Kind, target.Name)
+		}
+		selector = parsed.String()
+	}
+
+	pods, err := client.CoreV1().Pods(target.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*podRef, 0, len(pods.Items))
+	for i := range pods.Items {
+		refs = append(refs, podRefFrom(target.Cluster, &pods.Items[i]))
+	}
+	return refs, nil
+}
+
+// resolveResourceGroup returns the pods selected by the resource group of
+// the target in the search storage, the deleted pods are left out.
+func (a *Aggregator) resolveResourceGroup(ctx context.Context, target *Target) ([]*podRef, error) {
+	rg := *target.ResourceGroup
+	rg.Kind = "Pod"
+
+	var refs []*podRef
+	err := a.search.ScanByTerms(ctx, rg.ToTerms(), resolvePageSize, func(resources []*storage.Resource) error {
+		for _, r := range resources {
+			pod := &corev1.Pod{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(r.Object, pod); err != nil {
+				return errors.Wrapf(err, "failed to convert pod %s/%s", r.Namespace, r.Name)
+			}
+			refs = append(refs, podRefFrom(r.Cluster, pod))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// podRefFrom returns the reference of the pod in the cluster.
+func podRefFrom(cluster string, pod *corev1.Pod) *podRef {
+	ref := &podRef{
+		Cluster:   cluster,
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Finished:  pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed,
+	}
+	for _, container := range pod.Spec.Containers {
+		ref.Containers = append(ref.Containers, container.Name)
+	}
+	return ref
+}
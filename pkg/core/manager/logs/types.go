@@ -0,0 +1,120 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	ErrInvalidTarget       = errors.New("invalid log target")
+	ErrUnsupportedWorkload = errors.New("unsupported workload kind")
+)
+
+// ClientGetter returns the client of a cluster.
+type ClientGetter func(ctx context.Context, cluster string) (kubernetes.Interface, error)
+
+// Target selects the pods whose logs are aggregated. Exactly one of the
+// workload, the label selector and the resource group is set.
+type Target struct {
+	// Cluster and Namespace locate the workload or the pods selected by the
+	// label selector.
+	Cluster   string
+	Namespace string
+	// Kind and Name select the pods of a workload, such as a Deployment, or
+	// a single Pod.
+	Kind string
+	Name string
+	// Selector selects the pods by labels.
+	Selector string
+	// ResourceGroup selects the pods in the search index, possibly across
+	// clusters. It must set the cluster or labels, so that it doesn't
+	// select all pods.
+	ResourceGroup *entity.ResourceGroup
+}
+
+// Validate checks if the target is valid.
+func (t *Target) Validate() error {
+	targets := 0
+	if t.Kind != "" || t.Name != "" {
+		if t.Kind == "" || t.Name == "" {
+			return fmt.Errorf("%w: kind and name of the workload are both required", ErrInvalidTarget)
+		}
+		if _, ok := workloadKinds[strings.ToLower(t.Kind)]; !ok {
+			return fmt.Errorf("%w %q, expected one of Pod, Deployment, StatefulSet, DaemonSet, ReplicaSet and Job", ErrUnsupportedWorkload, t.Kind)
+		}
+		targets++
+	}
+	if t.Selector != "" {
+		if _, err := labels.Parse(t.Selector); err != nil {
+			return fmt.Errorf("%w: invalid label selector: %v", ErrInvalidTarget, err)
+		}
+		targets++
+	}
+	if t.ResourceGroup != nil {
+		if t.ResourceGroup.Kind != "" && !strings.EqualFold(t.ResourceGroup.Kind, "Pod") {
+			return fmt.Errorf("%w: resource group must select pods", ErrInvalidTarget)
+		}
+		if t.ResourceGroup.Cluster == "" && len(t.ResourceGroup.Labels) == 0 {
+			return fmt.Errorf("%w: resource group must select a cluster or labels", ErrInvalidTarget)
+		}
+		targets++
+	}
+	if targets != 1 {
+		return fmt.Errorf("%w: exactly one of workload, label selector and resource group is required", ErrInvalidTarget)
+	}
+	if t.ResourceGroup == nil && (t.Cluster == "" || t.Namespace == "") {
+		return fmt.Errorf("%w: cluster and namespace are required", ErrInvalidTarget)
+	}
+	return nil
+}
+
+// Options configures the streamed logs.
+type Options struct {
+	// Container only streams the container of the name, all containers of
+	// the pods are streamed if it is empty.
+	Container string
+	// TailLines and SinceSeconds limit the logs streamed from every
+	// container when it is first streamed.
+	TailLines    *int64
+	SinceSeconds *int64
+	// Filter only streams the lines which match it.
+	Filter *regexp.Regexp
+	// Follow keeps streaming the new logs, the new pods and the restarted
+	// containers until the context is done.
+	Follow bool
+}
+
+// Line is a log line of a container, or an error of streaming it.
+type Line struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// Prefix identifies the container the line is from, in the form of
+	// pod/container.
+	Prefix    string `json:"prefix"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
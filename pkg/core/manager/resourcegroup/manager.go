@@ -55,6 +55,9 @@ func (m *ResourceGroupManager) CreateResourceGroupRule(ctx context.Context, rgr
 	if len(rgr.Name) == 0 {
 		return ErrMissingResourceGroupRuleName
 	}
+	if err := validateResourceGroupRule(rgr); err != nil {
+		return err
+	}
 
 	// Check if the rule already exists to prevent duplicates.
 	_, err := m.GetResourceGroupRule(ctx, rgr.Name)
@@ -79,6 +82,9 @@ func (m *ResourceGroupManager) UpdateResourceGroupRule(ctx context.Context, name
 	if name != rgr.Name {
 		return ErrResourceGroupRuleNameCannotModify
 	}
+	if err := validateResourceGroupRule(rgr); err != nil {
+		return err
+	}
 
 	// Get the existing rule.
 	existingRGR, err := m.GetResourceGroupRule(ctx, name)
@@ -95,6 +101,8 @@ func (m *ResourceGroupManager) UpdateResourceGroupRule(ctx context.Context, name
 		Name:        existingRGR.Name,
 		Description: rgr.Description,
 		Fields:      rgr.Fields,
+		Filter:      rgr.Filter,
+		Metadata:    rgr.Metadata,
 		CreatedAt:   existingRGR.CreatedAt,
 		UpdatedAt:   rgr.UpdatedAt,
 		DeletedAt:   nil,
@@ -103,6 +111,16 @@ func (m *ResourceGroupManager) UpdateResourceGroupRule(ctx context.Context, name
 	return m.rgrStorage.SaveResourceGroupRule(ctx, newRGR)
 }
 
+// validateResourceGroupRule checks the resource group rule and that the
+// query of its filter can be converted.
+func validateResourceGroupRule(rgr *entity.ResourceGroupRule) error {
+	if err := rgr.Validate(); err != nil {
+		return err
+	}
+	_, err := elasticsearch.ResourceGroupFilterQuery(rgr.Filter)
+	return err
+}
+
 // DeleteResourceGroupRule deletes a resource group rule by name.
 func (m *ResourceGroupManager) DeleteResourceGroupRule(ctx context.Context, name string) error {
 	if len(name) == 0 {
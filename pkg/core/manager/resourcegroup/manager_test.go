@@ -16,6 +16,7 @@ package resourcegroup
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -28,12 +29,15 @@ import (
 
 // mockResourceGroupRuleStorage is a mock implementation of storage.ResourceGroupRuleStorage
 type mockResourceGroupRuleStorage struct {
-	rules map[string]*entity.ResourceGroupRule
+	rules     map[string]*entity.ResourceGroupRule
+	members   []entity.ResourceGroup
+	snapshots map[string]*entity.ResourceGroupSnapshot
 }
 
 func newMockResourceGroupRuleStorage() *mockResourceGroupRuleStorage {
 	return &mockResourceGroupRuleStorage{
-		rules: make(map[string]*entity.ResourceGroupRule),
+		rules:     make(map[string]*entity.ResourceGroupRule),
+		snapshots: make(map[string]*entity.ResourceGroupSnapshot),
 	}
 }
 
@@ -82,6 +86,45 @@ func (m *mockResourceGroupRuleStorage) ListResourceGroupsBy(ctx context.Context,
 	}, nil
 }
 
+func (m *mockResourceGroupRuleStorage) ScanResourceGroupMembers(ctx context.Context, rule *entity.ResourceGroupRule, resourceGroup *entity.ResourceGroup, fn func(members []entity.ResourceGroup) error) error {
+	return fn(append([]entity.ResourceGroup{}, m.members...))
+}
+
+func (m *mockResourceGroupRuleStorage) SaveResourceGroupSnapshot(ctx context.Context, snapshot *entity.ResourceGroupSnapshot) error {
+	if snapshot.ID == "" {
+		snapshot.ID = fmt.Sprintf("snapshot-%d", len(m.snapshots)+1)
+	}
+	m.snapshots[snapshot.ID] = snapshot
+	return nil
+}
+
+func (m *mockResourceGroupRuleStorage) GetResourceGroupSnapshot(ctx context.Context, id string) (*entity.ResourceGroupSnapshot, error) {
+	if snapshot, exists := m.snapshots[id]; exists {
+		return snapshot, nil
+	}
+	return nil, elasticsearch.ErrResourceGroupSnapshotNotFound
+}
+
+func (m *mockResourceGroupRuleStorage) ListResourceGroupSnapshots(ctx context.Context, ruleName string, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ResourceGroupSnapshot, error) {
+	var snapshots []*entity.ResourceGroupSnapshot
+	for _, snapshot := range m.snapshots {
+		if snapshot.Rule == ruleName && snapshot.ResourceGroup.Hash() == resourceGroup.Hash() &&
+			!snapshot.Timestamp.Time.Before(from) && !snapshot.Timestamp.Time.After(to) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+func (m *mockResourceGroupRuleStorage) DeleteResourceGroupSnapshotsBefore(ctx context.Context, before time.Time) error {
+	for id, snapshot := range m.snapshots {
+		if snapshot.Timestamp.Time.Before(before) {
+			delete(m.snapshots, id)
+		}
+	}
+	return nil
+}
+
 func TestNewResourceGroupManager(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -247,6 +290,37 @@ func TestResourceGroupManager_CreateResourceGroupRule(t *testing.T) {
 			rule:        testRule,
 			expectError: true,
 		},
+		{
+			name: "Success with filter and metadata",
+			rule: &entity.ResourceGroupRule{
+				Name:   "app-rule",
+				Fields: []string{"labels.app"},
+				Filter: &entity.ResourceGroupFilter{
+					Terms: map[string][]string{"kind": {"Deployment"}},
+					Query: "cluster LIKE 'prod-%'",
+				},
+				Metadata: []entity.ResourceGroupMetadata{{Owners: []string{"sre"}}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid filter field",
+			rule: &entity.ResourceGroupRule{
+				Name:   "invalid-field-rule",
+				Fields: []string{"labels.app"},
+				Filter: &entity.ResourceGroupFilter{Terms: map[string][]string{"spec.replicas": {"1"}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid filter query",
+			rule: &entity.ResourceGroupRule{
+				Name:   "invalid-query-rule",
+				Fields: []string{"labels.app"},
+				Filter: &entity.ResourceGroupFilter{Query: "cluster ="},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
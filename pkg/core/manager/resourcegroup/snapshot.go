@@ -0,0 +1,300 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// maxSnapshotMembers is the maximum number of members recorded in a resource
+// group snapshot, which is saved as a single document.
+const maxSnapshotMembers = 10000
+
+// snapshotPurgeInterval is the interval the expired resource group snapshots
+// are purged.
+const snapshotPurgeInterval = time.Hour
+
+// RunSnapshotter records the membership of the resource groups of all
+// resource group rules periodically until the context is done.
+func (m *ResourceGroupManager) RunSnapshotter(ctx context.Context, interval time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting resource group snapshotter", "interval", interval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.SnapshotAll(ctx); err != nil {
+			log.Error(err, "Resource group snapshots finished with errors")
+		}
+	}, interval)
+}
+
+// RunSnapshotPurger deletes the resource group snapshots recorded longer
+// than the retention ago periodically until the context is done.
+func (m *ResourceGroupManager) RunSnapshotPurger(ctx context.Context, retention time.Duration) {
+	log := ctxutil.GetLogger(ctx)
+	log.Info("Starting resource group snapshot purger", "retention", retention)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		before := time.Now().Add(-retention)
+		if err := m.PurgeSnapshots(ctx, before); err != nil {
+			log.Error(err, "Failed to purge resource group snapshots", "before", before)
+		}
+	}, snapshotPurgeInterval)
+}
+
+// PurgeSnapshots deletes the resource group snapshots recorded before the
+// given time.
+func (m *ResourceGroupManager) PurgeSnapshots(ctx context.Context, before time.Time) error {
+	return m.rgrStorage.DeleteResourceGroupSnapshotsBefore(ctx, before)
+}
+
+// SnapshotAll records the membership of the resource groups of all resource
+// group rules at the same time.
+func (m *ResourceGroupManager) SnapshotAll(ctx context.Context) error {
+	log := ctxutil.GetLogger(ctx)
+	startedAt := time.Now().Truncate(time.Second)
+
+	rules, err := m.ListResourceGroupRules(ctx)
+	if err != nil {
+		if errors.Is(err, elasticsearch.ErrResourceGroupRuleNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	recorded := 0
+	for _, rule := range rules {
+		groups, err := m.rgrStorage.ListResourceGroupsBy(ctx, rule.Name)
+		if err != nil {
+			if !errors.Is(err, elasticsearch.ErrResourceGroupNotFound) {
+				log.Error(err, "Failed to list resource groups, skip recording their snapshots", "rule", rule.Name)
+				errs = append(errs, err)
+			}
+			continue
+		}
+		for _, rg := range groups.Groups {
+			if _, err := m.snapshot(ctx, rule, *rg, startedAt, true); err != nil {
+				log.Error(err, "Failed to record resource group snapshot", "rule", rule.Name, "resourceGroup", rg)
+				errs = append(errs, err)
+				continue
+			}
+			recorded++
+		}
+	}
+
+	log.Info("Finished resource group snapshots", "rules", len(rules), "snapshots", recorded, "failed", len(errs))
+	return utilerrors.NewAggregate(errs)
+}
+
+// SnapshotResourceGroup records the membership of the resource group of the
+// resource group rule now.
+func (m *ResourceGroupManager) SnapshotResourceGroup(ctx context.Context, ruleName string, resourceGroup entity.ResourceGroup) (*entity.ResourceGroupSnapshot, error) {
+	if err := authz.Authorize(ctx, resourceGroup); err != nil {
+		return nil, err
+	}
+	rule, err := m.GetResourceGroupRule(ctx, ruleName)
+	if err != nil {
+		return nil, err
+	}
+	return m.snapshot(ctx, rule, resourceGroup, time.Now().Truncate(time.Second), true)
+}
+
+// ListResourceGroupSnapshots lists the snapshots of the resource group of
+// the resource group rule recorded between from and to, ordered by time and
+// without their members.
+func (m *ResourceGroupManager) ListResourceGroupSnapshots(ctx context.Context, ruleName string, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ResourceGroupSnapshot, error) {
+	if len(ruleName) == 0 {
+		return nil, ErrMissingResourceGroupRuleName
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("the start time must be before the end time")
+	}
+	// The snapshots cover the whole resource group, so they are only served
+	// to users granted access to all of its resources.
+	if err := authz.Authorize(ctx, resourceGroup); err != nil {
+		return nil, err
+	}
+	// The snapshots are recorded without the filter, which is the one of the
+	// rule.
+	resourceGroup.Filter = nil
+	return m.rgrStorage.ListResourceGroupSnapshots(ctx, ruleName, resourceGroup, from, to)
+}
+
+// DiffResourceGroupSnapshots reports how the resource group changed between
+// two snapshots of it recorded with the resource group rule. The snapshot is
+// compared with the current membership of the resource group if toID is
+// empty.
+func (m *ResourceGroupManager) DiffResourceGroupSnapshots(ctx context.Context, ruleName, fromID, toID string) (*ResourceGroupChange, error) {
+	if len(fromID) == 0 {
+		return nil, ErrMissingResourceGroupSnapshotID
+	}
+	from, err := m.rgrStorage.GetResourceGroupSnapshot(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	if from.Rule != ruleName {
+		return nil, elasticsearch.ErrResourceGroupSnapshotNotFound
+	}
+	if err := authz.Authorize(ctx, from.ResourceGroup); err != nil {
+		return nil, err
+	}
+
+	var to *entity.ResourceGroupSnapshot
+	if len(toID) == 0 {
+		rule, err := m.GetResourceGroupRule(ctx, from.Rule)
+		if err != nil {
+			return nil, err
+		}
+		if to, err = m.snapshot(ctx, rule, from.ResourceGroup, time.Now().Truncate(time.Second), false); err != nil {
+			return nil, err
+		}
+	} else {
+		if to, err = m.rgrStorage.GetResourceGroupSnapshot(ctx, toID); err != nil {
+			return nil, err
+		}
+		if to.Rule != from.Rule || to.ResourceGroup.Hash() != from.ResourceGroup.Hash() {
+			return nil, ErrResourceGroupSnapshotsMismatched
+		}
+	}
+
+	return diffSnapshots(from, to), nil
+}
+
+// snapshot returns the membership of the resource group of the resource
+// group rule at the time, which is saved if requested.
+func (m *ResourceGroupManager) snapshot(ctx context.Context, rule *entity.ResourceGroupRule, resourceGroup entity.ResourceGroup, at time.Time, save bool) (*entity.ResourceGroupSnapshot, error) {
+	// The members are filtered by the rule, so the snapshot is identified by
+	// the rule and the group without the filter, no matter where the group
+	// comes from.
+	resourceGroup.Filter = nil
+	snapshot := &entity.ResourceGroupSnapshot{
+		Rule:          rule.Name,
+		ResourceGroup: resourceGroup,
+		Members:       []entity.ResourceGroup{},
+		CountByGVK:    map[string]int{},
+		Timestamp:     &metav1.Time{Time: at},
+	}
+	err := m.rgrStorage.ScanResourceGroupMembers(ctx, rule, &resourceGroup, func(members []entity.ResourceGroup) error {
+		for _, member := range members {
+			snapshot.ResourceTotal++
+			snapshot.CountByGVK[gvkOf(member)]++
+			if len(snapshot.Members) < maxSnapshotMembers {
+				snapshot.Members = append(snapshot.Members, member)
+			} else {
+				snapshot.MembersTruncated = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if save {
+		if err := m.rgrStorage.SaveResourceGroupSnapshot(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// diffSnapshots returns the resources added to and removed from the resource
+// group between the snapshots, unless their members are truncated, and the
+// changes of the counts by GVK.
+func diffSnapshots(from, to *entity.ResourceGroupSnapshot) *ResourceGroupChange {
+	change := &ResourceGroupChange{
+		From:             withoutMembers(from),
+		To:               withoutMembers(to),
+		Added:            []entity.ResourceGroup{},
+		Removed:          []entity.ResourceGroup{},
+		MembersTruncated: from.MembersTruncated || to.MembersTruncated,
+		CountDelta:       map[string]int{},
+	}
+	if !change.MembersTruncated {
+		before := make(map[entity.ResourceGroupHash]struct{}, len(from.Members))
+		for _, member := range from.Members {
+			before[member.Hash()] = struct{}{}
+		}
+		after := make(map[entity.ResourceGroupHash]struct{}, len(to.Members))
+		for _, member := range to.Members {
+			after[member.Hash()] = struct{}{}
+		}
+		for _, member := range to.Members {
+			if _, ok := before[member.Hash()]; !ok {
+				change.Added = append(change.Added, member)
+			}
+		}
+		for _, member := range from.Members {
+			if _, ok := after[member.Hash()]; !ok {
+				change.Removed = append(change.Removed, member)
+			}
+		}
+		sortMembers(change.Added)
+		sortMembers(change.Removed)
+	}
+
+	for gvk, count := range to.CountByGVK {
+		if delta := count - from.CountByGVK[gvk]; delta != 0 {
+			change.CountDelta[gvk] = delta
+		}
+	}
+	for gvk, count := range from.CountByGVK {
+		if _, ok := to.CountByGVK[gvk]; !ok {
+			change.CountDelta[gvk] = -count
+		}
+	}
+	return change
+}
+
+// withoutMembers returns a copy of the snapshot without its members.
+func withoutMembers(snapshot *entity.ResourceGroupSnapshot) *entity.ResourceGroupSnapshot {
+	out := *snapshot
+	out.Members = nil
+	return &out
+}
+
+// sortMembers sorts the resources by cluster, namespace, kind and name.
+func sortMembers(members []entity.ResourceGroup) {
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+}
+
+// gvkOf returns the GVK of the resource in the format of the counts of
+// resource group summaries, such as "Deployment.apps/v1".
+func gvkOf(member entity.ResourceGroup) string {
+	return fmt.Sprintf("%s.%s", member.Kind, member.APIVersion)
+}
@@ -0,0 +1,250 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newMember(namespace, kind, name string) entity.ResourceGroup {
+	return entity.ResourceGroup{
+		Cluster:    "cluster1",
+		APIVersion: "v1",
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	from := &entity.ResourceGroupSnapshot{
+		ID: "from",
+		Members: []entity.ResourceGroup{
+			newMember("default", "Pod", "a"),
+			newMember("default", "Pod", "b"),
+			newMember("default", "ConfigMap", "c"),
+		},
+		ResourceTotal: 3,
+		CountByGVK:    map[string]int{"Pod.v1": 2, "ConfigMap.v1": 1},
+	}
+	to := &entity.ResourceGroupSnapshot{
+		ID: "to",
+		Members: []entity.ResourceGroup{
+			newMember("default", "Pod", "b"),
+			newMember("default", "Pod", "d"),
+			newMember("default", "Service", "e"),
+		},
+		ResourceTotal: 3,
+		CountByGVK:    map[string]int{"Pod.v1": 2, "Service.v1": 1},
+	}
+
+	change := diffSnapshots(from, to)
+	require.Equal(t, []entity.ResourceGroup{
+		newMember("default", "Pod", "d"),
+		newMember("default", "Service", "e"),
+	}, change.Added)
+	require.Equal(t, []entity.ResourceGroup{
+		newMember("default", "ConfigMap", "c"),
+		newMember("default", "Pod", "a"),
+	}, change.Removed)
+	require.Equal(t, map[string]int{"ConfigMap.v1": -1, "Service.v1": 1}, change.CountDelta)
+	require.Equal(t, "from", change.From.ID)
+	require.Equal(t, "to", change.To.ID)
+	require.Nil(t, change.From.Members)
+	require.Nil(t, change.To.Members)
+	// The compared snapshots are left untouched.
+	require.Len(t, from.Members, 3)
+
+	unchanged := diffSnapshots(from, from)
+	require.Empty(t, unchanged.Added)
+	require.NotNil(t, unchanged.Added)
+	require.Empty(t, unchanged.Removed)
+	require.Empty(t, unchanged.CountDelta)
+}
+
+func TestDiffTruncatedSnapshots(t *testing.T) {
+	from := &entity.ResourceGroupSnapshot{
+		Members:       []entity.ResourceGroup{newMember("default", "Pod", "a")},
+		ResourceTotal: 1,
+		CountByGVK:    map[string]int{"Pod.v1": 1},
+	}
+	to := &entity.ResourceGroupSnapshot{
+		Members:          []entity.ResourceGroup{newMember("default", "Pod", "b")},
+		MembersTruncated: true,
+		ResourceTotal:    3,
+		CountByGVK:       map[string]int{"Pod.v1": 3},
+	}
+
+	// Only the count deltas are reported, as the changes beyond the
+	// recorded members are unknown.
+	change := diffSnapshots(from, to)
+	require.True(t, change.MembersTruncated)
+	require.Empty(t, change.Added)
+	require.Empty(t, change.Removed)
+	require.Equal(t, map[string]int{"Pod.v1": 2}, change.CountDelta)
+}
+
+func TestResourceGroupManager_SnapshotTruncatesMembers(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := newMockResourceGroupRuleStorage()
+	manager, err := NewResourceGroupManager(mockStorage)
+	require.NoError(t, err)
+	require.NoError(t, manager.CreateResourceGroupRule(ctx, &entity.ResourceGroupRule{
+		Name:   "namespace",
+		Fields: []string{"cluster", "namespace"},
+	}))
+
+	mockStorage.members = make([]entity.ResourceGroup, 0, maxSnapshotMembers+1)
+	for i := 0; i <= maxSnapshotMembers; i++ {
+		mockStorage.members = append(mockStorage.members, newMember("default", "Pod", fmt.Sprintf("pod-%d", i)))
+	}
+	snapshot, err := manager.SnapshotResourceGroup(ctx, "namespace", entity.ResourceGroup{Cluster: "cluster1", Namespace: "default"})
+	require.NoError(t, err)
+	require.True(t, snapshot.MembersTruncated)
+	require.Len(t, snapshot.Members, maxSnapshotMembers)
+	require.Equal(t, maxSnapshotMembers+1, snapshot.ResourceTotal)
+	require.Equal(t, map[string]int{"Pod.v1": maxSnapshotMembers + 1}, snapshot.CountByGVK)
+}
+
+func TestResourceGroupManager_Snapshots(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := newMockResourceGroupRuleStorage()
+	manager, err := NewResourceGroupManager(mockStorage)
+	require.NoError(t, err)
+
+	rule := &entity.ResourceGroupRule{
+		Name:   "namespace",
+		Fields: []string{"cluster", "namespace"},
+	}
+	require.NoError(t, manager.CreateResourceGroupRule(ctx, rule))
+	rg := entity.ResourceGroup{Cluster: "cluster1", Namespace: "default"}
+
+	mockStorage.members = []entity.ResourceGroup{
+		newMember("default", "Pod", "a"),
+		newMember("default", "Pod", "b"),
+	}
+	first, err := manager.SnapshotResourceGroup(ctx, "namespace", rg)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.ID)
+	require.Equal(t, 2, first.ResourceTotal)
+	require.Equal(t, map[string]int{"Pod.v1": 2}, first.CountByGVK)
+
+	_, err = manager.SnapshotResourceGroup(ctx, "non-existent", rg)
+	require.ErrorIs(t, err, elasticsearch.ErrResourceGroupRuleNotFound)
+
+	restricted := authz.WithFilter(ctx, &authz.Filter{Scopes: []authz.Scope{{Namespaces: []string{"other"}}}})
+	_, err = manager.SnapshotResourceGroup(restricted, "namespace", rg)
+	require.ErrorIs(t, err, authz.ErrForbidden)
+
+	// Compare with the current membership.
+	mockStorage.members = []entity.ResourceGroup{
+		newMember("default", "Pod", "b"),
+		newMember("default", "Pod", "c"),
+		newMember("default", "Service", "d"),
+	}
+	change, err := manager.DiffResourceGroupSnapshots(ctx, "namespace", first.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, []entity.ResourceGroup{newMember("default", "Pod", "c"), newMember("default", "Service", "d")}, change.Added)
+	require.Equal(t, []entity.ResourceGroup{newMember("default", "Pod", "a")}, change.Removed)
+	require.Equal(t, map[string]int{"Service.v1": 1}, change.CountDelta)
+	require.Empty(t, change.To.ID)
+	require.Len(t, mockStorage.snapshots, 1)
+
+	// Compare with a recorded snapshot.
+	second, err := manager.SnapshotResourceGroup(ctx, "namespace", rg)
+	require.NoError(t, err)
+	change, err = manager.DiffResourceGroupSnapshots(ctx, "namespace", first.ID, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, change.To.ID)
+	require.Len(t, change.Added, 2)
+
+	_, err = manager.DiffResourceGroupSnapshots(ctx, "namespace", "", second.ID)
+	require.ErrorIs(t, err, ErrMissingResourceGroupSnapshotID)
+	_, err = manager.DiffResourceGroupSnapshots(ctx, "namespace", "non-existent", second.ID)
+	require.ErrorIs(t, err, elasticsearch.ErrResourceGroupSnapshotNotFound)
+	_, err = manager.DiffResourceGroupSnapshots(ctx, "other-rule", first.ID, second.ID)
+	require.ErrorIs(t, err, elasticsearch.ErrResourceGroupSnapshotNotFound)
+	_, err = manager.DiffResourceGroupSnapshots(restricted, "namespace", first.ID, second.ID)
+	require.ErrorIs(t, err, authz.ErrForbidden)
+
+	other, err := manager.SnapshotResourceGroup(ctx, "namespace", entity.ResourceGroup{Cluster: "cluster1", Namespace: "other"})
+	require.NoError(t, err)
+	_, err = manager.DiffResourceGroupSnapshots(ctx, "namespace", first.ID, other.ID)
+	require.ErrorIs(t, err, ErrResourceGroupSnapshotsMismatched)
+
+	now := time.Now()
+	snapshots, err := manager.ListResourceGroupSnapshots(ctx, "namespace", rg, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	_, err = manager.ListResourceGroupSnapshots(ctx, "namespace", rg, now, now.Add(-time.Hour))
+	require.Error(t, err)
+	_, err = manager.ListResourceGroupSnapshots(ctx, "", rg, now.Add(-time.Hour), now)
+	require.ErrorIs(t, err, ErrMissingResourceGroupRuleName)
+}
+
+func TestResourceGroupManager_SnapshotAll(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := newMockResourceGroupRuleStorage()
+	manager, err := NewResourceGroupManager(mockStorage)
+	require.NoError(t, err)
+
+	// No rules is not an error.
+	require.NoError(t, manager.SnapshotAll(ctx))
+
+	for _, name := range []string{"rule1", "rule2"} {
+		require.NoError(t, mockStorage.SaveResourceGroupRule(ctx, &entity.ResourceGroupRule{
+			Name:      name,
+			Fields:    []string{"name"},
+			CreatedAt: &metav1.Time{Time: time.Now()},
+		}))
+	}
+	mockStorage.members = []entity.ResourceGroup{newMember("default", "Pod", "test-group")}
+
+	require.NoError(t, manager.SnapshotAll(ctx))
+	require.Len(t, mockStorage.snapshots, 2)
+
+	var timestamps []time.Time
+	for _, snapshot := range mockStorage.snapshots {
+		require.Equal(t, "test-group", snapshot.ResourceGroup.Name)
+		require.Equal(t, 1, snapshot.ResourceTotal)
+		timestamps = append(timestamps, snapshot.Timestamp.Time)
+	}
+	// The snapshots of a round share the same timestamp.
+	require.Equal(t, timestamps[0], timestamps[1])
+}
+
+func TestResourceGroupManager_PurgeSnapshots(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := newMockResourceGroupRuleStorage()
+	manager, err := NewResourceGroupManager(mockStorage)
+	require.NoError(t, err)
+
+	now := time.Now()
+	mockStorage.snapshots["old"] = &entity.ResourceGroupSnapshot{ID: "old", Timestamp: &metav1.Time{Time: now.Add(-48 * time.Hour)}}
+	mockStorage.snapshots["new"] = &entity.ResourceGroupSnapshot{ID: "new", Timestamp: &metav1.Time{Time: now}}
+
+	require.NoError(t, manager.PurgeSnapshots(ctx, now.Add(-24*time.Hour)))
+	require.Len(t, mockStorage.snapshots, 1)
+	require.Contains(t, mockStorage.snapshots, "new")
+}
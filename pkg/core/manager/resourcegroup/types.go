@@ -14,7 +14,11 @@
 
 package resourcegroup
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
 
 var (
 	ErrNilResourceGroupRule              = errors.New("resource group rule cannot be nil")
@@ -22,4 +26,24 @@ var (
 	ErrResourceGroupRuleAlreadyExists    = errors.New("resource group rule already exists")
 	ErrResourceGroupRuleNotFound         = errors.New("resource group rule not found")
 	ErrResourceGroupRuleNameCannotModify = errors.New("resource group rule name cannot be modified")
+	ErrMissingResourceGroupSnapshotID    = errors.New("resource group snapshot id is required")
+	ErrResourceGroupSnapshotsMismatched  = errors.New("resource group snapshots must be of the same resource group")
 )
+
+// ResourceGroupChange is how a resource group changed between two snapshots.
+type ResourceGroupChange struct {
+	// From and To are the snapshots compared, without their members.
+	From *entity.ResourceGroupSnapshot `json:"from"`
+	To   *entity.ResourceGroupSnapshot `json:"to"`
+	// Added and Removed are the resources added to and removed from the
+	// resource group. They are empty if the members of either snapshot are
+	// truncated, as the changes beyond the recorded members are unknown.
+	Added   []entity.ResourceGroup `json:"added"`
+	Removed []entity.ResourceGroup `json:"removed"`
+	// MembersTruncated reports whether the members of either snapshot are
+	// truncated, in which case only the count deltas are reported.
+	MembersTruncated bool `json:"membersTruncated,omitempty"`
+	// CountDelta is the change of the number of resources by GVK, the GVKs
+	// whose numbers are unchanged are omitted.
+	CountDelta map[string]int `json:"countDelta"`
+}
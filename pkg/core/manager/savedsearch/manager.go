@@ -0,0 +1,189 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SavedSearchManager manages the saved searches of users. Private saved
+// searches are only visible to their owners, and saved searches can only be
+// modified by their owners.
+type SavedSearchManager struct {
+	storage storage.SavedSearchStorage
+}
+
+// NewSavedSearchManager creates a new instance of SavedSearchManager with the
+// given storage.
+func NewSavedSearchManager(storage storage.SavedSearchStorage) (*SavedSearchManager, error) {
+	return &SavedSearchManager{storage: storage}, nil
+}
+
+// GetSavedSearch retrieves the saved search visible to the user by its name.
+func (m *SavedSearchManager) GetSavedSearch(ctx context.Context, user, name string) (*entity.SavedSearch, error) {
+	if len(name) == 0 {
+		return nil, ErrMissingSavedSearchName
+	}
+	savedSearch, err := m.storage.GetSavedSearch(ctx, name)
+	if err != nil {
+		if errors.Is(err, elasticsearch.ErrSavedSearchNotFound) {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+	// The private saved searches of other users are reported as not found,
+	// so that their names are not disclosed.
+	if !savedSearch.VisibleTo(user) {
+		return nil, ErrSavedSearchNotFound
+	}
+	return savedSearch, nil
+}
+
+// ListSavedSearches returns the saved searches visible to the user, only the
+// favorites of the user if favoritesOnly is set.
+func (m *SavedSearchManager) ListSavedSearches(ctx context.Context, user string, favoritesOnly bool) ([]*entity.SavedSearch, error) {
+	all, err := m.storage.ListSavedSearches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	savedSearches := make([]*entity.SavedSearch, 0, len(all))
+	for _, savedSearch := range all {
+		if !savedSearch.VisibleTo(user) || (favoritesOnly && !savedSearch.IsFavoriteOf(user)) {
+			continue
+		}
+		savedSearches = append(savedSearches, savedSearch)
+	}
+	return savedSearches, nil
+}
+
+// CreateSavedSearch creates a new saved search owned by the user.
+func (m *SavedSearchManager) CreateSavedSearch(ctx context.Context, user string, savedSearch *entity.SavedSearch) error {
+	if savedSearch == nil {
+		return ErrNilSavedSearch
+	}
+	if len(savedSearch.Name) == 0 {
+		return ErrMissingSavedSearchName
+	}
+	if savedSearch.Visibility == "" {
+		savedSearch.Visibility = entity.SavedSearchVisibilityPrivate
+	}
+	if err := savedSearch.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedSearch, err)
+	}
+
+	// Check if the saved search already exists to prevent duplicates, the
+	// names are unique across the users.
+	_, err := m.storage.GetSavedSearch(ctx, savedSearch.Name)
+	if err == nil {
+		return ErrSavedSearchAlreadyExists
+	}
+	if !errors.Is(err, elasticsearch.ErrSavedSearchNotFound) {
+		return err
+	}
+
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	savedSearch.ID = ""
+	savedSearch.Owner = user
+	savedSearch.FavoritedBy = nil
+	savedSearch.CreatedAt = &now
+	savedSearch.UpdatedAt = &now
+	return m.storage.SaveSavedSearch(ctx, savedSearch)
+}
+
+// UpdateSavedSearch updates the saved search of the user, the owner and
+// the favorites of the saved search are kept.
+func (m *SavedSearchManager) UpdateSavedSearch(ctx context.Context, user, name string, savedSearch *entity.SavedSearch) error {
+	if savedSearch == nil {
+		return ErrNilSavedSearch
+	}
+	if name != savedSearch.Name {
+		return ErrSavedSearchNameCannotModify
+	}
+	current, err := m.ownedSavedSearch(ctx, user, name)
+	if err != nil {
+		return err
+	}
+	if savedSearch.Visibility == "" {
+		savedSearch.Visibility = current.Visibility
+	}
+	if err := savedSearch.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedSearch, err)
+	}
+
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	savedSearch.ID = current.ID
+	savedSearch.Owner = current.Owner
+	savedSearch.FavoritedBy = current.FavoritedBy
+	savedSearch.CreatedAt = current.CreatedAt
+	savedSearch.UpdatedAt = &now
+	return m.storage.SaveSavedSearch(ctx, savedSearch)
+}
+
+// DeleteSavedSearch deletes the saved search of the user.
+func (m *SavedSearchManager) DeleteSavedSearch(ctx context.Context, user, name string) error {
+	if _, err := m.ownedSavedSearch(ctx, user, name); err != nil {
+		return err
+	}
+	return m.storage.DeleteSavedSearch(ctx, name)
+}
+
+// SetFavorite marks or unmarks the saved search visible to the user as a
+// favorite of the user.
+func (m *SavedSearchManager) SetFavorite(ctx context.Context, user, name string, favorite bool) (*entity.SavedSearch, error) {
+	savedSearch, err := m.GetSavedSearch(ctx, user, name)
+	if err != nil {
+		return nil, err
+	}
+	if savedSearch.IsFavoriteOf(user) == favorite {
+		return savedSearch, nil
+	}
+
+	if favorite {
+		savedSearch.FavoritedBy = append(savedSearch.FavoritedBy, user)
+	} else {
+		favoritedBy := make([]string, 0, len(savedSearch.FavoritedBy))
+		for _, u := range savedSearch.FavoritedBy {
+			if u != user {
+				favoritedBy = append(favoritedBy, u)
+			}
+		}
+		savedSearch.FavoritedBy = favoritedBy
+	}
+	if err := m.storage.SaveSavedSearch(ctx, savedSearch); err != nil {
+		return nil, err
+	}
+	return savedSearch, nil
+}
+
+// ownedSavedSearch retrieves the saved search by its name, and returns an
+// error if it is not owned by the user.
+func (m *SavedSearchManager) ownedSavedSearch(ctx context.Context, user, name string) (*entity.SavedSearch, error) {
+	savedSearch, err := m.GetSavedSearch(ctx, user, name)
+	if err != nil {
+		return nil, err
+	}
+	if savedSearch.Owner != user {
+		return nil, ErrNotSavedSearchOwner
+	}
+	return savedSearch, nil
+}
@@ -0,0 +1,239 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSavedSearchStorage is a mock implementation of storage.SavedSearchStorage
+type mockSavedSearchStorage struct {
+	savedSearches map[string]*entity.SavedSearch
+}
+
+func newMockSavedSearchStorage() *mockSavedSearchStorage {
+	return &mockSavedSearchStorage{
+		savedSearches: make(map[string]*entity.SavedSearch),
+	}
+}
+
+func (m *mockSavedSearchStorage) GetSavedSearch(ctx context.Context, name string) (*entity.SavedSearch, error) {
+	if savedSearch, exists := m.savedSearches[name]; exists {
+		copied := *savedSearch
+		return &copied, nil
+	}
+	return nil, elasticsearch.ErrSavedSearchNotFound
+}
+
+func (m *mockSavedSearchStorage) SaveSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) error {
+	if savedSearch.ID == "" {
+		savedSearch.ID = entity.UUID()
+	}
+	copied := *savedSearch
+	m.savedSearches[savedSearch.Name] = &copied
+	return nil
+}
+
+func (m *mockSavedSearchStorage) DeleteSavedSearch(ctx context.Context, name string) error {
+	if _, exists := m.savedSearches[name]; !exists {
+		return elasticsearch.ErrSavedSearchNotFound
+	}
+	delete(m.savedSearches, name)
+	return nil
+}
+
+func (m *mockSavedSearchStorage) ListSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error) {
+	savedSearches := make([]*entity.SavedSearch, 0, len(m.savedSearches))
+	for _, savedSearch := range m.savedSearches {
+		copied := *savedSearch
+		savedSearches = append(savedSearches, &copied)
+	}
+	sort.Slice(savedSearches, func(i, j int) bool { return savedSearches[i].Name < savedSearches[j].Name })
+	return savedSearches, nil
+}
+
+func newTestSavedSearch(name, visibility string) *entity.SavedSearch {
+	return &entity.SavedSearch{
+		Name:       name,
+		Pattern:    "sql",
+		Query:      "select * from resources where kind = 'Pod'",
+		Visibility: visibility,
+	}
+}
+
+func TestSavedSearchManager_CreateSavedSearch(t *testing.T) {
+	manager, err := NewSavedSearchManager(newMockSavedSearchStorage())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		savedSearch *entity.SavedSearch
+		expectError error
+	}{
+		{
+			name:        "Success",
+			savedSearch: newTestSavedSearch("pods", ""),
+		},
+		{
+			name:        "Nil saved search",
+			savedSearch: nil,
+			expectError: ErrNilSavedSearch,
+		},
+		{
+			name:        "Empty name",
+			savedSearch: newTestSavedSearch("", ""),
+			expectError: ErrMissingSavedSearchName,
+		},
+		{
+			name:        "Already exists",
+			savedSearch: newTestSavedSearch("pods", entity.SavedSearchVisibilityShared),
+			expectError: ErrSavedSearchAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.CreateSavedSearch(ctx, "alice", tt.savedSearch)
+			if tt.expectError != nil {
+				require.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, tt.savedSearch.ID)
+			require.Equal(t, "alice", tt.savedSearch.Owner)
+			require.Equal(t, entity.SavedSearchVisibilityPrivate, tt.savedSearch.Visibility)
+			require.NotNil(t, tt.savedSearch.CreatedAt)
+		})
+	}
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		savedSearch := newTestSavedSearch("invalid", "")
+		savedSearch.Pattern = "regex"
+		require.ErrorIs(t, manager.CreateSavedSearch(ctx, "alice", savedSearch), ErrInvalidSavedSearch)
+	})
+}
+
+func TestSavedSearchManager_Visibility(t *testing.T) {
+	manager, err := NewSavedSearchManager(newMockSavedSearchStorage())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", newTestSavedSearch("private", entity.SavedSearchVisibilityPrivate)))
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", newTestSavedSearch("shared", entity.SavedSearchVisibilityShared)))
+
+	savedSearch, err := manager.GetSavedSearch(ctx, "alice", "private")
+	require.NoError(t, err)
+	require.Equal(t, "private", savedSearch.Name)
+
+	_, err = manager.GetSavedSearch(ctx, "bob", "private")
+	require.ErrorIs(t, err, ErrSavedSearchNotFound)
+
+	_, err = manager.GetSavedSearch(ctx, "bob", "shared")
+	require.NoError(t, err)
+
+	savedSearches, err := manager.ListSavedSearches(ctx, "alice", false)
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 2)
+
+	savedSearches, err = manager.ListSavedSearches(ctx, "bob", false)
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 1)
+	require.Equal(t, "shared", savedSearches[0].Name)
+}
+
+func TestSavedSearchManager_UpdateSavedSearch(t *testing.T) {
+	manager, err := NewSavedSearchManager(newMockSavedSearchStorage())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	original := newTestSavedSearch("pods", entity.SavedSearchVisibilityShared)
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", original))
+
+	updated := newTestSavedSearch("pods", "")
+	updated.Query = "select * from resources where kind = 'Deployment'"
+	updated.Owner = "bob"
+	require.NoError(t, manager.UpdateSavedSearch(ctx, "alice", "pods", updated))
+	require.Equal(t, original.ID, updated.ID)
+	require.Equal(t, "alice", updated.Owner)
+	require.Equal(t, entity.SavedSearchVisibilityShared, updated.Visibility)
+
+	savedSearch, err := manager.GetSavedSearch(ctx, "alice", "pods")
+	require.NoError(t, err)
+	require.Equal(t, updated.Query, savedSearch.Query)
+
+	err = manager.UpdateSavedSearch(ctx, "alice", "pods", newTestSavedSearch("renamed", ""))
+	require.ErrorIs(t, err, ErrSavedSearchNameCannotModify)
+
+	err = manager.UpdateSavedSearch(ctx, "bob", "pods", newTestSavedSearch("pods", ""))
+	require.ErrorIs(t, err, ErrNotSavedSearchOwner)
+	require.ErrorIs(t, err, authz.ErrForbidden)
+
+	err = manager.UpdateSavedSearch(ctx, "alice", "missing", newTestSavedSearch("missing", ""))
+	require.ErrorIs(t, err, ErrSavedSearchNotFound)
+}
+
+func TestSavedSearchManager_DeleteSavedSearch(t *testing.T) {
+	manager, err := NewSavedSearchManager(newMockSavedSearchStorage())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", newTestSavedSearch("pods", entity.SavedSearchVisibilityShared)))
+
+	require.ErrorIs(t, manager.DeleteSavedSearch(ctx, "bob", "pods"), authz.ErrForbidden)
+	require.NoError(t, manager.DeleteSavedSearch(ctx, "alice", "pods"))
+	require.ErrorIs(t, manager.DeleteSavedSearch(ctx, "alice", "pods"), ErrSavedSearchNotFound)
+}
+
+func TestSavedSearchManager_SetFavorite(t *testing.T) {
+	manager, err := NewSavedSearchManager(newMockSavedSearchStorage())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", newTestSavedSearch("shared", entity.SavedSearchVisibilityShared)))
+	require.NoError(t, manager.CreateSavedSearch(ctx, "alice", newTestSavedSearch("private", entity.SavedSearchVisibilityPrivate)))
+
+	savedSearch, err := manager.SetFavorite(ctx, "bob", "shared", true)
+	require.NoError(t, err)
+	require.True(t, savedSearch.IsFavoriteOf("bob"))
+
+	// Marking a favorite twice is a no-op.
+	savedSearch, err = manager.SetFavorite(ctx, "bob", "shared", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob"}, savedSearch.FavoritedBy)
+
+	_, err = manager.SetFavorite(ctx, "bob", "private", true)
+	require.ErrorIs(t, err, ErrSavedSearchNotFound)
+
+	favorites, err := manager.ListSavedSearches(ctx, "bob", true)
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+	require.Equal(t, "shared", favorites[0].Name)
+
+	savedSearch, err = manager.SetFavorite(ctx, "bob", "shared", false)
+	require.NoError(t, err)
+	require.False(t, savedSearch.IsFavoriteOf("bob"))
+
+	favorites, err = manager.ListSavedSearches(ctx, "bob", true)
+	require.NoError(t, err)
+	require.Empty(t, favorites)
+}
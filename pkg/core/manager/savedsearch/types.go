@@ -0,0 +1,32 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+)
+
+var (
+	ErrNilSavedSearch              = errors.New("saved search cannot be nil")
+	ErrMissingSavedSearchName      = errors.New("saved search name is required")
+	ErrInvalidSavedSearch          = errors.New("invalid saved search")
+	ErrSavedSearchAlreadyExists    = errors.New("saved search already exists")
+	ErrSavedSearchNotFound         = errors.New("saved search not found")
+	ErrSavedSearchNameCannotModify = errors.New("saved search name cannot be modified")
+	ErrNotSavedSearchOwner         = fmt.Errorf("%w: only the owner can modify the saved search", authz.ErrForbidden)
+)
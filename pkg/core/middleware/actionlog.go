@@ -0,0 +1,100 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// ActionLog is a middleware that records the action logs of the requests
+// tagged with RecordAction, along with the user, request ID and outcome.
+func ActionLog(recorder *actionlog.Recorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			start := time.Now()
+
+			log := &entity.ActionLog{
+				Timestamp: &metav1.Time{Time: start},
+				RequestID: middleware.GetReqID(ctx),
+				User:      user.Anonymous,
+				Method:    r.Method,
+				Path:      r.URL.Path,
+			}
+			if u, ok := request.UserFrom(ctx); ok {
+				log.User = u.GetName()
+				log.Groups = u.GetGroups()
+			}
+			if ip := utilnet.GetClientIP(r); ip != nil {
+				log.SourceIP = ip.String()
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(actionlog.WithLog(ctx, log)))
+			if log.Action == "" {
+				return
+			}
+
+			log.StatusCode = ww.Status()
+			if log.StatusCode == 0 {
+				log.StatusCode = http.StatusOK
+			}
+			log.Outcome = entity.ActionOutcomeSuccess
+			if log.StatusCode >= http.StatusBadRequest {
+				log.Outcome = entity.ActionOutcomeFailure
+			}
+			log.DurationMillis = time.Since(start).Milliseconds()
+			recorder.Record(log)
+		})
+	}
+}
+
+// RecordAction is a middleware that tags the requests of a route with the
+// action they perform, the key and value pairs are added to the details of
+// the action log. The target is taken from the URL parameters of the route.
+func RecordAction(action string, keysAndValues ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			actionlog.Annotate(ctx, action, keysAndValues...)
+
+			target := entity.ActionTarget{
+				Cluster:   chi.URLParam(r, "cluster"),
+				Namespace: chi.URLParam(r, "namespace"),
+				Name:      chi.URLParam(r, "name"),
+			}
+			if name := chi.URLParam(r, "clusterName"); name != "" {
+				target.Cluster = name
+			}
+			if name := chi.URLParam(r, "resourceGroupRuleName"); name != "" {
+				target.Kind = "ResourceGroupRule"
+				target.Name = name
+			}
+			actionlog.SetTarget(ctx, target)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+)
+
+// DataAuthorization resolves the data access filter of the requesting user
+// and stores it in the request context, so that storages and handlers only
+// return the resources granted to the user. Requests without an
+// authenticated user are treated as anonymous.
+func DataAuthorization(authorizer *authz.Authorizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, ok := request.UserFrom(r.Context())
+			if !ok {
+				u = &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}}
+			}
+
+			filter, err := authorizer.FilterFor(r.Context(), u)
+			if err != nil {
+				klog.ErrorS(err, "Failed to resolve data access rules", "user", u.GetName())
+				http.Error(w, "Failed to resolve data access rules.", http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(authz.WithFilter(r.Context(), filter)))
+		})
+	}
+}
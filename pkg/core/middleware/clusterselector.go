@@ -0,0 +1,62 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// ClusterSelectorParam is the query parameter selecting the clusters of a
+// request by their labels, such as "env=prod,region in (eu-west,eu-north)".
+const ClusterSelectorParam = "clusterSelector"
+
+// ClusterResolver returns the names of the clusters matching the selector.
+type ClusterResolver func(ctx context.Context, selector labels.Selector) ([]string, error)
+
+// ClusterSelector restricts the data access filter of the request context to
+// the clusters matching the clusterSelector query parameter, so that search
+// and insight only cover the selected clusters. Requests without the
+// parameter are passed through unchanged.
+func ClusterSelector(resolve ClusterResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			param := r.URL.Query().Get(ClusterSelectorParam)
+			if param == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			selector, err := labels.Parse(param)
+			if err != nil {
+				http.Error(w, "Invalid cluster selector: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			clusters, err := resolve(r.Context(), selector)
+			if err != nil {
+				klog.ErrorS(err, "Failed to resolve cluster selector", "selector", param)
+				http.Error(w, "Failed to resolve cluster selector.", http.StatusInternalServerError)
+				return
+			}
+
+			filter := authz.FilterFrom(r.Context()).RestrictClusters(clusters)
+			next.ServeHTTP(w, r.WithContext(authz.WithFilter(r.Context(), filter)))
+		})
+	}
+}
@@ -15,13 +15,19 @@
 package route
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"expvar"
 	"net/http"
 
 	docs "github.com/KusionStack/karpor/api/openapispec"
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/handler"
+	actionloghandler "github.com/KusionStack/karpor/pkg/core/handler/actionlog"
 	aggregatorhandler "github.com/KusionStack/karpor/pkg/core/handler/aggregator"
+	alerthandler "github.com/KusionStack/karpor/pkg/core/handler/alert"
 	authnhandler "github.com/KusionStack/karpor/pkg/core/handler/authn"
 	clusterhandler "github.com/KusionStack/karpor/pkg/core/handler/cluster"
 	detailhandler "github.com/KusionStack/karpor/pkg/core/handler/detail"
@@ -29,6 +35,7 @@ import (
 	eventshandler "github.com/KusionStack/karpor/pkg/core/handler/events"
 	resourcegrouphandler "github.com/KusionStack/karpor/pkg/core/handler/resourcegroup"
 	resourcegrouprulehandler "github.com/KusionStack/karpor/pkg/core/handler/resourcegrouprule"
+	savedsearchhandler "github.com/KusionStack/karpor/pkg/core/handler/savedsearch"
 	scannerhandler "github.com/KusionStack/karpor/pkg/core/handler/scanner"
 	searchhandler "github.com/KusionStack/karpor/pkg/core/handler/search"
 	statshandler "github.com/KusionStack/karpor/pkg/core/handler/stats"
@@ -36,24 +43,36 @@ import (
 	topologyhandler "github.com/KusionStack/karpor/pkg/core/handler/topology"
 	healthhandler "github.com/KusionStack/karpor/pkg/core/health"
 	aimanager "github.com/KusionStack/karpor/pkg/core/manager/ai"
+	alertmanager "github.com/KusionStack/karpor/pkg/core/manager/alert"
 	clustermanager "github.com/KusionStack/karpor/pkg/core/manager/cluster"
 	insightmanager "github.com/KusionStack/karpor/pkg/core/manager/insight"
+	logsmanager "github.com/KusionStack/karpor/pkg/core/manager/logs"
 	resourcegroupmanager "github.com/KusionStack/karpor/pkg/core/manager/resourcegroup"
+	savedsearchmanager "github.com/KusionStack/karpor/pkg/core/manager/savedsearch"
 	searchmanager "github.com/KusionStack/karpor/pkg/core/manager/search"
 	appmiddleware "github.com/KusionStack/karpor/pkg/core/middleware"
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
+	"github.com/KusionStack/karpor/pkg/infra/notification"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/exception"
+	"github.com/KusionStack/karpor/pkg/infra/scanner/policy"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpswagger "github.com/swaggo/http-swagger/v2"
+	"k8s.io/apimachinery/pkg/labels"
 	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
 
 // NewCoreRoute creates and configures an instance of chi.Mux with the given
-// configuration and extra configuration parameters.
+// configuration and extra configuration parameters. The background tasks of
+// the core components run until the given context is done.
 func NewCoreRoute(
+	ctx context.Context,
 	genericConfig *genericapiserver.CompletedConfig,
 	extraConfig *registry.ExtraConfig,
 ) (*chi.Mux, error) {
@@ -65,9 +84,17 @@ func NewCoreRoute(
 	router.Use(appmiddleware.APILogger)
 	router.Use(appmiddleware.Timing)
 	router.Use(middleware.Recoverer)
+	recorder := extraConfig.ActionRecorder
+	if recorder == nil {
+		recorder = actionlog.NewRecorder()
+	}
+	router.Use(appmiddleware.ActionLog(recorder))
 	if extraConfig.ReadOnlyMode {
 		router.Use(appmiddleware.ReadOnlyMode)
 	}
+	if extraConfig.EnableDataAccessRules {
+		router.Use(appmiddleware.DataAuthorization(authz.NewAuthorizer(authz.NewLoopbackSource(genericConfig))))
+	}
 
 	// Initialize managers, storage for the different core components of the API.
 	searchStorage, err := search.NewSearchStorage(*extraConfig)
@@ -82,19 +109,105 @@ func NewCoreRoute(
 	if err != nil {
 		return nil, err
 	}
+	savedSearchStorage, err := search.NewSavedSearchStorage(*extraConfig)
+	if err != nil {
+		return nil, err
+	}
+	alertRuleStorage, err := search.NewAlertRuleStorage(*extraConfig)
+	if err != nil {
+		return nil, err
+	}
+	eventStorage, err := search.NewEventStorage(*extraConfig)
+	if err != nil {
+		return nil, err
+	}
 	generalStorage, err := search.NewGeneralStorage(*extraConfig)
 	if err != nil {
 		return nil, err
 	}
+	auditStorage, err := search.NewAuditStorage(*extraConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	insightMgr, err := insightmanager.NewInsightManager(searchStorage, resourceStorage, resourceGroupRuleStorage, genericConfig)
+	actionLogStorage, err := search.NewActionLogStorage(*extraConfig)
+	if err != nil {
+		return nil, err
+	}
+	recorder.AddSink(actionlog.NewStorageSink(actionLogStorage))
+	go recorder.Run(ctx)
+
+	insightMgr, err := insightmanager.NewInsightManager(searchStorage, resourceStorage, resourceGroupRuleStorage, auditStorage, genericConfig)
+	if err != nil {
+		return nil, err
+	}
+	policyScanner, err := policy.New(policy.NewLoopbackSource(genericConfig))
 	if err != nil {
 		return nil, err
 	}
+	if err = insightMgr.RegisterScanner(policyScanner); err != nil {
+		return nil, err
+	}
+	insightMgr.SetExceptionSource(exception.NewLoopbackSource(genericConfig))
+	if extraConfig.AuditInterval > 0 {
+		go insightMgr.RunAuditor(ctx, extraConfig.AuditInterval)
+	}
+	var credentialSource healthhandler.CredentialSource
+	if extraConfig.ClusterProbeInterval > 0 {
+		client, err := versioned.NewForConfig(genericConfig.LoopbackClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		prober := clustermanager.NewHealthProber(client, clustermanager.NewProber(genericConfig.LoopbackClientConfig))
+		go prober.Run(ctx, extraConfig.ClusterProbeInterval)
+	}
+	if extraConfig.CredentialCheckInterval > 0 {
+		client, err := versioned.NewForConfig(genericConfig.LoopbackClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		tracker := clustermanager.NewCredentialTracker(client, clustermanager.NewTokenRequester(genericConfig.LoopbackClientConfig), clustermanager.CredentialTrackerOptions{
+			WarningWindow:              extraConfig.CredentialExpiryWarning,
+			RotateServiceAccountTokens: extraConfig.RotateServiceAccountTokens,
+			RotatedTokenTTL:            extraConfig.RotatedTokenTTL,
+		})
+		go tracker.Run(ctx, extraConfig.CredentialCheckInterval)
+		credentialSource = tracker
+	}
 	resourceGroupMgr, err := resourcegroupmanager.NewResourceGroupManager(resourceGroupRuleStorage)
 	if err != nil {
 		return nil, err
 	}
+	if extraConfig.ResourceGroupSnapshotInterval > 0 {
+		go resourceGroupMgr.RunSnapshotter(ctx, extraConfig.ResourceGroupSnapshotInterval)
+	}
+	if extraConfig.ResourceGroupSnapshotRetention > 0 {
+		go resourceGroupMgr.RunSnapshotPurger(ctx, extraConfig.ResourceGroupSnapshotRetention)
+	}
+	savedSearchMgr, err := savedsearchmanager.NewSavedSearchManager(savedSearchStorage)
+	if err != nil {
+		return nil, err
+	}
+	var syncSource alertmanager.SyncSource
+	if extraConfig.AlertEvaluationInterval > 0 {
+		client, err := versioned.NewForConfig(genericConfig.LoopbackClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		clusterSyncs, err := alertmanager.NewClusterSyncSource(client)
+		if err != nil {
+			return nil, err
+		}
+		go clusterSyncs.Run(ctx)
+		syncSource = clusterSyncs
+	}
+	alertMgr, err := alertmanager.NewAlertManager(alertRuleStorage, savedSearchMgr, searchStorage, syncSource, notification.NewSender(extraConfig.AlertSMTP))
+	if err != nil {
+		return nil, err
+	}
+	if extraConfig.AlertEvaluationInterval > 0 {
+		go alertMgr.RunEvaluator(ctx, extraConfig.AlertEvaluationInterval)
+	}
 	aiMgr, err := aimanager.NewAIManager(*extraConfig)
 	if err != nil {
 		if errors.Is(err, aimanager.ErrMissingAuthToken) {
@@ -103,20 +216,49 @@ func NewCoreRoute(
 			return nil, err
 		}
 	}
+	if aiMgr != nil && extraConfig.AIPromptDir != "" && extraConfig.AIPromptReloadInterval > 0 {
+		go aiMgr.RunPromptReloader(ctx, extraConfig.AIPromptReloadInterval)
+	}
+	// Expose the AI usage along with the server configurations, it is only
+	// published once as the route may be created more than once in tests.
+	if aiMgr != nil && expvar.Get("AIUsage") == nil {
+		expvar.Publish("AIUsage", expvar.Func(func() interface{} {
+			return aiMgr.Usage()
+		}))
+	}
 
 	clusterMgr := clustermanager.NewClusterManager()
 	searchMgr := searchmanager.NewSearchManager()
+	logAggregator := logsmanager.NewAggregator(searchStorage, clusterClients(genericConfig))
 
 	// Set up the API routes for version 1 of the API.
 	router.Route("/rest-api/v1", func(r chi.Router) {
 		setupRestAPIV1(r,
 			aiMgr,
+			alertMgr,
 			clusterMgr,
 			insightMgr,
+			logAggregator,
 			resourceGroupMgr,
+			savedSearchMgr,
 			searchMgr,
 			searchStorage,
+			eventStorage,
 			genericConfig)
+
+		if provider := extraConfig.OIDCProvider; provider != nil {
+			r.Route("/authn/oidc", func(r chi.Router) {
+				r.Get("/login", authnhandler.Login(provider))
+				r.Get("/callback", authnhandler.Callback(provider))
+				r.Post("/logout", authnhandler.Logout(provider))
+			})
+		}
+	})
+
+	// Set up the admin API routes. They are kept out of /rest-api so that
+	// they can be granted to the admins alone when RBAC is enabled.
+	router.Route("/admin-api/v1", func(r chi.Router) {
+		r.Get("/action-logs", actionloghandler.List(actionLogStorage))
 	})
 
 	// Set up the root routes.
@@ -129,7 +271,7 @@ func NewCoreRoute(
 	// Expose server configuration and runtime statistics.
 	router.Get("/server-configs", customVarHandler().ServeHTTP)
 
-	healthhandler.Register(router, generalStorage)
+	healthhandler.Register(router, generalStorage, credentialSource)
 	return router, nil
 }
 
@@ -138,13 +280,22 @@ func NewCoreRoute(
 func setupRestAPIV1(
 	r chi.Router,
 	aiMgr *aimanager.AIManager,
+	alertMgr *alertmanager.AlertManager,
 	clusterMgr *clustermanager.ClusterManager,
 	insightMgr *insightmanager.InsightManager,
+	logAggregator *logsmanager.Aggregator,
 	resourceGroupMgr *resourcegroupmanager.ResourceGroupManager,
+	savedSearchMgr *savedsearchmanager.SavedSearchManager,
 	searchMgr *searchmanager.SearchManager,
 	searchStorage storage.SearchStorage,
+	eventStorage storage.EventStorage,
 	genericConfig *genericapiserver.CompletedConfig,
 ) {
+	// Restricts search and insight to the clusters selected by labels.
+	selectClusters := appmiddleware.ClusterSelector(clusterResolver(clusterMgr, genericConfig))
+	// Rejects the AI calls of the users who exhausted their AI budgets.
+	limitAI := aiQuota(aiMgr)
+
 	// Define API routes for 'cluster', 'search', 'resourcegroup' and 'insight', etc.
 	r.Route("/clusters", func(r chi.Router) {
 		r.Get("/", clusterhandler.List(clusterMgr, genericConfig))
@@ -153,43 +304,128 @@ func setupRestAPIV1(
 	r.Route("/cluster", func(r chi.Router) {
 		r.Route("/{clusterName}", func(r chi.Router) {
 			r.Get("/", clusterhandler.Get(clusterMgr, genericConfig))
-			r.Post("/", clusterhandler.Create(clusterMgr, genericConfig))
-			r.Put("/", clusterhandler.Update(clusterMgr, genericConfig))
-			r.Delete("/", clusterhandler.Delete(clusterMgr, genericConfig))
+			r.With(appmiddleware.RecordAction(actionlog.ActionClusterCreate)).Post("/", clusterhandler.Create(clusterMgr, genericConfig))
+			r.With(appmiddleware.RecordAction(actionlog.ActionClusterUpdate)).Put("/", clusterhandler.Update(clusterMgr, genericConfig))
+			r.With(appmiddleware.RecordAction(actionlog.ActionClusterDelete)).Delete("/", clusterhandler.Delete(clusterMgr, genericConfig))
 		})
-		r.Post("/config/file", clusterhandler.UploadKubeConfig(clusterMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionKubeConfigUpload)).Post("/config/file", clusterhandler.UploadKubeConfig(clusterMgr))
 		r.Post("/config/validate", clusterhandler.ValidateKubeConfig(clusterMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionClusterImport)).Post("/config/import", clusterhandler.ImportKubeConfig(clusterMgr, genericConfig))
 	})
 
 	r.Route("/search", func(r chi.Router) {
-		r.Get("/", searchhandler.SearchForResource(searchMgr, aiMgr, searchStorage))
+		r.With(selectClusters).Get("/", searchhandler.SearchForResource(searchMgr, aiMgr, searchStorage))
+	})
+
+	r.Route("/saved-search", func(r chi.Router) {
+		r.With(appmiddleware.RecordAction(actionlog.ActionSavedSearchCreate)).Post("/", savedsearchhandler.Create(savedSearchMgr))
+		r.Route("/{savedSearchName}", func(r chi.Router) {
+			r.Get("/", savedsearchhandler.Get(savedSearchMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionSavedSearchUpdate)).Put("/", savedsearchhandler.Update(savedSearchMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionSavedSearchDelete)).Delete("/", savedsearchhandler.Delete(savedSearchMgr))
+			r.Put("/favorite", savedsearchhandler.Favorite(savedSearchMgr))
+			r.Delete("/favorite", savedsearchhandler.Unfavorite(savedSearchMgr))
+			r.With(selectClusters).Get("/run", searchhandler.RunSavedSearch(savedSearchMgr, aiMgr, searchStorage))
+		})
+	})
+	r.Get("/saved-searches", savedsearchhandler.List(savedSearchMgr))
+
+	r.Route("/alert-rule", func(r chi.Router) {
+		r.With(appmiddleware.RecordAction(actionlog.ActionAlertRuleCreate)).Post("/", alerthandler.Create(alertMgr))
+		r.Route("/{alertRuleName}", func(r chi.Router) {
+			r.Get("/", alerthandler.Get(alertMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionAlertRuleUpdate)).Put("/", alerthandler.Update(alertMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionAlertRuleDelete)).Delete("/", alerthandler.Delete(alertMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionAlertRuleSilence)).Put("/silence", alerthandler.Silence(alertMgr))
+			r.With(appmiddleware.RecordAction(actionlog.ActionAlertRuleSilence)).Delete("/silence", alerthandler.Unsilence(alertMgr))
+			r.Post("/test", alerthandler.Test(alertMgr))
+		})
 	})
+	r.Get("/alert-rules", alerthandler.List(alertMgr))
 
 	r.Route("/insight", func(r chi.Router) {
-		r.Get("/stats", statshandler.GetStatistics(insightMgr))
-		r.Get("/audit", scannerhandler.Audit(insightMgr))
-		r.Get("/score", scannerhandler.Score(insightMgr))
-		r.Get("/topology", topologyhandler.GetTopology(clusterMgr, insightMgr, genericConfig))
+		r.With(selectClusters).Get("/stats", statshandler.GetStatistics(insightMgr))
+		r.With(selectClusters).Get("/audit", scannerhandler.Audit(insightMgr))
+		r.With(selectClusters).Get("/score", scannerhandler.Score(insightMgr))
+		r.Get("/score/trend", scannerhandler.ScoreTrend(insightMgr))
+		r.Get("/issues/history", scannerhandler.IssueHistory(insightMgr))
+		r.With(selectClusters).Get("/topology", topologyhandler.GetTopology(clusterMgr, insightMgr, genericConfig))
 		r.Get("/summary", summaryhandler.GetSummary(insightMgr, genericConfig))
 		r.Get("/events", eventshandler.GetEvents(insightMgr, genericConfig))
+		r.With(selectClusters).Get("/events/history", eventshandler.GetEventHistory(eventStorage))
 		r.Get("/detail", detailhandler.GetDetail(clusterMgr, insightMgr, genericConfig))
-		r.Get("/aggregator/log/pod/{cluster}/{namespace}/{name}", aggregatorhandler.GetPodLogs(clusterMgr, genericConfig))
+		r.With(appmiddleware.RecordAction(actionlog.ActionLogDownload)).Get("/aggregator/log/pod/{cluster}/{namespace}/{name}", aggregatorhandler.GetPodLogs(clusterMgr, genericConfig))
+		r.With(appmiddleware.RecordAction(actionlog.ActionLogDownload)).Get("/aggregator/log/pods", aggregatorhandler.GetWorkloadLogs(logAggregator))
 		r.Get("/aggregator/event/{cluster}/{namespace}/{name}", aggregatorhandler.GetEvents(clusterMgr, genericConfig))
-		r.Post("/aggregator/log/diagnosis/stream", aggregatorhandler.DiagnosePodLogs(aiMgr, genericConfig))
-		r.Post("/aggregator/event/diagnosis/stream", aggregatorhandler.DiagnoseEvents(aiMgr, genericConfig))
-		r.Post("/yaml/interpret/stream", detailhandler.InterpretYAML(aiMgr, genericConfig))
-		r.Post("/issue/interpret/stream", scannerhandler.InterpretIssues(aiMgr, genericConfig))
+		r.With(recordAICall(aimanager.LogDiagnosisType), limitAI).Post("/aggregator/log/diagnosis/stream", aggregatorhandler.DiagnosePodLogs(aiMgr, genericConfig))
+		r.With(recordAICall(aimanager.EventDiagnosisType), limitAI).Post("/aggregator/event/diagnosis/stream", aggregatorhandler.DiagnoseEvents(aiMgr, genericConfig))
+		r.With(recordAICall(aimanager.YAMLInterpretType), limitAI).Post("/yaml/interpret/stream", detailhandler.InterpretYAML(aiMgr, genericConfig))
+		r.With(recordAICall(aimanager.IssueInterpretType), limitAI).Post("/issue/interpret/stream", scannerhandler.InterpretIssues(aiMgr, genericConfig))
+		r.With(recordAICall(aimanager.ResourceDiagnosisType), limitAI).Post("/resource/diagnosis/stream", detailhandler.DiagnoseResource(aiMgr, insightMgr, genericConfig))
+		r.With(recordAICall(aimanager.RemediationType), limitAI).Post("/issue/remediation/stream", scannerhandler.RemediateIssues(aiMgr, insightMgr, genericConfig))
+		r.With(recordAICall(aimanager.StateSummaryType), limitAI).Post("/summary/stream", summaryhandler.SummarizeState(aiMgr, insightMgr, genericConfig))
 	})
 
 	r.Route("/resource-group-rule", func(r chi.Router) {
 		r.Get("/{resourceGroupRuleName}", resourcegrouprulehandler.Get(resourceGroupMgr))
-		r.Post("/", resourcegrouprulehandler.Create(resourceGroupMgr))
-		r.Put("/", resourcegrouprulehandler.Update(resourceGroupMgr))
-		r.Delete("/{resourceGroupRuleName}", resourcegrouprulehandler.Delete(resourceGroupMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionResourceGroupRuleCreate)).Post("/", resourcegrouprulehandler.Create(resourceGroupMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionResourceGroupRuleUpdate)).Put("/", resourcegrouprulehandler.Update(resourceGroupMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionResourceGroupRuleDelete)).Delete("/{resourceGroupRuleName}", resourcegrouprulehandler.Delete(resourceGroupMgr))
 	})
 	r.Get("/resource-group-rules", resourcegrouprulehandler.List(resourceGroupMgr))
-	r.Get("/resource-groups/{resourceGroupRuleName}", resourcegrouphandler.List(resourceGroupMgr))
+	r.Route("/resource-groups/{resourceGroupRuleName}", func(r chi.Router) {
+		r.Get("/", resourcegrouphandler.List(resourceGroupMgr))
+		r.Get("/snapshots", resourcegrouphandler.ListSnapshots(resourceGroupMgr))
+		r.With(appmiddleware.RecordAction(actionlog.ActionResourceGroupSnapshot)).Post("/snapshots", resourcegrouphandler.CreateSnapshot(resourceGroupMgr))
+		r.Get("/snapshots/diff", resourcegrouphandler.DiffSnapshots(resourceGroupMgr))
+	})
 	r.Get("/authn", authnhandler.Get())
+	r.Get("/authn/user", authnhandler.GetUser())
+}
+
+// recordAICall tags the requests of a route as AI calls with the given
+// prompt type.
+func recordAICall(promptType aimanager.PromptType) func(http.Handler) http.Handler {
+	return appmiddleware.RecordAction(actionlog.ActionAICall, actionlog.DetailPromptType, string(promptType))
+}
+
+// aiQuota rejects the requests with too many requests if the user has
+// exhausted a budget of the AI calls.
+func aiQuota(aiMgr *aimanager.AIManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if aiMgr != nil {
+				if err := aiMgr.CheckQuota(r.Context()); err != nil {
+					handler.FailureRender(r.Context(), w, r, err)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clusterResolver resolves the cluster selectors of the requests with the
+// labels of the clusters in the hub.
+func clusterResolver(clusterMgr *clustermanager.ClusterManager, genericConfig *genericapiserver.CompletedConfig) appmiddleware.ClusterResolver {
+	return func(ctx context.Context, selector labels.Selector) ([]string, error) {
+		client, err := multicluster.BuildMultiClusterClient(ctx, genericConfig.LoopbackClientConfig, "")
+		if err != nil {
+			return nil, err
+		}
+		return clusterMgr.ListClusterName(ctx, client, selector.String(), clustermanager.ByName, false)
+	}
+}
+
+// clusterClients returns the clients of the clusters in the hub.
+func clusterClients(genericConfig *genericapiserver.CompletedConfig) logsmanager.ClientGetter {
+	return func(ctx context.Context, cluster string) (kubernetes.Interface, error) {
+		client, err := multicluster.BuildMultiClusterClient(ctx, genericConfig.LoopbackClientConfig, cluster)
+		if err != nil {
+			return nil, err
+		}
+		return client.ClientSet, nil
+	}
 }
 
 // @Summary      Get server configurations
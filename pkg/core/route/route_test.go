@@ -51,6 +51,36 @@ type mockResourceGroupRuleStorage struct {
 	storage.ResourceGroupRuleStorage
 }
 
+// mockSavedSearchStorage is an in-memory implementation of the
+// SavedSearchStorage interface for testing purposes.
+type mockSavedSearchStorage struct {
+	storage.SavedSearchStorage
+}
+
+// mockAlertRuleStorage is an in-memory implementation of the
+// AlertRuleStorage interface for testing purposes.
+type mockAlertRuleStorage struct {
+	storage.AlertRuleStorage
+}
+
+// mockEventStorage is an in-memory implementation of the EventStorage
+// interface for testing purposes.
+type mockEventStorage struct {
+	storage.EventStorage
+}
+
+// mockAuditStorage is an in-memory implementation of the AuditStorage
+// interface for testing purposes.
+type mockAuditStorage struct {
+	storage.AuditStorage
+}
+
+// mockActionLogStorage is an in-memory implementation of the
+// ActionLogStorage interface for testing purposes.
+type mockActionLogStorage struct {
+	storage.ActionLogStorage
+}
+
 // mockGeneralStorage is an in-memory implementation of the Storage interface
 // for testing purposes.
 type mockGeneralStorage struct {
@@ -65,7 +95,12 @@ func TestNewCoreRoute(t *testing.T) {
 	mockey.Mock(search.NewSearchStorage).Return(&mockSearchStorage{}, nil).Build()
 	mockey.Mock(search.NewResourceStorage).Return(&mockResourceStorage{}, nil).Build()
 	mockey.Mock(search.NewResourceGroupRuleStorage).Return(&mockResourceGroupRuleStorage{}, nil).Build()
+	mockey.Mock(search.NewSavedSearchStorage).Return(&mockSavedSearchStorage{}, nil).Build()
+	mockey.Mock(search.NewAlertRuleStorage).Return(&mockAlertRuleStorage{}, nil).Build()
+	mockey.Mock(search.NewEventStorage).Return(&mockEventStorage{}, nil).Build()
 	mockey.Mock(search.NewGeneralStorage).Return(&mockGeneralStorage{}, nil).Build()
+	mockey.Mock(search.NewAuditStorage).Return(&mockAuditStorage{}, nil).Build()
+	mockey.Mock(search.NewActionLogStorage).Return(&mockActionLogStorage{}, nil).Build()
 	defer mockey.UnPatchAll()
 
 	tests := []struct {
@@ -84,14 +119,18 @@ func TestNewCoreRoute(t *testing.T) {
 				"/endpoints",
 				"/server-configs",
 				"/rest-api/v1/search/",
+				"/admin-api/v1/action-logs",
 				"/livez",
 			},
 		},
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			router, err := NewCoreRoute(&server.CompletedConfig{}, &tt.extraConfig)
+			router, err := NewCoreRoute(ctx, &server.CompletedConfig{}, &tt.extraConfig)
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
@@ -50,7 +50,7 @@ func (c *AzureAIClient) Configure(cfg AIConfig) error {
 	return nil
 }
 
-func (c *AzureAIClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *AzureAIClient) Generate(ctx context.Context, prompt string) (string, *TokenUsage, error) {
 	resp, err := c.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
 		Messages: []openai.ChatCompletionMessage{
@@ -62,16 +62,16 @@ func (c *AzureAIClient) Generate(ctx context.Context, prompt string) (string, er
 		Temperature: c.temperature,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if len(resp.Choices) == 0 {
-		return "", errors.New("no completion choices returned from response")
+		return "", nil, errors.New("no completion choices returned from response")
 	}
-	return resp.Choices[0].Message.Content, nil
+	return resp.Choices[0].Message.Content, tokenUsageFrom(resp.Usage), nil
 }
 
-func (c *AzureAIClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, error) {
+func (c *AzureAIClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, *TokenUsage, error) {
 	// Create chat completion stream with streaming enabled
 	stream, err := c.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
@@ -85,11 +85,12 @@ func (c *AzureAIClient) GenerateStream(ctx context.Context, prompt string) (<-ch
 		Stream:      true,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create buffered channel for response chunks
 	resultChan := make(chan string, 100)
+	usage := &TokenUsage{}
 
 	// Start goroutine to handle streaming response
 	go func() {
@@ -107,6 +108,11 @@ func (c *AzureAIClient) GenerateStream(ctx context.Context, prompt string) (<-ch
 				return
 			}
 
+			// The usage comes with the last chunk if it is reported
+			if response.Usage != nil {
+				*usage = *tokenUsageFrom(*response.Usage)
+			}
+
 			// Send non-empty content chunks
 			if len(response.Choices) > 0 {
 				chunk := response.Choices[0].Delta.Content
@@ -117,5 +123,5 @@ func (c *AzureAIClient) GenerateStream(ctx context.Context, prompt string) (<-ch
 		}
 	}()
 
-	return resultChan, nil
+	return resultChan, usage, nil
 }
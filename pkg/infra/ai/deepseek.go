@@ -60,7 +60,7 @@ func (c *DeepseekClient) Configure(cfg AIConfig) error {
 	return nil
 }
 
-func (c *DeepseekClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *DeepseekClient) Generate(ctx context.Context, prompt string) (string, *TokenUsage, error) {
 	resp, err := c.client.CreateChatCompletion(ctx, deepseek.ChatCompletionRequest{
 		Model: c.model,
 		Messages: []deepseek.ChatCompletionMessage{
@@ -73,16 +73,16 @@ func (c *DeepseekClient) Generate(ctx context.Context, prompt string) (string, e
 		TopP:        c.topP,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if len(resp.Choices) == 0 {
-		return "", errors.New("no completion choices returned from response")
+		return "", nil, errors.New("no completion choices returned from response")
 	}
-	return resp.Choices[0].Message.Content, nil
+	return resp.Choices[0].Message.Content, tokenUsageFrom(resp.Usage), nil
 }
 
-func (c *DeepseekClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, error) {
+func (c *DeepseekClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, *TokenUsage, error) {
 	stream, err := c.client.CreateChatCompletionStream(ctx, deepseek.ChatCompletionRequest{
 		Model: c.model,
 		Messages: []deepseek.ChatCompletionMessage{
@@ -94,13 +94,17 @@ func (c *DeepseekClient) GenerateStream(ctx context.Context, prompt string) (<-c
 		Temperature: c.temperature,
 		TopP:        c.topP,
 		Stream:      true,
+		StreamOptions: &deepseek.StreamOptions{
+			IncludeUsage: true,
+		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create buffered channel for response chunks
 	resultChan := make(chan string, 100)
+	usage := &TokenUsage{}
 
 	// Start goroutine to handle streaming response
 	go func() {
@@ -118,6 +122,11 @@ func (c *DeepseekClient) GenerateStream(ctx context.Context, prompt string) (<-c
 				return
 			}
 
+			// The usage comes with the last chunk if it is reported
+			if response.Usage != nil {
+				*usage = *tokenUsageFrom(*response.Usage)
+			}
+
 			// Send non-empty content chunks
 			if len(response.Choices) > 0 {
 				chunk := response.Choices[0].Delta.Content
@@ -128,5 +137,5 @@ func (c *DeepseekClient) GenerateStream(ctx context.Context, prompt string) (<-c
 		}
 	}()
 
-	return resultChan, nil
+	return resultChan, usage, nil
 }
@@ -87,7 +87,12 @@ func TestDeepseekClient_Generate(t *testing.T) {
 				"message": {
 					"content": "test response"
 				}
-			}]
+			}],
+			"usage": {
+				"prompt_tokens": 12,
+				"completion_tokens": 3,
+				"total_tokens": 15
+			}
 		}`))
 	}))
 	defer server.Close()
@@ -101,9 +106,10 @@ func TestDeepseekClient_Generate(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Test generate
-	resp, err := client.Generate(context.Background(), "test prompt")
+	resp, usage, err := client.Generate(context.Background(), "test prompt")
 	assert.NoError(t, err)
 	assert.Equal(t, "test response", resp)
+	assert.Equal(t, &TokenUsage{PromptTokens: 12, CompletionTokens: 3}, usage)
 }
 
 func TestDeepseekClient_GenerateStream(t *testing.T) {
@@ -119,6 +125,7 @@ func TestDeepseekClient_GenerateStream(t *testing.T) {
 		w.Write([]byte(`data: {"choices":[{"delta":{"content":"first"}}]}
 data: {"choices":[{"delta":{"content":"second"}}]}
 data: {"choices":[{"delta":{"content":"third"}}]}
+data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}
 data: [DONE]
 `))
 		w.(http.Flusher).Flush()
@@ -134,7 +141,7 @@ data: [DONE]
 	assert.NoError(t, err)
 
 	// Test stream generate
-	stream, err := client.GenerateStream(context.Background(), "test prompt")
+	stream, usage, err := client.GenerateStream(context.Background(), "test prompt")
 	assert.NoError(t, err)
 
 	// Collect stream response
@@ -147,4 +154,5 @@ data: [DONE]
 	}
 
 	assert.Equal(t, "firstsecondthird", result)
+	assert.Equal(t, &TokenUsage{PromptTokens: 12, CompletionTokens: 3}, usage)
 }
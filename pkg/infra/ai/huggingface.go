@@ -43,7 +43,7 @@ func (c *HuggingfaceClient) Configure(cfg AIConfig) error {
 	return nil
 }
 
-func (c *HuggingfaceClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *HuggingfaceClient) Generate(ctx context.Context, prompt string) (string, *TokenUsage, error) {
 	resp, err := c.client.TextGeneration(ctx, &huggingface.TextGenerationRequest{
 		Inputs: prompt,
 		Parameters: huggingface.TextGenerationParameters{
@@ -55,20 +55,22 @@ func (c *HuggingfaceClient) Generate(ctx context.Context, prompt string) (string
 		Model: c.model,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return resp[0].GeneratedText[len(prompt):], nil
+	// The text generation API doesn't report the token usage.
+	return resp[0].GeneratedText[len(prompt):], &TokenUsage{}, nil
 }
 
-func (c *HuggingfaceClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, error) {
+func (c *HuggingfaceClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, *TokenUsage, error) {
 	// Hugging Face API doesn't support streaming yet, so we simulate streaming output
 	resultChan := make(chan string, 100)
+	usage := &TokenUsage{}
 
 	go func() {
 		defer close(resultChan)
 
-		resp, err := c.Generate(ctx, prompt)
+		resp, _, err := c.Generate(ctx, prompt)
 		if err != nil {
 			resultChan <- "ERROR: " + err.Error()
 			return
@@ -86,5 +88,5 @@ func (c *HuggingfaceClient) GenerateStream(ctx context.Context, prompt string) (
 		}
 	}()
 
-	return resultChan, nil
+	return resultChan, usage, nil
 }
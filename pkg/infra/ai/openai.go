@@ -51,7 +51,7 @@ func (c *OpenAIClient) Configure(cfg AIConfig) error {
 	return nil
 }
 
-func (c *OpenAIClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *OpenAIClient) Generate(ctx context.Context, prompt string) (string, *TokenUsage, error) {
 	resp, err := c.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
 		Messages: []openai.ChatCompletionMessage{
@@ -64,16 +64,16 @@ func (c *OpenAIClient) Generate(ctx context.Context, prompt string) (string, err
 		TopP:        c.topP,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if len(resp.Choices) == 0 {
-		return "", errors.New("no completion choices returned from response")
+		return "", nil, errors.New("no completion choices returned from response")
 	}
-	return resp.Choices[0].Message.Content, nil
+	return resp.Choices[0].Message.Content, tokenUsageFrom(resp.Usage), nil
 }
 
-func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, error) {
+func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string) (<-chan string, *TokenUsage, error) {
 	// Create chat completion stream with streaming enabled
 	stream, err := c.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
@@ -86,13 +86,17 @@ func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string) (<-cha
 		Temperature: c.temperature,
 		TopP:        c.topP,
 		Stream:      true,
+		StreamOptions: &openai.StreamOptions{
+			IncludeUsage: true,
+		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create buffered channel for response chunks
 	resultChan := make(chan string, 100)
+	usage := &TokenUsage{}
 
 	// Start goroutine to handle streaming response
 	go func() {
@@ -110,6 +114,11 @@ func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string) (<-cha
 				return
 			}
 
+			// The usage comes with the last chunk if it is reported
+			if response.Usage != nil {
+				*usage = *tokenUsageFrom(*response.Usage)
+			}
+
 			// Send non-empty content chunks
 			if len(response.Choices) > 0 {
 				chunk := response.Choices[0].Delta.Content
@@ -120,5 +129,5 @@ func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string) (<-cha
 		}
 	}()
 
-	return resultChan, nil
+	return resultChan, usage, nil
 }
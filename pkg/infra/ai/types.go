@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/sashabaranov/go-openai"
 	"k8s.io/klog/v2"
 )
 
@@ -48,12 +49,36 @@ type AIProvider interface {
 	// Configure sets up the AI service with the provided configuration.
 	Configure(config AIConfig) error
 	// Generate generates a response from the AI service based on
-	// the provided prompt and service type.
-	Generate(ctx context.Context, prompt string) (string, error)
+	// the provided prompt and service type, along with the token usage
+	// reported by the service.
+	Generate(ctx context.Context, prompt string) (string, *TokenUsage, error)
 	// GenerateStream generates a streaming response from the AI service
 	// based on the provided prompt. It returns a channel that will receive
-	// chunks of the response as they are generated.
-	GenerateStream(ctx context.Context, prompt string) (<-chan string, error)
+	// chunks of the response as they are generated, and the token usage
+	// reported by the service, which is filled in once the channel is
+	// closed.
+	GenerateStream(ctx context.Context, prompt string) (<-chan string, *TokenUsage, error)
+}
+
+// TokenUsage is the number of tokens of a prompt and its completion reported
+// by the AI service, both of them are zero if the service doesn't report
+// them.
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Reported returns whether the AI service reported the token usage.
+func (u *TokenUsage) Reported() bool {
+	return u != nil && (u.PromptTokens > 0 || u.CompletionTokens > 0)
+}
+
+// tokenUsageFrom converts the usage of the OpenAI compatible services.
+func tokenUsageFrom(usage openai.Usage) *TokenUsage {
+	return &TokenUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+	}
 }
 
 // AIConfig represents the configuration settings for an AI client.
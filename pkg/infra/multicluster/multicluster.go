@@ -18,6 +18,7 @@ import (
 	"context"
 	"net"
 	"net/url"
+	"strings"
 
 	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
 	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
@@ -56,7 +57,7 @@ func BuildMultiClusterClient(
 		return hubClient, nil
 	}
 	// otherwise, return the MultiClusterClient for the spoke cluster
-	client, err := BuildSpokeClients(ctx, hubClient.DynamicClient, name)
+	client, err := BuildSpokeClients(ctx, hubConfig, hubClient.DynamicClient, name)
 	if err != nil {
 		return nil, err
 	}
@@ -91,9 +92,10 @@ func BuildHubClients(
 }
 
 // BuildSpokeClients returns a MultiClusterClient for the spoke cluster based on the cluster name in
-// the request
+// the request. The clusters in Agent access mode are reached through the cluster proxy of the hub.
 func BuildSpokeClients(
 	ctx context.Context,
+	hubConfig *restclient.Config,
 	hubDynamicClient *dynamic.DynamicClient,
 	name string,
 ) (*MultiClusterClient, error) {
@@ -107,8 +109,10 @@ func BuildSpokeClients(
 	if err != nil {
 		return nil, err
 	}
-	spokeConfig, err := NewConfigFromCluster(spokeObj.(*clusterv1beta1.Cluster))
-	if err != nil {
+	var spokeConfig *restclient.Config
+	if spoke := spokeObj.(*clusterv1beta1.Cluster); spoke.Spec.Access.Mode == clusterv1beta1.ClusterAccessModeAgent {
+		spokeConfig = NewProxyConfig(hubConfig, name)
+	} else if spokeConfig, err = NewConfigFromCluster(spoke); err != nil {
 		return nil, err
 	}
 	// Build Dynamic
@@ -150,6 +154,20 @@ func UnstructuredToRuntimeObject(u *unstructured.Unstructured) (runtime.Object,
 	return obj, nil
 }
 
+// ProxyPath returns the path of the proxy subresource of the cluster on the hub.
+func ProxyPath(name string) string {
+	return "/apis/" + clusterv1beta1.SchemeGroupVersion.String() + "/clusters/" + name + "/proxy"
+}
+
+// NewProxyConfig returns a restclient.Config reaching the cluster through the
+// cluster proxy of the hub, which is how the clusters in Agent access mode
+// are reached.
+func NewProxyConfig(hubConfig *restclient.Config, name string) *restclient.Config {
+	cfg := restclient.CopyConfig(hubConfig)
+	cfg.Host = strings.TrimSuffix(hubConfig.Host, "/") + ProxyPath(name)
+	return cfg
+}
+
 // NewConfigFromCluster takes in a v1beta1.Cluster object and return the corresponding
 // restclient.Config object for client-go
 func NewConfigFromCluster(c *clusterv1beta1.Cluster) (*restclient.Config, error) {
@@ -0,0 +1,63 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
+
+// ErrSMTPNotConfigured is returned when an email notification is sent
+// without a mail server.
+var ErrSMTPNotConfigured = errors.New("the smtp server of email notifications is not configured")
+
+// sendMail is the function sending the emails, replaced in tests.
+var sendMail = smtp.SendMail
+
+// sendMail sends the notification as an email to the recipients.
+func (s *Sender) sendMail(to []string, n *entity.AlertNotification) error {
+	if s.smtp.Address == "" || s.smtp.From == "" {
+		return ErrSMTPNotConfigured
+	}
+
+	var auth smtp.Auth
+	if s.smtp.Username != "" {
+		host, _, err := net.SplitHostPort(s.smtp.Address)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.smtp.Username, s.smtp.Password, host)
+	}
+	return sendMail(s.smtp.Address, auth, s.smtp.From, to, buildMessage(s.smtp.From, to, n))
+}
+
+// buildMessage builds the email message of the notification.
+func buildMessage(from string, to []string, n *entity.AlertNotification) []byte {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	sb.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ", ")))
+	sb.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", Subject(n))))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(Text(n), "\n", "\r\n"))
+	return []byte(sb.String())
+}
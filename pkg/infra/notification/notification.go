@@ -0,0 +1,140 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notification delivers the notifications of alert rules to webhook,
+// Slack-compatible and email channels.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+)
+
+// sendTimeout is the timeout of delivering a notification to a channel.
+const sendTimeout = 10 * time.Second
+
+// maxListedObjects is the maximum number of appeared or disappeared objects
+// listed in the text of a notification.
+const maxListedObjects = 20
+
+// SMTPConfig configures the mail server the email notifications are sent
+// through.
+type SMTPConfig struct {
+	// Address is the host:port of the mail server.
+	Address string
+	// From is the sender address of the emails.
+	From string
+	// Username and Password authenticate to the mail server, the
+	// authentication is skipped if the username is empty.
+	Username string
+	Password string
+}
+
+// Sender delivers notifications to the channels of alert rules.
+type Sender struct {
+	client *http.Client
+	smtp   SMTPConfig
+}
+
+// NewSender creates a sender which sends the email notifications through
+// the mail server of the config.
+func NewSender(smtp SMTPConfig) *Sender {
+	return &Sender{
+		client: &http.Client{Timeout: sendTimeout},
+		smtp:   smtp,
+	}
+}
+
+// Send delivers the notification to the channel.
+func (s *Sender) Send(ctx context.Context, channel entity.AlertChannel, n *entity.AlertNotification) error {
+	switch channel.Type {
+	case entity.AlertChannelWebhook:
+		return s.post(ctx, channel.URL, n)
+	case entity.AlertChannelSlack:
+		return s.post(ctx, channel.URL, map[string]string{"text": Text(n)})
+	case entity.AlertChannelEmail:
+		return s.sendMail(channel.To, n)
+	default:
+		return fmt.Errorf("unsupported channel %q", channel.Type)
+	}
+}
+
+// post posts the payload as JSON to the URL.
+func (s *Sender) post(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification to %s failed with status %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Subject returns the subject of the notification.
+func Subject(n *entity.AlertNotification) string {
+	return fmt.Sprintf("[karpor] Alert %s fired", n.Rule)
+}
+
+// Text renders the notification as plain text.
+func Text(n *entity.AlertNotification) string {
+	var sb strings.Builder
+	sb.WriteString(Subject(n))
+	sb.WriteString("\n")
+	if n.Description != "" {
+		sb.WriteString(n.Description)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(n.Summary)
+	sb.WriteString("\n")
+	writeObjects(&sb, "Appeared", n.Appeared)
+	writeObjects(&sb, "Disappeared", n.Disappeared)
+	sb.WriteString(fmt.Sprintf("Fired at %s\n", n.FiredAt.UTC().Format(time.RFC3339)))
+	return sb.String()
+}
+
+// writeObjects lists the first objects under the title.
+func writeObjects(sb *strings.Builder, title string, objects []string) {
+	if len(objects) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%s (%d):\n", title, len(objects)))
+	for i, object := range objects {
+		if i == maxListedObjects {
+			sb.WriteString(fmt.Sprintf("- ... and %d more\n", len(objects)-maxListedObjects))
+			break
+		}
+		sb.WriteString("- " + object + "\n")
+	}
+}
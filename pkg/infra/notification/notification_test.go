@@ -0,0 +1,120 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotification() *entity.AlertNotification {
+	return &entity.AlertNotification{
+		Rule:     "crashloop",
+		Summary:  "1 object appeared",
+		Count:    1,
+		Appeared: []string{"prod/v1/Pod/default/web-0"},
+		FiredAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	var received entity.AlertNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	sender := NewSender(SMTPConfig{})
+	err := sender.Send(context.Background(), entity.AlertChannel{Type: entity.AlertChannelWebhook, URL: server.URL}, newTestNotification())
+	require.NoError(t, err)
+	require.Equal(t, "crashloop", received.Rule)
+	require.Equal(t, []string{"prod/v1/Pod/default/web-0"}, received.Appeared)
+}
+
+func TestSendSlack(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	sender := NewSender(SMTPConfig{})
+	err := sender.Send(context.Background(), entity.AlertChannel{Type: entity.AlertChannelSlack, URL: server.URL}, newTestNotification())
+	require.NoError(t, err)
+	require.Contains(t, received["text"], "Alert crashloop fired")
+	require.Contains(t, received["text"], "- prod/v1/Pod/default/web-0")
+}
+
+func TestSendFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sender := NewSender(SMTPConfig{})
+	err := sender.Send(context.Background(), entity.AlertChannel{Type: entity.AlertChannelWebhook, URL: server.URL}, newTestNotification())
+	require.ErrorContains(t, err, "status 403: invalid token")
+}
+
+func TestSendEmail(t *testing.T) {
+	sender := NewSender(SMTPConfig{})
+	err := sender.Send(context.Background(), entity.AlertChannel{Type: entity.AlertChannelEmail, To: []string{"ops@example.com"}}, newTestNotification())
+	require.ErrorIs(t, err, ErrSMTPNotConfigured)
+
+	var (
+		addr string
+		auth smtp.Auth
+		to   []string
+		msg  []byte
+	)
+	original := sendMail
+	sendMail = func(a string, au smtp.Auth, from string, t []string, m []byte) error {
+		addr, auth, to, msg = a, au, t, m
+		return nil
+	}
+	defer func() { sendMail = original }()
+
+	sender = NewSender(SMTPConfig{Address: "smtp.example.com:587", From: "karpor@example.com", Username: "karpor", Password: "secret"})
+	err = sender.Send(context.Background(), entity.AlertChannel{Type: entity.AlertChannelEmail, To: []string{"ops@example.com"}}, newTestNotification())
+	require.NoError(t, err)
+	require.Equal(t, "smtp.example.com:587", addr)
+	require.NotNil(t, auth)
+	require.Equal(t, []string{"ops@example.com"}, to)
+	require.Contains(t, string(msg), "To: ops@example.com\r\n")
+	require.Contains(t, string(msg), "Subject: [karpor] Alert crashloop fired\r\n")
+	require.Contains(t, string(msg), "- prod/v1/Pod/default/web-0\r\n")
+}
+
+func TestText(t *testing.T) {
+	n := newTestNotification()
+	n.Appeared = nil
+	for i := 0; i < maxListedObjects+5; i++ {
+		n.Disappeared = append(n.Disappeared, "object")
+	}
+	text := Text(n)
+	require.Contains(t, text, "Disappeared (25):")
+	require.Contains(t, text, "- ... and 5 more")
+	require.NotContains(t, text, "Appeared")
+	require.Contains(t, text, "Fired at 2024-01-01T00:00:00Z")
+}
@@ -135,6 +135,50 @@ func (cl *Client) UpdateDocument(
 	return nil
 }
 
+// Bulk performs the actions of the newline delimited JSON body in the
+// specified index in a single request. An error is returned if any of the
+// actions fails, with the reason of the first failure.
+func (cl *Client) Bulk(ctx context.Context, indexName string, body io.Reader) error {
+	resp, err := cl.client.Bulk(body, cl.client.Bulk.WithContext(ctx), cl.client.Bulk.WithIndex(indexName))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return &ESError{
+			StatusCode: resp.StatusCode,
+			Message:    resp.String(),
+		}
+	}
+
+	br := &BulkResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(br); err != nil {
+		return err
+	}
+	if !br.Errors {
+		return nil
+	}
+	failed := 0
+	var first *BulkItemResult
+	for _, item := range br.Items {
+		for _, result := range item {
+			if result != nil && result.Error != nil {
+				failed++
+				if first == nil {
+					first = result
+				}
+			}
+		}
+	}
+	if first == nil {
+		return &ESError{StatusCode: resp.StatusCode, Message: "bulk request failed"}
+	}
+	return &ESError{
+		StatusCode: first.Status,
+		Message:    fmt.Sprintf("%d of %d bulk actions failed, the first on document %s: %v", failed, len(br.Items), first.ID, first.Error),
+	}
+}
+
 // DeleteDocument deletes a document with the specified ID
 func (cl *Client) DeleteDocument(ctx context.Context, indexName, documentID string) error {
 	if _, err := cl.GetDocument(ctx, indexName, documentID); err != nil {
@@ -179,6 +223,32 @@ func (cl *Client) DeleteDocumentByQuery(
 	return nil
 }
 
+// UpdateDocumentByQuery updates documents in the specified index based on the
+// provided query and script in the body.
+func (cl *Client) UpdateDocumentByQuery(
+	ctx context.Context,
+	indexName string,
+	body io.Reader,
+) error {
+	resp, err := cl.client.UpdateByQuery(
+		[]string{indexName},
+		cl.client.UpdateByQuery.WithBody(body),
+		cl.client.UpdateByQuery.WithContext(ctx),
+		cl.client.UpdateByQuery.WithConflicts("proceed"),
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.IsError() {
+		return &ESError{
+			StatusCode: resp.StatusCode,
+			Message:    resp.String(),
+		}
+	}
+	return nil
+}
+
 // SearchDocument performs a search query in the specified index
 func (cl *Client) SearchDocument(
 	ctx context.Context,
@@ -226,11 +296,24 @@ func (cl *Client) SearchDocument(
 func (cl *Client) Count(
 	ctx context.Context,
 	indexName string,
+) (*CountResponse, error) {
+	return cl.CountByQuery(ctx, indexName, nil)
+}
+
+// CountByQuery counts the documents matching the query of the body in the
+// specified index, all documents are counted if the body is nil.
+func (cl *Client) CountByQuery(
+	ctx context.Context,
+	indexName string,
+	body io.Reader,
 ) (*CountResponse, error) {
 	opts := []func(*esapi.CountRequest){
 		cl.client.Count.WithContext(ctx),
 		cl.client.Count.WithIndex(indexName),
 	}
+	if body != nil {
+		opts = append(opts, cl.client.Count.WithBody(body))
+	}
 
 	resp, err := cl.client.Count(opts...)
 	if err != nil {
@@ -318,16 +401,17 @@ func (cl *Client) SearchDocumentByTerms(ctx context.Context, index string, keysA
 }
 
 // AggregateDocumentByTerms performs an aggregation query based on the provided fields.
-func (cl *Client) AggregateDocumentByTerms(ctx context.Context, index string, fields []string) (*AggResults, error) {
+// Only the documents matching the query are aggregated if the query is not nil.
+func (cl *Client) AggregateDocumentByTerms(ctx context.Context, index string, fields []string, query map[string]interface{}) (*AggResults, error) {
 	if len(fields) == 0 {
 		return nil, fmt.Errorf("no fields provided for aggregation")
 	}
 	if len(fields) == 1 {
 		// Perform single-term aggregation if only one field is provided.
-		return cl.termsAgg(ctx, index, fields[0])
+		return cl.termsAgg(ctx, index, fields[0], query)
 	}
 	// Perform multi-term aggregation if multiple fields are provided.
-	return cl.multiTermsAgg(ctx, index, fields)
+	return cl.multiTermsAgg(ctx, index, fields, query)
 }
 
 // Refresh refresh specified index in Elasticsearch.
@@ -348,7 +432,7 @@ func (cl *Client) Refresh(
 }
 
 // multiTermsAggSearch executes a multi-term aggregation query on specified fields.
-func (cl *Client) multiTermsAgg(ctx context.Context, index string, fields []string) (*AggResults, error) {
+func (cl *Client) multiTermsAgg(ctx context.Context, index string, fields []string, query map[string]interface{}) (*AggResults, error) {
 	// Construct the terms for multi-term aggregation based on the fields.
 	terms := make([]types.MultiTermLookup, len(fields))
 	for i := range fields {
@@ -357,23 +441,19 @@ func (cl *Client) multiTermsAgg(ctx context.Context, index string, fields []stri
 
 	// Execute the search request with the constructed multi-term aggregation.
 	name := strings.Join(fields, "-")
-	resp, err := cl.typedClient.
-		Search().
-		Index(index).
-		Request(&search.Request{
-			// Set the number of search hits to return to 0 as we only need aggregation data.
-			Size: some.Int(0),
-			Aggregations: map[string]types.Aggregations{
-				name: {
-					MultiTerms: &types.MultiTermsAggregation{
-						Terms: terms,
-						// maxAggSize should be predefined to limit the size of the aggregation.
-						Size: some.Int(maxAggSize),
-					},
+	resp, err := cl.searchAgg(ctx, index, &search.Request{
+		// Set the number of search hits to return to 0 as we only need aggregation data.
+		Size: some.Int(0),
+		Aggregations: map[string]types.Aggregations{
+			name: {
+				MultiTerms: &types.MultiTermsAggregation{
+					Terms: terms,
+					// maxAggSize should be predefined to limit the size of the aggregation.
+					Size: some.Int(maxAggSize),
 				},
 			},
-		}).
-		Do(ctx)
+		},
+	}, query)
 	if err != nil {
 		return nil, err
 	}
@@ -398,25 +478,21 @@ func (cl *Client) multiTermsAgg(ctx context.Context, index string, fields []stri
 }
 
 // termsAgg executes a single-term aggregation query on the specified field.
-func (cl *Client) termsAgg(ctx context.Context, index, field string) (*AggResults, error) {
+func (cl *Client) termsAgg(ctx context.Context, index, field string, query map[string]interface{}) (*AggResults, error) {
 	// Execute the search request with the single-term aggregation.
-	resp, err := cl.typedClient.
-		Search().
-		Index(index).
-		Request(&search.Request{
-			// Set the number of search hits to return to 0 as we only need aggregation data.
-			Size: some.Int(0),
-			Aggregations: map[string]types.Aggregations{
-				field: {
-					Terms: &types.TermsAggregation{
-						Field: some.String(field),
-						// maxAggSize should be predefined to limit the size of the aggregation.
-						Size: some.Int(maxAggSize),
-					},
+	resp, err := cl.searchAgg(ctx, index, &search.Request{
+		// Set the number of search hits to return to 0 as we only need aggregation data.
+		Size: some.Int(0),
+		Aggregations: map[string]types.Aggregations{
+			field: {
+				Terms: &types.TermsAggregation{
+					Field: some.String(field),
+					// maxAggSize should be predefined to limit the size of the aggregation.
+					Size: some.Int(maxAggSize),
 				},
 			},
-		}).
-		Do(ctx)
+		},
+	}, query)
 	if err != nil {
 		return nil, err
 	}
@@ -436,6 +512,31 @@ func (cl *Client) termsAgg(ctx context.Context, index, field string) (*AggResult
 	}, nil
 }
 
+// searchAgg executes the search request of the aggregations. The request is
+// sent as a raw body with the query if the query is not nil, since the typed
+// query does not cover every query the query maps may contain.
+func (cl *Client) searchAgg(ctx context.Context, index string, req *search.Request, query map[string]interface{}) (*search.Response, error) {
+	s := cl.typedClient.Search().Index(index)
+	if query == nil {
+		return s.Request(req).Do(ctx)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	raw["query"] = query
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(raw); err != nil {
+		return nil, err
+	}
+	return s.Raw(buf).Do(ctx)
+}
+
 // CheckElasticSearchLiveness would ping ElasticSearch client
 func (cl *Client) CheckElasticSearchLiveness(ctx context.Context) error {
 	_, err := cl.client.Info(cl.client.Info.WithContext(ctx))
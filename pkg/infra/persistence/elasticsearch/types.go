@@ -99,6 +99,22 @@ type Hit struct {
 	ID     string                 `json:"_id"`
 	Score  float32                `json:"_score"`
 	Source map[string]interface{} `json:"_source"`
+	// Sort is the sort values of the hit, which are set when the search is
+	// sorted and are used to search after the hit.
+	Sort []interface{} `json:"sort,omitempty"`
+}
+
+// BulkResponse represents the response structure for a bulk operation.
+type BulkResponse struct {
+	Errors bool                         `json:"errors"`
+	Items  []map[string]*BulkItemResult `json:"items"`
+}
+
+// BulkItemResult is the result of a single action of a bulk operation.
+type BulkItemResult struct {
+	ID     string                 `json:"_id"`
+	Status int                    `json:"status"`
+	Error  map[string]interface{} `json:"error,omitempty"`
 }
 
 // AggResults is assumed to be a struct that holds aggregation results.
@@ -0,0 +1,203 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exception applies user-provided AuditException objects to scan
+// results, so that accepted risks are flagged as suppressed and left out of
+// the scores while staying visible in the audit.
+package exception
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Source provides the audit exceptions to be applied by the suppressor.
+type Source interface {
+	ListAuditExceptions(ctx context.Context) ([]searchv1beta1.AuditException, error)
+}
+
+// Suppressor flags the issues of a scan result which are covered by an
+// active audit exception.
+type Suppressor struct {
+	source Source
+	now    func() time.Time
+}
+
+// activeException is an audit exception which has not expired, with its
+// label selector parsed.
+type activeException struct {
+	name     string
+	spec     searchv1beta1.AuditExceptionSpec
+	selector labels.Selector
+}
+
+// New creates a suppressor which applies the audit exceptions provided by
+// the given source.
+func New(source Source) *Suppressor {
+	return &Suppressor{source: source, now: time.Now}
+}
+
+// Apply returns a copy of the scan result in which the issues covered by an
+// active audit exception are flagged as suppressed. The given result is left
+// untouched, so that it can be cached and re-evaluated once exceptions change
+// or expire.
+func (s *Suppressor) Apply(ctx context.Context, result scanner.ScanResult) (scanner.ScanResult, error) {
+	exceptions, err := s.active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(exceptions) == 0 {
+		return result, nil
+	}
+
+	applied := scanner.NewScanResult()
+	// Keep the resources without issues, they still count in the score.
+	for _, resource := range result.Resources() {
+		applied.Add(resource, nil)
+	}
+	for issue, resources := range result.ByIssue() {
+		for _, resource := range resources {
+			issue := issue
+			for _, e := range exceptions {
+				if e.matches(&issue, resource) {
+					issue.Suppressed = true
+					issue.Exception = e.name
+					break
+				}
+			}
+			applied.Add(resource, scanner.IssueList{&issue})
+		}
+	}
+
+	return applied, nil
+}
+
+// Unsuppressed returns the audit issues which are not covered by an active
+// audit exception. The issues are matched against the labels the resources
+// had when the issues were last seen.
+func (s *Suppressor) Unsuppressed(ctx context.Context, issues []*entity.AuditIssue) ([]*entity.AuditIssue, error) {
+	exceptions, err := s.active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(exceptions) == 0 {
+		return issues, nil
+	}
+
+	kept := make([]*entity.AuditIssue, 0, len(issues))
+	for _, auditIssue := range issues {
+		issue := &scanner.Issue{Scanner: auditIssue.Scanner, Title: auditIssue.Title}
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetLabels(auditIssue.Labels)
+		res := &storage.Resource{ResourceGroup: auditIssue.Resource, Object: obj.Object}
+
+		suppressed := false
+		for _, e := range exceptions {
+			if e.matches(issue, res) {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, auditIssue)
+		}
+	}
+
+	return kept, nil
+}
+
+// active lists the audit exceptions from the source and returns the ones
+// which have not expired. Exceptions with an invalid label selector are
+// skipped.
+func (s *Suppressor) active(ctx context.Context) ([]*activeException, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	list, err := s.source.ListAuditExceptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	exceptions := make([]*activeException, 0, len(list))
+	for i := range list {
+		e := &list[i]
+		if e.Spec.ExpiresAt != nil && !now.Before(e.Spec.ExpiresAt.Time) {
+			continue
+		}
+
+		selector := labels.Everything()
+		if e.Spec.Match.LabelSelector != nil {
+			if selector, err = metav1.LabelSelectorAsSelector(e.Spec.Match.LabelSelector); err != nil {
+				log.Error(err, "Skipping invalid audit exception", "exception", e.Name)
+				continue
+			}
+		}
+
+		exceptions = append(exceptions, &activeException{
+			name:     e.Name,
+			spec:     e.Spec,
+			selector: selector,
+		})
+	}
+
+	return exceptions, nil
+}
+
+// matches reports whether the exception covers the given issue of the
+// resource.
+func (e *activeException) matches(issue *scanner.Issue, res *storage.Resource) bool {
+	if e.spec.Scanner != "" && !strings.EqualFold(e.spec.Scanner, issue.Scanner) {
+		return false
+	}
+	if e.spec.Title != "" && e.spec.Title != issue.Title {
+		return false
+	}
+
+	match := e.spec.Match
+	if !containsOrEmpty(match.Clusters, res.Cluster, false) ||
+		!containsOrEmpty(match.APIVersions, res.APIVersion, false) ||
+		!containsOrEmpty(match.Kinds, res.Kind, true) ||
+		!containsOrEmpty(match.Namespaces, res.Namespace, false) {
+		return false
+	}
+
+	if e.selector.Empty() {
+		return true
+	}
+	obj := &unstructured.Unstructured{Object: res.Object}
+	return e.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// containsOrEmpty reports whether the list is empty or contains the value.
+func containsOrEmpty(list []string, value string, ignoreCase bool) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value || (ignoreCase && strings.EqualFold(item, value)) {
+			return true
+		}
+	}
+	return false
+}
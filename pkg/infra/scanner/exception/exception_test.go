@@ -0,0 +1,185 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newException(name string, spec searchv1beta1.AuditExceptionSpec) searchv1beta1.AuditException {
+	spec.Justification = "accepted risk"
+	spec.Owner = "platform-team"
+	return searchv1beta1.AuditException{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func newResource(cluster, namespace, name string, labels map[string]interface{}) *storage.Resource {
+	return &storage.Resource{
+		ResourceGroup: entity.ResourceGroup{
+			Cluster:    cluster,
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    labels,
+			},
+		},
+	}
+}
+
+func TestSuppressor_Apply(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	privileged := scanner.Issue{Scanner: "kubeaudit", Severity: scanner.High, Title: "Privileged container", Message: "privileged"}
+	latestTag := scanner.Issue{Scanner: "kubeaudit", Severity: scanner.Low, Title: "Image latest tag", Message: "latest"}
+
+	legacy := newResource("prod", "legacy", "app", map[string]interface{}{"tier": "legacy"})
+	payments := newResource("prod", "payments", "api", map[string]interface{}{"tier": "backend"})
+	clean := newResource("prod", "payments", "clean", nil)
+
+	result := scanner.NewScanResult()
+	result.Add(legacy, scanner.IssueList{&privileged, &latestTag})
+	result.Add(payments, scanner.IssueList{&privileged})
+	result.Add(clean, nil)
+
+	tests := []struct {
+		name             string
+		exceptions       StaticSource
+		expectSuppressed map[string]string
+	}{
+		{
+			name: "no exceptions",
+		},
+		{
+			name: "suppress by title and label selector",
+			exceptions: StaticSource{
+				newException("legacy-privileged", searchv1beta1.AuditExceptionSpec{
+					Scanner: "KubeAudit",
+					Title:   "Privileged container",
+					Match: searchv1beta1.ScanPolicyMatch{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "legacy"}},
+					},
+				}),
+			},
+			expectSuppressed: map[string]string{"app/Privileged container": "legacy-privileged"},
+		},
+		{
+			name: "suppress all issues in a namespace",
+			exceptions: StaticSource{
+				newException("payments", searchv1beta1.AuditExceptionSpec{
+					Match: searchv1beta1.ScanPolicyMatch{Clusters: []string{"prod"}, Namespaces: []string{"payments"}, Kinds: []string{"deployment"}},
+				}),
+			},
+			expectSuppressed: map[string]string{"api/Privileged container": "payments"},
+		},
+		{
+			name: "expired exceptions are ignored",
+			exceptions: StaticSource{
+				newException("expired", searchv1beta1.AuditExceptionSpec{
+					ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)},
+				}),
+			},
+		},
+		{
+			name: "unexpired exceptions apply",
+			exceptions: StaticSource{
+				newException("latest-tag", searchv1beta1.AuditExceptionSpec{
+					Title:     "Image latest tag",
+					ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)},
+				}),
+			},
+			expectSuppressed: map[string]string{"app/Image latest tag": "latest-tag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.exceptions)
+			s.now = func() time.Time { return now }
+
+			applied, err := s.Apply(context.Background(), result)
+			require.NoError(t, err)
+			require.Len(t, applied.ByResource(), 3)
+			require.Empty(t, applied.ByResource()[clean.ResourceGroup.Hash()])
+
+			suppressed := map[string]string{}
+			total := 0
+			for issue, resources := range applied.ByIssue() {
+				for _, res := range resources {
+					total++
+					if issue.Suppressed {
+						suppressed[res.Name+"/"+issue.Title] = issue.Exception
+					}
+				}
+			}
+			require.Equal(t, 3, total)
+			if tt.expectSuppressed == nil {
+				tt.expectSuppressed = map[string]string{}
+			}
+			require.Equal(t, tt.expectSuppressed, suppressed)
+		})
+	}
+
+	// The original result is left untouched.
+	for issue := range result.ByIssue() {
+		require.False(t, issue.Suppressed)
+	}
+}
+
+func TestSuppressor_Unsuppressed(t *testing.T) {
+	newIssue := func(namespace, title string, labels map[string]string) *entity.AuditIssue {
+		return &entity.AuditIssue{
+			Resource: entity.ResourceGroup{Cluster: "prod", APIVersion: "apps/v1", Kind: "Deployment", Namespace: namespace, Name: "app"},
+			Labels:   labels,
+			Scanner:  "kubeaudit",
+			Title:    title,
+		}
+	}
+	legacy := newIssue("legacy", "Privileged container", map[string]string{"tier": "legacy"})
+	payments := newIssue("payments", "Privileged container", map[string]string{"tier": "backend"})
+	latestTag := newIssue("legacy", "Image latest tag", map[string]string{"tier": "legacy"})
+	issues := []*entity.AuditIssue{legacy, payments, latestTag}
+
+	s := New(StaticSource{
+		newException("legacy-privileged", searchv1beta1.AuditExceptionSpec{
+			Title: "Privileged container",
+			Match: searchv1beta1.ScanPolicyMatch{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "legacy"}},
+			},
+		}),
+	})
+	kept, err := s.Unsuppressed(context.Background(), issues)
+	require.NoError(t, err)
+	require.Equal(t, []*entity.AuditIssue{payments, latestTag}, kept)
+
+	kept, err = New(StaticSource{}).Unsuppressed(context.Background(), issues)
+	require.NoError(t, err)
+	require.Equal(t, issues, kept)
+}
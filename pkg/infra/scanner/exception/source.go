@@ -0,0 +1,60 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exception
+
+import (
+	"context"
+
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+// Ensure that loopbackSource implements the Source interface.
+var _ Source = &loopbackSource{}
+
+// loopbackSource is a Source which lists audit exceptions from the karpor
+// apiserver itself through the loopback client.
+type loopbackSource struct {
+	genericConfig *genericapiserver.CompletedConfig
+}
+
+// NewLoopbackSource returns a Source which lists audit exceptions from the karpor
+// apiserver through the loopback client of the given config.
+func NewLoopbackSource(genericConfig *genericapiserver.CompletedConfig) Source {
+	return &loopbackSource{genericConfig: genericConfig}
+}
+
+// ListAuditExceptions lists all audit exceptions from the karpor apiserver.
+func (s *loopbackSource) ListAuditExceptions(ctx context.Context) ([]searchv1beta1.AuditException, error) {
+	client, err := versioned.NewForConfig(s.genericConfig.LoopbackClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.SearchV1beta1().AuditExceptions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// StaticSource is a Source which always returns the same audit exceptions.
+type StaticSource []searchv1beta1.AuditException
+
+// ListAuditExceptions returns the audit exceptions held by the static source.
+func (s StaticSource) ListAuditExceptions(ctx context.Context) ([]searchv1beta1.AuditException, error) {
+	return s, nil
+}
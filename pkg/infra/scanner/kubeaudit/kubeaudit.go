@@ -132,7 +132,7 @@ func (s *kubeauditScanner) Scan(ctx context.Context, noCache bool, resources ...
 		close(errChan)
 	}()
 
-	allResults := newScanResult()
+	allResults := scanner.NewScanResult()
 
 	for report := range resultsChan {
 		allResults.MergeFrom(report)
@@ -178,15 +178,15 @@ func (s *kubeauditScanner) scanManifestFor(ctx context.Context, resource *storag
 	}
 	result := results[0]
 
-	r := newScanResult()
-	issues := []*scanner.Issue{}
+	r := scanner.NewScanResult()
+	issues := scanner.IssueList{}
 	for _, auditResult := range result.GetAuditResults() {
 		newIssue := AuditResult2Issue(auditResult)
 		if int(newIssue.Severity) >= int(s.attentionLevel) {
 			issues = append(issues, newIssue)
 		}
 	}
-	r.add(resource, issues)
+	r.Add(resource, issues)
 	s.c.Set(resource.ResourceGroup.Hash(), r)
 
 	return r, nil
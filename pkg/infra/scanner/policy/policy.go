@@ -0,0 +1,300 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy provides a scanner.KubeScanner implementation which evaluates
+// user-provided ScanPolicy objects against Kubernetes resources, so that
+// organization-specific compliance rules can be audited alongside the built-in
+// security checks.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/KusionStack/karpor/pkg/util/ctxutil"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ScannerName is the name of the scanner.
+const ScannerName = "Policy"
+
+// Variable names which are available in the CEL expression of a policy rule.
+const (
+	objectVariable  = "object"
+	clusterVariable = "cluster"
+)
+
+// Limits of the evaluation of a policy rule, so that a rule written by any
+// user can't hang the scans. The cost limit is the same as the one of the
+// CEL expressions in Kubernetes validation rules.
+const (
+	ruleCostLimit              = 1000000
+	ruleInterruptCheckInterval = 100
+)
+
+// Ensure that policyScanner implements the scanner.KubeScanner interface.
+var _ scanner.KubeScanner = &policyScanner{}
+
+// Source provides the scan policies to be evaluated by the policy scanner.
+type Source interface {
+	ListScanPolicies(ctx context.Context) ([]searchv1beta1.ScanPolicy, error)
+}
+
+// policyScanner is an implementation of scanner.KubeScanner that evaluates
+// the scan policies provided by the source against resources.
+type policyScanner struct {
+	source Source
+	env    *cel.Env
+
+	// compiled caches the compiled policies by uid, and is invalidated when
+	// the resource version of a policy changes.
+	compiled map[types.UID]*compiledPolicy
+	lock     sync.Mutex
+}
+
+// compiledPolicy is a scan policy with its rule compiled and its severity and
+// selectors parsed, ready to be evaluated.
+type compiledPolicy struct {
+	resourceVersion string
+	name            string
+	title           string
+	message         string
+	severity        scanner.IssueSeverityLevel
+	match           searchv1beta1.ScanPolicyMatch
+	selector        labels.Selector
+	program         cel.Program
+}
+
+// New creates a new instance of the policy scanner which evaluates the scan
+// policies provided by the given source.
+func New(source Source) (scanner.KubeScanner, error) {
+	env, err := NewEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyScanner{
+		source:   source,
+		env:      env,
+		compiled: map[types.UID]*compiledPolicy{},
+	}, nil
+}
+
+// NewEnv creates the CEL environment in which policy rules are compiled.
+func NewEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(objectVariable, cel.DynType),
+		cel.Variable(clusterVariable, cel.StringType),
+		ext.Strings(),
+	)
+}
+
+// Name returns the name of the policy scanner.
+func (s *policyScanner) Name() string {
+	return ScannerName
+}
+
+// Scan evaluates all scan policies against the provided resources and returns
+// the violations found. Policies which fail to compile are skipped, and so are
+// resources on which a rule fails to evaluate, e.g. because of a missing field.
+// The noCache flag is ignored since policies are cheap to evaluate and may
+// change at any time.
+func (s *policyScanner) Scan(ctx context.Context, noCache bool, resources ...*storage.Resource) (scanner.ScanResult, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	policies, err := s.policies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := scanner.NewScanResult()
+	for _, res := range resources {
+		issues := scanner.IssueList{}
+		for _, p := range policies {
+			if !p.matches(res) {
+				continue
+			}
+
+			violated, message, err := p.evaluate(ctx, res)
+			if err != nil {
+				log.V(1).Info("Failed to evaluate scan policy", "policy", p.name, "resource", res.ResourceGroup, "error", err.Error())
+				continue
+			}
+			if violated {
+				issues = append(issues, &scanner.Issue{
+					Scanner:  ScannerName,
+					Severity: p.severity,
+					Title:    p.title,
+					Message:  message,
+				})
+			}
+		}
+		result.Add(res, issues)
+	}
+
+	return result, nil
+}
+
+// policies lists the scan policies from the source and returns them compiled.
+func (s *policyScanner) policies(ctx context.Context) ([]*compiledPolicy, error) {
+	log := ctxutil.GetLogger(ctx)
+
+	list, err := s.source.ListScanPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	seen := make(map[types.UID]struct{}, len(list))
+	policies := make([]*compiledPolicy, 0, len(list))
+	for i := range list {
+		p := &list[i]
+		seen[p.UID] = struct{}{}
+
+		if cp, ok := s.compiled[p.UID]; ok && cp.resourceVersion == p.ResourceVersion {
+			policies = append(policies, cp)
+			continue
+		}
+
+		cp, err := compile(s.env, p)
+		if err != nil {
+			log.Error(err, "Skipping invalid scan policy", "policy", p.Name)
+			delete(s.compiled, p.UID)
+			continue
+		}
+		s.compiled[p.UID] = cp
+		policies = append(policies, cp)
+	}
+
+	// Drop the policies that no longer exist.
+	for uid := range s.compiled {
+		if _, ok := seen[uid]; !ok {
+			delete(s.compiled, uid)
+		}
+	}
+
+	return policies, nil
+}
+
+// compile validates the given scan policy and compiles its rule in the given
+// CEL environment.
+func compile(env *cel.Env, p *searchv1beta1.ScanPolicy) (*compiledPolicy, error) {
+	if p.Spec.Engine != "" && !strings.EqualFold(p.Spec.Engine, searchv1beta1.ScanPolicyEngineCEL) {
+		return nil, fmt.Errorf("unsupported policy engine: %s", p.Spec.Engine)
+	}
+
+	severity, err := scanner.ParseSeverity(p.Spec.Severity)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.Everything()
+	if p.Spec.Match.LabelSelector != nil {
+		if selector, err = metav1.LabelSelectorAsSelector(p.Spec.Match.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	ast, issues := env.Compile(p.Spec.Rule)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	program, err := env.Program(ast, cel.CostLimit(ruleCostLimit), cel.InterruptCheckFrequency(ruleInterruptCheckInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	title := p.Spec.Title
+	if title == "" {
+		title = p.Name
+	}
+	message := p.Spec.Message
+	if message == "" {
+		message = fmt.Sprintf("The resource violates the scan policy %s", p.Name)
+	}
+
+	return &compiledPolicy{
+		resourceVersion: p.ResourceVersion,
+		name:            p.Name,
+		title:           title,
+		message:         message,
+		severity:        severity,
+		match:           p.Spec.Match,
+		selector:        selector,
+		program:         program,
+	}, nil
+}
+
+// matches reports whether the policy applies to the given resource.
+func (p *compiledPolicy) matches(res *storage.Resource) bool {
+	if !containsOrEmpty(p.match.Clusters, res.Cluster, false) ||
+		!containsOrEmpty(p.match.APIVersions, res.APIVersion, false) ||
+		!containsOrEmpty(p.match.Kinds, res.Kind, true) ||
+		!containsOrEmpty(p.match.Namespaces, res.Namespace, false) {
+		return false
+	}
+
+	if p.selector.Empty() {
+		return true
+	}
+	obj := &unstructured.Unstructured{Object: res.Object}
+	return p.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// evaluate runs the policy rule against the given resource, and returns
+// whether the resource violates the policy along with the violation message.
+// The evaluation fails once it exceeds the cost limit or the context is done.
+func (p *compiledPolicy) evaluate(ctx context.Context, res *storage.Resource) (bool, string, error) {
+	out, _, err := p.program.ContextEval(ctx, map[string]any{
+		objectVariable:  res.Object,
+		clusterVariable: res.Cluster,
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	switch v := out.Value().(type) {
+	case bool:
+		return v, p.message, nil
+	case string:
+		return v != "", v, nil
+	default:
+		return false, "", fmt.Errorf("rule must evaluate to a bool or a string, got %T", v)
+	}
+}
+
+// containsOrEmpty reports whether the list is empty or contains the value.
+func containsOrEmpty(list []string, value string, ignoreCase bool) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value || (ignoreCase && strings.EqualFold(item, value)) {
+			return true
+		}
+	}
+	return false
+}
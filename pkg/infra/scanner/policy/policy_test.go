@@ -0,0 +1,245 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newPolicy(name string, spec searchv1beta1.ScanPolicySpec) searchv1beta1.ScanPolicy {
+	return searchv1beta1.ScanPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name), ResourceVersion: "1"},
+		Spec:       spec,
+	}
+}
+
+func newDeployment(cluster, namespace, name string, labels map[string]interface{}, image string) *storage.Resource {
+	return &storage.Resource{
+		ResourceGroup: entity.ResourceGroup{
+			Cluster:    cluster,
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "main", "image": image},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPolicyScanner_Scan(t *testing.T) {
+	policies := StaticSource{
+		newPolicy("required-team-label", searchv1beta1.ScanPolicySpec{
+			Title:    "Missing team label",
+			Severity: "Medium",
+			Rule:     "!('team' in object.metadata.labels)",
+		}),
+		newPolicy("approved-registry", searchv1beta1.ScanPolicySpec{
+			Severity: "High",
+			Match:    searchv1beta1.ScanPolicyMatch{Kinds: []string{"deployment"}, Clusters: []string{"prod"}},
+			Rule:     "object.spec.template.spec.containers.exists(c, !c.image.startsWith('registry.example.com/')) ? 'image from unapproved registry' : ''",
+		}),
+		newPolicy("invalid-rule", searchv1beta1.ScanPolicySpec{
+			Severity: "Low",
+			Rule:     "object.metadata.",
+		}),
+	}
+
+	s, err := New(policies)
+	require.NoError(t, err)
+	require.Equal(t, ScannerName, s.Name())
+
+	compliant := newDeployment("prod", "default", "compliant", map[string]interface{}{"team": "payments"}, "registry.example.com/app:v1")
+	violating := newDeployment("prod", "default", "violating", map[string]interface{}{"app": "foo"}, "docker.io/library/nginx")
+	otherCluster := newDeployment("dev", "default", "other", map[string]interface{}{"team": "payments"}, "docker.io/library/nginx")
+
+	result, err := s.Scan(context.Background(), false, compliant, violating, otherCluster)
+	require.NoError(t, err)
+	require.Len(t, result.ByResource(), 3)
+	require.Empty(t, result.ByResource()[compliant.ResourceGroup.Hash()])
+	require.Empty(t, result.ByResource()[otherCluster.ResourceGroup.Hash()])
+
+	issues := result.ByResource()[violating.ResourceGroup.Hash()]
+	require.Len(t, issues, 2)
+	require.ElementsMatch(t, scanner.IssueList{
+		{
+			Scanner:  ScannerName,
+			Severity: scanner.Medium,
+			Title:    "Missing team label",
+			Message:  "The resource violates the scan policy required-team-label",
+		},
+		{
+			Scanner:  ScannerName,
+			Severity: scanner.High,
+			Title:    "approved-registry",
+			Message:  "image from unapproved registry",
+		},
+	}, issues)
+}
+
+func TestPolicyScanner_LabelSelector(t *testing.T) {
+	policies := StaticSource{
+		newPolicy("prod-only", searchv1beta1.ScanPolicySpec{
+			Severity: "Low",
+			Match: searchv1beta1.ScanPolicyMatch{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			Rule: "true",
+		}),
+	}
+
+	s, err := New(policies)
+	require.NoError(t, err)
+
+	prod := newDeployment("c", "ns", "prod", map[string]interface{}{"env": "prod"}, "nginx")
+	dev := newDeployment("c", "ns", "dev", map[string]interface{}{"env": "dev"}, "nginx")
+
+	result, err := s.Scan(context.Background(), false, prod, dev)
+	require.NoError(t, err)
+	require.Len(t, result.ByResource()[prod.ResourceGroup.Hash()], 1)
+	require.Empty(t, result.ByResource()[dev.ResourceGroup.Hash()])
+}
+
+func TestCompile(t *testing.T) {
+	env, err := NewEnv()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		spec        searchv1beta1.ScanPolicySpec
+		expectError bool
+	}{
+		{
+			name: "valid CEL rule",
+			spec: searchv1beta1.ScanPolicySpec{Severity: "high", Engine: "CEL", Rule: "cluster == 'prod'"},
+		},
+		{
+			name:        "unsupported engine",
+			spec:        searchv1beta1.ScanPolicySpec{Severity: "High", Engine: "Rego", Rule: "true"},
+			expectError: true,
+		},
+		{
+			name:        "invalid severity",
+			spec:        searchv1beta1.ScanPolicySpec{Severity: "Urgent", Rule: "true"},
+			expectError: true,
+		},
+		{
+			name:        "syntax error",
+			spec:        searchv1beta1.ScanPolicySpec{Severity: "Low", Rule: "object.("},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPolicy("test", tt.spec)
+			_, err := compile(env, &p)
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateCostLimit(t *testing.T) {
+	env, err := NewEnv()
+	require.NoError(t, err)
+
+	// The rule iterates over the labels three times nested, which costs far
+	// more than the limit for a few hundred labels.
+	p := newPolicy("expensive", searchv1beta1.ScanPolicySpec{
+		Severity: "Low",
+		Rule:     "object.metadata.labels.all(a, object.metadata.labels.all(b, object.metadata.labels.all(c, a != '' && b != '' && c != '')))",
+	})
+	compiled, err := compile(env, &p)
+	require.NoError(t, err)
+
+	labels := map[string]interface{}{}
+	for i := 0; i < 300; i++ {
+		labels[fmt.Sprintf("label-%d", i)] = "value"
+	}
+	res := newDeployment("prod", "default", "many-labels", labels, "nginx")
+
+	start := time.Now()
+	_, _, err = compiled.evaluate(context.Background(), res)
+	require.ErrorContains(t, err, "cost limit exceeded")
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	// The scan goes on without an issue of the policy.
+	s, err := New(StaticSource{p})
+	require.NoError(t, err)
+	result, err := s.Scan(context.Background(), false, res)
+	require.NoError(t, err)
+	require.Empty(t, result.ByResource()[res.ResourceGroup.Hash()])
+}
+
+// countingSource counts the lists of the scan policies.
+type countingSource struct {
+	StaticSource
+	lists int
+}
+
+func (s *countingSource) ListScanPolicies(ctx context.Context) ([]searchv1beta1.ScanPolicy, error) {
+	s.lists++
+	return s.StaticSource, nil
+}
+
+func TestCachedSource(t *testing.T) {
+	source := &countingSource{StaticSource: StaticSource{newPolicy("p1", searchv1beta1.ScanPolicySpec{Rule: "true"})}}
+
+	cached := NewCachedSource(source, time.Hour)
+	for i := 0; i < 3; i++ {
+		policies, err := cached.ListScanPolicies(context.Background())
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+	}
+	require.Equal(t, 1, source.lists)
+
+	expired := NewCachedSource(source, 0)
+	for i := 0; i < 2; i++ {
+		_, err := expired.ListScanPolicies(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, source.lists)
+}
@@ -0,0 +1,101 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/generated/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+// Ensure that loopbackSource implements the Source interface.
+var _ Source = &loopbackSource{}
+
+// loopbackSource is a Source which lists scan policies from the karpor
+// apiserver itself through the loopback client.
+type loopbackSource struct {
+	genericConfig *genericapiserver.CompletedConfig
+}
+
+// loopbackCacheTTL is how long the scan policies listed through the loopback
+// client are reused, since a scan lists them once per page of resources.
+const loopbackCacheTTL = 30 * time.Second
+
+// NewLoopbackSource returns a Source which lists scan policies from the karpor
+// apiserver through the loopback client of the given config. The listed
+// policies are cached for a short time.
+func NewLoopbackSource(genericConfig *genericapiserver.CompletedConfig) Source {
+	return NewCachedSource(&loopbackSource{genericConfig: genericConfig}, loopbackCacheTTL)
+}
+
+// ListScanPolicies lists all scan policies from the karpor apiserver.
+func (s *loopbackSource) ListScanPolicies(ctx context.Context) ([]searchv1beta1.ScanPolicy, error) {
+	client, err := versioned.NewForConfig(s.genericConfig.LoopbackClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.SearchV1beta1().ScanPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// cachedSource is a Source which reuses the scan policies listed from another
+// source until they expire.
+type cachedSource struct {
+	source Source
+	ttl    time.Duration
+
+	lock     sync.Mutex
+	policies []searchv1beta1.ScanPolicy
+	listedAt time.Time
+}
+
+// NewCachedSource returns a Source which lists the scan policies from the
+// given source at most once per ttl. Failed lists are not cached.
+func NewCachedSource(source Source, ttl time.Duration) Source {
+	return &cachedSource{source: source, ttl: ttl}
+}
+
+// ListScanPolicies returns the cached scan policies, or lists them from the
+// underlying source if they have expired.
+func (s *cachedSource) ListScanPolicies(ctx context.Context) ([]searchv1beta1.ScanPolicy, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.listedAt.IsZero() && time.Since(s.listedAt) < s.ttl {
+		return s.policies, nil
+	}
+	policies, err := s.source.ListScanPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.policies, s.listedAt = policies, time.Now()
+	return policies, nil
+}
+
+// StaticSource is a Source which always returns the same scan policies.
+type StaticSource []searchv1beta1.ScanPolicy
+
+// ListScanPolicies returns the scan policies held by the static source.
+func (s StaticSource) ListScanPolicies(ctx context.Context) ([]searchv1beta1.ScanPolicy, error) {
+	return s, nil
+}
@@ -0,0 +1,131 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/util/safeutil"
+	"github.com/elliotxx/safe"
+	"github.com/pkg/errors"
+)
+
+// RegistryName is the name of the scanner registry.
+const RegistryName = "Registry"
+
+// Ensure that Registry implements the KubeScanner interface.
+var _ KubeScanner = &Registry{}
+
+// Registry is a KubeScanner which holds multiple scanners. Each scan is
+// dispatched to all registered scanners in parallel, and their results are
+// merged into a single ScanResult.
+type Registry struct {
+	scanners []KubeScanner
+	lock     sync.RWMutex
+}
+
+// NewRegistry creates a new scanner registry with the given scanners.
+func NewRegistry(scanners ...KubeScanner) (*Registry, error) {
+	r := &Registry{}
+	for _, s := range scanners {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a scanner to the registry. Scanner names must be unique.
+func (r *Registry) Register(s KubeScanner) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if s == nil {
+		return fmt.Errorf("scanner cannot be nil")
+	}
+	for _, existing := range r.scanners {
+		if existing.Name() == s.Name() {
+			return fmt.Errorf("scanner %s is already registered", s.Name())
+		}
+	}
+	r.scanners = append(r.scanners, s)
+	return nil
+}
+
+// Scanners returns the scanners registered in the registry.
+func (r *Registry) Scanners() []KubeScanner {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	scanners := make([]KubeScanner, len(r.scanners))
+	copy(scanners, r.scanners)
+	return scanners
+}
+
+// Name returns the name of the scanner registry.
+func (r *Registry) Name() string {
+	return RegistryName
+}
+
+// Scan runs all registered scanners against the provided resources in parallel
+// and merges their results. The scan fails if any of the scanners fails.
+func (r *Registry) Scan(ctx context.Context, noCache bool, resources ...*storage.Resource) (ScanResult, error) {
+	scanners := r.Scanners()
+
+	var wg sync.WaitGroup
+	wg.Add(len(scanners))
+
+	resultsChan := make(chan ScanResult, len(scanners))
+	errChan := make(chan error, len(scanners))
+
+	for _, s := range scanners {
+		go func(s KubeScanner) {
+			defer safe.HandleCrash(safeutil.RecoverHandler(ctx, errChan))
+			defer wg.Done()
+
+			result, err := s.Scan(ctx, noCache, resources...)
+			if err != nil {
+				errChan <- errors.Wrapf(err, "scanner %s failed", s.Name())
+				return
+			}
+
+			resultsChan <- result
+		}(s)
+	}
+
+	go func() {
+		defer safe.HandleCrash(safeutil.RecoverHandler(ctx, errChan))
+		wg.Wait()
+		close(resultsChan)
+		close(errChan)
+	}()
+
+	allResults := NewScanResult()
+
+	for result := range resultsChan {
+		allResults.MergeFrom(result)
+	}
+
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return allResults, nil
+}
@@ -0,0 +1,74 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+)
+
+// csvHeader is the header row of the CSV report.
+var csvHeader = []string{
+	"cluster", "apiVersion", "kind", "namespace", "name",
+	"scanner", "severity", "title", "message", "suppressed", "exception",
+}
+
+// writeCSV exports the scan result as a CSV report with one row for each
+// issue on each resource.
+func writeCSV(w io.Writer, result scanner.ScanResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, f := range findings(result) {
+		row := []string{
+			f.resource.Cluster,
+			f.resource.APIVersion,
+			f.resource.Kind,
+			f.resource.Namespace,
+			f.resource.Name,
+			f.issue.Scanner,
+			f.issue.Severity.String(),
+			f.issue.Title,
+			f.issue.Message,
+			strconv.FormatBool(f.issue.Suppressed),
+			f.issue.Exception,
+		}
+		for i := range row {
+			row[i] = escapeCSVCell(row[i])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeCSVCell prefixes the cell with a single quote if it starts with a
+// character which makes spreadsheets evaluate it as a formula, as the cells
+// come from user-controlled resources and policies.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,115 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+)
+
+// The subset of the JUnit XML report model used by the report.
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name         `xml:"testsuites"`
+		Name     string           `xml:"name,attr"`
+		Tests    int              `xml:"tests,attr"`
+		Failures int              `xml:"failures,attr"`
+		Skipped  int              `xml:"skipped,attr"`
+		Suites   []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name     string          `xml:"name,attr"`
+		Tests    int             `xml:"tests,attr"`
+		Failures int             `xml:"failures,attr"`
+		Skipped  int             `xml:"skipped,attr"`
+		Cases    []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+
+	junitSkipped struct {
+		Message string `xml:"message,attr"`
+	}
+)
+
+// junitPassedCase is the name of the test case reported for resources
+// without any issue.
+const junitPassedCase = "No issues found"
+
+// writeJUnit exports the scan result as a JUnit XML report with one test
+// suite for each resource and one failed test case for each issue on it.
+// Suppressed issues are reported as skipped, and resources without issues
+// get a single passed test case.
+func writeJUnit(w io.Writer, result scanner.ScanResult) error {
+	issuesByResource := map[entity.ResourceGroupHash][]*finding{}
+	for _, f := range findings(result) {
+		hash := f.resource.Hash()
+		issuesByResource[hash] = append(issuesByResource[hash], f)
+	}
+
+	report := junitTestSuites{Name: "karpor audit", Suites: []junitTestSuite{}}
+	for _, resource := range sortedResources(result) {
+		path := resourcePath(resource.ResourceGroup)
+		suite := junitTestSuite{Name: path, Cases: []junitTestCase{}}
+
+		for _, f := range issuesByResource[resource.ResourceGroup.Hash()] {
+			tc := junitTestCase{Name: f.issue.Title, ClassName: f.issue.Scanner}
+			if f.issue.Suppressed {
+				tc.Skipped = &junitSkipped{Message: fmt.Sprintf("Suppressed by audit exception %s", f.issue.Exception)}
+				suite.Skipped++
+			} else {
+				tc.Failure = &junitFailure{Message: f.issue.Title, Type: f.issue.Severity.String(), Text: f.issue.Message}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: junitPassedCase, ClassName: "karpor"})
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
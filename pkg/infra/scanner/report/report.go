@@ -0,0 +1,127 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report exports scan results in formats understood by other tools,
+// such as SARIF for code scanning dashboards and JUnit for CI reports.
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+)
+
+// Supported report formats.
+const (
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+	FormatCSV   = "csv"
+)
+
+// Format describes how a scan result is exported.
+type Format struct {
+	// Name is the name of the format used in requests, e.g. sarif.
+	Name string
+	// ContentType is the media type of the exported report.
+	ContentType string
+	// Extension is the file extension of the exported report.
+	Extension string
+
+	write func(w io.Writer, result scanner.ScanResult) error
+}
+
+// formats is the list of supported report formats.
+var formats = []*Format{
+	{Name: FormatSARIF, ContentType: "application/sarif+json", Extension: "sarif", write: writeSARIF},
+	{Name: FormatJUnit, ContentType: "application/xml", Extension: "xml", write: writeJUnit},
+	{Name: FormatCSV, ContentType: "text/csv", Extension: "csv", write: writeCSV},
+}
+
+// Lookup returns the report format of the given name, case-insensitively.
+func Lookup(name string) (*Format, error) {
+	names := make([]string, 0, len(formats))
+	for _, f := range formats {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+		names = append(names, f.Name)
+	}
+	return nil, fmt.Errorf("unsupported report format %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Write exports the scan result to w in the format.
+func (f *Format) Write(w io.Writer, result scanner.ScanResult) error {
+	return f.write(w, result)
+}
+
+// finding is a single issue reported on a single resource.
+type finding struct {
+	issue    scanner.Issue
+	resource entity.ResourceGroup
+}
+
+// id returns a stable identifier of the finding.
+func (f *finding) id() string {
+	return entity.AuditIssueID(f.resource, f.issue.Scanner, f.issue.Title, f.issue.Message)
+}
+
+// findings flattens the scan result into findings, ordered by severity from
+// high to low, then by resource and title, so that reports are stable.
+func findings(result scanner.ScanResult) []*finding {
+	out := []*finding{}
+	for issue, resources := range result.ByIssue() {
+		for _, resource := range resources {
+			out = append(out, &finding{issue: issue, resource: resource.ResourceGroup})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].issue.Severity != out[j].issue.Severity {
+			return out[i].issue.Severity > out[j].issue.Severity
+		}
+		if pi, pj := resourcePath(out[i].resource), resourcePath(out[j].resource); pi != pj {
+			return pi < pj
+		}
+		if out[i].issue.Title != out[j].issue.Title {
+			return out[i].issue.Title < out[j].issue.Title
+		}
+		return out[i].issue.Message < out[j].issue.Message
+	})
+	return out
+}
+
+// sortedResources returns the resources of the scan result ordered by path.
+func sortedResources(result scanner.ScanResult) []*storage.Resource {
+	resources := result.Resources()
+	sort.Slice(resources, func(i, j int) bool {
+		return resourcePath(resources[i].ResourceGroup) < resourcePath(resources[j].ResourceGroup)
+	})
+	return resources
+}
+
+// resourcePath returns the path locating the resource, in the form of
+// cluster/apiVersion/kind/namespace/name. The namespace is omitted for
+// cluster-scoped resources.
+func resourcePath(rg entity.ResourceGroup) string {
+	parts := []string{rg.Cluster, rg.APIVersion, rg.Kind}
+	if rg.Namespace != "" {
+		parts = append(parts, rg.Namespace)
+	}
+	return strings.Join(append(parts, rg.Name), "/")
+}
@@ -0,0 +1,138 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func newResult() scanner.ScanResult {
+	newResource := func(namespace, name string) *storage.Resource {
+		return &storage.Resource{ResourceGroup: entity.ResourceGroup{
+			Cluster: "prod", APIVersion: "apps/v1", Kind: "Deployment", Namespace: namespace, Name: name,
+		}}
+	}
+
+	result := scanner.NewScanResult()
+	result.Add(newResource("default", "app"), scanner.IssueList{
+		{Scanner: "kubeaudit", Severity: scanner.High, Title: "Privileged container", Message: "container is privileged"},
+		{Scanner: "Policy", Severity: scanner.Low, Title: "Missing team label", Message: "team label is required", Suppressed: true, Exception: "legacy"},
+	})
+	result.Add(newResource("default", "clean"), nil)
+	return result
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"sarif", "JUnit", "csv"} {
+		f, err := Lookup(name)
+		require.NoError(t, err)
+		require.NotEmpty(t, f.ContentType)
+	}
+
+	_, err := Lookup("pdf")
+	require.Error(t, err)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeSARIF(buf, newResult()))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, sarifVersion, log.Version)
+	require.Len(t, log.Runs, 2)
+
+	// Runs are ordered by scanner name.
+	policyRun, kubeauditRun := log.Runs[0], log.Runs[1]
+	require.Equal(t, "Policy", policyRun.Tool.Driver.Name)
+	require.Equal(t, "kubeaudit", kubeauditRun.Tool.Driver.Name)
+
+	require.Len(t, kubeauditRun.Results, 1)
+	res := kubeauditRun.Results[0]
+	require.Equal(t, "Privileged container", res.RuleID)
+	require.Equal(t, "error", res.Level)
+	require.Equal(t, "prod/apps/v1/Deployment/default/app", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.NotEmpty(t, res.PartialFingerprints[sarifFingerprintKey])
+	require.Empty(t, res.Suppressions)
+
+	require.Len(t, policyRun.Results, 1)
+	require.Equal(t, "note", policyRun.Results[0].Level)
+	require.Len(t, policyRun.Results[0].Suppressions, 1)
+	require.Equal(t, "accepted", policyRun.Results[0].Suppressions[0].Status)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeJUnit(buf, newResult()))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	require.Equal(t, 3, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Equal(t, 1, report.Skipped)
+	require.Len(t, report.Suites, 2)
+
+	app, clean := report.Suites[0], report.Suites[1]
+	require.Equal(t, "prod/apps/v1/Deployment/default/app", app.Name)
+	require.Len(t, app.Cases, 2)
+	require.NotNil(t, app.Cases[0].Failure)
+	require.Equal(t, "High", app.Cases[0].Failure.Type)
+	require.NotNil(t, app.Cases[1].Skipped)
+
+	require.Equal(t, "prod/apps/v1/Deployment/default/clean", clean.Name)
+	require.Len(t, clean.Cases, 1)
+	require.Equal(t, junitPassedCase, clean.Cases[0].Name)
+	require.Nil(t, clean.Cases[0].Failure)
+}
+
+func TestWriteCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeCSV(buf, newResult()))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		csvHeader,
+		{"prod", "apps/v1", "Deployment", "default", "app", "kubeaudit", "High", "Privileged container", "container is privileged", "false", ""},
+		{"prod", "apps/v1", "Deployment", "default", "app", "Policy", "Low", "Missing team label", "team label is required", "true", "legacy"},
+	}, records)
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	result := scanner.NewScanResult()
+	result.Add(&storage.Resource{ResourceGroup: entity.ResourceGroup{
+		Cluster: "prod", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "@evil",
+	}}, scanner.IssueList{
+		{Scanner: "Policy", Severity: scanner.Low, Title: "=HYPERLINK(\"http://example.com\")", Message: "-1+1"},
+	})
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeCSV(buf, result))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"prod", "v1", "ConfigMap", "default", "'@evil", "Policy", "Low", "'=HYPERLINK(\"http://example.com\")", "'-1+1", "false", "",
+	}, records[1])
+}
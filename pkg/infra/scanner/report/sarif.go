@@ -0,0 +1,215 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/KusionStack/karpor/pkg/infra/scanner"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifFingerprintKey is the key of the partial fingerprint which
+	// identifies the same finding across reports.
+	sarifFingerprintKey = "karporFinding/v1"
+)
+
+// The subset of the SARIF 2.1.0 object model used by the report.
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri,omitempty"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID                   string            `json:"id"`
+		Name                 string            `json:"name,omitempty"`
+		ShortDescription     sarifMessage      `json:"shortDescription"`
+		DefaultConfiguration sarifRuleConfig   `json:"defaultConfiguration"`
+		Properties           map[string]string `json:"properties,omitempty"`
+	}
+
+	sarifRuleConfig struct {
+		Level string `json:"level"`
+	}
+
+	sarifResult struct {
+		RuleID              string             `json:"ruleId"`
+		Level               string             `json:"level"`
+		Message             sarifMessage       `json:"message"`
+		Locations           []sarifLocation    `json:"locations"`
+		PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+		Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifLogicalLocation struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+
+	sarifSuppression struct {
+		Kind          string `json:"kind"`
+		Status        string `json:"status"`
+		Justification string `json:"justification,omitempty"`
+	}
+)
+
+// writeSARIF exports the scan result as a SARIF log with one run for each
+// scanner. Each distinct issue title is reported as a rule of the scanner,
+// and suppressed issues are reported with an accepted suppression.
+func writeSARIF(w io.Writer, result scanner.ScanResult) error {
+	runs := map[string]*sarifRun{}
+	rules := map[string]map[string]struct{}{}
+	for _, f := range findings(result) {
+		run, ok := runs[f.issue.Scanner]
+		if !ok {
+			run = &sarifRun{
+				Tool: sarifTool{Driver: sarifDriver{
+					Name:           f.issue.Scanner,
+					InformationURI: "https://github.com/KusionStack/karpor",
+					Rules:          []sarifRule{},
+				}},
+				Results: []sarifResult{},
+			}
+			runs[f.issue.Scanner] = run
+			rules[f.issue.Scanner] = map[string]struct{}{}
+		}
+
+		if _, ok := rules[f.issue.Scanner][f.issue.Title]; !ok {
+			rules[f.issue.Scanner][f.issue.Title] = struct{}{}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:                   f.issue.Title,
+				Name:                 f.issue.Title,
+				ShortDescription:     sarifMessage{Text: f.issue.Title},
+				DefaultConfiguration: sarifRuleConfig{Level: sarifLevel(f.issue.Severity)},
+				Properties: map[string]string{
+					"severity":          f.issue.Severity.String(),
+					"security-severity": sarifSecuritySeverity(f.issue.Severity),
+				},
+			})
+		}
+
+		path := resourcePath(f.resource)
+		res := sarifResult{
+			RuleID:  f.issue.Title,
+			Level:   sarifLevel(f.issue.Severity),
+			Message: sarifMessage{Text: fmt.Sprintf("%s: %s", path, f.issue.Message)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               f.resource.Name,
+					FullyQualifiedName: path,
+					Kind:               "resource",
+				}},
+			}},
+			PartialFingerprints: map[string]string{sarifFingerprintKey: f.id()},
+		}
+		if f.issue.Suppressed {
+			res.Suppressions = []sarifSuppression{{
+				Kind:          "external",
+				Status:        "accepted",
+				Justification: fmt.Sprintf("Suppressed by audit exception %s", f.issue.Exception),
+			}}
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	// Order the runs by scanner name so that reports are stable.
+	names := make([]string, 0, len(runs))
+	for name := range runs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	log := sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: make([]sarifRun, 0, len(runs))}
+	for _, name := range names {
+		log.Runs = append(log.Runs, *runs[name])
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifLevel maps the severity of an issue to a SARIF result level.
+func sarifLevel(severity scanner.IssueSeverityLevel) string {
+	switch {
+	case severity >= scanner.High:
+		return "error"
+	case severity == scanner.Medium:
+		return "warning"
+	case severity == scanner.Low:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// sarifSecuritySeverity maps the severity of an issue to the numeric score
+// used by code scanning dashboards to rank security findings.
+func sarifSecuritySeverity(severity scanner.IssueSeverityLevel) string {
+	switch {
+	case severity >= scanner.Critical:
+		return "9.5"
+	case severity >= scanner.High:
+		return "8.0"
+	case severity == scanner.Medium:
+		return "5.5"
+	case severity == scanner.Low:
+		return "2.0"
+	default:
+		return "0.0"
+	}
+}
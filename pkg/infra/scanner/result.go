@@ -12,23 +12,22 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package kubeaudit
+package scanner
 
 import (
 	"sync"
 
 	"github.com/KusionStack/karpor/pkg/core/entity"
-	"github.com/KusionStack/karpor/pkg/infra/scanner"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 )
 
-var _ scanner.ScanResult = &scanResult{}
+var _ ScanResult = &scanResult{}
 
-// scanResult implements the scanner.ScanResult interface and represents the
+// scanResult implements the ScanResult interface and represents the
 // result of scanning Kubernetes resources.
 type scanResult struct {
-	issueResourceMap  map[scanner.Issue]scanner.ResourceList         // Map of issues to resources
-	resourceIssueMap  map[entity.ResourceGroupHash]scanner.IssueList // Map of resources to issues
+	issueResourceMap  map[Issue]ResourceList                         // Map of issues to resources
+	resourceIssueMap  map[entity.ResourceGroupHash]IssueList         // Map of resources to issues
 	resourceGroupMap  map[entity.ResourceGroupHash]*storage.Resource // Map of resourceGroup to resources
 	relationshipExist map[relationship]struct{}                      // Map to track relationships
 	lock              sync.RWMutex                                   // Mutex for concurrent access
@@ -36,20 +35,21 @@ type scanResult struct {
 
 // relationship represents the relationship between an issue and a resourceGroups.
 type relationship struct {
-	scanner.Issue
+	Issue
 	entity.ResourceGroupHash
 }
 
-// NewScanResult creates a new instance of scanResult.
-func NewScanResult() scanner.ScanResult {
+// NewScanResult creates a new, empty ScanResult which can be shared by all
+// scanner implementations, so that results of different scanners can be merged.
+func NewScanResult() ScanResult {
 	return newScanResult()
 }
 
 // newScanResult creates and returns a new instance of scanResult.
 func newScanResult() *scanResult {
 	return &scanResult{
-		issueResourceMap:  make(map[scanner.Issue]scanner.ResourceList),
-		resourceIssueMap:  make(map[entity.ResourceGroupHash]scanner.IssueList),
+		issueResourceMap:  make(map[Issue]ResourceList),
+		resourceIssueMap:  make(map[entity.ResourceGroupHash]IssueList),
 		resourceGroupMap:  make(map[entity.ResourceGroupHash]*storage.Resource),
 		relationshipExist: map[relationship]struct{}{},
 		lock:              sync.RWMutex{},
@@ -57,15 +57,27 @@ func newScanResult() *scanResult {
 }
 
 // ByIssue returns the map of issues to resources.
-func (sr *scanResult) ByIssue() map[scanner.Issue]scanner.ResourceList {
+func (sr *scanResult) ByIssue() map[Issue]ResourceList {
 	return sr.issueResourceMap
 }
 
 // ByResource returns the map of resources to issues.
-func (sr *scanResult) ByResource() map[entity.ResourceGroupHash]scanner.IssueList {
+func (sr *scanResult) ByResource() map[entity.ResourceGroupHash]IssueList {
 	return sr.resourceIssueMap
 }
 
+// Resources returns all scanned resources, including the ones without issues.
+func (sr *scanResult) Resources() ResourceList {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	resources := make(ResourceList, 0, len(sr.resourceGroupMap))
+	for _, resource := range sr.resourceGroupMap {
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
 // IssueTotal calculates the total number of issues.
 func (sr *scanResult) IssueTotal() int {
 	sr.lock.RLock()
@@ -92,7 +104,7 @@ func (sr *scanResult) IssueTotal() int {
 }
 
 // MergeFrom merges the results from another scanResult into the current one.
-func (sr *scanResult) MergeFrom(result scanner.ScanResult) {
+func (sr *scanResult) MergeFrom(result ScanResult) {
 	if result == nil {
 		return
 	}
@@ -106,15 +118,18 @@ func (sr *scanResult) MergeFrom(result scanner.ScanResult) {
 		sr = newScanResult()
 	}
 
+	newResult.lock.RLock()
+	defer newResult.lock.RUnlock()
+
 	for resourceGroup, issues := range newResult.resourceIssueMap {
 		if resource, exist := newResult.resourceGroupMap[resourceGroup]; exist {
-			sr.add(resource, issues)
+			sr.Add(resource, issues)
 		}
 	}
 }
 
-// add adds a resource with its associated issues to the scanResult.
-func (sr *scanResult) add(resource *storage.Resource, issues []*scanner.Issue) {
+// Add adds a resource with its associated issues to the scanResult.
+func (sr *scanResult) Add(resource *storage.Resource, issues IssueList) {
 	sr.lock.Lock()
 	defer sr.lock.Unlock()
 
@@ -123,7 +138,7 @@ func (sr *scanResult) add(resource *storage.Resource, issues []*scanner.Issue) {
 	}
 
 	if len(issues) == 0 {
-		issues = make([]*scanner.Issue, 0)
+		issues = make([]*Issue, 0)
 	}
 
 	if _, exist := sr.resourceGroupMap[resource.ResourceGroup.Hash()]; !exist {
@@ -131,12 +146,12 @@ func (sr *scanResult) add(resource *storage.Resource, issues []*scanner.Issue) {
 	}
 
 	if _, ok := sr.resourceIssueMap[resource.ResourceGroup.Hash()]; !ok {
-		sr.resourceIssueMap[resource.ResourceGroup.Hash()] = make([]*scanner.Issue, 0)
+		sr.resourceIssueMap[resource.ResourceGroup.Hash()] = make([]*Issue, 0)
 	}
 
 	for _, issue := range issues {
 		if _, ok := sr.issueResourceMap[*issue]; !ok {
-			sr.issueResourceMap[*issue] = make(scanner.ResourceList, 0)
+			sr.issueResourceMap[*issue] = make(ResourceList, 0)
 		}
 	}
 
@@ -20,6 +20,8 @@ package scanner
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
@@ -46,7 +48,9 @@ type KubeScanner interface {
 type ScanResult interface {
 	ByIssue() map[Issue]ResourceList
 	ByResource() map[entity.ResourceGroupHash]IssueList
+	Resources() ResourceList
 	IssueTotal() int
+	Add(resource *storage.Resource, issues IssueList)
 	MergeFrom(result ScanResult)
 }
 
@@ -69,6 +73,11 @@ type Issue struct {
 	Title string `json:"title" yaml:"title"`
 	// Message provides a detailed human-readable description of the issue.
 	Message string `json:"message" yaml:"message"`
+	// Suppressed indicates that the issue is an accepted risk, it is still
+	// reported but not counted in the score.
+	Suppressed bool `json:"suppressed,omitempty" yaml:"suppressed,omitempty"`
+	// Exception is the name of the audit exception suppressing the issue.
+	Exception string `json:"exception,omitempty" yaml:"exception,omitempty"`
 }
 
 // IssueSeverityLevel represents the severity level of an issue.
@@ -92,6 +101,17 @@ func (s IssueSeverityLevel) String() string {
 	}
 }
 
+// ParseSeverity converts the case-insensitive name of a severity level, such as
+// "high", to the corresponding IssueSeverityLevel.
+func ParseSeverity(s string) (IssueSeverityLevel, error) {
+	for _, level := range []IssueSeverityLevel{Safe, Low, Medium, High, Critical} {
+		if strings.EqualFold(s, level.String()) {
+			return level, nil
+		}
+	}
+	return Safe, fmt.Errorf("invalid severity level: %q", s)
+}
+
 // MarshalJSON implements the json.Marshaler interface for IssueSeverityLevel.
 func (s IssueSeverityLevel) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
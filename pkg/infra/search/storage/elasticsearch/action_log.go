@@ -0,0 +1,107 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	actionLogKeyTimestamp = "timestamp"
+	actionLogKeyUser      = "user"
+	actionLogKeyAction    = "action"
+	actionLogKeyCluster   = "target.cluster"
+	actionLogKeyOutcome   = "outcome"
+)
+
+// SaveActionLog saves an action log to the storage.
+func (s *Storage) SaveActionLog(ctx context.Context, log *entity.ActionLog) error {
+	if len(log.ID) == 0 {
+		log.ID = entity.UUID()
+	}
+
+	body, err := json.Marshal(log)
+	if err != nil {
+		return err
+	}
+	return s.client.SaveDocument(ctx, s.actionLogIndexName, log.ID, bytes.NewReader(body))
+}
+
+// ListActionLogs lists the action logs selected by the filter, the latest
+// first.
+func (s *Storage) ListActionLogs(ctx context.Context, filter *storage.ActionLogFilter, pagination *storage.Pagination) (*storage.ActionLogResult, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.actionLogIndexName); err != nil {
+		return nil, err
+	}
+
+	conds := []esquery.Mappable{}
+	for key, value := range map[string]string{
+		actionLogKeyUser:    filter.User,
+		actionLogKeyAction:  filter.Action,
+		actionLogKeyCluster: filter.Cluster,
+		actionLogKeyOutcome: filter.Outcome,
+	} {
+		if value != "" {
+			conds = append(conds, esquery.Term(key, value))
+		}
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		timeRange := esquery.Range(actionLogKeyTimestamp)
+		if !filter.From.IsZero() {
+			timeRange.Gte(formatAuditTime(filter.From))
+		}
+		if !filter.To.IsZero() {
+			timeRange.Lte(formatAuditTime(filter.To))
+		}
+		conds = append(conds, timeRange)
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(conds...).Map(),
+		"sort": []map[string]interface{}{
+			{actionLogKeyTimestamp: map[string]string{"order": "desc"}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.SearchDocument(ctx, s.actionLogIndexName, buf, elasticsearch.Pagination(pagination.Page, pagination.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &storage.ActionLogResult{Logs: make([]*entity.ActionLog, 0, len(resp.Hits.Hits))}
+	if resp.Hits.Total != nil {
+		result.Total = resp.Hits.Total.Value
+	}
+	for _, hit := range resp.Hits.Hits {
+		log, err := storage.Map2ActionLog(hit.Source)
+		if err != nil {
+			return nil, err
+		}
+		result.Logs = append(result.Logs, log)
+	}
+	return result, nil
+}
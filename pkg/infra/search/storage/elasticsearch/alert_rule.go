@@ -0,0 +1,116 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	alertRuleKeyName = "name"
+
+	// maxAlertRules is the maximum number of alert rules listed.
+	maxAlertRules = 10000
+)
+
+var ErrAlertRuleNotFound = fmt.Errorf("alert rule not found")
+
+// GetAlertRule retrieves an alert rule based on the given name.
+func (s *Storage) GetAlertRule(ctx context.Context, name string) (*entity.AlertRule, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.alertRuleIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(esquery.Term(alertRuleKeyName, name)).Map(),
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchDocument(ctx, s.alertRuleIndexName, buf)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Hits.Total.Value == 0 {
+		return nil, ErrAlertRuleNotFound
+	}
+	return storage.Map2AlertRule(resp.Hits.Hits[0].Source)
+}
+
+// SaveAlertRule saves an alert rule to the storage, an id is assigned to
+// new alert rules.
+func (s *Storage) SaveAlertRule(ctx context.Context, data *entity.AlertRule) error {
+	if len(data.ID) == 0 {
+		data.ID = entity.UUID()
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.client.SaveDocument(ctx, s.alertRuleIndexName, data.ID, bytes.NewReader(body))
+}
+
+// DeleteAlertRule deletes an alert rule based on the given name.
+func (s *Storage) DeleteAlertRule(ctx context.Context, name string) error {
+	alertRule, err := s.GetAlertRule(ctx, name)
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteDocument(ctx, s.alertRuleIndexName, alertRule.ID)
+}
+
+// ListAlertRules lists all alert rules sorted by name.
+func (s *Storage) ListAlertRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.alertRuleIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.MatchAll().Map(),
+		"sort": []map[string]interface{}{
+			{alertRuleKeyName: map[string]string{"order": "asc"}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchDocument(ctx, s.alertRuleIndexName, buf, elasticsearch.Pagination(1, maxAlertRules))
+	if err != nil {
+		return nil, err
+	}
+
+	alertRules := make([]*entity.AlertRule, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		alertRule, err := storage.Map2AlertRule(hit.Source)
+		if err != nil {
+			return nil, err
+		}
+		alertRules = append(alertRules, alertRule)
+	}
+	return alertRules, nil
+}
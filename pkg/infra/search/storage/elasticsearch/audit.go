@@ -0,0 +1,253 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	auditIssueKeyID         = "id"
+	auditIssueKeyCluster    = "cluster"
+	auditIssueKeyAPIVersion = "apiVersion"
+	auditIssueKeyKind       = "kind"
+	auditIssueKeyNamespace  = "namespace"
+	auditIssueKeyName       = "name"
+	auditIssueKeyLabels     = "labels"
+	auditIssueKeyScanner    = "scanner"
+	auditIssueKeySeverity   = "severity"
+	auditIssueKeyTitle      = "title"
+	auditIssueKeyMessage    = "message"
+	auditIssueKeyFirstSeen  = "firstSeen"
+	auditIssueKeyLastSeen   = "lastSeen"
+	auditIssueKeyResolvedAt = "resolvedAt"
+
+	// auditIssuePageSize is the page size used to list audit issues.
+	auditIssuePageSize = 1000
+	// auditIssueBulkSize is the maximum number of audit issues saved in a
+	// single bulk request.
+	auditIssueBulkSize = 500
+)
+
+// upsertAuditIssueScript refreshes the last seen timestamp of an existing
+// audit issue. An issue which has been resolved before is reopened, and it
+// is considered as newly introduced again.
+const upsertAuditIssueScript = `
+if (ctx._source.resolvedAt != null) {
+  ctx._source.firstSeen = params.lastSeen;
+  ctx._source.resolvedAt = null;
+}
+ctx._source.lastSeen = params.lastSeen;
+ctx._source.severity = params.severity;
+ctx._source.labels = params.labels;`
+
+// SaveAuditIssues saves the audit issues found at the given time. The first
+// seen timestamp is kept for issues which already exist in the storage. The
+// issues are upserted with bulk requests of at most auditIssueBulkSize issues.
+func (s *Storage) SaveAuditIssues(ctx context.Context, issues []*entity.AuditIssue, seenAt time.Time) error {
+	seen := formatAuditTime(seenAt)
+	for start := 0; start < len(issues); start += auditIssueBulkSize {
+		end := min(start+auditIssueBulkSize, len(issues))
+
+		buf := &bytes.Buffer{}
+		encoder := json.NewEncoder(buf)
+		for _, issue := range issues[start:end] {
+			id := issue.ID
+			if len(id) == 0 {
+				id = entity.AuditIssueID(issue.Resource, issue.Scanner, issue.Title, issue.Message)
+			}
+
+			action := map[string]interface{}{
+				"update": map[string]interface{}{"_id": id},
+			}
+			body := map[string]interface{}{
+				"script": map[string]interface{}{
+					"lang":   "painless",
+					"source": upsertAuditIssueScript,
+					"params": map[string]interface{}{
+						auditIssueKeyLastSeen: seen,
+						auditIssueKeySeverity: issue.Severity,
+						auditIssueKeyLabels:   issue.Labels,
+					},
+				},
+				"upsert": map[string]interface{}{
+					auditIssueKeyID:         id,
+					auditIssueKeyCluster:    issue.Resource.Cluster,
+					auditIssueKeyAPIVersion: issue.Resource.APIVersion,
+					auditIssueKeyKind:       issue.Resource.Kind,
+					auditIssueKeyNamespace:  issue.Resource.Namespace,
+					auditIssueKeyName:       issue.Resource.Name,
+					auditIssueKeyLabels:     issue.Labels,
+					auditIssueKeyScanner:    issue.Scanner,
+					auditIssueKeySeverity:   issue.Severity,
+					auditIssueKeyTitle:      issue.Title,
+					auditIssueKeyMessage:    issue.Message,
+					auditIssueKeyFirstSeen:  seen,
+					auditIssueKeyLastSeen:   seen,
+				},
+			}
+			// The encoder terminates each line with a newline as required by
+			// the bulk API.
+			if err := encoder.Encode(action); err != nil {
+				return err
+			}
+			if err := encoder.Encode(body); err != nil {
+				return err
+			}
+		}
+
+		if err := s.client.Bulk(ctx, s.auditIssueIndexName, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveAuditIssues marks the open audit issues in the given cluster and
+// namespace which have not been seen since seenBefore as resolved at that
+// time. An empty namespace stands for the cluster-scoped resources.
+func (s *Storage) ResolveAuditIssues(ctx context.Context, cluster, namespace string, seenBefore time.Time) error {
+	// Refresh the index before updating to ensure the latest issues are seen.
+	if err := s.client.Refresh(ctx, s.auditIssueIndexName); err != nil {
+		return err
+	}
+
+	resolvedAt := formatAuditTime(seenBefore)
+	body, err := json.Marshal(map[string]interface{}{
+		"query": esquery.Bool().
+			Must(
+				esquery.Term(auditIssueKeyCluster, cluster),
+				esquery.Term(auditIssueKeyNamespace, namespace),
+				esquery.Range(auditIssueKeyLastSeen).Lt(resolvedAt),
+			).
+			MustNot(esquery.Exists(auditIssueKeyResolvedAt)).
+			Map(),
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": "ctx._source.resolvedAt = params.resolvedAt",
+			"params": map[string]interface{}{
+				auditIssueKeyResolvedAt: resolvedAt,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.client.UpdateDocumentByQuery(ctx, s.auditIssueIndexName, bytes.NewReader(body))
+}
+
+// ListAuditIssues lists the audit issues of the resources located by the given
+// resource group. Resolved issues are only returned if includeResolved is set.
+func (s *Storage) ListAuditIssues(ctx context.Context, resourceGroup entity.ResourceGroup, includeResolved bool) ([]*entity.AuditIssue, error) {
+	boolQuery, err := auditIssueQuery(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	if !includeResolved {
+		boolQuery.MustNot(esquery.Exists(auditIssueKeyResolvedAt))
+	}
+	return s.listAuditIssues(ctx, boolQuery)
+}
+
+// ListAuditIssuesIntroduced lists the audit issues of the resources located by
+// the given resource group which are first seen between from and to.
+func (s *Storage) ListAuditIssuesIntroduced(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error) {
+	boolQuery, err := auditIssueQuery(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	boolQuery.Must(esquery.Range(auditIssueKeyFirstSeen).Gte(formatAuditTime(from)).Lte(formatAuditTime(to)))
+	return s.listAuditIssues(ctx, boolQuery)
+}
+
+// ListAuditIssuesResolved lists the audit issues of the resources located by
+// the given resource group which are resolved between from and to.
+func (s *Storage) ListAuditIssuesResolved(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error) {
+	boolQuery, err := auditIssueQuery(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	boolQuery.Must(esquery.Range(auditIssueKeyResolvedAt).Gte(formatAuditTime(from)).Lte(formatAuditTime(to)))
+	return s.listAuditIssues(ctx, boolQuery)
+}
+
+// listAuditIssues lists all audit issues matching the given query.
+func (s *Storage) listAuditIssues(ctx context.Context, boolQuery *esquery.BoolQuery) ([]*entity.AuditIssue, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.auditIssueIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": boolQuery.Map(),
+	}
+
+	issues := []*entity.AuditIssue{}
+	for page := 1; ; page++ {
+		buf := &bytes.Buffer{}
+		if err := json.NewEncoder(buf).Encode(query); err != nil {
+			return nil, err
+		}
+
+		resp, err := s.client.SearchDocument(ctx, s.auditIssueIndexName, buf, elasticsearch.Pagination(page, auditIssuePageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			issue, err := storage.Map2AuditIssue(hit.Source)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, issue)
+		}
+
+		if len(resp.Hits.Hits) < auditIssuePageSize {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+// auditIssueQuery creates a query to search for the audit issues of the
+// resources located by the given resource group.
+func auditIssueQuery(resourceGroup entity.ResourceGroup) (*esquery.BoolQuery, error) {
+	if len(resourceGroup.Labels) != 0 || len(resourceGroup.Annotations) != 0 {
+		return nil, fmt.Errorf("audit issues cannot be filtered by labels or annotations")
+	}
+
+	boolQuery := esquery.Bool()
+	for k, v := range resourceGroup.ToTerms() {
+		boolQuery.Must(esquery.Term(k, v))
+	}
+	return boolQuery, nil
+}
+
+// formatAuditTime formats the time in the date format of the audit issue
+// index.
+func formatAuditTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,133 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/elliotxx/esquery"
+	"github.com/xwb1989/sqlparser"
+)
+
+// Fields of the resource index restricted by data access filters.
+const (
+	accessFieldCluster   = "cluster"
+	accessFieldNamespace = "namespace"
+	accessFieldKind      = "kind"
+)
+
+// accessFilterExpr converts the data access filter into an SQL expression,
+// which selects the resources in any of the scopes of the filter.
+func accessFilterExpr(filter *authz.Filter) sqlparser.Expr {
+	var expr sqlparser.Expr
+	for _, scope := range filter.Scopes {
+		var scopeExpr sqlparser.Expr
+		for _, cond := range []struct {
+			field  string
+			values []string
+		}{
+			{accessFieldCluster, scope.Clusters},
+			{accessFieldNamespace, scope.Namespaces},
+			{accessFieldKind, scope.Kinds},
+		} {
+			if len(cond.values) == 0 {
+				continue
+			}
+			tuple := make(sqlparser.ValTuple, 0, len(cond.values))
+			for _, v := range cond.values {
+				tuple = append(tuple, sqlparser.NewStrVal([]byte(v)))
+			}
+			in := &sqlparser.ComparisonExpr{
+				Operator: sqlparser.InStr,
+				Left:     &sqlparser.ColName{Name: sqlparser.NewColIdent(cond.field)},
+				Right:    tuple,
+			}
+			if scopeExpr == nil {
+				scopeExpr = in
+			} else {
+				scopeExpr = &sqlparser.AndExpr{Left: scopeExpr, Right: in}
+			}
+		}
+		if scopeExpr == nil {
+			// A scope without any restriction selects all resources.
+			return nil
+		}
+
+		if expr == nil {
+			expr = &sqlparser.ParenExpr{Expr: scopeExpr}
+		} else {
+			expr = &sqlparser.OrExpr{Left: expr, Right: &sqlparser.ParenExpr{Expr: scopeExpr}}
+		}
+	}
+	return expr
+}
+
+// accessFilterQuery converts the data access filter into an elasticsearch
+// query, which selects the resources in any of the scopes of the filter.
+func accessFilterQuery(filter *authz.Filter) esquery.Mappable {
+	scopes := make([]esquery.Mappable, 0, len(filter.Scopes))
+	for _, scope := range filter.Scopes {
+		must := []esquery.Mappable{}
+		if len(scope.Clusters) > 0 {
+			must = append(must, esquery.Terms(accessFieldCluster, toInterfaces(scope.Clusters)...))
+		}
+		if len(scope.Namespaces) > 0 {
+			must = append(must, esquery.Terms(accessFieldNamespace, toInterfaces(scope.Namespaces)...))
+		}
+		if len(scope.Kinds) > 0 {
+			must = append(must, esquery.Terms(accessFieldKind, toInterfaces(scope.Kinds)...))
+		}
+		scopes = append(scopes, esquery.Bool().Must(must...))
+	}
+	return esquery.Bool().Should(scopes...).MinimumShouldMatch(1)
+}
+
+// restrictQuery returns a copy of the search request body whose query only
+// matches the resources allowed by the data access filter.
+func restrictQuery(query map[string]interface{}, filter *authz.Filter) map[string]interface{} {
+	restricted := make(map[string]interface{}, len(query)+1)
+	for k, v := range query {
+		restricted[k] = v
+	}
+
+	boolQuery := esquery.Bool().Filter(accessFilterQuery(filter))
+	if q, ok := query["query"].(map[string]interface{}); ok {
+		boolQuery.Must(esquery.CustomQuery(q))
+	}
+	restricted["query"] = boolQuery.Map()
+	return restricted
+}
+
+// restrictAggregationQuery returns a query which only matches the resources
+// matched by the given query and allowed by the data access filter, for the
+// aggregations of resources. A nil query matches all resources.
+func restrictAggregationQuery(query map[string]interface{}, filter *authz.Filter) map[string]interface{} {
+	if filter == nil {
+		return query
+	}
+	boolQuery := esquery.Bool().Filter(accessFilterQuery(filter))
+	if query != nil {
+		boolQuery.Filter(esquery.CustomQuery(query))
+	}
+	return boolQuery.Map()
+}
+
+// toInterfaces converts a slice of strings into a slice of interfaces.
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -37,14 +37,26 @@ var (
 	_ storage.ResourceStorage          = &Storage{}
 	_ storage.ResourceGroupRuleStorage = &Storage{}
 	_ storage.SearchStorage            = &Storage{}
+	_ storage.AuditStorage             = &Storage{}
+	_ storage.ActionLogStorage         = &Storage{}
+	_ storage.SavedSearchStorage       = &Storage{}
+	_ storage.AlertRuleStorage         = &Storage{}
+	_ storage.EventStorage             = &Storage{}
 )
 
 // Storage is the struct that holds the necessary fields for interacting with the Elasticsearch cluster.
 type Storage struct {
-	client                     *elasticsearch.Client
-	resourceIndexName          string
-	resourceGroupRuleIndexName string
-	objectEncoder              runtime.Encoder
+	client                         *elasticsearch.Client
+	resourceIndexName              string
+	resourceGroupRuleIndexName     string
+	resourceGroupSnapshotIndexName string
+	auditIssueIndexName            string
+	scoreSnapshotIndexName         string
+	actionLogIndexName             string
+	savedSearchIndexName           string
+	alertRuleIndexName             string
+	eventIndexName                 string
+	objectEncoder                  runtime.Encoder
 }
 
 // NewStorage creates and returns a new instance of the Storage struct with the provided Elasticsearch configuration.
@@ -62,15 +74,50 @@ func NewStorage(cfg esv8.Config) (*Storage, error) {
 		return nil, err
 	}
 
+	if err = cl.CreateIndex(context.Background(), defaultResourceGroupSnapshotIndexName, strings.NewReader(defaultResourceGroupSnapshotMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultAuditIssueIndexName, strings.NewReader(defaultAuditIssueMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultScoreSnapshotIndexName, strings.NewReader(defaultScoreSnapshotMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultActionLogIndexName, strings.NewReader(defaultActionLogMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultSavedSearchIndexName, strings.NewReader(defaultSavedSearchMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultAlertRuleIndexName, strings.NewReader(defaultAlertRuleMapping)); err != nil {
+		return nil, err
+	}
+
+	if err = cl.CreateIndex(context.Background(), defaultEventIndexName, strings.NewReader(defaultEventMapping)); err != nil {
+		return nil, err
+	}
+
 	// Check if the default resource group rule exists, if not, create it.
 	if err = createResourceGroupRuleIfNotExists(cl, "namespace"); err != nil {
 		return nil, err
 	}
 
 	return &Storage{
-		client:                     cl,
-		resourceIndexName:          defaultResourceIndexName,
-		resourceGroupRuleIndexName: defaultResourceGroupRuleIndexName,
+		client:                         cl,
+		resourceIndexName:              defaultResourceIndexName,
+		resourceGroupRuleIndexName:     defaultResourceGroupRuleIndexName,
+		resourceGroupSnapshotIndexName: defaultResourceGroupSnapshotIndexName,
+		auditIssueIndexName:            defaultAuditIssueIndexName,
+		scoreSnapshotIndexName:         defaultScoreSnapshotIndexName,
+		actionLogIndexName:             defaultActionLogIndexName,
+		savedSearchIndexName:           defaultSavedSearchIndexName,
+		alertRuleIndexName:             defaultAlertRuleIndexName,
+		eventIndexName:                 defaultEventIndexName,
 		objectEncoder: runtimejson.NewSerializerWithOptions(
 			runtimejson.DefaultMetaFactory,
 			scheme.Scheme,
@@ -0,0 +1,213 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/authz"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	eventKeyID             = "id"
+	eventKeyCluster        = "cluster"
+	eventKeyAPIVersion     = "apiVersion"
+	eventKeyKind           = "kind"
+	eventKeyNamespace      = "namespace"
+	eventKeyName           = "name"
+	eventKeyUID            = "uid"
+	eventKeyType           = "type"
+	eventKeyReason         = "reason"
+	eventKeyMessage        = "message"
+	eventKeySource         = "source"
+	eventKeyCount          = "count"
+	eventKeyCounts         = "counts"
+	eventKeyEventUID       = "eventUID"
+	eventKeyFirstTimestamp = "firstTimestamp"
+	eventKeyLastTimestamp  = "lastTimestamp"
+)
+
+// archiveEventScript merges an occurrence of an event into its series. The
+// count of every event object is kept in the counts of the series, so that
+// the same event object seen again, e.g. when the syncer restarts, is not
+// counted twice, while the counts of the event objects recreated after they
+// expired add up.
+const archiveEventScript = `
+boolean changed = false;
+if (ctx._source.counts == null) {
+  ctx._source.counts = [:];
+}
+long previous = ctx._source.counts.containsKey(params.eventUID) ? ctx._source.counts.get(params.eventUID) : 0L;
+if (params.count > previous) {
+  ctx._source.count += params.count - previous;
+  ctx._source.counts.put(params.eventUID, params.count);
+  changed = true;
+}
+if (params.firstTimestamp.compareTo(ctx._source.firstTimestamp) < 0) {
+  ctx._source.firstTimestamp = params.firstTimestamp;
+  changed = true;
+}
+if (params.lastTimestamp.compareTo(ctx._source.lastTimestamp) > 0) {
+  ctx._source.lastTimestamp = params.lastTimestamp;
+  ctx._source.source = params.source;
+  changed = true;
+}
+if (!changed) {
+  ctx.op = 'noop';
+}`
+
+// ArchiveEvent merges an occurrence of an event into the series archived in
+// the storage, or archives a new series. The count of the event is the count
+// of the event object identified by eventUID.
+func (s *Storage) ArchiveEvent(ctx context.Context, eventUID string, event *entity.ArchivedEvent) error {
+	id := event.ID
+	if len(id) == 0 {
+		id = entity.ArchivedEventID(event.InvolvedObject, event.UID, event.Type, event.Reason, event.Message)
+	}
+
+	now := time.Now()
+	firstTimestamp, lastTimestamp := now, now
+	if event.FirstTimestamp != nil {
+		firstTimestamp = event.FirstTimestamp.Time
+	}
+	if event.LastTimestamp != nil {
+		lastTimestamp = event.LastTimestamp.Time
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": archiveEventScript,
+			"params": map[string]interface{}{
+				eventKeyEventUID:       eventUID,
+				eventKeyCount:          event.Count,
+				eventKeySource:         event.Source,
+				eventKeyFirstTimestamp: formatAuditTime(firstTimestamp),
+				eventKeyLastTimestamp:  formatAuditTime(lastTimestamp),
+			},
+		},
+		"upsert": map[string]interface{}{
+			eventKeyID:             id,
+			eventKeyCluster:        event.InvolvedObject.Cluster,
+			eventKeyAPIVersion:     event.InvolvedObject.APIVersion,
+			eventKeyKind:           event.InvolvedObject.Kind,
+			eventKeyNamespace:      event.InvolvedObject.Namespace,
+			eventKeyName:           event.InvolvedObject.Name,
+			eventKeyUID:            event.UID,
+			eventKeyType:           event.Type,
+			eventKeyReason:         event.Reason,
+			eventKeyMessage:        event.Message,
+			eventKeySource:         event.Source,
+			eventKeyCount:          event.Count,
+			eventKeyCounts:         map[string]int{eventUID: event.Count},
+			eventKeyFirstTimestamp: formatAuditTime(firstTimestamp),
+			eventKeyLastTimestamp:  formatAuditTime(lastTimestamp),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.client.UpdateDocument(ctx, s.eventIndexName, id, bytes.NewReader(body))
+}
+
+// ListArchivedEvents lists the archived events selected by the filter, the
+// latest first. The events are restricted by the data access filter of the
+// context.
+func (s *Storage) ListArchivedEvents(ctx context.Context, filter *storage.EventFilter, pagination *storage.Pagination) (*storage.EventResult, error) {
+	accessFilter := authz.FilterFrom(ctx)
+	if accessFilter.AllowsNone() {
+		return &storage.EventResult{Events: []*entity.ArchivedEvent{}}, nil
+	}
+
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.eventIndexName); err != nil {
+		return nil, err
+	}
+
+	conds := []esquery.Mappable{}
+	for key, value := range map[string]string{
+		eventKeyCluster:    filter.ResourceGroup.Cluster,
+		eventKeyAPIVersion: filter.ResourceGroup.APIVersion,
+		eventKeyKind:       filter.ResourceGroup.Kind,
+		eventKeyNamespace:  filter.ResourceGroup.Namespace,
+		eventKeyName:       filter.ResourceGroup.Name,
+		eventKeyType:       filter.Type,
+		eventKeyReason:     filter.Reason,
+	} {
+		if value != "" {
+			conds = append(conds, esquery.Term(key, value))
+		}
+	}
+	// The series which occurred in the time range are those which last
+	// occurred after it starts and first occurred before it ends.
+	if !filter.From.IsZero() {
+		conds = append(conds, esquery.Range(eventKeyLastTimestamp).Gte(formatAuditTime(filter.From)))
+	}
+	if !filter.To.IsZero() {
+		conds = append(conds, esquery.Range(eventKeyFirstTimestamp).Lte(formatAuditTime(filter.To)))
+	}
+	if accessFilter != nil {
+		conds = append(conds, accessFilterQuery(accessFilter))
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(conds...).Map(),
+		"sort": []map[string]interface{}{
+			{eventKeyLastTimestamp: map[string]string{"order": "desc"}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.SearchDocument(ctx, s.eventIndexName, buf, elasticsearch.Pagination(pagination.Page, pagination.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &storage.EventResult{Events: make([]*entity.ArchivedEvent, 0, len(resp.Hits.Hits))}
+	if resp.Hits.Total != nil {
+		result.Total = resp.Hits.Total.Value
+	}
+	for _, hit := range resp.Hits.Hits {
+		event, err := storage.Map2ArchivedEvent(hit.Source)
+		if err != nil {
+			return nil, err
+		}
+		result.Events = append(result.Events, event)
+	}
+	return result, nil
+}
+
+// DeleteArchivedEventsBefore deletes the archived events which last occurred
+// before the given time.
+func (s *Storage) DeleteArchivedEventsBefore(ctx context.Context, before time.Time) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": esquery.Range(eventKeyLastTimestamp).Lt(formatAuditTime(before)).Map(),
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteDocumentByQuery(ctx, s.eventIndexName, bytes.NewReader(body))
+}
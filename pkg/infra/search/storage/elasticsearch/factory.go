@@ -23,6 +23,11 @@ var (
 	_ storage.SearchStorageGetter            = &SearchStorageGetter{}
 	_ storage.ResourceStorageGetter          = &ResourceStorageGetter{}
 	_ storage.ResourceGroupRuleStorageGetter = &ResourceGroupRuleStorageGetter{}
+	_ storage.AuditStorageGetter             = &AuditStorageGetter{}
+	_ storage.ActionLogStorageGetter         = &ActionLogStorageGetter{}
+	_ storage.SavedSearchStorageGetter       = &SavedSearchStorageGetter{}
+	_ storage.AlertRuleStorageGetter         = &AlertRuleStorageGetter{}
+	_ storage.EventStorageGetter             = &EventStorageGetter{}
 )
 
 // SearchStorageGetter represents a structure for getting search storage instances.
@@ -83,6 +88,106 @@ func (s *ResourceGroupRuleStorageGetter) GetResourceGroupRuleStorage() (storage.
 	return esClient, nil
 }
 
+// AuditStorageGetter represents a structure for getting audit storage
+// instances.
+type AuditStorageGetter struct {
+	cfg *Config
+}
+
+// GetAuditStorage retrieves and returns an audit storage instance based on the
+// provided configuration.
+func (s *AuditStorageGetter) GetAuditStorage() (storage.AuditStorage, error) {
+	esClient, err := NewStorage(elasticsearch.Config{
+		Addresses: s.cfg.Addresses,
+		Username:  s.cfg.UserName,
+		Password:  s.cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esClient, nil
+}
+
+// ActionLogStorageGetter represents a structure for getting action log
+// storage instances.
+type ActionLogStorageGetter struct {
+	cfg *Config
+}
+
+// GetActionLogStorage retrieves and returns an action log storage instance
+// based on the provided configuration.
+func (s *ActionLogStorageGetter) GetActionLogStorage() (storage.ActionLogStorage, error) {
+	esClient, err := NewStorage(elasticsearch.Config{
+		Addresses: s.cfg.Addresses,
+		Username:  s.cfg.UserName,
+		Password:  s.cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esClient, nil
+}
+
+// SavedSearchStorageGetter represents a structure for getting saved search
+// storage instances.
+type SavedSearchStorageGetter struct {
+	cfg *Config
+}
+
+// GetSavedSearchStorage retrieves and returns a saved search storage
+// instance based on the provided configuration.
+func (s *SavedSearchStorageGetter) GetSavedSearchStorage() (storage.SavedSearchStorage, error) {
+	esClient, err := NewStorage(elasticsearch.Config{
+		Addresses: s.cfg.Addresses,
+		Username:  s.cfg.UserName,
+		Password:  s.cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esClient, nil
+}
+
+// AlertRuleStorageGetter represents a structure for getting alert rule
+// storage instances.
+type AlertRuleStorageGetter struct {
+	cfg *Config
+}
+
+// GetAlertRuleStorage retrieves and returns an alert rule storage
+// instance based on the provided configuration.
+func (s *AlertRuleStorageGetter) GetAlertRuleStorage() (storage.AlertRuleStorage, error) {
+	esClient, err := NewStorage(elasticsearch.Config{
+		Addresses: s.cfg.Addresses,
+		Username:  s.cfg.UserName,
+		Password:  s.cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esClient, nil
+}
+
+// EventStorageGetter represents a structure for getting event storage
+// instances.
+type EventStorageGetter struct {
+	cfg *Config
+}
+
+// GetEventStorage retrieves and returns an event storage instance based on
+// the provided configuration.
+func (s *EventStorageGetter) GetEventStorage() (storage.EventStorage, error) {
+	esClient, err := NewStorage(elasticsearch.Config{
+		Addresses: s.cfg.Addresses,
+		Username:  s.cfg.UserName,
+		Password:  s.cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esClient, nil
+}
+
 // GeneralStorageGetter retrieves and returns a general storage instance based on
 // the provided configuration.
 type GeneralStorageGetter struct {
@@ -153,6 +258,79 @@ func NewResourceGroupRuleStorageGetter(addresses []string, userName, password st
 	}
 }
 
+// NewAuditStorageGetter creates a new instance of the AuditStorageGetter with
+// the given Elasticsearch addresses, user name, and password.
+func NewAuditStorageGetter(addresses []string, userName, password string) *AuditStorageGetter {
+	cfg := &Config{
+		Addresses: addresses,
+		UserName:  userName,
+		Password:  password,
+	}
+
+	return &AuditStorageGetter{
+		cfg,
+	}
+}
+
+// NewActionLogStorageGetter creates a new instance of the
+// ActionLogStorageGetter with the given Elasticsearch addresses, user name,
+// and password.
+func NewActionLogStorageGetter(addresses []string, userName, password string) *ActionLogStorageGetter {
+	cfg := &Config{
+		Addresses: addresses,
+		UserName:  userName,
+		Password:  password,
+	}
+
+	return &ActionLogStorageGetter{
+		cfg,
+	}
+}
+
+// NewSavedSearchStorageGetter creates a new instance of the
+// SavedSearchStorageGetter with the given Elasticsearch addresses, user name,
+// and password.
+func NewSavedSearchStorageGetter(addresses []string, userName, password string) *SavedSearchStorageGetter {
+	cfg := &Config{
+		Addresses: addresses,
+		UserName:  userName,
+		Password:  password,
+	}
+
+	return &SavedSearchStorageGetter{
+		cfg,
+	}
+}
+
+// NewAlertRuleStorageGetter creates a new instance of the
+// AlertRuleStorageGetter with the given Elasticsearch addresses, user name,
+// and password.
+func NewAlertRuleStorageGetter(addresses []string, userName, password string) *AlertRuleStorageGetter {
+	cfg := &Config{
+		Addresses: addresses,
+		UserName:  userName,
+		Password:  password,
+	}
+
+	return &AlertRuleStorageGetter{
+		cfg,
+	}
+}
+
+// NewEventStorageGetter creates a new instance of the EventStorageGetter
+// with the given Elasticsearch addresses, user name, and password.
+func NewEventStorageGetter(addresses []string, userName, password string) *EventStorageGetter {
+	cfg := &Config{
+		Addresses: addresses,
+		UserName:  userName,
+		Password:  password,
+	}
+
+	return &EventStorageGetter{
+		cfg,
+	}
+}
+
 // NewGeneralStorageGetter creates a new instance of the GeneralStorageGetter
 // with the given Elasticsearch addresses, user name, and password.
 func NewGeneralStorageGetter(addresses []string, userName, password string) *GeneralStorageGetter {
@@ -125,6 +125,14 @@ const (
       "fields": {
         "type": "keyword"
       },
+      "filter": {
+        "type": "object",
+        "enabled": false
+      },
+      "metadata": {
+        "type": "object",
+        "enabled": false
+      },
       "createdAt": {
         "type": "date",
         "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
@@ -139,5 +147,435 @@ const (
       }
     }
   }
+}`
+	defaultAuditIssueIndexName = "audit_issues"
+	defaultAuditIssueMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    },
+    "analysis":{
+      "normalizer":{
+        "lowercase":{
+          "type":"custom",
+          "filter":[
+            "lowercase"
+          ]
+        }
+      }
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "cluster":{
+        "type":"keyword"
+      },
+      "apiVersion":{
+        "type":"keyword"
+      },
+      "kind":{
+        "type":"keyword",
+        "normalizer":"lowercase"
+      },
+      "namespace":{
+        "type":"keyword"
+      },
+      "name":{
+        "type":"keyword"
+      },
+      "labels":{
+        "type":"object",
+        "enabled":false
+      },
+      "scanner":{
+        "type":"keyword"
+      },
+      "severity":{
+        "type":"keyword"
+      },
+      "title":{
+        "type":"keyword"
+      },
+      "message":{
+        "type":"text"
+      },
+      "firstSeen":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "lastSeen":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "resolvedAt":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
+}`
+	defaultScoreSnapshotIndexName = "score_snapshots"
+	defaultScoreSnapshotMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "scope":{
+        "type":"keyword"
+      },
+      "resourceGroup":{
+        "type":"object",
+        "enabled":false
+      },
+      "score":{
+        "type":"double"
+      },
+      "resourceTotal":{
+        "type":"integer"
+      },
+      "issuesTotal":{
+        "type":"integer"
+      },
+      "suppressedTotal":{
+        "type":"integer"
+      },
+      "severityStatistic":{
+        "type":"object",
+        "enabled":false
+      },
+      "timestamp":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
+}`
+	defaultActionLogIndexName = "action_logs"
+	defaultActionLogMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "timestamp":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "requestID":{
+        "type":"keyword"
+      },
+      "user":{
+        "type":"keyword"
+      },
+      "groups":{
+        "type":"keyword"
+      },
+      "sourceIP":{
+        "type":"keyword"
+      },
+      "action":{
+        "type":"keyword"
+      },
+      "method":{
+        "type":"keyword"
+      },
+      "path":{
+        "type":"keyword",
+        "ignore_above":1024
+      },
+      "target":{
+        "properties":{
+          "cluster":{
+            "type":"keyword"
+          },
+          "namespace":{
+            "type":"keyword"
+          },
+          "kind":{
+            "type":"keyword"
+          },
+          "name":{
+            "type":"keyword"
+          }
+        }
+      },
+      "details":{
+        "type":"flattened"
+      },
+      "statusCode":{
+        "type":"integer"
+      },
+      "outcome":{
+        "type":"keyword"
+      },
+      "durationMillis":{
+        "type":"long"
+      }
+    }
+  }
+}`
+	defaultSavedSearchIndexName = "saved_searches"
+	defaultSavedSearchMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "name":{
+        "type":"keyword"
+      },
+      "description":{
+        "type":"text"
+      },
+      "owner":{
+        "type":"keyword"
+      },
+      "pattern":{
+        "type":"keyword"
+      },
+      "query":{
+        "type":"text"
+      },
+      "format":{
+        "type":"keyword"
+      },
+      "columns":{
+        "type":"keyword"
+      },
+      "visibility":{
+        "type":"keyword"
+      },
+      "favoritedBy":{
+        "type":"keyword"
+      },
+      "createdAt":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "updatedAt":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
+}`
+	defaultResourceGroupSnapshotIndexName = "resource_group_snapshots"
+	defaultResourceGroupSnapshotMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "rule":{
+        "type":"keyword"
+      },
+      "scope":{
+        "type":"keyword"
+      },
+      "resourceGroup":{
+        "type":"object",
+        "enabled":false
+      },
+      "members":{
+        "type":"object",
+        "enabled":false
+      },
+      "membersTruncated":{
+        "type":"boolean"
+      },
+      "resourceTotal":{
+        "type":"integer"
+      },
+      "countByGVK":{
+        "type":"object",
+        "enabled":false
+      },
+      "timestamp":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
+}`
+	defaultAlertRuleIndexName = "alert_rules"
+	defaultAlertRuleMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "name":{
+        "type":"keyword"
+      },
+      "description":{
+        "type":"text"
+      },
+      "owner":{
+        "type":"keyword"
+      },
+      "access":{
+        "type":"object",
+        "enabled":false
+      },
+      "savedSearch":{
+        "type":"keyword"
+      },
+      "query":{
+        "type":"text"
+      },
+      "resourceGroup":{
+        "type":"flattened"
+      },
+      "trigger":{
+        "type":"flattened"
+      },
+      "condition":{
+        "type":"flattened"
+      },
+      "channels":{
+        "type":"object",
+        "enabled":false
+      },
+      "dedupeWindow":{
+        "type":"keyword"
+      },
+      "silencedUntil":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "disabled":{
+        "type":"boolean"
+      },
+      "status":{
+        "type":"object",
+        "enabled":false
+      },
+      "createdAt":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "updatedAt":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
+}`
+	defaultEventIndexName = "events"
+	defaultEventMapping   = `{
+  "settings":{
+    "index":{
+      "max_result_window": "1000000",
+      "number_of_shards":1,
+      "auto_expand_replicas":"0-1",
+      "number_of_replicas":0
+    }
+  },
+  "mappings":{
+    "properties":{
+      "id":{
+        "type":"keyword",
+        "ignore_above":256
+      },
+      "cluster":{
+        "type":"keyword"
+      },
+      "apiVersion":{
+        "type":"keyword"
+      },
+      "kind":{
+        "type":"keyword"
+      },
+      "namespace":{
+        "type":"keyword"
+      },
+      "name":{
+        "type":"keyword"
+      },
+      "uid":{
+        "type":"keyword"
+      },
+      "type":{
+        "type":"keyword"
+      },
+      "reason":{
+        "type":"keyword"
+      },
+      "message":{
+        "type":"text"
+      },
+      "source":{
+        "type":"keyword"
+      },
+      "count":{
+        "type":"long"
+      },
+      "counts":{
+        "type":"object",
+        "enabled":false
+      },
+      "firstTimestamp":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      },
+      "lastTimestamp":{
+        "type":"date",
+        "format":"yyyy-MM-dd'T'HH:mm:ss'Z'"
+      }
+    }
+  }
 }`
 )
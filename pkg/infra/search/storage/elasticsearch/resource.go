@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 	"github.com/elliotxx/esquery"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -137,12 +138,33 @@ func (s *Storage) GetResource(ctx context.Context, cluster string, obj runtime.O
 }
 
 // CountResources return a count of resources in the Elasticsearch storage.
+// Only the resources allowed by the data access filter of the context are
+// counted.
 func (s *Storage) CountResources(ctx context.Context) (int, error) {
-	if resp, err := s.client.Count(ctx, s.resourceIndexName); err != nil {
+	filter := authz.FilterFrom(ctx)
+	if filter == nil {
+		if resp, err := s.client.Count(ctx, s.resourceIndexName); err != nil {
+			return 0, err
+		} else {
+			return int(resp.Count), nil
+		}
+	}
+	if filter.AllowsNone() {
+		return 0, nil
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(accessFilterQuery(filter)).Map(),
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return 0, err
+	}
+	resp, err := s.client.CountByQuery(ctx, s.resourceIndexName, buf)
+	if err != nil {
 		return 0, err
-	} else {
-		return int(resp.Count), nil
 	}
+	return int(resp.Count), nil
 }
 
 // DeleteAllResources removes all resources from the Elasticsearch storage for the specified cluster.
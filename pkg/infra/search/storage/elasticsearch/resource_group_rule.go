@@ -19,11 +19,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/KusionStack/karpor/pkg/util/sql2es"
 	"github.com/elliotxx/esquery"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -31,6 +35,8 @@ const (
 	resourceGroupRuleKeyName        = "name"
 	resourceGroupRuleKeyDescription = "description"
 	resourceGroupRuleKeyFields      = "fields"
+	resourceGroupRuleKeyFilter      = "filter"
+	resourceGroupRuleKeyMetadata    = "metadata"
 	resourceGroupRuleKeyCreatedAt   = "createdAt"
 	resourceGroupRuleKeyUpdatedAt   = "updatedAt"
 	resourceGroupRuleKeyDeletedAt   = "deletedAt"
@@ -145,7 +151,17 @@ func (s *Storage) ListResourceGroupsBy(ctx context.Context, ruleName string) (*s
 		return nil, err
 	}
 
-	resp, err := s.client.AggregateDocumentByTerms(ctx, s.resourceIndexName, rgr.Fields)
+	// Only the resources allowed by the data access filter of the context
+	// form the resource groups.
+	filter := authz.FilterFrom(ctx)
+	if filter.AllowsNone() {
+		return nil, ErrResourceGroupNotFound
+	}
+	query, err := ResourceGroupFilterQuery(rgr.Filter)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.AggregateDocumentByTerms(ctx, s.resourceIndexName, rgr.Fields, restrictAggregationQuery(query, filter))
 	if err != nil {
 		return nil, err
 	}
@@ -163,8 +179,10 @@ func (s *Storage) ListResourceGroupsBy(ctx context.Context, ruleName string) (*s
 		if len(rgr.Fields) != len(bucket.Keys) {
 			return nil, fmt.Errorf("mismatched number of fields: expected %d, got %d", len(rgr.Fields), len(bucket.Keys))
 		}
-		// Convert the current bucket to a resource group.
-		rg := &entity.ResourceGroup{}
+		// Convert the current bucket to a resource group, which keeps the
+		// filter of the rule so that only the resources grouped by the rule
+		// are located by it.
+		rg := &entity.ResourceGroup{Filter: rgr.Filter}
 		for i, v := range bucket.Keys {
 			field := rgr.Fields[i]
 			switch field {
@@ -199,12 +217,56 @@ func (s *Storage) ListResourceGroupsBy(ctx context.Context, ruleName string) (*s
 		rgList = append(rgList, rg)
 	}
 
+	// Attach the metadata of the rule to the groups it matches.
+	var metadata []*entity.ResourceGroupMetadata
+	if len(rgr.Metadata) > 0 {
+		metadata = make([]*entity.ResourceGroupMetadata, len(rgList))
+		for i, rg := range rgList {
+			metadata[i] = rgr.MetadataOf(rg)
+		}
+	}
+
 	return &storage.ResourceGroupResult{
-		Groups: rgList,
-		Fields: rgr.Fields,
+		Groups:   rgList,
+		Fields:   rgr.Fields,
+		Metadata: metadata,
 	}, nil
 }
 
+// ResourceGroupFilterQuery converts the filter of a resource group rule into
+// an elasticsearch query, which matches the resources not deleted that match
+// both the terms and the SQL where clause of the filter.
+func ResourceGroupFilterQuery(filter *entity.ResourceGroupFilter) (map[string]interface{}, error) {
+	boolQuery := esquery.Bool().Filter(esquery.Term(resourceKeyDeleted, false))
+	if filter.IsEmpty() {
+		return boolQuery.Map(), nil
+	}
+
+	fields := make([]string, 0, len(filter.Terms))
+	for field := range filter.Terms {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		boolQuery.Filter(esquery.Terms(field, toInterfaces(filter.Terms[field])...))
+	}
+
+	if where := strings.TrimSpace(filter.Query); where != "" {
+		dsl, _, err := sql2es.ConvertWithDefaultFilter(fmt.Sprintf("select * from %s where %s", defaultResourceIndexName, where), &sql2es.DeletedFilter)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid filter query")
+		}
+		var body struct {
+			Query map[string]interface{} `json:"query"`
+		}
+		if err := json.Unmarshal([]byte(dsl), &body); err != nil {
+			return nil, errors.Wrap(err, "invalid filter query")
+		}
+		boolQuery.Filter(esquery.CustomQuery(body.Query))
+	}
+	return boolQuery.Map(), nil
+}
+
 // SaveResourceGroupRule saves a resource group rule to the storage.
 func (s *Storage) SaveResourceGroupRule(ctx context.Context, data *entity.ResourceGroupRule) error {
 	id, body, err := s.generateResourceGroupRuleDocument(data)
@@ -237,6 +299,8 @@ func (s *Storage) generateResourceGroupRuleDocument(data *entity.ResourceGroupRu
 		resourceGroupRuleKeyName:        data.Name,
 		resourceGroupRuleKeyDescription: data.Description,
 		resourceGroupRuleKeyFields:      data.Fields,
+		resourceGroupRuleKeyFilter:      data.Filter,
+		resourceGroupRuleKeyMetadata:    data.Metadata,
 		resourceGroupRuleKeyCreatedAt:   data.CreatedAt,
 		resourceGroupRuleKeyUpdatedAt:   data.UpdatedAt,
 	})
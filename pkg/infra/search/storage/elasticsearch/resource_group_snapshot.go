@@ -0,0 +1,233 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	resourceGroupSnapshotKeyID            = "id"
+	resourceGroupSnapshotKeyRule          = "rule"
+	resourceGroupSnapshotKeyScope         = "scope"
+	resourceGroupSnapshotKeyResourceGroup = "resourceGroup"
+	resourceGroupSnapshotKeyMembers       = "members"
+	resourceGroupSnapshotKeyTruncated     = "membersTruncated"
+	resourceGroupSnapshotKeyResourceTotal = "resourceTotal"
+	resourceGroupSnapshotKeyCountByGVK    = "countByGVK"
+	resourceGroupSnapshotKeyTimestamp     = "timestamp"
+
+	// resourceGroupSnapshotPageSize is the page size used to list resource
+	// group snapshots.
+	resourceGroupSnapshotPageSize = 1000
+	// resourceGroupMemberPageSize is the page size used to list the
+	// resources in a resource group.
+	resourceGroupMemberPageSize = 1000
+)
+
+var ErrResourceGroupSnapshotNotFound = fmt.Errorf("resource group snapshot not found")
+
+// ScanResourceGroupMembers calls fn with the resources in the resource group
+// of the resource group rule page by page, which match both the resource
+// group and the filter of the rule. The resources are identified by their
+// cluster, apiVersion, kind, namespace and name, and ordered by cluster,
+// namespace, kind, name and apiVersion.
+func (s *Storage) ScanResourceGroupMembers(ctx context.Context, rule *entity.ResourceGroupRule, resourceGroup *entity.ResourceGroup, fn func(members []entity.ResourceGroup) error) error {
+	query, err := resourceGroupMembersQuery(rule, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	return s.searchAfter(ctx, query, resourceGroupMemberPageSize, func(hits []*elasticsearch.Hit) error {
+		members := make([]entity.ResourceGroup, 0, len(hits))
+		for _, hit := range hits {
+			member := entity.ResourceGroup{}
+			member.Cluster, _ = hit.Source[resourceKeyCluster].(string)
+			member.APIVersion, _ = hit.Source[resourceKeyAPIVersion].(string)
+			member.Kind, _ = hit.Source[resourceKeyKind].(string)
+			member.Namespace, _ = hit.Source[resourceKeyNamespace].(string)
+			member.Name, _ = hit.Source[resourceKeyName].(string)
+			members = append(members, member)
+		}
+		return fn(members)
+	})
+}
+
+// resourceGroupMembersQuery creates a query to search for the resources in
+// the resource group of the resource group rule which are not deleted.
+func resourceGroupMembersQuery(rule *entity.ResourceGroupRule, resourceGroup *entity.ResourceGroup) (map[string]interface{}, error) {
+	boolQuery := esquery.Bool()
+	for field, value := range map[string]string{
+		resourceKeyCluster:    resourceGroup.Cluster,
+		resourceKeyAPIVersion: resourceGroup.APIVersion,
+		resourceKeyKind:       resourceGroup.Kind,
+		resourceKeyNamespace:  resourceGroup.Namespace,
+		resourceKeyName:       resourceGroup.Name,
+	} {
+		if value != "" {
+			boolQuery.Filter(esquery.Term(field, value))
+		}
+	}
+	for prefix, values := range map[string]map[string]string{
+		resourceKeyLabels:      resourceGroup.Labels,
+		resourceKeyAnnotations: resourceGroup.Annotations,
+	} {
+		for k, v := range values {
+			boolQuery.Filter(esquery.Term(prefix+"."+k, v))
+		}
+	}
+
+	filterQuery, err := ResourceGroupFilterQuery(rule.Filter)
+	if err != nil {
+		return nil, err
+	}
+	boolQuery.Filter(esquery.CustomQuery(filterQuery))
+
+	return map[string]interface{}{
+		"query": boolQuery.Map(),
+		"_source": []string{
+			resourceKeyCluster, resourceKeyAPIVersion, resourceKeyKind, resourceKeyNamespace, resourceKeyName,
+		},
+	}, nil
+}
+
+// SaveResourceGroupSnapshot saves a resource group snapshot to the storage,
+// an id is assigned to new snapshots.
+func (s *Storage) SaveResourceGroupSnapshot(ctx context.Context, snapshot *entity.ResourceGroupSnapshot) error {
+	if len(snapshot.ID) == 0 {
+		snapshot.ID = entity.UUID()
+	}
+
+	var timestamp string
+	if snapshot.Timestamp != nil {
+		timestamp = formatAuditTime(snapshot.Timestamp.Time)
+	} else {
+		timestamp = formatAuditTime(time.Now())
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		resourceGroupSnapshotKeyID:            snapshot.ID,
+		resourceGroupSnapshotKeyRule:          snapshot.Rule,
+		resourceGroupSnapshotKeyScope:         string(snapshot.ResourceGroup.Hash()),
+		resourceGroupSnapshotKeyResourceGroup: snapshot.ResourceGroup,
+		resourceGroupSnapshotKeyMembers:       snapshot.Members,
+		resourceGroupSnapshotKeyTruncated:     snapshot.MembersTruncated,
+		resourceGroupSnapshotKeyResourceTotal: snapshot.ResourceTotal,
+		resourceGroupSnapshotKeyCountByGVK:    snapshot.CountByGVK,
+		resourceGroupSnapshotKeyTimestamp:     timestamp,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.client.SaveDocument(ctx, s.resourceGroupSnapshotIndexName, snapshot.ID, bytes.NewReader(body))
+}
+
+// GetResourceGroupSnapshot retrieves a resource group snapshot with its
+// members based on the given id.
+func (s *Storage) GetResourceGroupSnapshot(ctx context.Context, id string) (*entity.ResourceGroupSnapshot, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.resourceGroupSnapshotIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(esquery.Term(resourceGroupSnapshotKeyID, id)).Map(),
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchDocument(ctx, s.resourceGroupSnapshotIndexName, buf)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Hits.Total.Value == 0 {
+		return nil, ErrResourceGroupSnapshotNotFound
+	}
+	return storage.Map2ResourceGroupSnapshot(resp.Hits.Hits[0].Source)
+}
+
+// ListResourceGroupSnapshots lists the snapshots of exactly the given
+// resource group of the resource group rule recorded between from and to,
+// ordered by time. The members of the snapshots are omitted.
+func (s *Storage) ListResourceGroupSnapshots(ctx context.Context, ruleName string, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ResourceGroupSnapshot, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.resourceGroupSnapshotIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(
+			esquery.Term(resourceGroupSnapshotKeyRule, ruleName),
+			esquery.Term(resourceGroupSnapshotKeyScope, string(resourceGroup.Hash())),
+			esquery.Range(resourceGroupSnapshotKeyTimestamp).Gte(formatAuditTime(from)).Lte(formatAuditTime(to)),
+		).Map(),
+		"_source": map[string]interface{}{
+			"excludes": []string{resourceGroupSnapshotKeyMembers},
+		},
+		"sort": []map[string]interface{}{
+			{resourceGroupSnapshotKeyTimestamp: map[string]string{"order": "asc"}},
+		},
+	}
+
+	snapshots := []*entity.ResourceGroupSnapshot{}
+	for page := 1; ; page++ {
+		buf := &bytes.Buffer{}
+		if err := json.NewEncoder(buf).Encode(query); err != nil {
+			return nil, err
+		}
+
+		resp, err := s.client.SearchDocument(ctx, s.resourceGroupSnapshotIndexName, buf, elasticsearch.Pagination(page, resourceGroupSnapshotPageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			snapshot, err := storage.Map2ResourceGroupSnapshot(hit.Source)
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		if len(resp.Hits.Hits) < resourceGroupSnapshotPageSize {
+			break
+		}
+	}
+
+	return snapshots, nil
+}
+
+// DeleteResourceGroupSnapshotsBefore deletes the resource group snapshots
+// recorded before the given time.
+func (s *Storage) DeleteResourceGroupSnapshotsBefore(ctx context.Context, before time.Time) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": esquery.Range(resourceGroupSnapshotKeyTimestamp).Lt(formatAuditTime(before)).Map(),
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteDocumentByQuery(ctx, s.resourceGroupSnapshotIndexName, bytes.NewReader(body))
+}
@@ -0,0 +1,116 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	savedSearchKeyName = "name"
+
+	// maxSavedSearches is the maximum number of saved searches listed.
+	maxSavedSearches = 10000
+)
+
+var ErrSavedSearchNotFound = fmt.Errorf("saved search not found")
+
+// GetSavedSearch retrieves a saved search based on the given name.
+func (s *Storage) GetSavedSearch(ctx context.Context, name string) (*entity.SavedSearch, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.savedSearchIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Filter(esquery.Term(savedSearchKeyName, name)).Map(),
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchDocument(ctx, s.savedSearchIndexName, buf)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Hits.Total.Value == 0 {
+		return nil, ErrSavedSearchNotFound
+	}
+	return storage.Map2SavedSearch(resp.Hits.Hits[0].Source)
+}
+
+// SaveSavedSearch saves a saved search to the storage, an id is assigned to
+// new saved searches.
+func (s *Storage) SaveSavedSearch(ctx context.Context, data *entity.SavedSearch) error {
+	if len(data.ID) == 0 {
+		data.ID = entity.UUID()
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.client.SaveDocument(ctx, s.savedSearchIndexName, data.ID, bytes.NewReader(body))
+}
+
+// DeleteSavedSearch deletes a saved search based on the given name.
+func (s *Storage) DeleteSavedSearch(ctx context.Context, name string) error {
+	savedSearch, err := s.GetSavedSearch(ctx, name)
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteDocument(ctx, s.savedSearchIndexName, savedSearch.ID)
+}
+
+// ListSavedSearches lists all saved searches sorted by name.
+func (s *Storage) ListSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.savedSearchIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.MatchAll().Map(),
+		"sort": []map[string]interface{}{
+			{savedSearchKeyName: map[string]string{"order": "asc"}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.SearchDocument(ctx, s.savedSearchIndexName, buf, elasticsearch.Pagination(1, maxSavedSearches))
+	if err != nil {
+		return nil, err
+	}
+
+	savedSearches := make([]*entity.SavedSearch, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		savedSearch, err := storage.Map2SavedSearch(hit.Source)
+		if err != nil {
+			return nil, err
+		}
+		savedSearches = append(savedSearches, savedSearch)
+	}
+	return savedSearches, nil
+}
@@ -0,0 +1,120 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/elliotxx/esquery"
+)
+
+const (
+	scoreSnapshotKeyID                = "id"
+	scoreSnapshotKeyScope             = "scope"
+	scoreSnapshotKeyResourceGroup     = "resourceGroup"
+	scoreSnapshotKeyScore             = "score"
+	scoreSnapshotKeyResourceTotal     = "resourceTotal"
+	scoreSnapshotKeyIssuesTotal       = "issuesTotal"
+	scoreSnapshotKeySuppressedTotal   = "suppressedTotal"
+	scoreSnapshotKeySeverityStatistic = "severityStatistic"
+	scoreSnapshotKeyTimestamp         = "timestamp"
+
+	// scoreSnapshotPageSize is the page size used to list score snapshots.
+	scoreSnapshotPageSize = 1000
+)
+
+// SaveScoreSnapshot saves a score snapshot to the storage.
+func (s *Storage) SaveScoreSnapshot(ctx context.Context, snapshot *entity.ScoreSnapshot) error {
+	id := snapshot.ID
+	if len(id) == 0 {
+		id = entity.UUID()
+	}
+
+	var timestamp string
+	if snapshot.Timestamp != nil {
+		timestamp = formatAuditTime(snapshot.Timestamp.Time)
+	} else {
+		timestamp = formatAuditTime(time.Now())
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		scoreSnapshotKeyID:                id,
+		scoreSnapshotKeyScope:             string(snapshot.ResourceGroup.Hash()),
+		scoreSnapshotKeyResourceGroup:     snapshot.ResourceGroup,
+		scoreSnapshotKeyScore:             snapshot.Score,
+		scoreSnapshotKeyResourceTotal:     snapshot.ResourceTotal,
+		scoreSnapshotKeyIssuesTotal:       snapshot.IssuesTotal,
+		scoreSnapshotKeySuppressedTotal:   snapshot.SuppressedTotal,
+		scoreSnapshotKeySeverityStatistic: snapshot.SeverityStatistic,
+		scoreSnapshotKeyTimestamp:         timestamp,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.client.SaveDocument(ctx, s.scoreSnapshotIndexName, id, bytes.NewReader(body))
+}
+
+// ListScoreSnapshots lists the score snapshots of exactly the given resource
+// group recorded between from and to, ordered by time.
+func (s *Storage) ListScoreSnapshots(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ScoreSnapshot, error) {
+	// Refresh the index before searching to ensure real-time data.
+	if err := s.client.Refresh(ctx, s.scoreSnapshotIndexName); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": esquery.Bool().Must(
+			esquery.Term(scoreSnapshotKeyScope, string(resourceGroup.Hash())),
+			esquery.Range(scoreSnapshotKeyTimestamp).Gte(formatAuditTime(from)).Lte(formatAuditTime(to)),
+		).Map(),
+		"sort": []map[string]interface{}{
+			{scoreSnapshotKeyTimestamp: map[string]string{"order": "asc"}},
+		},
+	}
+
+	snapshots := []*entity.ScoreSnapshot{}
+	for page := 1; ; page++ {
+		buf := &bytes.Buffer{}
+		if err := json.NewEncoder(buf).Encode(query); err != nil {
+			return nil, err
+		}
+
+		resp, err := s.client.SearchDocument(ctx, s.scoreSnapshotIndexName, buf, elasticsearch.Pagination(page, scoreSnapshotPageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			snapshot, err := storage.Map2ScoreSnapshot(hit.Source)
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		if len(resp.Hits.Hits) < scoreSnapshotPageSize {
+			break
+		}
+	}
+
+	return snapshots, nil
+}
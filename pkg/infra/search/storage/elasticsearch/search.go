@@ -23,11 +23,14 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/core/authz"
 	"github.com/KusionStack/karpor/pkg/core/entity"
 	"github.com/KusionStack/karpor/pkg/infra/persistence/elasticsearch"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 	"github.com/KusionStack/karpor/pkg/util/sql2es"
+	"github.com/elliotxx/esquery"
 	"github.com/pkg/errors"
+	"github.com/xwb1989/sqlparser"
 )
 
 // Pagination defines the struct for pagination which contains page number and page size.
@@ -37,10 +40,15 @@ type Pagination struct {
 }
 
 // Search performs a search operation with the given query string, pattern type, and pagination settings.
+// The results are restricted by the data access filter of the context.
 func (s *Storage) Search(ctx context.Context, queryStr, patternType string, pagination *storage.Pagination) (*storage.SearchResult, error) {
 	var sr *storage.SearchResult
 	var err error
 
+	if authz.FilterFrom(ctx).AllowsNone() {
+		return &storage.SearchResult{Resources: []*storage.Resource{}}, nil
+	}
+
 	switch patternType {
 	case storage.DSLPatternType:
 		sr, err = s.searchByDSL(ctx, queryStr, pagination)
@@ -60,7 +68,12 @@ func (s *Storage) Search(ctx context.Context, queryStr, patternType string, pagi
 }
 
 // SearchByQuery performs a search operation using a query map and pagination settings.
+// The results are restricted by the data access filter of the context.
 func (s *Storage) SearchByQuery(ctx context.Context, query map[string]interface{}, pagination *storage.Pagination) (*storage.SearchResult, error) {
+	if filter := authz.FilterFrom(ctx); filter != nil {
+		query = restrictQuery(query, filter)
+	}
+
 	buf := &bytes.Buffer{}
 	if err := json.NewEncoder(buf).Encode(query); err != nil {
 		return nil, err
@@ -87,7 +100,11 @@ func (s *Storage) searchByDSL(ctx context.Context, dslStr string, pagination *st
 
 // searchBySQL performs a search operation using an SQL string and pagination settings.
 func (s *Storage) searchBySQL(ctx context.Context, sqlStr string, pagination *storage.Pagination) (*storage.SearchResult, error) {
-	dsl, _, err := sql2es.ConvertWithDefaultFilter(sqlStr, &sql2es.DeletedFilter)
+	var accessFilter sqlparser.Expr
+	if filter := authz.FilterFrom(ctx); filter != nil {
+		accessFilter = accessFilterExpr(filter)
+	}
+	dsl, _, err := sql2es.ConvertWithRequiredFilter(sqlStr, &sql2es.DeletedFilter, accessFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +126,19 @@ func (s *Storage) search(ctx context.Context, body io.Reader, pagination *storag
 }
 
 // SearchByTerms performs a search operation with a map of keys and values and pagination information.
+// The results are restricted by the data access filter of the context.
 func (s *Storage) SearchByTerms(ctx context.Context, keysAndValues map[string]any, pagination *storage.Pagination) (*storage.SearchResult, error) {
+	if filter := authz.FilterFrom(ctx); filter != nil {
+		if filter.AllowsNone() {
+			return &storage.SearchResult{Resources: []*storage.Resource{}}, nil
+		}
+		boolQuery := esquery.Bool()
+		for k, v := range keysAndValues {
+			boolQuery.Must(esquery.Term(k, v))
+		}
+		return s.SearchByQuery(ctx, map[string]interface{}{"query": boolQuery.Map()}, pagination)
+	}
+
 	var opts []elasticsearch.Option
 	if pagination != nil {
 		opts = append(opts, elasticsearch.Pagination(pagination.Page, pagination.PageSize))
@@ -121,6 +150,77 @@ func (s *Storage) SearchByTerms(ctx context.Context, keysAndValues map[string]an
 	return convertSearchResult(resp)
 }
 
+// ScanByTerms calls fn with the resources matching the keys and values page
+// by page, until all of them are visited or fn returns an error. Deleted
+// resources are left out. The pages are fetched with search_after, so that
+// all resources are reached however many match. The results are restricted
+// by the data access filter of the context.
+func (s *Storage) ScanByTerms(ctx context.Context, keysAndValues map[string]any, pageSize int, fn func(resources []*storage.Resource) error) error {
+	filter := authz.FilterFrom(ctx)
+	if filter.AllowsNone() {
+		return nil
+	}
+
+	boolQuery := esquery.Bool().Filter(esquery.Term(resourceKeyDeleted, false))
+	for k, v := range keysAndValues {
+		boolQuery.Filter(esquery.Term(k, v))
+	}
+	if filter != nil {
+		boolQuery.Filter(accessFilterQuery(filter))
+	}
+
+	return s.searchAfter(ctx, map[string]interface{}{"query": boolQuery.Map()}, pageSize, func(hits []*elasticsearch.Hit) error {
+		resources := make([]*storage.Resource, len(hits))
+		for i, hit := range hits {
+			var err error
+			if resources[i], err = storage.Map2Resource(hit.Source); err != nil {
+				return err
+			}
+		}
+		return fn(resources)
+	})
+}
+
+// searchAfter calls fn with the hits of the resources matching the search
+// request body page by page, paging with search_after on the identity of the
+// resources. The identity is only unique among the resources which are not
+// deleted, so the query must leave the deleted resources out.
+func (s *Storage) searchAfter(ctx context.Context, body map[string]interface{}, pageSize int, fn func(hits []*elasticsearch.Hit) error) error {
+	request := make(map[string]interface{}, len(body)+2)
+	for k, v := range body {
+		request[k] = v
+	}
+	request["sort"] = []map[string]interface{}{
+		{resourceKeyCluster: map[string]string{"order": "asc"}},
+		{resourceKeyNamespace: map[string]string{"order": "asc"}},
+		{resourceKeyKind: map[string]string{"order": "asc"}},
+		{resourceKeyName: map[string]string{"order": "asc"}},
+		{resourceKeyAPIVersion: map[string]string{"order": "asc"}},
+	}
+
+	for {
+		buf := &bytes.Buffer{}
+		if err := json.NewEncoder(buf).Encode(request); err != nil {
+			return err
+		}
+		resp, err := s.client.SearchDocument(ctx, s.resourceIndexName, buf, elasticsearch.Pagination(1, pageSize))
+		if err != nil {
+			return err
+		}
+
+		hits := resp.Hits.Hits
+		if len(hits) > 0 {
+			if err := fn(hits); err != nil {
+				return err
+			}
+		}
+		if len(hits) < pageSize {
+			return nil
+		}
+		request["search_after"] = hits[len(hits)-1].Sort
+	}
+}
+
 // convertSearchResult converts an elasticsearch.SearchResponse to a storage.SearchResult.
 func convertSearchResult(in *elasticsearch.SearchResponse) (*storage.SearchResult, error) {
 	out := &storage.SearchResult{
@@ -154,8 +254,13 @@ func convertAggregationResult(in *elasticsearch.AggResults) *storage.AggregateRe
 }
 
 // AggregateByTerms performs an aggregation operation using the provided list of keys and returns the results.
+// Only the resources allowed by the data access filter of the context are aggregated.
 func (s *Storage) AggregateByTerms(ctx context.Context, keys []string) (*storage.AggregateResults, error) {
-	resp, err := s.client.AggregateDocumentByTerms(ctx, s.resourceIndexName, keys)
+	filter := authz.FilterFrom(ctx)
+	if filter.AllowsNone() {
+		return &storage.AggregateResults{Buckets: []storage.Bucket{}}, nil
+	}
+	resp, err := s.client.AggregateDocumentByTerms(ctx, s.resourceIndexName, keys, restrictAggregationQuery(nil, filter))
 	if err != nil {
 		return nil, err
 	}
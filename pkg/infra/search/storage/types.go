@@ -17,6 +17,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -41,6 +42,11 @@ type Storage interface {
 	ResourceStorage
 	ResourceGroupRuleStorage
 	SearchStorage
+	AuditStorage
+	ActionLogStorage
+	SavedSearchStorage
+	AlertRuleStorage
+	EventStorage
 	CheckHealth
 }
 
@@ -64,15 +70,99 @@ type ResourceGroupRuleStorage interface {
 	ListResourceGroupRules(ctx context.Context) ([]*entity.ResourceGroupRule, error)
 	CountResourceGroupRules(ctx context.Context) (int, error)
 	ListResourceGroupsBy(ctx context.Context, ruleName string) (*ResourceGroupResult, error)
+	ScanResourceGroupMembers(ctx context.Context, rule *entity.ResourceGroupRule, resourceGroup *entity.ResourceGroup, fn func(members []entity.ResourceGroup) error) error
+	SaveResourceGroupSnapshot(ctx context.Context, snapshot *entity.ResourceGroupSnapshot) error
+	GetResourceGroupSnapshot(ctx context.Context, id string) (*entity.ResourceGroupSnapshot, error)
+	ListResourceGroupSnapshots(ctx context.Context, ruleName string, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ResourceGroupSnapshot, error)
+	DeleteResourceGroupSnapshotsBefore(ctx context.Context, before time.Time) error
 }
 
 // SearchStorage interface defines the basic operations for search storage.
 type SearchStorage interface {
 	Search(ctx context.Context, queryString, patternType string, pagination *Pagination) (*SearchResult, error)
 	SearchByTerms(ctx context.Context, keysAndValues map[string]any, pagination *Pagination) (*SearchResult, error)
+	ScanByTerms(ctx context.Context, keysAndValues map[string]any, pageSize int, fn func(resources []*Resource) error) error
 	AggregateByTerms(ctx context.Context, keys []string) (*AggregateResults, error)
 }
 
+// AuditStorage interface defines the basic operations for storage of the
+// issues found by the background audit.
+type AuditStorage interface {
+	SaveAuditIssues(ctx context.Context, issues []*entity.AuditIssue, seenAt time.Time) error
+	ResolveAuditIssues(ctx context.Context, cluster, namespace string, seenBefore time.Time) error
+	ListAuditIssues(ctx context.Context, resourceGroup entity.ResourceGroup, includeResolved bool) ([]*entity.AuditIssue, error)
+	ListAuditIssuesIntroduced(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error)
+	ListAuditIssuesResolved(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.AuditIssue, error)
+	SaveScoreSnapshot(ctx context.Context, snapshot *entity.ScoreSnapshot) error
+	ListScoreSnapshots(ctx context.Context, resourceGroup entity.ResourceGroup, from, to time.Time) ([]*entity.ScoreSnapshot, error)
+}
+
+// ActionLogStorage interface defines the basic operations for storage of the
+// action logs of users.
+type ActionLogStorage interface {
+	SaveActionLog(ctx context.Context, log *entity.ActionLog) error
+	ListActionLogs(ctx context.Context, filter *ActionLogFilter, pagination *Pagination) (*ActionLogResult, error)
+}
+
+// SavedSearchStorage interface defines the basic operations for storage of
+// the saved searches.
+type SavedSearchStorage interface {
+	GetSavedSearch(ctx context.Context, name string) (*entity.SavedSearch, error)
+	SaveSavedSearch(ctx context.Context, data *entity.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, name string) error
+	ListSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error)
+}
+
+// AlertRuleStorage interface defines the basic operations for storage of the
+// alert rules.
+type AlertRuleStorage interface {
+	GetAlertRule(ctx context.Context, name string) (*entity.AlertRule, error)
+	SaveAlertRule(ctx context.Context, data *entity.AlertRule) error
+	DeleteAlertRule(ctx context.Context, name string) error
+	ListAlertRules(ctx context.Context) ([]*entity.AlertRule, error)
+}
+
+// EventStorage interface defines the basic operations for storage of the
+// archived Kubernetes events.
+type EventStorage interface {
+	ArchiveEvent(ctx context.Context, eventUID string, event *entity.ArchivedEvent) error
+	ListArchivedEvents(ctx context.Context, filter *EventFilter, pagination *Pagination) (*EventResult, error)
+	DeleteArchivedEventsBefore(ctx context.Context, before time.Time) error
+}
+
+// ActionLogFilter selects action logs, empty fields select all values.
+type ActionLogFilter struct {
+	User    string
+	Action  string
+	Cluster string
+	Outcome string
+	From    time.Time
+	To      time.Time
+}
+
+// ActionLogResult contains the listed action logs and the total count.
+type ActionLogResult struct {
+	Total int                 `json:"total"`
+	Logs  []*entity.ActionLog `json:"logs"`
+}
+
+// EventFilter selects archived events, empty fields select all values.
+type EventFilter struct {
+	// ResourceGroup locates the objects the events are about.
+	ResourceGroup entity.ResourceGroup
+	Type          string
+	Reason        string
+	// From and To select the events which occurred in the time range.
+	From time.Time
+	To   time.Time
+}
+
+// EventResult contains the listed archived events and the total count.
+type EventResult struct {
+	Total  int                     `json:"total"`
+	Events []*entity.ArchivedEvent `json:"events"`
+}
+
 type SearchStorageGetter interface {
 	GetSearchStorage() (SearchStorage, error)
 }
@@ -84,6 +174,26 @@ type ResourceGroupRuleStorageGetter interface {
 	GetResourceGroupRuleStorage() (ResourceGroupRuleStorage, error)
 }
 
+type AuditStorageGetter interface {
+	GetAuditStorage() (AuditStorage, error)
+}
+
+type ActionLogStorageGetter interface {
+	GetActionLogStorage() (ActionLogStorage, error)
+}
+
+type SavedSearchStorageGetter interface {
+	GetSavedSearchStorage() (SavedSearchStorage, error)
+}
+
+type AlertRuleStorageGetter interface {
+	GetAlertRuleStorage() (AlertRuleStorage, error)
+}
+
+type EventStorageGetter interface {
+	GetEventStorage() (EventStorage, error)
+}
+
 type GeneralStorageGetter interface {
 	GetGeneralStorage() (Storage, error)
 }
@@ -126,6 +236,9 @@ type Bucket struct {
 type ResourceGroupResult struct {
 	Groups []*entity.ResourceGroup `json:"groups" yaml:"groups"`
 	Fields []string                `json:"fields" yaml:"fields"`
+	// Metadata is the metadata of the groups by index, which is nil for the
+	// groups without metadata.
+	Metadata []*entity.ResourceGroupMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // Overview returns a brief summary of the search result.
@@ -239,6 +352,21 @@ func Map2ResourceGroupRule(in map[string]interface{}) (*entity.ResourceGroupRule
 		out.Fields[i] = toString(field)
 	}
 
+	// The filter and the metadata are newly added, so they don't exist in
+	// the old data.
+	for key, value := range map[string]interface{}{"filter": &out.Filter, "metadata": &out.Metadata} {
+		if in[key] == nil {
+			continue
+		}
+		b, err := json.Marshal(in[key])
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(b, value); err != nil {
+			return nil, err
+		}
+	}
+
 	var err error
 	if out.CreatedAt, err = toTime(in["createdAt"]); err != nil {
 		return nil, err
@@ -252,6 +380,134 @@ func Map2ResourceGroupRule(in map[string]interface{}) (*entity.ResourceGroupRule
 	return out, nil
 }
 
+// Map2AuditIssue converts a map to an AuditIssue object.
+func Map2AuditIssue(in map[string]interface{}) (*entity.AuditIssue, error) {
+	out := &entity.AuditIssue{}
+	out.ID = toString(in["id"])
+	out.Resource = entity.ResourceGroup{
+		Cluster:    toString(in["cluster"]),
+		APIVersion: toString(in["apiVersion"]),
+		Kind:       toString(in["kind"]),
+		Namespace:  toString(in["namespace"]),
+		Name:       toString(in["name"]),
+	}
+	if labels, ok := in["labels"].(map[string]interface{}); ok {
+		out.Labels = make(map[string]string, len(labels))
+		for k, v := range labels {
+			out.Labels[k] = toString(v)
+		}
+	}
+	out.Scanner = toString(in["scanner"])
+	out.Severity = toString(in["severity"])
+	out.Title = toString(in["title"])
+	out.Message = toString(in["message"])
+
+	var err error
+	if out.FirstSeen, err = toTime(in["firstSeen"]); err != nil {
+		return nil, err
+	}
+	if out.LastSeen, err = toTime(in["lastSeen"]); err != nil {
+		return nil, err
+	}
+	if out.ResolvedAt, err = toTime(in["resolvedAt"]); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Map2ScoreSnapshot converts a map to a ScoreSnapshot object.
+func Map2ScoreSnapshot(in map[string]interface{}) (*entity.ScoreSnapshot, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.ScoreSnapshot{}
+	if err = json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Map2ResourceGroupSnapshot converts a map to a ResourceGroupSnapshot object.
+func Map2ResourceGroupSnapshot(in map[string]interface{}) (*entity.ResourceGroupSnapshot, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.ResourceGroupSnapshot{}
+	if err = json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Map2ActionLog converts a map to an ActionLog object.
+func Map2ActionLog(in map[string]interface{}) (*entity.ActionLog, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.ActionLog{}
+	if err = json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Map2ArchivedEvent converts a map to an ArchivedEvent object.
+func Map2ArchivedEvent(in map[string]interface{}) (*entity.ArchivedEvent, error) {
+	out := &entity.ArchivedEvent{}
+	out.ID = toString(in["id"])
+	out.InvolvedObject = entity.ResourceGroup{
+		Cluster:    toString(in["cluster"]),
+		APIVersion: toString(in["apiVersion"]),
+		Kind:       toString(in["kind"]),
+		Namespace:  toString(in["namespace"]),
+		Name:       toString(in["name"]),
+	}
+	out.UID = toString(in["uid"])
+	out.Type = toString(in["type"])
+	out.Reason = toString(in["reason"])
+	out.Message = toString(in["message"])
+	out.Source = toString(in["source"])
+	if count, ok := in["count"].(float64); ok {
+		out.Count = int(count)
+	}
+
+	var err error
+	if out.FirstTimestamp, err = toTime(in["firstTimestamp"]); err != nil {
+		return nil, err
+	}
+	if out.LastTimestamp, err = toTime(in["lastTimestamp"]); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func Map2SavedSearch(in map[string]interface{}) (*entity.SavedSearch, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.SavedSearch{}
+	if err = json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func Map2AlertRule(in map[string]interface{}) (*entity.AlertRule, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.AlertRule{}
+	if err = json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 //nolint:nilnil
 func toTime(in interface{}) (*metav1.Time, error) {
 	if in == nil {
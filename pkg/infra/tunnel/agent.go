@@ -0,0 +1,222 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Agent runs in a member cluster, it keeps a tunnel open to the hub and
+// proxies the requests of the hub to the API server of the cluster.
+type Agent struct {
+	hubConfig *rest.Config
+	cluster   string
+	proxy     http.Handler
+}
+
+// NewAgent creates an agent connecting to the hub as the cluster, the
+// requests of the hub are sent to the API server with the cluster config.
+func NewAgent(hubConfig *rest.Config, cluster string, clusterConfig *rest.Config) (*Agent, error) {
+	target, err := url.Parse(clusterConfig.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster host %s", clusterConfig.Host)
+	}
+	transport, err := rest.TransportFor(clusterConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the cluster transport")
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = transport
+	proxy.FlushInterval = -1
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// The agent authenticates with its own credential of the cluster.
+		req.Header.Del("Authorization")
+		req.Host = ""
+	}
+
+	return &Agent{
+		hubConfig: hubConfig,
+		cluster:   cluster,
+		proxy:     proxy,
+	}, nil
+}
+
+// Run keeps the tunnel open until the context is done, reconnecting with
+// backoff whenever the tunnel is closed.
+func (a *Agent) Run(ctx context.Context) error {
+	delay := minReconnectDelay
+	for {
+		start := time.Now()
+		err := a.serve(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if time.Since(start) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		klog.ErrorS(err, "Tunnel to the hub closed, reconnecting", "cluster", a.cluster, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// serve opens a tunnel and serves the requests of the hub over it until
+// the tunnel is closed.
+func (a *Agent) serve(ctx context.Context) error {
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return err
+	}
+	klog.InfoS("Tunnel to the hub connected", "cluster", a.cluster)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	server := &http2.Server{}
+	server.ServeConn(conn, &http2.ServeConnOpts{Context: ctx, Handler: a.proxy})
+	return errors.New("tunnel closed")
+}
+
+// dial connects to the hub and upgrades the connection to a tunnel. The
+// upgrade request is sent through the wrappers of the hub config, so that it
+// carries the credential of the agent.
+func (a *Agent) dial(ctx context.Context) (net.Conn, error) {
+	hubURL, _, err := rest.DefaultServerURL(a.hubConfig.Host, "", schema.GroupVersion{}, rest.IsConfigTransportTLS(*a.hubConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hub host")
+	}
+	tlsConfig, err := rest.TLSConfigFor(a.hubConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the hub TLS config")
+	}
+	if tlsConfig != nil {
+		// The tunnel is upgraded from HTTP/1.1.
+		tlsConfig.NextProtos = []string{"http/1.1"}
+	}
+
+	upgrader := &upgradeRoundTripper{tlsConfig: tlsConfig}
+	rt, err := rest.HTTPWrappersForConfig(a.hubConfig, upgrader)
+	if err != nil {
+		return nil, err
+	}
+
+	location := *hubURL
+	location.Path = Path(a.cluster)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", Protocol)
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the hub")
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		if upgrader.conn != nil {
+			upgrader.conn.Close()
+		}
+		return nil, errors.Errorf("the hub refused the tunnel: %s", resp.Status)
+	}
+	return upgrader.conn, nil
+}
+
+// upgradeRoundTripper sends a single upgrade request on a new connection and
+// keeps the connection for the tunnel.
+type upgradeRoundTripper struct {
+	tlsConfig *tls.Config
+	conn      net.Conn
+}
+
+func (u *upgradeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if req.URL.Port() == "" {
+		port := "80"
+		if req.URL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(req.URL.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	var conn net.Conn
+	var err error
+	if req.URL.Scheme == "https" {
+		tlsConfig := u.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig = tlsConfig.Clone()
+			tlsConfig.ServerName = req.URL.Hostname()
+		}
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(req.Context(), "tcp", host)
+	} else {
+		conn, err = dialer.DialContext(req.Context(), "tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	u.conn = &bufferedConn{Conn: conn, reader: reader}
+	return resp, nil
+}
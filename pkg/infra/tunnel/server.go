@@ -0,0 +1,169 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/klog/v2"
+)
+
+// Server keeps the tunnels opened by the agents of the clusters on the hub,
+// and sends the requests to the clusters through them.
+type Server struct {
+	transport *http2.Transport
+
+	lock    sync.RWMutex
+	tunnels map[string]*agentTunnel
+}
+
+// agentTunnel is a tunnel opened by the agent of a cluster.
+type agentTunnel struct {
+	// agent is the user the agent authenticated as.
+	agent string
+	conn  *http2.ClientConn
+}
+
+// NewServer creates a server without any tunnel.
+func NewServer() *Server {
+	return &Server{
+		transport: &http2.Transport{
+			ReadIdleTimeout: pingInterval,
+			PingTimeout:     pingTimeout,
+		},
+		tunnels: map[string]*agentTunnel{},
+	}
+}
+
+// Accept upgrades the request of the agent of the cluster, authenticated as
+// the given user, to a tunnel. The previous tunnel of the cluster, if any, is
+// closed, unless it is still open and was opened by another user.
+func (s *Server) Accept(cluster, agent string, w http.ResponseWriter, r *http.Request) error {
+	if !httpstream.IsUpgradeRequest(r) || !strings.EqualFold(r.Header.Get("Upgrade"), Protocol) {
+		return errors.Errorf("the request is not an upgrade to %s", Protocol)
+	}
+	if err := s.checkReplace(cluster, agent); err != nil {
+		return err
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return errors.New("the connection cannot be hijacked to open a tunnel")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return errors.Wrap(err, "failed to hijack the connection")
+	}
+
+	if _, err := conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: " + Protocol + "\r\n\r\n")); err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed to switch protocols")
+	}
+	cc, err := s.transport.NewClientConn(&bufferedConn{Conn: conn, reader: rw.Reader})
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed to open the tunnel")
+	}
+
+	s.lock.Lock()
+	if err := s.checkReplaceLocked(cluster, agent); err != nil {
+		s.lock.Unlock()
+		cc.Close()
+		return err
+	}
+	previous := s.tunnels[cluster]
+	s.tunnels[cluster] = &agentTunnel{agent: agent, conn: cc}
+	s.lock.Unlock()
+	if previous != nil {
+		previous.conn.Close()
+	}
+	klog.InfoS("Agent tunnel connected", "cluster", cluster, "agent", agent, "remoteAddr", conn.RemoteAddr().String())
+	return nil
+}
+
+// checkReplace returns an ErrTunnelInUse error if the cluster has an open
+// tunnel opened by another agent than the given one.
+func (s *Server) checkReplace(cluster, agent string) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.checkReplaceLocked(cluster, agent)
+}
+
+// checkReplaceLocked is checkReplace with the lock held.
+func (s *Server) checkReplaceLocked(cluster, agent string) error {
+	previous := s.tunnels[cluster]
+	if previous == nil || previous.agent == agent || !previous.conn.CanTakeNewRequest() {
+		return nil
+	}
+	klog.InfoS("Refused to replace the agent tunnel opened by another agent", "cluster", cluster, "agent", agent, "previousAgent", previous.agent)
+	return errors.Wrapf(ErrTunnelInUse, "cluster %s", cluster)
+}
+
+// Connected reports whether the agent of the cluster has a tunnel open.
+func (s *Server) Connected(cluster string) bool {
+	_, err := s.conn(cluster)
+	return err == nil
+}
+
+// RoundTripper returns a round tripper sending the requests to the cluster
+// through its tunnel. The tunnel is looked up for every request, so that
+// the round tripper keeps working when the agent reconnects.
+func (s *Server) RoundTripper(cluster string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		cc, err := s.conn(cluster)
+		if err != nil {
+			return nil, err
+		}
+		return cc.RoundTrip(req)
+	})
+}
+
+// conn returns the tunnel of the cluster, the closed tunnels are forgotten.
+func (s *Server) conn(cluster string) (*http2.ClientConn, error) {
+	s.lock.RLock()
+	t := s.tunnels[cluster]
+	s.lock.RUnlock()
+	if t == nil {
+		return nil, errors.Wrapf(ErrNotConnected, "cluster %s", cluster)
+	}
+	cc := t.conn
+	if !cc.CanTakeNewRequest() {
+		s.lock.Lock()
+		if s.tunnels[cluster] == t {
+			delete(s.tunnels, cluster)
+		}
+		s.lock.Unlock()
+		klog.InfoS("Agent tunnel disconnected", "cluster", cluster)
+		return nil, errors.Wrapf(ErrNotConnected, "cluster %s", cluster)
+	}
+	return cc, nil
+}
+
+// bufferedConn is a connection whose reads are served from the reader first,
+// which may hold data read ahead from the connection.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
@@ -0,0 +1,63 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tunnel provides reverse tunnels from the agents in the member
+// clusters to the hub, so that the hub can reach the clusters which do not
+// accept inbound connections. The agent dials out to the hub and upgrades
+// the connection to a tunnel, then the hub sends HTTP/2 requests over the
+// tunnel, which the agent serves by proxying them to the API server of its
+// cluster.
+package tunnel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+)
+
+// Protocol is the protocol of the Upgrade header opening a tunnel.
+const Protocol = "karpor-tunnel"
+
+const (
+	// pingInterval is the idle time after which a tunnel is health checked
+	// with a ping.
+	pingInterval = 30 * time.Second
+	// pingTimeout is the timeout of the ping, after which the tunnel is
+	// closed.
+	pingTimeout = 15 * time.Second
+)
+
+// ErrNotConnected is returned when the agent of the cluster has no tunnel
+// open to the hub.
+var ErrNotConnected = errors.New("the agent of the cluster is not connected")
+
+// ErrTunnelInUse is returned when the cluster has a tunnel open by another
+// agent.
+var ErrTunnelInUse = errors.New("the cluster has a tunnel open by another agent")
+
+// Path returns the path of the tunnel subresource of the cluster on the hub,
+// which the agent of the cluster connects to.
+func Path(cluster string) string {
+	return fmt.Sprintf("/apis/%s/clusters/%s/tunnel", clusterv1beta1.SchemeGroupVersion.String(), cluster)
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,93 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestPath(t *testing.T) {
+	require.Equal(t, "/apis/cluster.karpor.io/v1beta1/clusters/edge/tunnel", Path("edge"))
+}
+
+func TestTunnel(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, r.Method+" "+r.URL.RequestURI())
+	}))
+	defer target.Close()
+
+	server := NewServer()
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents := map[string]string{"Bearer agent-token": "agent", "Bearer other-token": "other"}
+		agent, ok := agents[r.Header.Get("Authorization")]
+		if r.URL.Path != Path("edge") || !ok {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := server.Accept("edge", agent, w, r); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer hub.Close()
+
+	_, err := server.RoundTripper("edge").RoundTrip(httptest.NewRequest(http.MethodGet, "https://edge/version", nil))
+	require.ErrorIs(t, err, ErrNotConnected)
+
+	agent, err := NewAgent(&rest.Config{Host: hub.URL, BearerToken: "agent-token"}, "edge", &rest.Config{Host: target.URL})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = agent.Run(ctx)
+	}()
+	require.Eventually(t, func() bool { return server.Connected("edge") }, 10*time.Second, 50*time.Millisecond)
+
+	client := &http.Client{Transport: server.RoundTripper("edge")}
+	req, err := http.NewRequest(http.MethodGet, "https://edge/api/v1/namespaces?limit=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer hub-token")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "GET /api/v1/namespaces?limit=1", string(body))
+	require.Empty(t, resp.Header.Get("X-Authorization"))
+
+	agent2, err := NewAgent(&rest.Config{Host: hub.URL, BearerToken: "wrong-token"}, "edge", &rest.Config{Host: target.URL})
+	require.NoError(t, err)
+	_, err = agent2.dial(context.Background())
+	require.ErrorContains(t, err, "refused")
+
+	// Another agent cannot replace the open tunnel.
+	other, err := NewAgent(&rest.Config{Host: hub.URL, BearerToken: "other-token"}, "edge", &rest.Config{Host: target.URL})
+	require.NoError(t, err)
+	_, err = other.dial(context.Background())
+	require.ErrorContains(t, err, "refused")
+	require.True(t, server.Connected("edge"))
+
+	cancel()
+	require.Eventually(t, func() bool { return !server.Connected("edge") }, 10*time.Second, 50*time.Millisecond)
+}
@@ -56,6 +56,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&Cluster{},
 		&ClusterList{},
 		&ClusterProxyOptions{},
+		&ClusterProxyPolicy{},
+		&ClusterProxyPolicyList{},
 	)
 	return nil
 }
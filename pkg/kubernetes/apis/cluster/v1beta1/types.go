@@ -28,6 +28,19 @@ const (
 	CredentialTypeOIDC                CredentialType = "OIDC"
 )
 
+// ClusterAccessMode is how the hub connects to the API server of a cluster.
+type ClusterAccessMode string
+
+const (
+	// ClusterAccessModeDirect connects to the endpoint of the cluster with
+	// the credential of the cluster.
+	ClusterAccessModeDirect ClusterAccessMode = "Direct"
+	// ClusterAccessModeAgent tunnels the requests through an agent in the
+	// cluster, which dials out to the hub. It is used for the clusters
+	// which cannot be reached by the hub, such as those behind NAT.
+	ClusterAccessModeAgent ClusterAccessMode = "Agent"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -64,10 +77,59 @@ type ClusterSpec struct {
 type ClusterStatus struct {
 	// +optional
 	Healthy bool `json:"healthy,omitempty"`
+	// CredentialExpiration is the time when the credential of the cluster
+	// expires. It is empty if the credential does not expire or its expiry
+	// is unknown, such as for exec credentials.
+	// +optional
+	CredentialExpiration *metav1.Time `json:"credentialExpiration,omitempty"`
+	// CredentialRotationTime is the last time the service account token of
+	// the cluster was rotated by karpor.
+	// +optional
+	CredentialRotationTime *metav1.Time `json:"credentialRotationTime,omitempty"`
+	// Version is the version of the API server of the cluster.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// LatencyMillis is the latency of the API server of the cluster in the
+	// last probe.
+	// +optional
+	LatencyMillis int64 `json:"latencyMillis,omitempty"`
+	// LastProbeTime is the last time the cluster was probed.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastSyncTime is the last time the resources of the cluster were saved
+	// or deleted by the syncer. It is updated at most every few seconds.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Conditions are the latest observations of the state of the cluster.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// Types of the conditions of clusters.
+const (
+	// ClusterConditionReachable means the API server of the cluster can be
+	// reached.
+	ClusterConditionReachable = "Reachable"
+	// ClusterConditionAuthenticated means the credential of the cluster is
+	// accepted by its API server.
+	ClusterConditionAuthenticated = "Authenticated"
+	// ClusterConditionSyncing means the resources of the cluster are being
+	// synced by the syncer.
+	ClusterConditionSyncing = "Syncing"
+)
+
 type ClusterAccess struct {
-	Endpoint string `json:"endpoint"`
+	// Mode is how the hub connects to the cluster, Direct by default. The
+	// endpoint and credential are not used in Agent mode.
+	// +optional
+	Mode ClusterAccessMode `json:"mode,omitempty"`
+	// AgentUser is the user the agent authenticates to the hub as in Agent
+	// mode, only the user can open the tunnel of the cluster.
+	// +optional
+	AgentUser string `json:"agentUser,omitempty"`
+	Endpoint  string `json:"endpoint"`
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
 	// +optional
@@ -117,3 +179,87 @@ type ClusterProxyOptions struct {
 	// +optional
 	Path string `json:"path,omitempty"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterProxyPolicy allows users and groups to send requests to clusters
+// through the cluster proxy. Once cluster proxy policies are enabled, the
+// proxied requests are denied unless they are allowed by a policy.
+type ClusterProxyPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ClusterProxyPolicySpec `json:"spec,omitempty"`
+}
+
+type ClusterProxyPolicySpec struct {
+	// Subjects are the users and groups the policy applies to.
+	// +required
+	Subjects []ClusterProxySubject `json:"subjects"`
+
+	// Clusters are the names of the clusters the policy applies to, an empty
+	// list applies to all clusters.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// ReadOnly only allows the requests reading resources, such as get, list
+	// and watch.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Rules are the requests allowed by the policy, a request is allowed if
+	// any rule matches it. An empty list allows all requests.
+	// +optional
+	Rules []ClusterProxyRule `json:"rules,omitempty"`
+}
+
+// Kinds of subjects of a cluster proxy policy.
+const (
+	ClusterProxySubjectUser  = "User"
+	ClusterProxySubjectGroup = "Group"
+)
+
+// ClusterProxySubject is a user or a group a cluster proxy policy applies to.
+type ClusterProxySubject struct {
+	// Kind is the kind of the subject, either User or Group.
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the user or the group.
+	// +required
+	Name string `json:"name"`
+}
+
+// ClusterProxyRule matches proxied requests. Multiple fields are ANDed, and
+// multiple values of a single field are ORed. An empty field matches all
+// values.
+type ClusterProxyRule struct {
+	// Verbs are the kubernetes verbs of the requests, such as get, list,
+	// watch, create, update, patch and delete, "*" matches all verbs.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+
+	// Paths are the paths of the requests, a path ending with "*" matches
+	// the paths with the prefix before it, e.g. "/api/v1/namespaces/*".
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// Namespaces are the namespaces of the requests, the requests which are
+	// not namespaced never match a rule with namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterProxyPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterProxyPolicy `json:"items"`
+}
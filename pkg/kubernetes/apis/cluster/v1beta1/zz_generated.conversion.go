@@ -26,6 +26,7 @@ import (
 	unsafe "unsafe"
 
 	cluster "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -87,6 +88,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterProxyPolicy)(nil), (*cluster.ClusterProxyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterProxyPolicy_To_cluster_ClusterProxyPolicy(a.(*ClusterProxyPolicy), b.(*cluster.ClusterProxyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*cluster.ClusterProxyPolicy)(nil), (*ClusterProxyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_cluster_ClusterProxyPolicy_To_v1beta1_ClusterProxyPolicy(a.(*cluster.ClusterProxyPolicy), b.(*ClusterProxyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterProxyPolicyList)(nil), (*cluster.ClusterProxyPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterProxyPolicyList_To_cluster_ClusterProxyPolicyList(a.(*ClusterProxyPolicyList), b.(*cluster.ClusterProxyPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*cluster.ClusterProxyPolicyList)(nil), (*ClusterProxyPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_cluster_ClusterProxyPolicyList_To_v1beta1_ClusterProxyPolicyList(a.(*cluster.ClusterProxyPolicyList), b.(*ClusterProxyPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterProxyPolicySpec)(nil), (*cluster.ClusterProxyPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec(a.(*ClusterProxyPolicySpec), b.(*cluster.ClusterProxyPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*cluster.ClusterProxyPolicySpec)(nil), (*ClusterProxyPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec(a.(*cluster.ClusterProxyPolicySpec), b.(*ClusterProxyPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterProxyRule)(nil), (*cluster.ClusterProxyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterProxyRule_To_cluster_ClusterProxyRule(a.(*ClusterProxyRule), b.(*cluster.ClusterProxyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*cluster.ClusterProxyRule)(nil), (*ClusterProxyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_cluster_ClusterProxyRule_To_v1beta1_ClusterProxyRule(a.(*cluster.ClusterProxyRule), b.(*ClusterProxyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterProxySubject)(nil), (*cluster.ClusterProxySubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterProxySubject_To_cluster_ClusterProxySubject(a.(*ClusterProxySubject), b.(*cluster.ClusterProxySubject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*cluster.ClusterProxySubject)(nil), (*ClusterProxySubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_cluster_ClusterProxySubject_To_v1beta1_ClusterProxySubject(a.(*cluster.ClusterProxySubject), b.(*ClusterProxySubject), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterSpec)(nil), (*cluster.ClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterSpec_To_cluster_ClusterSpec(a.(*ClusterSpec), b.(*cluster.ClusterSpec), scope)
 	}); err != nil {
@@ -178,6 +229,8 @@ func Convert_cluster_Cluster_To_v1beta1_Cluster(in *cluster.Cluster, out *Cluste
 }
 
 func autoConvert_v1beta1_ClusterAccess_To_cluster_ClusterAccess(in *ClusterAccess, out *cluster.ClusterAccess, s conversion.Scope) error {
+	out.Mode = cluster.ClusterAccessMode(in.Mode)
+	out.AgentUser = in.AgentUser
 	out.Endpoint = in.Endpoint
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.Insecure = (*bool)(unsafe.Pointer(in.Insecure))
@@ -191,6 +244,8 @@ func Convert_v1beta1_ClusterAccess_To_cluster_ClusterAccess(in *ClusterAccess, o
 }
 
 func autoConvert_cluster_ClusterAccess_To_v1beta1_ClusterAccess(in *cluster.ClusterAccess, out *ClusterAccess, s conversion.Scope) error {
+	out.Mode = ClusterAccessMode(in.Mode)
+	out.AgentUser = in.AgentUser
 	out.Endpoint = in.Endpoint
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.Insecure = (*bool)(unsafe.Pointer(in.Insecure))
@@ -289,6 +344,126 @@ func Convert_url_Values_To_v1beta1_ClusterProxyOptions(in *url.Values, out *Clus
 	return autoConvert_url_Values_To_v1beta1_ClusterProxyOptions(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterProxyPolicy_To_cluster_ClusterProxyPolicy(in *ClusterProxyPolicy, out *cluster.ClusterProxyPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxyPolicy_To_cluster_ClusterProxyPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxyPolicy_To_cluster_ClusterProxyPolicy(in *ClusterProxyPolicy, out *cluster.ClusterProxyPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxyPolicy_To_cluster_ClusterProxyPolicy(in, out, s)
+}
+
+func autoConvert_cluster_ClusterProxyPolicy_To_v1beta1_ClusterProxyPolicy(in *cluster.ClusterProxyPolicy, out *ClusterProxyPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_cluster_ClusterProxyPolicy_To_v1beta1_ClusterProxyPolicy is an autogenerated conversion function.
+func Convert_cluster_ClusterProxyPolicy_To_v1beta1_ClusterProxyPolicy(in *cluster.ClusterProxyPolicy, out *ClusterProxyPolicy, s conversion.Scope) error {
+	return autoConvert_cluster_ClusterProxyPolicy_To_v1beta1_ClusterProxyPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProxyPolicyList_To_cluster_ClusterProxyPolicyList(in *ClusterProxyPolicyList, out *cluster.ClusterProxyPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]cluster.ClusterProxyPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxyPolicyList_To_cluster_ClusterProxyPolicyList is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxyPolicyList_To_cluster_ClusterProxyPolicyList(in *ClusterProxyPolicyList, out *cluster.ClusterProxyPolicyList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxyPolicyList_To_cluster_ClusterProxyPolicyList(in, out, s)
+}
+
+func autoConvert_cluster_ClusterProxyPolicyList_To_v1beta1_ClusterProxyPolicyList(in *cluster.ClusterProxyPolicyList, out *ClusterProxyPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterProxyPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_cluster_ClusterProxyPolicyList_To_v1beta1_ClusterProxyPolicyList is an autogenerated conversion function.
+func Convert_cluster_ClusterProxyPolicyList_To_v1beta1_ClusterProxyPolicyList(in *cluster.ClusterProxyPolicyList, out *ClusterProxyPolicyList, s conversion.Scope) error {
+	return autoConvert_cluster_ClusterProxyPolicyList_To_v1beta1_ClusterProxyPolicyList(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec(in *ClusterProxyPolicySpec, out *cluster.ClusterProxyPolicySpec, s conversion.Scope) error {
+	out.Subjects = *(*[]cluster.ClusterProxySubject)(unsafe.Pointer(&in.Subjects))
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.ReadOnly = in.ReadOnly
+	out.Rules = *(*[]cluster.ClusterProxyRule)(unsafe.Pointer(&in.Rules))
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec(in *ClusterProxyPolicySpec, out *cluster.ClusterProxyPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxyPolicySpec_To_cluster_ClusterProxyPolicySpec(in, out, s)
+}
+
+func autoConvert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec(in *cluster.ClusterProxyPolicySpec, out *ClusterProxyPolicySpec, s conversion.Scope) error {
+	out.Subjects = *(*[]ClusterProxySubject)(unsafe.Pointer(&in.Subjects))
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.ReadOnly = in.ReadOnly
+	out.Rules = *(*[]ClusterProxyRule)(unsafe.Pointer(&in.Rules))
+	return nil
+}
+
+// Convert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec is an autogenerated conversion function.
+func Convert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec(in *cluster.ClusterProxyPolicySpec, out *ClusterProxyPolicySpec, s conversion.Scope) error {
+	return autoConvert_cluster_ClusterProxyPolicySpec_To_v1beta1_ClusterProxyPolicySpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProxyRule_To_cluster_ClusterProxyRule(in *ClusterProxyRule, out *cluster.ClusterProxyRule, s conversion.Scope) error {
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.Paths = *(*[]string)(unsafe.Pointer(&in.Paths))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxyRule_To_cluster_ClusterProxyRule is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxyRule_To_cluster_ClusterProxyRule(in *ClusterProxyRule, out *cluster.ClusterProxyRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxyRule_To_cluster_ClusterProxyRule(in, out, s)
+}
+
+func autoConvert_cluster_ClusterProxyRule_To_v1beta1_ClusterProxyRule(in *cluster.ClusterProxyRule, out *ClusterProxyRule, s conversion.Scope) error {
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.Paths = *(*[]string)(unsafe.Pointer(&in.Paths))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_cluster_ClusterProxyRule_To_v1beta1_ClusterProxyRule is an autogenerated conversion function.
+func Convert_cluster_ClusterProxyRule_To_v1beta1_ClusterProxyRule(in *cluster.ClusterProxyRule, out *ClusterProxyRule, s conversion.Scope) error {
+	return autoConvert_cluster_ClusterProxyRule_To_v1beta1_ClusterProxyRule(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterProxySubject_To_cluster_ClusterProxySubject(in *ClusterProxySubject, out *cluster.ClusterProxySubject, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ClusterProxySubject_To_cluster_ClusterProxySubject is an autogenerated conversion function.
+func Convert_v1beta1_ClusterProxySubject_To_cluster_ClusterProxySubject(in *ClusterProxySubject, out *cluster.ClusterProxySubject, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterProxySubject_To_cluster_ClusterProxySubject(in, out, s)
+}
+
+func autoConvert_cluster_ClusterProxySubject_To_v1beta1_ClusterProxySubject(in *cluster.ClusterProxySubject, out *ClusterProxySubject, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_cluster_ClusterProxySubject_To_v1beta1_ClusterProxySubject is an autogenerated conversion function.
+func Convert_cluster_ClusterProxySubject_To_v1beta1_ClusterProxySubject(in *cluster.ClusterProxySubject, out *ClusterProxySubject, s conversion.Scope) error {
+	return autoConvert_cluster_ClusterProxySubject_To_v1beta1_ClusterProxySubject(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterSpec_To_cluster_ClusterSpec(in *ClusterSpec, out *cluster.ClusterSpec, s conversion.Scope) error {
 	out.Provider = in.Provider
 	if err := Convert_v1beta1_ClusterAccess_To_cluster_ClusterAccess(&in.Access, &out.Access, s); err != nil {
@@ -323,6 +498,13 @@ func Convert_cluster_ClusterSpec_To_v1beta1_ClusterSpec(in *cluster.ClusterSpec,
 
 func autoConvert_v1beta1_ClusterStatus_To_cluster_ClusterStatus(in *ClusterStatus, out *cluster.ClusterStatus, s conversion.Scope) error {
 	out.Healthy = in.Healthy
+	out.CredentialExpiration = (*v1.Time)(unsafe.Pointer(in.CredentialExpiration))
+	out.CredentialRotationTime = (*v1.Time)(unsafe.Pointer(in.CredentialRotationTime))
+	out.Version = in.Version
+	out.LatencyMillis = in.LatencyMillis
+	out.LastProbeTime = (*v1.Time)(unsafe.Pointer(in.LastProbeTime))
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -333,6 +515,13 @@ func Convert_v1beta1_ClusterStatus_To_cluster_ClusterStatus(in *ClusterStatus, o
 
 func autoConvert_cluster_ClusterStatus_To_v1beta1_ClusterStatus(in *cluster.ClusterStatus, out *ClusterStatus, s conversion.Scope) error {
 	out.Healthy = in.Healthy
+	out.CredentialExpiration = (*v1.Time)(unsafe.Pointer(in.CredentialExpiration))
+	out.CredentialRotationTime = (*v1.Time)(unsafe.Pointer(in.CredentialRotationTime))
+	out.Version = in.Version
+	out.LatencyMillis = in.LatencyMillis
+	out.LastProbeTime = (*v1.Time)(unsafe.Pointer(in.LastProbeTime))
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -15,6 +15,8 @@
 package validation
 
 import (
+	"strings"
+
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -25,3 +27,39 @@ func ValidateCluster(f *cluster.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 	return allErrs
 }
+
+// supportedProxySubjectKinds is the list of subject kinds accepted by cluster
+// proxy policies.
+var supportedProxySubjectKinds = []string{cluster.ClusterProxySubjectUser, cluster.ClusterProxySubjectGroup}
+
+// ValidateClusterProxyPolicy validates the spec of a ClusterProxyPolicy.
+func ValidateClusterProxyPolicy(p *cluster.ClusterProxyPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	subjectsPath := field.NewPath("spec", "subjects")
+
+	if len(p.Spec.Subjects) == 0 {
+		allErrs = append(allErrs, field.Required(subjectsPath, "at least one subject is required"))
+	}
+	for i, subject := range p.Spec.Subjects {
+		if subject.Kind != cluster.ClusterProxySubjectUser && subject.Kind != cluster.ClusterProxySubjectGroup {
+			allErrs = append(allErrs, field.NotSupported(subjectsPath.Index(i).Child("kind"), subject.Kind, supportedProxySubjectKinds))
+		}
+		if len(strings.TrimSpace(subject.Name)) == 0 {
+			allErrs = append(allErrs, field.Required(subjectsPath.Index(i).Child("name"), "name cannot be empty"))
+		}
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+	for i, rule := range p.Spec.Rules {
+		for j, path := range rule.Paths {
+			if !strings.HasPrefix(path, "/") {
+				allErrs = append(allErrs, field.Invalid(rulesPath.Index(i).Child("paths").Index(j), path, "path must start with /"))
+			}
+			if strings.Contains(strings.TrimSuffix(path, "*"), "*") {
+				allErrs = append(allErrs, field.Invalid(rulesPath.Index(i).Child("paths").Index(j), path, "* is only allowed at the end of a path"))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -56,6 +56,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TransformRuleList{},
 		&TrimRule{},
 		&TrimRuleList{},
+		&ScanPolicy{},
+		&ScanPolicyList{},
+		&AuditException{},
+		&AuditExceptionList{},
+		&DataAccessRule{},
+		&DataAccessRuleList{},
 	)
 	return nil
 }
@@ -181,6 +181,186 @@ type TransformRuleList struct {
 	Items []TransformRule
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanPolicy defines an organization-specific compliance rule, which is
+// evaluated by the built-in policy scanner against the synced resources.
+type ScanPolicy struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec ScanPolicySpec
+}
+
+type ScanPolicySpec struct {
+	// Title is a brief summary of the issue reported for violating resources.
+	// Defaults to the name of the policy.
+	Title string
+
+	// Message is the detailed description of the issue reported for violating
+	// resources. It is overridden by the message returned from the rule.
+	Message string
+
+	// Severity is the severity of the reported issue, one of Safe, Low, Medium,
+	// High or Critical.
+	Severity string
+
+	// Match selects the resources the policy applies to. An empty match
+	// selects all resources.
+	Match ScanPolicyMatch
+
+	// Engine is the policy engine used to evaluate the rule. Only CEL is
+	// supported currently.
+	Engine string
+
+	// Rule is the policy expression evaluated against each matched resource.
+	Rule string
+}
+
+// ScanPolicyEngineCEL is the engine which evaluates scan policy rules written
+// in the Common Expression Language.
+const ScanPolicyEngineCEL = "CEL"
+
+// ScanPolicyMatch selects the resources a scan policy applies to. Multiple
+// fields are ANDed, and multiple values of a single field are ORed.
+type ScanPolicyMatch struct {
+	// Clusters is the list of clusters the policy applies to.
+	Clusters []string
+
+	// APIVersions is the list of api versions the policy applies to.
+	APIVersions []string
+
+	// Kinds is the list of kinds the policy applies to.
+	Kinds []string
+
+	// Namespaces is the list of namespaces the policy applies to.
+	Namespaces []string
+
+	// LabelSelector is a filter to select resources by labels.
+	LabelSelector *metav1.LabelSelector
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ScanPolicyList struct {
+	metav1.TypeMeta
+
+	metav1.ListMeta
+
+	Items []ScanPolicy
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuditException suppresses the audit issues which are accepted risks, so
+// that they are not counted in the score of the affected resources.
+type AuditException struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec AuditExceptionSpec
+}
+
+type AuditExceptionSpec struct {
+	// Scanner is the name of the scanner whose issues are suppressed. An
+	// empty scanner suppresses the issues of all scanners.
+	Scanner string
+
+	// Title is the title of the suppressed issues. An empty title suppresses
+	// all issues of the scanner.
+	Title string
+
+	// Match selects the resources whose issues are suppressed. An empty match
+	// selects all resources.
+	Match ScanPolicyMatch
+
+	// ExpiresAt is the time after which the exception no longer applies. A
+	// nil value means the exception never expires.
+	ExpiresAt *metav1.Time
+
+	// Justification explains why the issues are accepted.
+	Justification string
+
+	// Owner is the person or team responsible for the exception.
+	Owner string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AuditExceptionList struct {
+	metav1.TypeMeta
+
+	metav1.ListMeta
+
+	Items []AuditException
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DataAccessRule grants users and groups access to the synced resources in
+// the given clusters, namespaces and kinds. It restricts the data returned by
+// search and insight once data access rules are enabled.
+type DataAccessRule struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec DataAccessRuleSpec
+}
+
+type DataAccessRuleSpec struct {
+	// Subjects are the users and groups the rule grants access to.
+	Subjects []DataAccessSubject
+
+	// Scopes are the resources the subjects can access. An empty list grants
+	// access to all resources.
+	Scopes []DataAccessScope
+}
+
+// Kinds of subjects of a data access rule.
+const (
+	DataAccessSubjectUser  = "User"
+	DataAccessSubjectGroup = "Group"
+)
+
+// DataAccessSubject is a user or a group granted by a data access rule.
+type DataAccessSubject struct {
+	// Kind is the kind of the subject, either User or Group.
+	Kind string
+
+	// Name is the name of the user or the group.
+	Name string
+}
+
+// DataAccessScope selects the resources a data access rule grants access
+// to. Multiple fields are ANDed, and multiple values of a single field are
+// ORed. An empty field selects all values.
+type DataAccessScope struct {
+	// Clusters is the list of accessible clusters.
+	Clusters []string
+
+	// Namespaces is the list of accessible namespaces.
+	Namespaces []string
+
+	// Kinds is the list of accessible kinds.
+	Kinds []string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DataAccessRuleList struct {
+	metav1.TypeMeta
+
+	metav1.ListMeta
+
+	Items []DataAccessRule
+}
+
 // Selector represents a resource filter
 type Selector struct {
 	// LabelSelector is a filter to select resources by labels.
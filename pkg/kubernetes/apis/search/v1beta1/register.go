@@ -57,6 +57,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TransformRuleList{},
 		&TrimRule{},
 		&TrimRuleList{},
+		&ScanPolicy{},
+		&ScanPolicyList{},
+		&AuditException{},
+		&AuditExceptionList{},
+		&DataAccessRule{},
+		&DataAccessRuleList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -210,6 +210,220 @@ type TransformRuleList struct {
 	Items []TransformRule `json:"items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanPolicy defines an organization-specific compliance rule, which is
+// evaluated by the built-in policy scanner against the synced resources.
+type ScanPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ScanPolicySpec `json:"spec,omitempty"`
+}
+
+type ScanPolicySpec struct {
+	// Title is a brief summary of the issue reported for violating resources.
+	// Defaults to the name of the policy.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Message is the detailed description of the issue reported for violating
+	// resources. It is overridden by the message returned from the rule.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Severity is the severity of the reported issue, one of Safe, Low, Medium,
+	// High or Critical.
+	// +required
+	Severity string `json:"severity"`
+
+	// Match selects the resources the policy applies to. An empty match
+	// selects all resources.
+	// +optional
+	Match ScanPolicyMatch `json:"match,omitempty"`
+
+	// Engine is the policy engine used to evaluate the rule. Only CEL is
+	// supported currently.
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// Rule is the policy expression evaluated against each matched resource.
+	// For the CEL engine, the resource is bound to the variable "object" and
+	// its cluster to "cluster". The expression must return a bool, which is
+	// true when the resource violates the policy, or a string, which is a
+	// non-empty violation message.
+	// +required
+	Rule string `json:"rule"`
+}
+
+// ScanPolicyEngineCEL is the engine which evaluates scan policy rules written
+// in the Common Expression Language.
+const ScanPolicyEngineCEL = "CEL"
+
+// ScanPolicyMatch selects the resources a scan policy applies to. Multiple
+// fields are ANDed, and multiple values of a single field are ORed.
+type ScanPolicyMatch struct {
+	// Clusters is the list of clusters the policy applies to.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// APIVersions is the list of api versions the policy applies to.
+	// +optional
+	APIVersions []string `json:"apiVersions,omitempty"`
+
+	// Kinds is the list of kinds the policy applies to.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Namespaces is the list of namespaces the policy applies to.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// LabelSelector is a filter to select resources by labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ScanPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ScanPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuditException suppresses the audit issues which are accepted risks, so
+// that they are not counted in the score of the affected resources.
+type AuditException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec AuditExceptionSpec `json:"spec,omitempty"`
+}
+
+type AuditExceptionSpec struct {
+	// Scanner is the name of the scanner whose issues are suppressed. An
+	// empty scanner suppresses the issues of all scanners.
+	// +optional
+	Scanner string `json:"scanner,omitempty"`
+
+	// Title is the title of the suppressed issues. An empty title suppresses
+	// all issues of the scanner.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Match selects the resources whose issues are suppressed. An empty match
+	// selects all resources.
+	// +optional
+	Match ScanPolicyMatch `json:"match,omitempty"`
+
+	// ExpiresAt is the time after which the exception no longer applies. A
+	// nil value means the exception never expires.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Justification explains why the issues are accepted.
+	// +required
+	Justification string `json:"justification"`
+
+	// Owner is the person or team responsible for the exception.
+	// +required
+	Owner string `json:"owner"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AuditExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AuditException `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DataAccessRule grants users and groups access to the synced resources in
+// the given clusters, namespaces and kinds. It restricts the data returned by
+// search and insight once data access rules are enabled.
+type DataAccessRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec DataAccessRuleSpec `json:"spec,omitempty"`
+}
+
+type DataAccessRuleSpec struct {
+	// Subjects are the users and groups the rule grants access to.
+	// +required
+	Subjects []DataAccessSubject `json:"subjects"`
+
+	// Scopes are the resources the subjects can access. An empty list grants
+	// access to all resources.
+	// +optional
+	Scopes []DataAccessScope `json:"scopes,omitempty"`
+}
+
+// Kinds of subjects of a data access rule.
+const (
+	DataAccessSubjectUser  = "User"
+	DataAccessSubjectGroup = "Group"
+)
+
+// DataAccessSubject is a user or a group granted by a data access rule.
+type DataAccessSubject struct {
+	// Kind is the kind of the subject, either User or Group.
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the user or the group.
+	// +required
+	Name string `json:"name"`
+}
+
+// DataAccessScope selects the resources a data access rule grants access
+// to. Multiple fields are ANDed, and multiple values of a single field are
+// ORed. An empty field selects all values.
+type DataAccessScope struct {
+	// Clusters is the list of accessible clusters.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Namespaces is the list of accessible namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Kinds is the list of accessible kinds.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DataAccessRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DataAccessRule `json:"items"`
+}
+
 // Selector represents a resource filter
 type Selector struct {
 	// LabelSelector is a filter to select resources by labels.
@@ -37,6 +37,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AuditException)(nil), (*search.AuditException)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditException_To_search_AuditException(a.(*AuditException), b.(*search.AuditException), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.AuditException)(nil), (*AuditException)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_AuditException_To_v1beta1_AuditException(a.(*search.AuditException), b.(*AuditException), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AuditExceptionList)(nil), (*search.AuditExceptionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditExceptionList_To_search_AuditExceptionList(a.(*AuditExceptionList), b.(*search.AuditExceptionList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.AuditExceptionList)(nil), (*AuditExceptionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_AuditExceptionList_To_v1beta1_AuditExceptionList(a.(*search.AuditExceptionList), b.(*AuditExceptionList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AuditExceptionSpec)(nil), (*search.AuditExceptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec(a.(*AuditExceptionSpec), b.(*search.AuditExceptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.AuditExceptionSpec)(nil), (*AuditExceptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec(a.(*search.AuditExceptionSpec), b.(*AuditExceptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterResourcesSyncCondition)(nil), (*search.ClusterResourcesSyncCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterResourcesSyncCondition_To_search_ClusterResourcesSyncCondition(a.(*ClusterResourcesSyncCondition), b.(*search.ClusterResourcesSyncCondition), scope)
 	}); err != nil {
@@ -47,6 +77,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DataAccessRule)(nil), (*search.DataAccessRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataAccessRule_To_search_DataAccessRule(a.(*DataAccessRule), b.(*search.DataAccessRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.DataAccessRule)(nil), (*DataAccessRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_DataAccessRule_To_v1beta1_DataAccessRule(a.(*search.DataAccessRule), b.(*DataAccessRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataAccessRuleList)(nil), (*search.DataAccessRuleList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataAccessRuleList_To_search_DataAccessRuleList(a.(*DataAccessRuleList), b.(*search.DataAccessRuleList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.DataAccessRuleList)(nil), (*DataAccessRuleList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_DataAccessRuleList_To_v1beta1_DataAccessRuleList(a.(*search.DataAccessRuleList), b.(*DataAccessRuleList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataAccessRuleSpec)(nil), (*search.DataAccessRuleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec(a.(*DataAccessRuleSpec), b.(*search.DataAccessRuleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.DataAccessRuleSpec)(nil), (*DataAccessRuleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec(a.(*search.DataAccessRuleSpec), b.(*DataAccessRuleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataAccessScope)(nil), (*search.DataAccessScope)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataAccessScope_To_search_DataAccessScope(a.(*DataAccessScope), b.(*search.DataAccessScope), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.DataAccessScope)(nil), (*DataAccessScope)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_DataAccessScope_To_v1beta1_DataAccessScope(a.(*search.DataAccessScope), b.(*DataAccessScope), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DataAccessSubject)(nil), (*search.DataAccessSubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataAccessSubject_To_search_DataAccessSubject(a.(*DataAccessSubject), b.(*search.DataAccessSubject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.DataAccessSubject)(nil), (*DataAccessSubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_DataAccessSubject_To_v1beta1_DataAccessSubject(a.(*search.DataAccessSubject), b.(*DataAccessSubject), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FieldSelector)(nil), (*search.FieldSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FieldSelector_To_search_FieldSelector(a.(*FieldSelector), b.(*search.FieldSelector), scope)
 	}); err != nil {
@@ -77,6 +157,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ScanPolicy)(nil), (*search.ScanPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ScanPolicy_To_search_ScanPolicy(a.(*ScanPolicy), b.(*search.ScanPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.ScanPolicy)(nil), (*ScanPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_ScanPolicy_To_v1beta1_ScanPolicy(a.(*search.ScanPolicy), b.(*ScanPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScanPolicyList)(nil), (*search.ScanPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ScanPolicyList_To_search_ScanPolicyList(a.(*ScanPolicyList), b.(*search.ScanPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.ScanPolicyList)(nil), (*ScanPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_ScanPolicyList_To_v1beta1_ScanPolicyList(a.(*search.ScanPolicyList), b.(*ScanPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScanPolicyMatch)(nil), (*search.ScanPolicyMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(a.(*ScanPolicyMatch), b.(*search.ScanPolicyMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.ScanPolicyMatch)(nil), (*ScanPolicyMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(a.(*search.ScanPolicyMatch), b.(*ScanPolicyMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScanPolicySpec)(nil), (*search.ScanPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec(a.(*ScanPolicySpec), b.(*search.ScanPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*search.ScanPolicySpec)(nil), (*ScanPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec(a.(*search.ScanPolicySpec), b.(*ScanPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Selector)(nil), (*search.Selector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Selector_To_search_Selector(a.(*Selector), b.(*search.Selector), scope)
 	}); err != nil {
@@ -230,6 +350,88 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1beta1_AuditException_To_search_AuditException(in *AuditException, out *search.AuditException, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_AuditException_To_search_AuditException is an autogenerated conversion function.
+func Convert_v1beta1_AuditException_To_search_AuditException(in *AuditException, out *search.AuditException, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditException_To_search_AuditException(in, out, s)
+}
+
+func autoConvert_search_AuditException_To_v1beta1_AuditException(in *search.AuditException, out *AuditException, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_search_AuditException_To_v1beta1_AuditException is an autogenerated conversion function.
+func Convert_search_AuditException_To_v1beta1_AuditException(in *search.AuditException, out *AuditException, s conversion.Scope) error {
+	return autoConvert_search_AuditException_To_v1beta1_AuditException(in, out, s)
+}
+
+func autoConvert_v1beta1_AuditExceptionList_To_search_AuditExceptionList(in *AuditExceptionList, out *search.AuditExceptionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]search.AuditException)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_AuditExceptionList_To_search_AuditExceptionList is an autogenerated conversion function.
+func Convert_v1beta1_AuditExceptionList_To_search_AuditExceptionList(in *AuditExceptionList, out *search.AuditExceptionList, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditExceptionList_To_search_AuditExceptionList(in, out, s)
+}
+
+func autoConvert_search_AuditExceptionList_To_v1beta1_AuditExceptionList(in *search.AuditExceptionList, out *AuditExceptionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]AuditException)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_search_AuditExceptionList_To_v1beta1_AuditExceptionList is an autogenerated conversion function.
+func Convert_search_AuditExceptionList_To_v1beta1_AuditExceptionList(in *search.AuditExceptionList, out *AuditExceptionList, s conversion.Scope) error {
+	return autoConvert_search_AuditExceptionList_To_v1beta1_AuditExceptionList(in, out, s)
+}
+
+func autoConvert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec(in *AuditExceptionSpec, out *search.AuditExceptionSpec, s conversion.Scope) error {
+	out.Scanner = in.Scanner
+	out.Title = in.Title
+	if err := Convert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(&in.Match, &out.Match, s); err != nil {
+		return err
+	}
+	out.ExpiresAt = (*v1.Time)(unsafe.Pointer(in.ExpiresAt))
+	out.Justification = in.Justification
+	out.Owner = in.Owner
+	return nil
+}
+
+// Convert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec is an autogenerated conversion function.
+func Convert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec(in *AuditExceptionSpec, out *search.AuditExceptionSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditExceptionSpec_To_search_AuditExceptionSpec(in, out, s)
+}
+
+func autoConvert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec(in *search.AuditExceptionSpec, out *AuditExceptionSpec, s conversion.Scope) error {
+	out.Scanner = in.Scanner
+	out.Title = in.Title
+	if err := Convert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(&in.Match, &out.Match, s); err != nil {
+		return err
+	}
+	out.ExpiresAt = (*v1.Time)(unsafe.Pointer(in.ExpiresAt))
+	out.Justification = in.Justification
+	out.Owner = in.Owner
+	return nil
+}
+
+// Convert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec is an autogenerated conversion function.
+func Convert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec(in *search.AuditExceptionSpec, out *AuditExceptionSpec, s conversion.Scope) error {
+	return autoConvert_search_AuditExceptionSpec_To_v1beta1_AuditExceptionSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterResourcesSyncCondition_To_search_ClusterResourcesSyncCondition(in *ClusterResourcesSyncCondition, out *search.ClusterResourcesSyncCondition, s conversion.Scope) error {
 	out.Cluster = in.Cluster
 	out.Status = in.Status
@@ -254,6 +456,122 @@ func Convert_search_ClusterResourcesSyncCondition_To_v1beta1_ClusterResourcesSyn
 	return autoConvert_search_ClusterResourcesSyncCondition_To_v1beta1_ClusterResourcesSyncCondition(in, out, s)
 }
 
+func autoConvert_v1beta1_DataAccessRule_To_search_DataAccessRule(in *DataAccessRule, out *search.DataAccessRule, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_DataAccessRule_To_search_DataAccessRule is an autogenerated conversion function.
+func Convert_v1beta1_DataAccessRule_To_search_DataAccessRule(in *DataAccessRule, out *search.DataAccessRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataAccessRule_To_search_DataAccessRule(in, out, s)
+}
+
+func autoConvert_search_DataAccessRule_To_v1beta1_DataAccessRule(in *search.DataAccessRule, out *DataAccessRule, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_search_DataAccessRule_To_v1beta1_DataAccessRule is an autogenerated conversion function.
+func Convert_search_DataAccessRule_To_v1beta1_DataAccessRule(in *search.DataAccessRule, out *DataAccessRule, s conversion.Scope) error {
+	return autoConvert_search_DataAccessRule_To_v1beta1_DataAccessRule(in, out, s)
+}
+
+func autoConvert_v1beta1_DataAccessRuleList_To_search_DataAccessRuleList(in *DataAccessRuleList, out *search.DataAccessRuleList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]search.DataAccessRule)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_DataAccessRuleList_To_search_DataAccessRuleList is an autogenerated conversion function.
+func Convert_v1beta1_DataAccessRuleList_To_search_DataAccessRuleList(in *DataAccessRuleList, out *search.DataAccessRuleList, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataAccessRuleList_To_search_DataAccessRuleList(in, out, s)
+}
+
+func autoConvert_search_DataAccessRuleList_To_v1beta1_DataAccessRuleList(in *search.DataAccessRuleList, out *DataAccessRuleList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DataAccessRule)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_search_DataAccessRuleList_To_v1beta1_DataAccessRuleList is an autogenerated conversion function.
+func Convert_search_DataAccessRuleList_To_v1beta1_DataAccessRuleList(in *search.DataAccessRuleList, out *DataAccessRuleList, s conversion.Scope) error {
+	return autoConvert_search_DataAccessRuleList_To_v1beta1_DataAccessRuleList(in, out, s)
+}
+
+func autoConvert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec(in *DataAccessRuleSpec, out *search.DataAccessRuleSpec, s conversion.Scope) error {
+	out.Subjects = *(*[]search.DataAccessSubject)(unsafe.Pointer(&in.Subjects))
+	out.Scopes = *(*[]search.DataAccessScope)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec is an autogenerated conversion function.
+func Convert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec(in *DataAccessRuleSpec, out *search.DataAccessRuleSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataAccessRuleSpec_To_search_DataAccessRuleSpec(in, out, s)
+}
+
+func autoConvert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec(in *search.DataAccessRuleSpec, out *DataAccessRuleSpec, s conversion.Scope) error {
+	out.Subjects = *(*[]DataAccessSubject)(unsafe.Pointer(&in.Subjects))
+	out.Scopes = *(*[]DataAccessScope)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec is an autogenerated conversion function.
+func Convert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec(in *search.DataAccessRuleSpec, out *DataAccessRuleSpec, s conversion.Scope) error {
+	return autoConvert_search_DataAccessRuleSpec_To_v1beta1_DataAccessRuleSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_DataAccessScope_To_search_DataAccessScope(in *DataAccessScope, out *search.DataAccessScope, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	return nil
+}
+
+// Convert_v1beta1_DataAccessScope_To_search_DataAccessScope is an autogenerated conversion function.
+func Convert_v1beta1_DataAccessScope_To_search_DataAccessScope(in *DataAccessScope, out *search.DataAccessScope, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataAccessScope_To_search_DataAccessScope(in, out, s)
+}
+
+func autoConvert_search_DataAccessScope_To_v1beta1_DataAccessScope(in *search.DataAccessScope, out *DataAccessScope, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	return nil
+}
+
+// Convert_search_DataAccessScope_To_v1beta1_DataAccessScope is an autogenerated conversion function.
+func Convert_search_DataAccessScope_To_v1beta1_DataAccessScope(in *search.DataAccessScope, out *DataAccessScope, s conversion.Scope) error {
+	return autoConvert_search_DataAccessScope_To_v1beta1_DataAccessScope(in, out, s)
+}
+
+func autoConvert_v1beta1_DataAccessSubject_To_search_DataAccessSubject(in *DataAccessSubject, out *search.DataAccessSubject, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_DataAccessSubject_To_search_DataAccessSubject is an autogenerated conversion function.
+func Convert_v1beta1_DataAccessSubject_To_search_DataAccessSubject(in *DataAccessSubject, out *search.DataAccessSubject, s conversion.Scope) error {
+	return autoConvert_v1beta1_DataAccessSubject_To_search_DataAccessSubject(in, out, s)
+}
+
+func autoConvert_search_DataAccessSubject_To_v1beta1_DataAccessSubject(in *search.DataAccessSubject, out *DataAccessSubject, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_search_DataAccessSubject_To_v1beta1_DataAccessSubject is an autogenerated conversion function.
+func Convert_search_DataAccessSubject_To_v1beta1_DataAccessSubject(in *search.DataAccessSubject, out *DataAccessSubject, s conversion.Scope) error {
+	return autoConvert_search_DataAccessSubject_To_v1beta1_DataAccessSubject(in, out, s)
+}
+
 func autoConvert_v1beta1_FieldSelector_To_search_FieldSelector(in *FieldSelector, out *search.FieldSelector, s conversion.Scope) error {
 	out.MatchFields = *(*map[string]string)(unsafe.Pointer(&in.MatchFields))
 	out.ServerSupported = in.ServerSupported
@@ -346,6 +664,116 @@ func Convert_search_ResourceSyncRule_To_v1beta1_ResourceSyncRule(in *search.Reso
 	return autoConvert_search_ResourceSyncRule_To_v1beta1_ResourceSyncRule(in, out, s)
 }
 
+func autoConvert_v1beta1_ScanPolicy_To_search_ScanPolicy(in *ScanPolicy, out *search.ScanPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ScanPolicy_To_search_ScanPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ScanPolicy_To_search_ScanPolicy(in *ScanPolicy, out *search.ScanPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ScanPolicy_To_search_ScanPolicy(in, out, s)
+}
+
+func autoConvert_search_ScanPolicy_To_v1beta1_ScanPolicy(in *search.ScanPolicy, out *ScanPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_search_ScanPolicy_To_v1beta1_ScanPolicy is an autogenerated conversion function.
+func Convert_search_ScanPolicy_To_v1beta1_ScanPolicy(in *search.ScanPolicy, out *ScanPolicy, s conversion.Scope) error {
+	return autoConvert_search_ScanPolicy_To_v1beta1_ScanPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ScanPolicyList_To_search_ScanPolicyList(in *ScanPolicyList, out *search.ScanPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]search.ScanPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ScanPolicyList_To_search_ScanPolicyList is an autogenerated conversion function.
+func Convert_v1beta1_ScanPolicyList_To_search_ScanPolicyList(in *ScanPolicyList, out *search.ScanPolicyList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ScanPolicyList_To_search_ScanPolicyList(in, out, s)
+}
+
+func autoConvert_search_ScanPolicyList_To_v1beta1_ScanPolicyList(in *search.ScanPolicyList, out *ScanPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ScanPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_search_ScanPolicyList_To_v1beta1_ScanPolicyList is an autogenerated conversion function.
+func Convert_search_ScanPolicyList_To_v1beta1_ScanPolicyList(in *search.ScanPolicyList, out *ScanPolicyList, s conversion.Scope) error {
+	return autoConvert_search_ScanPolicyList_To_v1beta1_ScanPolicyList(in, out, s)
+}
+
+func autoConvert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(in *ScanPolicyMatch, out *search.ScanPolicyMatch, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch is an autogenerated conversion function.
+func Convert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(in *ScanPolicyMatch, out *search.ScanPolicyMatch, s conversion.Scope) error {
+	return autoConvert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(in, out, s)
+}
+
+func autoConvert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(in *search.ScanPolicyMatch, out *ScanPolicyMatch, s conversion.Scope) error {
+	out.Clusters = *(*[]string)(unsafe.Pointer(&in.Clusters))
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch is an autogenerated conversion function.
+func Convert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(in *search.ScanPolicyMatch, out *ScanPolicyMatch, s conversion.Scope) error {
+	return autoConvert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(in, out, s)
+}
+
+func autoConvert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec(in *ScanPolicySpec, out *search.ScanPolicySpec, s conversion.Scope) error {
+	out.Title = in.Title
+	out.Message = in.Message
+	out.Severity = in.Severity
+	if err := Convert_v1beta1_ScanPolicyMatch_To_search_ScanPolicyMatch(&in.Match, &out.Match, s); err != nil {
+		return err
+	}
+	out.Engine = in.Engine
+	out.Rule = in.Rule
+	return nil
+}
+
+// Convert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec is an autogenerated conversion function.
+func Convert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec(in *ScanPolicySpec, out *search.ScanPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ScanPolicySpec_To_search_ScanPolicySpec(in, out, s)
+}
+
+func autoConvert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec(in *search.ScanPolicySpec, out *ScanPolicySpec, s conversion.Scope) error {
+	out.Title = in.Title
+	out.Message = in.Message
+	out.Severity = in.Severity
+	if err := Convert_search_ScanPolicyMatch_To_v1beta1_ScanPolicyMatch(&in.Match, &out.Match, s); err != nil {
+		return err
+	}
+	out.Engine = in.Engine
+	out.Rule = in.Rule
+	return nil
+}
+
+// Convert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec is an autogenerated conversion function.
+func Convert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec(in *search.ScanPolicySpec, out *ScanPolicySpec, s conversion.Scope) error {
+	return autoConvert_search_ScanPolicySpec_To_v1beta1_ScanPolicySpec(in, out, s)
+}
+
 func autoConvert_v1beta1_Selector_To_search_Selector(in *Selector, out *search.Selector, s conversion.Scope) error {
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
 	out.FieldSelector = (*search.FieldSelector)(unsafe.Pointer(in.FieldSelector))
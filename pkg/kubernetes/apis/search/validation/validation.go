@@ -0,0 +1,138 @@
+/*
+Copyright The Karpor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/KusionStack/karpor/pkg/infra/scanner/policy"
+	"github.com/KusionStack/karpor/pkg/kubernetes/apis/search"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// supportedSeverities is the list of severity levels accepted by scan policies.
+var supportedSeverities = []string{"Safe", "Low", "Medium", "High", "Critical"}
+
+// policyEnv returns the CEL environment of the policy scanner, in which the
+// rules of scan policies are compiled.
+var policyEnv = sync.OnceValues(policy.NewEnv)
+
+// ValidateScanPolicy validates the spec of a ScanPolicy. The rule is compiled
+// in the same CEL environment as the policy scanner, so that policies which
+// could never be evaluated are rejected.
+func ValidateScanPolicy(p *search.ScanPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	if p.Spec.Engine != "" && !strings.EqualFold(p.Spec.Engine, search.ScanPolicyEngineCEL) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("engine"), p.Spec.Engine, []string{search.ScanPolicyEngineCEL}))
+	}
+
+	if len(strings.TrimSpace(p.Spec.Rule)) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("rule"), "rule cannot be empty"))
+	} else if env, err := policyEnv(); err != nil {
+		allErrs = append(allErrs, field.InternalError(specPath.Child("rule"), err))
+	} else if _, issues := env.Compile(p.Spec.Rule); issues != nil && issues.Err() != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("rule"), p.Spec.Rule, issues.Err().Error()))
+	}
+
+	validSeverity := false
+	for _, severity := range supportedSeverities {
+		if strings.EqualFold(p.Spec.Severity, severity) {
+			validSeverity = true
+			break
+		}
+	}
+	if !validSeverity {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("severity"), p.Spec.Severity, supportedSeverities))
+	}
+
+	if p.Spec.Match.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			p.Spec.Match.LabelSelector,
+			metav1validation.LabelSelectorValidationOptions{},
+			specPath.Child("match", "labelSelector"),
+		)...)
+	}
+
+	return allErrs
+}
+
+// ValidateAuditException validates the spec of an AuditException. An
+// exception must select the suppressed issues by at least one of the scanner,
+// the title and the match, so that no exception suppresses all issues of all
+// resources.
+func ValidateAuditException(e *search.AuditException) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	if len(strings.TrimSpace(e.Spec.Scanner)) == 0 && len(strings.TrimSpace(e.Spec.Title)) == 0 && isEmptyMatch(&e.Spec.Match) {
+		allErrs = append(allErrs, field.Required(specPath, "at least one of scanner, title and match is required"))
+	}
+
+	if len(strings.TrimSpace(e.Spec.Justification)) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("justification"), "justification cannot be empty"))
+	}
+
+	if len(strings.TrimSpace(e.Spec.Owner)) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("owner"), "owner cannot be empty"))
+	}
+
+	if e.Spec.Match.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			e.Spec.Match.LabelSelector,
+			metav1validation.LabelSelectorValidationOptions{},
+			specPath.Child("match", "labelSelector"),
+		)...)
+	}
+
+	return allErrs
+}
+
+// isEmptyMatch reports whether the match selects all resources.
+func isEmptyMatch(m *search.ScanPolicyMatch) bool {
+	selector := m.LabelSelector
+	return len(m.Clusters) == 0 && len(m.APIVersions) == 0 && len(m.Kinds) == 0 && len(m.Namespaces) == 0 &&
+		(selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0))
+}
+
+// supportedSubjectKinds is the list of subject kinds accepted by data access
+// rules.
+var supportedSubjectKinds = []string{search.DataAccessSubjectUser, search.DataAccessSubjectGroup}
+
+// ValidateDataAccessRule validates the spec of a DataAccessRule.
+func ValidateDataAccessRule(r *search.DataAccessRule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	subjectsPath := field.NewPath("spec", "subjects")
+
+	if len(r.Spec.Subjects) == 0 {
+		allErrs = append(allErrs, field.Required(subjectsPath, "at least one subject is required"))
+	}
+
+	for i, subject := range r.Spec.Subjects {
+		if subject.Kind != search.DataAccessSubjectUser && subject.Kind != search.DataAccessSubjectGroup {
+			allErrs = append(allErrs, field.NotSupported(subjectsPath.Index(i).Child("kind"), subject.Kind, supportedSubjectKinds))
+		}
+		if len(strings.TrimSpace(subject.Name)) == 0 {
+			allErrs = append(allErrs, field.Required(subjectsPath.Index(i).Child("name"), "name cannot be empty"))
+		}
+	}
+
+	return allErrs
+}
@@ -29,6 +29,7 @@ import (
 type ClusterV1beta1Interface interface {
 	RESTClient() rest.Interface
 	ClustersGetter
+	ClusterProxyPoliciesGetter
 }
 
 // ClusterV1beta1Client is used to interact with features provided by the cluster.karpor.io group.
@@ -40,6 +41,10 @@ func (c *ClusterV1beta1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *ClusterV1beta1Client) ClusterProxyPolicies() ClusterProxyPolicyInterface {
+	return newClusterProxyPolicies(c)
+}
+
 // NewForConfig creates a new ClusterV1beta1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -32,6 +32,10 @@ func (c *FakeClusterV1beta1) Clusters() v1beta1.ClusterInterface {
 	return &FakeClusters{c}
 }
 
+func (c *FakeClusterV1beta1) ClusterProxyPolicies() v1beta1.ClusterProxyPolicyInterface {
+	return &FakeClusterProxyPolicies{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeClusterV1beta1) RESTClient() rest.Interface {
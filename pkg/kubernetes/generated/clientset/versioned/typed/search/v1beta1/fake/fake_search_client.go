@@ -28,6 +28,18 @@ type FakeSearchV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSearchV1beta1) AuditExceptions() v1beta1.AuditExceptionInterface {
+	return &FakeAuditExceptions{c}
+}
+
+func (c *FakeSearchV1beta1) DataAccessRules() v1beta1.DataAccessRuleInterface {
+	return &FakeDataAccessRules{c}
+}
+
+func (c *FakeSearchV1beta1) ScanPolicies() v1beta1.ScanPolicyInterface {
+	return &FakeScanPolicies{c}
+}
+
 func (c *FakeSearchV1beta1) SyncRegistries() v1beta1.SyncRegistryInterface {
 	return &FakeSyncRegistries{c}
 }
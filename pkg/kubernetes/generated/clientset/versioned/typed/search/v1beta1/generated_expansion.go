@@ -18,6 +18,12 @@ limitations under the License.
 
 package v1beta1
 
+type AuditExceptionExpansion interface{}
+
+type DataAccessRuleExpansion interface{}
+
+type ScanPolicyExpansion interface{}
+
 type SyncRegistryExpansion interface{}
 
 type SyncResourcesExpansion interface{}
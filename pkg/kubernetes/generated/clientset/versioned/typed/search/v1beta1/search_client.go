@@ -28,6 +28,9 @@ import (
 
 type SearchV1beta1Interface interface {
 	RESTClient() rest.Interface
+	AuditExceptionsGetter
+	DataAccessRulesGetter
+	ScanPoliciesGetter
 	SyncRegistriesGetter
 	SyncResourcesesGetter
 	TransformRulesGetter
@@ -39,6 +42,18 @@ type SearchV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *SearchV1beta1Client) AuditExceptions() AuditExceptionInterface {
+	return newAuditExceptions(c)
+}
+
+func (c *SearchV1beta1Client) DataAccessRules() DataAccessRuleInterface {
+	return newDataAccessRules(c)
+}
+
+func (c *SearchV1beta1Client) ScanPolicies() ScanPolicyInterface {
+	return newScanPolicies(c)
+}
+
 func (c *SearchV1beta1Client) SyncRegistries() SyncRegistryInterface {
 	return newSyncRegistries(c)
 }
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
+	// ClusterProxyPolicies returns a ClusterProxyPolicyInformer.
+	ClusterProxyPolicies() ClusterProxyPolicyInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ClusterProxyPolicies returns a ClusterProxyPolicyInformer.
+func (v *version) ClusterProxyPolicies() ClusterProxyPolicyInformer {
+	return &clusterProxyPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -56,8 +56,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=cluster.karpor.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("clusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cluster().V1beta1().Clusters().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterproxypolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cluster().V1beta1().ClusterProxyPolicies().Informer()}, nil
 
 		// Group=search.karpor.io, Version=v1beta1
+	case searchv1beta1.SchemeGroupVersion.WithResource("auditexceptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Search().V1beta1().AuditExceptions().Informer()}, nil
+	case searchv1beta1.SchemeGroupVersion.WithResource("dataaccessrules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Search().V1beta1().DataAccessRules().Informer()}, nil
+	case searchv1beta1.SchemeGroupVersion.WithResource("scanpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Search().V1beta1().ScanPolicies().Informer()}, nil
 	case searchv1beta1.SchemeGroupVersion.WithResource("syncregistries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Search().V1beta1().SyncRegistries().Informer()}, nil
 	case searchv1beta1.SchemeGroupVersion.WithResource("syncresourceses"):
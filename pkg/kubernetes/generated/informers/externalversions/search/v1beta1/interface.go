@@ -24,6 +24,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AuditExceptions returns a AuditExceptionInformer.
+	AuditExceptions() AuditExceptionInformer
+	// DataAccessRules returns a DataAccessRuleInformer.
+	DataAccessRules() DataAccessRuleInformer
+	// ScanPolicies returns a ScanPolicyInformer.
+	ScanPolicies() ScanPolicyInformer
 	// SyncRegistries returns a SyncRegistryInformer.
 	SyncRegistries() SyncRegistryInformer
 	// SyncResourceses returns a SyncResourcesInformer.
@@ -45,6 +51,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AuditExceptions returns a AuditExceptionInformer.
+func (v *version) AuditExceptions() AuditExceptionInformer {
+	return &auditExceptionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// DataAccessRules returns a DataAccessRuleInformer.
+func (v *version) DataAccessRules() DataAccessRuleInformer {
+	return &dataAccessRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ScanPolicies returns a ScanPolicyInformer.
+func (v *version) ScanPolicies() ScanPolicyInformer {
+	return &scanPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SyncRegistries returns a SyncRegistryInformer.
 func (v *version) SyncRegistries() SyncRegistryInformer {
 	return &syncRegistryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -21,3 +21,7 @@ package v1beta1
 // ClusterListerExpansion allows custom methods to be added to
 // ClusterLister.
 type ClusterListerExpansion interface{}
+
+// ClusterProxyPolicyListerExpansion allows custom methods to be added to
+// ClusterProxyPolicyLister.
+type ClusterProxyPolicyListerExpansion interface{}
@@ -18,6 +18,18 @@ limitations under the License.
 
 package v1beta1
 
+// AuditExceptionListerExpansion allows custom methods to be added to
+// AuditExceptionLister.
+type AuditExceptionListerExpansion interface{}
+
+// DataAccessRuleListerExpansion allows custom methods to be added to
+// DataAccessRuleLister.
+type DataAccessRuleListerExpansion interface{}
+
+// ScanPolicyListerExpansion allows custom methods to be added to
+// ScanPolicyLister.
+type ScanPolicyListerExpansion interface{}
+
 // SyncRegistryListerExpansion allows custom methods to be added to
 // SyncRegistryLister.
 type SyncRegistryListerExpansion interface{}
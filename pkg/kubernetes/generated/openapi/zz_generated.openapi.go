@@ -36,15 +36,32 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterAccessCredential":      schema_kubernetes_apis_cluster_v1beta1_ClusterAccessCredential(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterList":                  schema_kubernetes_apis_cluster_v1beta1_ClusterList(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyOptions":          schema_kubernetes_apis_cluster_v1beta1_ClusterProxyOptions(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicy":           schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicy(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicyList":       schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicyList(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicySpec":       schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicySpec(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyRule":             schema_kubernetes_apis_cluster_v1beta1_ClusterProxyRule(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxySubject":          schema_kubernetes_apis_cluster_v1beta1_ClusterProxySubject(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterSpec":                  schema_kubernetes_apis_cluster_v1beta1_ClusterSpec(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterStatus":                schema_kubernetes_apis_cluster_v1beta1_ClusterStatus(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecConfig":                   schema_kubernetes_apis_cluster_v1beta1_ExecConfig(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecEnvVar":                   schema_kubernetes_apis_cluster_v1beta1_ExecEnvVar(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.X509":                         schema_kubernetes_apis_cluster_v1beta1_X509(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditException":                schema_kubernetes_apis_search_v1beta1_AuditException(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditExceptionList":            schema_kubernetes_apis_search_v1beta1_AuditExceptionList(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditExceptionSpec":            schema_kubernetes_apis_search_v1beta1_AuditExceptionSpec(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ClusterResourcesSyncCondition": schema_kubernetes_apis_search_v1beta1_ClusterResourcesSyncCondition(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRule":                schema_kubernetes_apis_search_v1beta1_DataAccessRule(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRuleList":            schema_kubernetes_apis_search_v1beta1_DataAccessRuleList(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRuleSpec":            schema_kubernetes_apis_search_v1beta1_DataAccessRuleSpec(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessScope":               schema_kubernetes_apis_search_v1beta1_DataAccessScope(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessSubject":             schema_kubernetes_apis_search_v1beta1_DataAccessSubject(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.FieldSelector":                 schema_kubernetes_apis_search_v1beta1_FieldSelector(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncCondition":         schema_kubernetes_apis_search_v1beta1_ResourceSyncCondition(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncRule":              schema_kubernetes_apis_search_v1beta1_ResourceSyncRule(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicy":                    schema_kubernetes_apis_search_v1beta1_ScanPolicy(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyList":                schema_kubernetes_apis_search_v1beta1_ScanPolicyList(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyMatch":               schema_kubernetes_apis_search_v1beta1_ScanPolicyMatch(ref),
+		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicySpec":                schema_kubernetes_apis_search_v1beta1_ScanPolicySpec(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.Selector":                      schema_kubernetes_apis_search_v1beta1_Selector(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.SyncRegistry":                  schema_kubernetes_apis_search_v1beta1_SyncRegistry(ref),
 		"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.SyncRegistryList":              schema_kubernetes_apis_search_v1beta1_SyncRegistryList(ref),
@@ -169,6 +186,20 @@ func schema_kubernetes_apis_cluster_v1beta1_ClusterAccess(ref common.ReferenceCa
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is how the hub connects to the cluster, Direct by default. The endpoint and credential are not used in Agent mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"agentUser": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AgentUser is the user the agent authenticates to the hub as in Agent mode, only the user can open the tunnel of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"endpoint": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
@@ -322,88 +353,119 @@ func schema_kubernetes_apis_cluster_v1beta1_ClusterProxyOptions(ref common.Refer
 	}
 }
 
-func schema_kubernetes_apis_cluster_v1beta1_ClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ClusterProxyPolicy allows users and groups to send requests to clusters through the cluster proxy. Once cluster proxy policies are enabled, the proxied requests are denied unless they are allowed by a policy.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"provider": {
-						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
-						},
-					},
-					"access": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterAccess"),
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"description": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"displayName": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"finalized": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicySpec"),
 						},
 					},
 				},
-				Required: []string{"provider", "access", "displayName"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterAccess"},
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicySpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_kubernetes_apis_cluster_v1beta1_ClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"healthy": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicy"),
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"items"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_kubernetes_apis_cluster_v1beta1_ExecConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterProxyPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"command": {
+					"subjects": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Subjects are the users and groups the policy applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxySubject"),
+									},
+								},
+							},
 						},
 					},
-					"args": {
+					"clusters": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Clusters are the names of the clusters the policy applies to, an empty list applies to all clusters.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -415,167 +477,807 @@ func schema_kubernetes_apis_cluster_v1beta1_ExecConfig(ref common.ReferenceCallb
 							},
 						},
 					},
-					"env": {
+					"readOnly": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "ReadOnly only allows the requests reading resources, such as get, list and watch.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules are the requests allowed by the policy, a request is allowed if any rule matches it. An empty list allows all requests.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecEnvVar"),
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyRule"),
 									},
 								},
 							},
 						},
 					},
-					"apiVersion": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"installHint": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"provideClusterInfo": {
-						SchemaProps: spec.SchemaProps{
-							Default: false,
-							Type:    []string{"boolean"},
-							Format:  "",
-						},
-					},
-					"interactiveMode": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
 				},
-				Required: []string{"command", "args", "env", "provideClusterInfo"},
+				Required: []string{"subjects"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecEnvVar"},
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxyRule", "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterProxySubject"},
 	}
 }
 
-func schema_kubernetes_apis_cluster_v1beta1_ExecEnvVar(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterProxyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ClusterProxyRule matches proxied requests. Multiple fields are ANDed, and multiple values of a single field are ORed. An empty field matches all values.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"verbs": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Verbs are the kubernetes verbs of the requests, such as get, list, watch, create, update, patch and delete, \"*\" matches all verbs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"value": {
+					"paths": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Paths are the paths of the requests, a path ending with \"*\" matches the paths with the prefix before it, e.g. \"/api/v1/namespaces/*\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces are the namespaces of the requests, the requests which are not namespaced never match a rule with namespaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"name", "value"},
 			},
 		},
 	}
 }
 
-func schema_kubernetes_apis_cluster_v1beta1_X509(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterProxySubject(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ClusterProxySubject is a user or a group a cluster proxy policy applies to.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"certificate": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "byte",
+							Description: "Kind is the kind of the subject, either User or Group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"privateKey": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "byte",
+							Description: "Name is the name of the user or the group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"certificate", "privateKey"},
+				Required: []string{"kind", "name"},
 			},
 		},
 	}
 }
 
-func schema_kubernetes_apis_search_v1beta1_ClusterResourcesSyncCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"cluster": {
+					"provider": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"status": {
+					"access": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterAccess"),
+						},
+					},
+					"description": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"resources": {
+					"displayName": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncCondition"),
-									},
-								},
-							},
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"finalized": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
 				},
-				Required: []string{"cluster", "status"},
+				Required: []string{"provider", "access", "displayName"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncCondition"},
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ClusterAccess"},
 	}
 }
 
-func schema_kubernetes_apis_search_v1beta1_FieldSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubernetes_apis_cluster_v1beta1_ClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "FieldSelector is a field filter.",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"matchFields": {
+					"healthy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MatchFields is a map of {field,value} pairs. A single {field,value} in the matchFields map means that the specified field should have an exact match with the specified value. Multiple entries are ANDed.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"credentialExpiration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialExpiration is the time when the credential of the cluster expires. It is empty if the credential does not expire or its expiry is unknown, such as for exec credentials.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"credentialRotationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialRotationTime is the last time the service account token of the cluster was rotated by karpor.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the API server of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latencyMillis": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LatencyMillis is the latency of the API server of the cluster in the last probe.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastProbeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastProbeTime is the last time the cluster was probed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the last time the resources of the cluster were saved or deleted by the syncer. It is updated at most every few seconds.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest observations of the state of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubernetes_apis_cluster_v1beta1_ExecConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecEnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"installHint": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"provideClusterInfo": {
+						SchemaProps: spec.SchemaProps{
+							Default: false,
+							Type:    []string{"boolean"},
+							Format:  "",
+						},
+					},
+					"interactiveMode": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"command", "args", "env", "provideClusterInfo"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1.ExecEnvVar"},
+	}
+}
+
+func schema_kubernetes_apis_cluster_v1beta1_ExecEnvVar(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"name", "value"},
+			},
+		},
+	}
+}
+
+func schema_kubernetes_apis_cluster_v1beta1_X509(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"certificate": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "byte",
+						},
+					},
+					"privateKey": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "byte",
+						},
+					},
+				},
+				Required: []string{"certificate", "privateKey"},
+			},
+		},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_AuditException(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AuditException suppresses the audit issues which are accepted risks, so that they are not counted in the score of the affected resources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditExceptionSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditExceptionSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_AuditExceptionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditException"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.AuditException", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_AuditExceptionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scanner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scanner is the name of the scanner whose issues are suppressed. An empty scanner suppresses the issues of all scanners.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"title": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Title is the title of the suppressed issues. An empty title suppresses all issues of the scanner.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"match": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Match selects the resources whose issues are suppressed. An empty match selects all resources.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyMatch"),
+						},
+					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpiresAt is the time after which the exception no longer applies. A nil value means the exception never expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"justification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Justification explains why the issues are accepted.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Owner is the person or team responsible for the exception.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"justification", "owner"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyMatch", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_ClusterResourcesSyncCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncCondition"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ResourceSyncCondition"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_DataAccessRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataAccessRule grants users and groups access to the synced resources in the given clusters, namespaces and kinds. It restricts the data returned by search and insight once data access rules are enabled.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRuleSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRuleSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_DataAccessRuleList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessRule", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_DataAccessRuleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subjects are the users and groups the rule grants access to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessSubject"),
+									},
+								},
+							},
+						},
+					},
+					"scopes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scopes are the resources the subjects can access. An empty list grants access to all resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessScope"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"subjects"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessScope", "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.DataAccessSubject"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_DataAccessScope(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataAccessScope selects the resources a data access rule grants access to. Multiple fields are ANDed, and multiple values of a single field are ORed. An empty field selects all values.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clusters is the list of accessible clusters.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces is the list of accessible namespaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"kinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kinds is the list of accessible kinds.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_DataAccessSubject(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataAccessSubject is a user or a group granted by a data access rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the subject, either User or Group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the user or the group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+		},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_FieldSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FieldSelector is a field filter.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"matchFields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MatchFields is a map of {field,value} pairs. A single {field,value} in the matchFields map means that the specified field should have an exact match with the specified value. Multiple entries are ANDed.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
 							},
 						},
 					},
@@ -745,6 +1447,235 @@ func schema_kubernetes_apis_search_v1beta1_ResourceSyncRule(ref common.Reference
 	}
 }
 
+func schema_kubernetes_apis_search_v1beta1_ScanPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScanPolicy defines an organization-specific compliance rule, which is evaluated by the built-in policy scanner against the synced resources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicySpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicySpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_ScanPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicy"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_ScanPolicyMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScanPolicyMatch selects the resources a scan policy applies to. Multiple fields are ANDed, and multiple values of a single field are ORed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clusters is the list of clusters the policy applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"apiVersions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersions is the list of api versions the policy applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"kinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kinds is the list of kinds the policy applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces is the list of namespaces the policy applies to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LabelSelector is a filter to select resources by labels.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_kubernetes_apis_search_v1beta1_ScanPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"title": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Title is a brief summary of the issue reported for violating resources. Defaults to the name of the policy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the detailed description of the issue reported for violating resources. It is overridden by the message returned from the rule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"severity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Severity is the severity of the reported issue, one of Safe, Low, Medium, High or Critical.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"match": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Match selects the resources the policy applies to. An empty match selects all resources.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyMatch"),
+						},
+					},
+					"engine": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Engine is the policy engine used to evaluate the rule. Only CEL is supported currently.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rule is the policy expression evaluated against each matched resource. For the CEL engine, the resource is bound to the variable \"object\" and its cluster to \"cluster\". The expression must return a bool, which is true when the resource violates the policy, or a string, which is a non-empty violation message.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"severity", "rule"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1.ScanPolicyMatch"},
+	}
+}
+
 func schema_kubernetes_apis_search_v1beta1_Selector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
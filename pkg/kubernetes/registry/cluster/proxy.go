@@ -20,13 +20,26 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/tunnel"
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/proxy"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/endpoints/responsewriter"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/registry/rest"
 	restclient "k8s.io/client-go/rest"
@@ -39,6 +52,14 @@ var _ rest.Connecter = &ProxyREST{}
 
 type ProxyREST struct {
 	Store *genericregistry.Store
+	// Recorder records the proxied requests as action logs, it is optional.
+	Recorder *actionlog.Recorder
+	// Tunnels sends the requests to the clusters in Agent access mode.
+	Tunnels *tunnel.Server
+	// Authorizer decides whether the requests are proxied, it is optional.
+	Authorizer *ProxyAuthorizer
+	// Impersonate sends the proxied requests as the requesting user.
+	Impersonate bool
 }
 
 func (r *ProxyREST) Destroy() {
@@ -74,8 +95,36 @@ func (r *ProxyREST) Connect(
 	}
 	clusterExtension := obj.(*cluster.Cluster)
 
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		location, transport, err := resourceLocation(clusterExtension, proxyOpts.Path, r)
+	requester, ok := request.UserFrom(ctx)
+	if !ok {
+		requester = &user.DefaultInfo{Name: user.Anonymous}
+	}
+	authorizer, tunnels, impersonateUser := r.Authorizer, r.Tunnels, r.Impersonate
+	proxyHandlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorizer != nil {
+			allowed, reason, err := authorizer.Authorize(ctx, requester, id, r, proxyOpts.Path)
+			if err != nil {
+				proxyError(w, r, apierrors.NewInternalError(err))
+				return
+			}
+			if !allowed {
+				proxyError(w, r, apierrors.NewForbidden(cluster.Resource("clusters/proxy"), id, errors.New(reason)))
+				return
+			}
+		}
+		if impersonateUser {
+			r = r.Clone(r.Context())
+			impersonate(r.Header, requester)
+		}
+
+		var location *url.URL
+		var transport http.RoundTripper
+		var err error
+		if clusterExtension.Spec.Access.Mode == cluster.ClusterAccessModeAgent {
+			location, transport, err = tunnelLocation(clusterExtension, tunnels, proxyOpts.Path, r)
+		} else {
+			location, transport, err = resourceLocation(clusterExtension, proxyOpts.Path, r)
+		}
 		if err != nil {
 			responsewriters.InternalError(w, r, err)
 			return
@@ -90,7 +139,74 @@ func (r *ProxyREST) Connect(
 		)
 		proxyHandler.UseLocationHost = true
 		proxyHandler.ServeHTTP(w, r)
-	}), nil
+	})
+	if r.Recorder == nil {
+		return proxyHandlerFunc, nil
+	}
+	return r.recordProxy(ctx, id, proxyOpts.Path, proxyHandlerFunc), nil
+}
+
+// recordProxy wraps the proxy handler to record the proxied request as an
+// action log once it has been served.
+func (r *ProxyREST) recordProxy(ctx context.Context, id, path string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		log := &entity.ActionLog{
+			Timestamp: &metav1.Time{Time: start},
+			RequestID: audit.GetAuditIDTruncated(ctx),
+			User:      user.Anonymous,
+			Action:    actionlog.ActionClusterProxy,
+			Method:    req.Method,
+			Path:      req.URL.Path,
+			Target:    entity.ActionTarget{Cluster: id},
+			Details:   map[string]string{"path": path},
+		}
+		if u, ok := request.UserFrom(ctx); ok {
+			log.User = u.GetName()
+			log.Groups = u.GetGroups()
+		}
+		if ip := utilnet.GetClientIP(req); ip != nil {
+			log.SourceIP = ip.String()
+		}
+
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(responsewriter.WrapForHTTP1Or2(sw), req)
+
+		log.StatusCode = sw.status
+		if log.StatusCode == 0 {
+			log.StatusCode = http.StatusOK
+		}
+		log.Outcome = entity.ActionOutcomeSuccess
+		if log.StatusCode >= http.StatusBadRequest {
+			log.Outcome = entity.ActionOutcomeFailure
+		}
+		log.DurationMillis = time.Since(start).Milliseconds()
+		r.Recorder.Record(log)
+	})
+}
+
+// proxyError writes the error to the response as a status, through the
+// writer of the request so that the status is recorded.
+func proxyError(w http.ResponseWriter, req *http.Request, err error) {
+	responsewriters.ErrorNegotiated(err, scheme.Codecs, clusterv1beta1.SchemeGroupVersion, w, req)
+}
+
+// statusWriter captures the status code written to the response, while
+// WrapForHTTP1Or2 keeps the hijacking needed for upgraded connections.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
 }
 
 func resourceLocation(
@@ -130,6 +246,30 @@ func resourceLocation(
 	return location, transport, nil
 }
 
+// tunnelLocation returns the location and transport proxying the request
+// through the tunnel of the agent of the cluster. The tunnel carries plain
+// HTTP/2 requests, so upgraded requests such as exec are not supported.
+func tunnelLocation(
+	clusterExtension *cluster.Cluster,
+	tunnels *tunnel.Server,
+	path string,
+	request *http.Request,
+) (*url.URL, http.RoundTripper, error) {
+	if tunnels == nil {
+		return nil, nil, errors.Wrapf(tunnel.ErrNotConnected, "cluster %s", clusterExtension.Name)
+	}
+	if httpstream.IsUpgradeRequest(request) {
+		return nil, nil, fmt.Errorf("upgrade requests are not supported for cluster %s in %s access mode", clusterExtension.Name, cluster.ClusterAccessModeAgent)
+	}
+	location := &url.URL{
+		Scheme:   "https",
+		Host:     clusterExtension.Name,
+		Path:     path,
+		RawQuery: request.URL.RawQuery,
+	}
+	return location, tunnels.RoundTripper(clusterExtension.Name), nil
+}
+
 func NewConfigFromCluster(c *cluster.Cluster) (*restclient.Config, error) {
 	cfg := &restclient.Config{}
 	cfg.Host = c.Spec.Access.Endpoint
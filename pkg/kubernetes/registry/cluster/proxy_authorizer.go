@@ -0,0 +1,264 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	"github.com/KusionStack/karpor/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// proxyPoliciesCacheExpiration is how long the cluster proxy policies are
+// cached, so that they are not listed on every proxied request.
+const proxyPoliciesCacheExpiration = 10 * time.Second
+
+// readVerbs are the verbs of the requests reading resources.
+var readVerbs = sets.NewString("get", "list", "watch", "head")
+
+// interactiveSubresources are the subresources which run commands in or open
+// connections to the workloads, they are never read requests even though
+// some of them are served for GET requests.
+var interactiveSubresources = sets.NewString("exec", "attach", "portforward", "proxy")
+
+// ProxyOptions configures the access control of the cluster proxy.
+type ProxyOptions struct {
+	// ReadOnly only allows the proxied requests reading resources.
+	ReadOnly bool
+	// EnablePolicies denies the proxied requests which are not allowed by a
+	// ClusterProxyPolicy.
+	EnablePolicies bool
+	// Impersonate sends the proxied requests as the requesting user, by
+	// adding the impersonation headers of the user to them.
+	Impersonate bool
+}
+
+// ProxyPolicySource lists the cluster proxy policies.
+type ProxyPolicySource func(ctx context.Context) ([]cluster.ClusterProxyPolicy, error)
+
+// ProxyAuthorizer decides whether the requests of users are proxied to the
+// clusters, before they are forwarded with the credentials of the clusters.
+type ProxyAuthorizer struct {
+	readOnly bool
+	source   ProxyPolicySource
+	policies *cache.Cache[struct{}, []cluster.ClusterProxyPolicy]
+}
+
+// NewProxyAuthorizer creates a proxy authorizer. The requests are checked
+// against the policies of the source unless it is nil.
+func NewProxyAuthorizer(readOnly bool, source ProxyPolicySource) *ProxyAuthorizer {
+	return &ProxyAuthorizer{
+		readOnly: readOnly,
+		source:   source,
+		policies: cache.NewCache[struct{}, []cluster.ClusterProxyPolicy](proxyPoliciesCacheExpiration),
+	}
+}
+
+// proxyAttributes are the attributes of a proxied request the policies are
+// evaluated against.
+type proxyAttributes struct {
+	verb        string
+	path        string
+	namespace   string
+	subresource string
+	upgrade     bool
+}
+
+// newProxyAttributes resolves the attributes of the request proxied to the
+// path of the cluster.
+func newProxyAttributes(req *http.Request, path string) (*proxyAttributes, error) {
+	factory := &request.RequestInfoFactory{
+		APIPrefixes:          sets.NewString("api", "apis"),
+		GrouplessAPIPrefixes: sets.NewString("api"),
+	}
+	info, err := factory.NewRequestInfo(&http.Request{
+		Method: req.Method,
+		URL:    &url.URL{Path: path, RawQuery: req.URL.RawQuery},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proxyAttributes{
+		verb:        info.Verb,
+		path:        path,
+		namespace:   info.Namespace,
+		subresource: info.Subresource,
+		upgrade:     httpstream.IsUpgradeRequest(req),
+	}, nil
+}
+
+// isRead reports whether the request only reads resources.
+func (a *proxyAttributes) isRead() bool {
+	return readVerbs.Has(a.verb) && !a.upgrade && !interactiveSubresources.Has(a.subresource)
+}
+
+// Authorize returns whether the request of the user proxied to the path of
+// the cluster is allowed, and the reason if it is denied. Members of the
+// system:masters group are not restricted by the policies, but are by the
+// read only mode.
+func (a *ProxyAuthorizer) Authorize(ctx context.Context, u user.Info, clusterName string, req *http.Request, path string) (bool, string, error) {
+	attrs, err := newProxyAttributes(req, path)
+	if err != nil {
+		return false, "", err
+	}
+	if a.readOnly && !attrs.isRead() {
+		return false, fmt.Sprintf("the cluster proxy only allows read requests, %s %s is not allowed", attrs.verb, path), nil
+	}
+	if a.source == nil {
+		return true, "", nil
+	}
+
+	groups := sets.NewString(u.GetGroups()...)
+	if groups.Has(user.SystemPrivilegedGroup) {
+		return true, "", nil
+	}
+	policies, err := a.listPolicies(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	for i := range policies {
+		if policyAllows(&policies[i], u.GetName(), groups, clusterName, attrs) {
+			return true, "", nil
+		}
+	}
+	return false, fmt.Sprintf("no cluster proxy policy allows user %q to %s %s in cluster %s", u.GetName(), attrs.verb, path, clusterName), nil
+}
+
+// listPolicies lists the cluster proxy policies from the cache or the
+// source, sorted by name.
+func (a *ProxyAuthorizer) listPolicies(ctx context.Context) ([]cluster.ClusterProxyPolicy, error) {
+	if policies, ok := a.policies.Get(struct{}{}); ok {
+		return policies, nil
+	}
+	policies, err := a.source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	a.policies.Set(struct{}{}, policies)
+	return policies, nil
+}
+
+// policyAllows reports whether the policy allows the request of the user to
+// the cluster.
+func policyAllows(
+	policy *cluster.ClusterProxyPolicy,
+	username string,
+	groups sets.String,
+	clusterName string,
+	attrs *proxyAttributes,
+) bool {
+	if !appliesTo(policy, username, groups) {
+		return false
+	}
+	if len(policy.Spec.Clusters) > 0 && !sets.NewString(policy.Spec.Clusters...).Has(clusterName) {
+		return false
+	}
+	if policy.Spec.ReadOnly && !attrs.isRead() {
+		return false
+	}
+	if len(policy.Spec.Rules) == 0 {
+		return true
+	}
+	for i := range policy.Spec.Rules {
+		if ruleMatches(&policy.Spec.Rules[i], attrs) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo reports whether the policy applies to the user or one of the
+// given groups.
+func appliesTo(policy *cluster.ClusterProxyPolicy, username string, groups sets.String) bool {
+	for _, subject := range policy.Spec.Subjects {
+		switch subject.Kind {
+		case cluster.ClusterProxySubjectUser:
+			if subject.Name == username {
+				return true
+			}
+		case cluster.ClusterProxySubjectGroup:
+			if groups.Has(subject.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ruleMatches reports whether the rule matches the request.
+func ruleMatches(rule *cluster.ClusterProxyRule, attrs *proxyAttributes) bool {
+	if len(rule.Verbs) > 0 {
+		verbs := sets.NewString(rule.Verbs...)
+		if !verbs.Has("*") && !verbs.Has(attrs.verb) {
+			return false
+		}
+	}
+	if len(rule.Paths) > 0 && !pathMatches(rule.Paths, attrs.path) {
+		return false
+	}
+	if len(rule.Namespaces) > 0 && (attrs.namespace == "" || !sets.NewString(rule.Namespaces...).Has(attrs.namespace)) {
+		return false
+	}
+	return true
+}
+
+// pathMatches reports whether the path matches one of the patterns, a
+// pattern ending with "*" matches the paths with its prefix.
+func pathMatches(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if pattern == path {
+			return true
+		}
+	}
+	return false
+}
+
+// impersonate replaces the impersonation headers of the proxied request with
+// the ones of the user, so that the cluster authorizes the request as the
+// user rather than the credential of the cluster.
+func impersonate(header http.Header, u user.Info) {
+	for key := range header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(key), "Impersonate-") {
+			header.Del(key)
+		}
+	}
+	header.Set("Impersonate-User", u.GetName())
+	for _, group := range u.GetGroups() {
+		header.Add("Impersonate-Group", group)
+	}
+	if uid := u.GetUID(); uid != "" {
+		header.Set("Impersonate-Uid", uid)
+	}
+	for key, values := range u.GetExtra() {
+		for _, value := range values {
+			header.Add("Impersonate-Extra-"+url.PathEscape(key), value)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestProxyAuthorizer(t *testing.T) {
+	policies := []cluster.ClusterProxyPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+			Spec: cluster.ClusterProxyPolicySpec{
+				Subjects: []cluster.ClusterProxySubject{{Kind: cluster.ClusterProxySubjectGroup, Name: "developers"}},
+				Clusters: []string{"dev"},
+				Rules: []cluster.ClusterProxyRule{{
+					Verbs:      []string{"get", "list", "create"},
+					Paths:      []string{"/api/v1/namespaces/*"},
+					Namespaces: []string{"team-a"},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Spec: cluster.ClusterProxyPolicySpec{
+				Subjects: []cluster.ClusterProxySubject{{Kind: cluster.ClusterProxySubjectUser, Name: "alice"}},
+				ReadOnly: true,
+			},
+		},
+	}
+	source := func(ctx context.Context) ([]cluster.ClusterProxyPolicy, error) {
+		return policies, nil
+	}
+	developer := &user.DefaultInfo{Name: "bob", Groups: []string{"developers"}}
+	viewer := &user.DefaultInfo{Name: "alice"}
+	admin := &user.DefaultInfo{Name: "admin", Groups: []string{user.SystemPrivilegedGroup}}
+
+	tests := []struct {
+		name     string
+		readOnly bool
+		source   ProxyPolicySource
+		user     user.Info
+		cluster  string
+		method   string
+		path     string
+		upgrade  bool
+		allowed  bool
+	}{
+		{name: "no policies", source: nil, user: developer, cluster: "prod", method: http.MethodDelete, path: "/api/v1/namespaces/default/pods/a", allowed: true},
+		{name: "rule matches", source: source, user: developer, cluster: "dev", method: http.MethodGet, path: "/api/v1/namespaces/team-a/pods", allowed: true},
+		{name: "create in namespace", source: source, user: developer, cluster: "dev", method: http.MethodPost, path: "/api/v1/namespaces/team-a/configmaps", allowed: true},
+		{name: "verb not allowed", source: source, user: developer, cluster: "dev", method: http.MethodDelete, path: "/api/v1/namespaces/team-a/pods/a"},
+		{name: "namespace not allowed", source: source, user: developer, cluster: "dev", method: http.MethodGet, path: "/api/v1/namespaces/team-b/pods"},
+		{name: "path not allowed", source: source, user: developer, cluster: "dev", method: http.MethodGet, path: "/api/v1/nodes"},
+		{name: "cluster not allowed", source: source, user: developer, cluster: "prod", method: http.MethodGet, path: "/api/v1/namespaces/team-a/pods"},
+		{name: "read only policy", source: source, user: viewer, cluster: "prod", method: http.MethodGet, path: "/api/v1/nodes?watch=true", allowed: true},
+		{name: "read only policy denies writes", source: source, user: viewer, cluster: "prod", method: http.MethodPatch, path: "/api/v1/nodes/a"},
+		{name: "read only policy denies exec", source: source, user: viewer, cluster: "prod", method: http.MethodGet, path: "/api/v1/namespaces/default/pods/a/exec", upgrade: true},
+		{name: "masters bypass policies", source: source, user: admin, cluster: "prod", method: http.MethodDelete, path: "/api/v1/nodes/a", allowed: true},
+		{name: "read only mode", readOnly: true, source: nil, user: admin, cluster: "prod", method: http.MethodDelete, path: "/api/v1/nodes/a"},
+		{name: "read only mode allows reads", readOnly: true, source: nil, user: admin, cluster: "prod", method: http.MethodGet, path: "/version", allowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := NewProxyAuthorizer(tt.readOnly, tt.source)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.upgrade {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "SPDY/3.1")
+			}
+			allowed, reason, err := authorizer.Authorize(context.Background(), tt.user, tt.cluster, req, req.URL.Path)
+			require.NoError(t, err)
+			require.Equal(t, tt.allowed, allowed, reason)
+			if !allowed {
+				require.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestImpersonate(t *testing.T) {
+	header := http.Header{}
+	header.Set("Impersonate-User", "system:admin")
+	header.Set("Impersonate-Extra-Scopes", "all")
+	impersonate(header, &user.DefaultInfo{
+		Name:   "bob",
+		UID:    "42",
+		Groups: []string{"developers", "system:authenticated"},
+		Extra:  map[string][]string{"scopes": {"view"}},
+	})
+
+	require.Equal(t, "bob", header.Get("Impersonate-User"))
+	require.Equal(t, []string{"developers", "system:authenticated"}, header.Values("Impersonate-Group"))
+	require.Equal(t, "42", header.Get("Impersonate-Uid"))
+	require.Equal(t, []string{"view"}, header.Values("Impersonate-Extra-Scopes"))
+}
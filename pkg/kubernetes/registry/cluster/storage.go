@@ -17,8 +17,14 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
+
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/infra/tunnel"
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry/cluster/proxypolicy"
 
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
@@ -34,10 +40,17 @@ type Storage struct {
 	Cluster *REST
 	Status  *StatusREST
 	Proxy   *ProxyREST
+	Tunnel  *TunnelREST
+
+	ProxyPolicy *proxypolicy.REST
 }
 
 // NewREST returns a RESTStorage object that will work against API services.
-func NewREST(optsGetter generic.RESTOptionsGetter) (*Storage, error) {
+func NewREST(
+	optsGetter generic.RESTOptionsGetter,
+	recorder *actionlog.Recorder,
+	proxyOpts ProxyOptions,
+) (*Storage, error) {
 	store := &genericregistry.Store{
 		NewFunc:                  func() runtime.Object { return &cluster.Cluster{} },
 		NewListFunc:              func() runtime.Object { return &cluster.ClusterList{} },
@@ -60,10 +73,37 @@ func NewREST(optsGetter generic.RESTOptionsGetter) (*Storage, error) {
 	statusStore := *store
 	statusStore.UpdateStrategy = StatusStartegy
 
+	// The tunnels of the agents are shared by the tunnel subresource opening
+	// them and the proxy subresource using them.
+	tunnels := tunnel.NewServer()
+
+	proxyPolicy, err := proxypolicy.NewREST(optsGetter)
+	if err != nil {
+		return nil, err
+	}
+	var source ProxyPolicySource
+	if proxyOpts.EnablePolicies {
+		source = func(ctx context.Context) ([]cluster.ClusterProxyPolicy, error) {
+			obj, err := proxyPolicy.List(ctx, &metainternalversion.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return obj.(*cluster.ClusterProxyPolicyList).Items, nil
+		}
+	}
+
 	return &Storage{
 		Cluster: &REST{store},
 		Status:  &StatusREST{&statusStore},
-		Proxy:   &ProxyREST{store},
+		Proxy: &ProxyREST{
+			Store:       store,
+			Recorder:    recorder,
+			Tunnels:     tunnels,
+			Authorizer:  NewProxyAuthorizer(proxyOpts.ReadOnly, source),
+			Impersonate: proxyOpts.Impersonate,
+		},
+		Tunnel:      &TunnelREST{Store: store, Tunnels: tunnels},
+		ProxyPolicy: proxyPolicy,
 	}, nil
 }
 
@@ -15,6 +15,7 @@
 package cluster
 
 import (
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
 	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
@@ -26,7 +27,11 @@ import (
 
 var _ registry.RESTStorageProvider = &RESTStorageProvider{}
 
-type RESTStorageProvider struct{}
+type RESTStorageProvider struct {
+	ActionRecorder *actionlog.Recorder
+	// ProxyOptions configures the access control of the cluster proxy.
+	ProxyOptions ProxyOptions
+}
 
 func (p RESTStorageProvider) GroupName() string {
 	return cluster.GroupName
@@ -44,7 +49,7 @@ func (p RESTStorageProvider) NewRESTStorage(
 	)
 
 	v1beta1 := map[string]rest.Storage{}
-	clusterStorage, err := NewREST(restOptionsGetter)
+	clusterStorage, err := NewREST(restOptionsGetter, p.ActionRecorder, p.ProxyOptions)
 	if err != nil {
 		return genericapiserver.APIGroupInfo{}, err
 	}
@@ -52,6 +57,8 @@ func (p RESTStorageProvider) NewRESTStorage(
 	v1beta1["clusters"] = clusterStorage.Cluster
 	v1beta1["clusters/status"] = clusterStorage.Status
 	v1beta1["clusters/proxy"] = clusterStorage.Proxy
+	v1beta1["clusters/tunnel"] = clusterStorage.Tunnel
+	v1beta1["clusterproxypolicies"] = clusterStorage.ProxyPolicy
 
 	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1
 	return apiGroupInfo, nil
@@ -19,7 +19,9 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
 	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
+	"github.com/KusionStack/karpor/pkg/util/credentialutil"
 )
 
 var Strategy = clusterStrategy{scheme.Scheme, names.SimpleNameGenerator}
@@ -69,11 +72,15 @@ func (clusterStrategy) NamespaceScoped() bool {
 }
 
 func (clusterStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
-	obj.(*cluster.Cluster).Status = cluster.ClusterStatus{}
+	c := obj.(*cluster.Cluster)
+	c.Status = cluster.ClusterStatus{}
+	c.Status.CredentialExpiration = credentialExpiration(c)
 }
 
 func (clusterStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
-	obj.(*cluster.Cluster).Status = old.(*cluster.Cluster).Status
+	c := obj.(*cluster.Cluster)
+	c.Status = old.(*cluster.Cluster).Status
+	c.Status.CredentialExpiration = credentialExpiration(c)
 }
 
 func (clusterStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
@@ -119,7 +126,33 @@ var StatusStartegy = clusterStatusStrategy{Strategy}
 
 // PrepareForUpdate clears fields that are not allowed to be set by end users on update of status
 func (clusterStatusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
-	obj.(*cluster.Cluster).Spec = old.(*cluster.Cluster).Spec
+	c := obj.(*cluster.Cluster)
+	c.Spec = old.(*cluster.Cluster).Spec
+	c.Status.CredentialExpiration = credentialExpiration(c)
+}
+
+// credentialExpiration returns the expiry of the credential of the cluster,
+// or nil if the credential does not expire or its expiry is unknown.
+func credentialExpiration(c *cluster.Cluster) *metav1.Time {
+	credential := c.Spec.Access.Credential
+	if credential == nil {
+		return nil
+	}
+
+	var expiration *time.Time
+	var err error
+	switch credential.Type {
+	case cluster.CredentialTypeServiceAccountToken:
+		expiration, err = credentialutil.TokenExpiration(credential.ServiceAccountToken)
+	case cluster.CredentialTypeX509Certificate:
+		if credential.X509 != nil {
+			expiration, err = credentialutil.CertificateExpiration(credential.X509.Certificate)
+		}
+	}
+	if err != nil || expiration == nil {
+		return nil
+	}
+	return &metav1.Time{Time: *expiration}
 }
 
 // ValidateUpdate is the default update validation for an end user updating status
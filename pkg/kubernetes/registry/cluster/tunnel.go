@@ -0,0 +1,91 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/KusionStack/karpor/pkg/infra/tunnel"
+	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+var _ rest.Connecter = &TunnelREST{}
+
+// TunnelREST is the subresource the agents of the clusters in Agent access
+// mode connect to, the connection is upgraded to a tunnel which the hub
+// sends the requests to the cluster through.
+type TunnelREST struct {
+	Store   *genericregistry.Store
+	Tunnels *tunnel.Server
+}
+
+func (r *TunnelREST) Destroy() {
+}
+
+// New returns empty Cluster object.
+func (r *TunnelREST) New() runtime.Object {
+	return &cluster.Cluster{}
+}
+
+func (r *TunnelREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+func (r *TunnelREST) ConnectMethods() []string {
+	return []string{"GET"}
+}
+
+func (r *TunnelREST) Connect(
+	ctx context.Context,
+	id string,
+	options runtime.Object,
+	responder rest.Responder,
+) (http.Handler, error) {
+	obj, err := r.Store.Get(ctx, id, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterExtension := obj.(*cluster.Cluster)
+	if clusterExtension.Spec.Access.Mode != cluster.ClusterAccessModeAgent {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("cluster %s is not in %s access mode", id, cluster.ClusterAccessModeAgent))
+	}
+
+	// Only the agent of the cluster can open its tunnel, as the hub sends
+	// the requests to the cluster through it.
+	agentUser := clusterExtension.Spec.Access.AgentUser
+	requester, ok := request.UserFrom(ctx)
+	if agentUser == "" || !ok || requester.GetName() != agentUser {
+		return nil, apierrors.NewForbidden(cluster.Resource("clusters/tunnel"), id, fmt.Errorf("only the agent user of the cluster can open its tunnel"))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		err := r.Tunnels.Accept(id, agentUser, w, req)
+		switch {
+		case errors.Is(err, tunnel.ErrTunnelInUse):
+			responder.Error(apierrors.NewConflict(cluster.Resource("clusters/tunnel"), id, err))
+		case err != nil:
+			responder.Error(apierrors.NewBadRequest(err.Error()))
+		}
+	}), nil
+}
@@ -0,0 +1,86 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KusionStack/karpor/pkg/infra/tunnel"
+	"github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster"
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+)
+
+func TestTunnelRESTConnect(t *testing.T) {
+	edge := &cluster.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge"},
+		Spec: cluster.ClusterSpec{Access: cluster.ClusterAccess{
+			Mode:      cluster.ClusterAccessModeAgent,
+			AgentUser: "system:serviceaccount:karpor:edge-agent",
+		}},
+	}
+	direct := &cluster.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "direct"},
+		Spec:       cluster.ClusterSpec{Access: cluster.ClusterAccess{Endpoint: "https://direct.example.com"}},
+	}
+	unbound := &cluster.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "unbound"},
+		Spec:       cluster.ClusterSpec{Access: cluster.ClusterAccess{Mode: cluster.ClusterAccessModeAgent}},
+	}
+	clusters := map[string]*cluster.Cluster{"edge": edge, "direct": direct, "unbound": unbound}
+	defer mockey.Mock((*genericregistry.Store).Get).To(func(_ *genericregistry.Store, _ context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+		return clusters[name], nil
+	}).Build().UnPatch()
+
+	r := &TunnelREST{Store: &genericregistry.Store{}, Tunnels: tunnel.NewServer()}
+	agent := &user.DefaultInfo{Name: "system:serviceaccount:karpor:edge-agent"}
+	other := &user.DefaultInfo{Name: "bob", Groups: []string{"karpor:admins"}}
+
+	tests := []struct {
+		name      string
+		cluster   string
+		user      user.Info
+		wantError func(error) bool
+	}{
+		{name: "agent user", cluster: "edge", user: agent},
+		{name: "another user", cluster: "edge", user: other, wantError: apierrors.IsForbidden},
+		{name: "no user", cluster: "edge", wantError: apierrors.IsForbidden},
+		{name: "no agent user", cluster: "unbound", user: agent, wantError: apierrors.IsForbidden},
+		{name: "direct access", cluster: "direct", user: agent, wantError: apierrors.IsBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = request.WithUser(ctx, tt.user)
+			}
+			h, err := r.Connect(ctx, tt.cluster, nil, nil)
+			if tt.wantError != nil {
+				require.True(t, tt.wantError(err), err)
+				require.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, h)
+		})
+	}
+}
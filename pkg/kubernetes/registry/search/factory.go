@@ -70,6 +70,74 @@ func NewResourceGroupRuleStorage(c registry.ExtraConfig) (storage.ResourceGroupR
 	return resourceGroupRuleStorageGetter.GetResourceGroupRuleStorage()
 }
 
+// NewAuditStorage creates a new instance of an audit storage component using the provided extra configuration.
+func NewAuditStorage(c registry.ExtraConfig) (storage.AuditStorage, error) {
+	storage := RESTStorageProvider{
+		SearchStorageType:      c.SearchStorageType,
+		ElasticSearchAddresses: c.ElasticSearchAddresses,
+		ElasticSearchName:      c.ElasticSearchUsername,
+		ElasticSearchPassword:  c.ElasticSearchPassword,
+	}
+
+	auditStorageGetter, err := storage.AuditStorageGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return auditStorageGetter.GetAuditStorage()
+}
+
+// NewActionLogStorage creates a new instance of an action log storage component using the provided extra configuration.
+func NewActionLogStorage(c registry.ExtraConfig) (storage.ActionLogStorage, error) {
+	storage := RESTStorageProvider{
+		SearchStorageType:      c.SearchStorageType,
+		ElasticSearchAddresses: c.ElasticSearchAddresses,
+		ElasticSearchName:      c.ElasticSearchUsername,
+		ElasticSearchPassword:  c.ElasticSearchPassword,
+	}
+
+	actionLogStorageGetter, err := storage.ActionLogStorageGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return actionLogStorageGetter.GetActionLogStorage()
+}
+
+// NewSavedSearchStorage creates a new instance of a saved search storage component using the provided extra configuration.
+func NewSavedSearchStorage(c registry.ExtraConfig) (storage.SavedSearchStorage, error) {
+	storage := RESTStorageProvider{
+		SearchStorageType:      c.SearchStorageType,
+		ElasticSearchAddresses: c.ElasticSearchAddresses,
+		ElasticSearchName:      c.ElasticSearchUsername,
+		ElasticSearchPassword:  c.ElasticSearchPassword,
+	}
+
+	savedSearchStorageGetter, err := storage.SavedSearchStorageGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return savedSearchStorageGetter.GetSavedSearchStorage()
+}
+
+// NewAlertRuleStorage creates a new instance of an alert rule storage component using the provided extra configuration.
+func NewAlertRuleStorage(c registry.ExtraConfig) (storage.AlertRuleStorage, error) {
+	storage := RESTStorageProvider{
+		SearchStorageType:      c.SearchStorageType,
+		ElasticSearchAddresses: c.ElasticSearchAddresses,
+		ElasticSearchName:      c.ElasticSearchUsername,
+		ElasticSearchPassword:  c.ElasticSearchPassword,
+	}
+
+	alertRuleStorageGetter, err := storage.AlertRuleStorageGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return alertRuleStorageGetter.GetAlertRuleStorage()
+}
+
 // NewGeneralStorage creates a new instance of a general storage component using the provided extra configuration.
 func NewGeneralStorage(c registry.ExtraConfig) (storage.Storage, error) {
 	storage := RESTStorageProvider{
@@ -86,3 +154,20 @@ func NewGeneralStorage(c registry.ExtraConfig) (storage.Storage, error) {
 
 	return generalStorageGetter.GetGeneralStorage()
 }
+
+// NewEventStorage creates a new instance of an event storage component using the provided extra configuration.
+func NewEventStorage(c registry.ExtraConfig) (storage.EventStorage, error) {
+	storage := RESTStorageProvider{
+		SearchStorageType:      c.SearchStorageType,
+		ElasticSearchAddresses: c.ElasticSearchAddresses,
+		ElasticSearchName:      c.ElasticSearchUsername,
+		ElasticSearchPassword:  c.ElasticSearchPassword,
+	}
+
+	eventStorageGetter, err := storage.EventStorageGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return eventStorageGetter.GetEventStorage()
+}
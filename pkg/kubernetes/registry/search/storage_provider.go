@@ -23,6 +23,9 @@ import (
 	"github.com/KusionStack/karpor/pkg/infra/search/storage/elasticsearch"
 	"github.com/KusionStack/karpor/pkg/kubernetes/apis/search"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/auditexception"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/dataaccessrule"
+	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/scanpolicy"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/syncclusterresources"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/transformrule"
 	"github.com/KusionStack/karpor/pkg/kubernetes/registry/search/trimrule"
@@ -97,6 +100,24 @@ func (p RESTStorageProvider) v1beta1Storage(
 	}
 	v1beta1Storage["trimrules"] = trimRule
 
+	scanPolicy, err := scanpolicy.NewREST(restOptionsGetter)
+	if err != nil {
+		return map[string]rest.Storage{}, err
+	}
+	v1beta1Storage["scanpolicies"] = scanPolicy
+
+	auditException, err := auditexception.NewREST(restOptionsGetter)
+	if err != nil {
+		return map[string]rest.Storage{}, err
+	}
+	v1beta1Storage["auditexceptions"] = auditException
+
+	dataAccessRule, err := dataaccessrule.NewREST(restOptionsGetter)
+	if err != nil {
+		return map[string]rest.Storage{}, err
+	}
+	v1beta1Storage["dataaccessrules"] = dataAccessRule
+
 	return v1beta1Storage, nil
 }
 
@@ -142,6 +163,76 @@ func (p RESTStorageProvider) ResourceGroupRuleStorageGetter() (storage.ResourceG
 	}
 }
 
+// AuditStorageGetter returns the audit storage getter for the provider.
+func (p RESTStorageProvider) AuditStorageGetter() (storage.AuditStorageGetter, error) {
+	switch p.SearchStorageType {
+	case elasticSearchType:
+		return elasticsearch.NewAuditStorageGetter(
+			p.ElasticSearchAddresses,
+			p.ElasticSearchName,
+			p.ElasticSearchPassword,
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid audit storage type %s", p.SearchStorageType)
+	}
+}
+
+// ActionLogStorageGetter returns the action log storage getter for the provider.
+func (p RESTStorageProvider) ActionLogStorageGetter() (storage.ActionLogStorageGetter, error) {
+	switch p.SearchStorageType {
+	case elasticSearchType:
+		return elasticsearch.NewActionLogStorageGetter(
+			p.ElasticSearchAddresses,
+			p.ElasticSearchName,
+			p.ElasticSearchPassword,
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid action log storage type %s", p.SearchStorageType)
+	}
+}
+
+// SavedSearchStorageGetter returns the saved search storage getter for the provider.
+func (p RESTStorageProvider) SavedSearchStorageGetter() (storage.SavedSearchStorageGetter, error) {
+	switch p.SearchStorageType {
+	case elasticSearchType:
+		return elasticsearch.NewSavedSearchStorageGetter(
+			p.ElasticSearchAddresses,
+			p.ElasticSearchName,
+			p.ElasticSearchPassword,
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid saved search storage type %s", p.SearchStorageType)
+	}
+}
+
+// AlertRuleStorageGetter returns the alert rule storage getter for the provider.
+func (p RESTStorageProvider) AlertRuleStorageGetter() (storage.AlertRuleStorageGetter, error) {
+	switch p.SearchStorageType {
+	case elasticSearchType:
+		return elasticsearch.NewAlertRuleStorageGetter(
+			p.ElasticSearchAddresses,
+			p.ElasticSearchName,
+			p.ElasticSearchPassword,
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid alert rule storage type %s", p.SearchStorageType)
+	}
+}
+
+// EventStorageGetter returns the event storage getter for the provider.
+func (p RESTStorageProvider) EventStorageGetter() (storage.EventStorageGetter, error) {
+	switch p.SearchStorageType {
+	case elasticSearchType:
+		return elasticsearch.NewEventStorageGetter(
+			p.ElasticSearchAddresses,
+			p.ElasticSearchName,
+			p.ElasticSearchPassword,
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid event storage type %s", p.SearchStorageType)
+	}
+}
+
 // GeneralStorageGetter returns the general storage getter for the provider.
 func (p RESTStorageProvider) GeneralStorageGetter() (storage.GeneralStorageGetter, error) {
 	switch p.SearchStorageType {
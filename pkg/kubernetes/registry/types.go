@@ -17,6 +17,9 @@ package registry
 import (
 	"time"
 
+	"github.com/KusionStack/karpor/pkg/core/actionlog"
+	"github.com/KusionStack/karpor/pkg/core/authn/oidc"
+	"github.com/KusionStack/karpor/pkg/infra/notification"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	serverstorage "k8s.io/apiserver/pkg/server/storage"
@@ -41,6 +44,35 @@ type ExtraConfig struct {
 	ReadOnlyMode           bool
 	GithubBadge            bool
 	EnableRBAC             bool
+	EnableDataAccessRules  bool
+	AuditInterval          time.Duration
+
+	// Resource group configs
+	ResourceGroupSnapshotInterval  time.Duration
+	ResourceGroupSnapshotRetention time.Duration
+
+	// Cluster proxy configs
+	ClusterProxyReadOnly       bool
+	EnableClusterProxyPolicies bool
+	ClusterProxyImpersonation  bool
+
+	// Cluster health and credential configs
+	ClusterProbeInterval       time.Duration
+	CredentialCheckInterval    time.Duration
+	CredentialExpiryWarning    time.Duration
+	RotateServiceAccountTokens bool
+	RotatedTokenTTL            time.Duration
+
+	// Alert configs
+	AlertEvaluationInterval time.Duration
+	AlertSMTP               notification.SMTPConfig
+
+	// OIDCProvider performs the OpenID Connect login, it is nil if the
+	// login is not configured.
+	OIDCProvider *oidc.Provider
+
+	// ActionRecorder records the actions performed by users.
+	ActionRecorder *actionlog.Recorder
 
 	// ServiceAccount configs
 	ServiceAccountIssuer        serviceaccount.TokenGenerator
@@ -58,4 +90,14 @@ type ExtraConfig struct {
 	AIHTTPProxy    string
 	AIHTTPSProxy   string
 	AINoProxy      string
+	// AI caching and budgets, zero means disabled or unlimited
+	AICacheTTL                time.Duration
+	AIUserRequestsPerMinute   int
+	AIUserTokensPerDay        int
+	AIGlobalRequestsPerMinute int
+	AIGlobalTokensPerDay      int
+	// AI prompt templates overriding the built-in prompts, which are
+	// reloaded in the interval if it is positive
+	AIPromptDir            string
+	AIPromptReloadInterval time.Duration
 }
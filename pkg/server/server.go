@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
@@ -59,7 +60,14 @@ func (s *KarporServer) InstallKubernetesServer(c *CompletedConfig) *KarporServer
 
 	// Initialize REST storage providers for the server.
 	restStorageProviders := []registry.RESTStorageProvider{
-		clusterstorage.RESTStorageProvider{},
+		clusterstorage.RESTStorageProvider{
+			ActionRecorder: c.ExtraConfig.ActionRecorder,
+			ProxyOptions: clusterstorage.ProxyOptions{
+				ReadOnly:       c.ExtraConfig.ReadOnlyMode || c.ExtraConfig.ClusterProxyReadOnly,
+				EnablePolicies: c.ExtraConfig.EnableClusterProxyPolicies,
+				Impersonate:    c.ExtraConfig.ClusterProxyImpersonation,
+			},
+		},
 		searchstorage.RESTStorageProvider{
 			SearchStorageType:      c.ExtraConfig.SearchStorageType,
 			ElasticSearchAddresses: c.ExtraConfig.ElasticSearchAddresses,
@@ -88,14 +96,24 @@ func (s *KarporServer) InstallCoreServer(c *CompletedConfig) *KarporServer {
 		return s
 	}
 
-	// Instantiate and set up the core route.
-	if mux, err := route.NewCoreRoute(&c.GenericConfig, c.ExtraConfig); err == nil {
+	// Instantiate and set up the core route, whose background tasks stop
+	// when the server shuts down.
+	ctx, cancel := context.WithCancel(context.Background())
+	if mux, err := route.NewCoreRoute(ctx, &c.GenericConfig, c.ExtraConfig); err == nil {
 		s.mux = mux
 	} else {
 		// Capture any errors encountered during core server setup.
+		cancel()
 		s.err = err
 		return s
 	}
+	s.GenericAPIServer.AddPostStartHookOrDie("stop-core-background-tasks", func(hookContext genericapiserver.PostStartHookContext) error {
+		go func() {
+			<-hookContext.StopCh
+			cancel()
+		}()
+		return nil
+	})
 
 	// Mount the core server's Mux to the GenericAPIServer's non-API request
 	// handler.
@@ -0,0 +1,234 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	clientgocache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// eventPurgeInterval is the interval the expired archived events are purged.
+const eventPurgeInterval = time.Hour
+
+var (
+	eventsV1GVR   = schema.GroupVersionResource{Group: "events.k8s.io", Version: "v1", Resource: "events"}
+	coreEventsGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"}
+)
+
+// EventArchiver archives the events of a cluster into the event storage, so
+// that they are kept after they expire in the cluster or the cluster is
+// deleted. The events are watched through events.k8s.io/v1 if the cluster
+// serves it, and through v1 otherwise, both of them serve the same events.
+type EventArchiver struct {
+	cluster         string
+	dynamicClient   dynamic.Interface
+	discoveryClient discovery.DiscoveryInterface
+	storage         storage.EventStorage
+
+	logger logr.Logger
+}
+
+// NewEventArchiver creates a new EventArchiver for the cluster.
+func NewEventArchiver(cluster string, dynamicClient dynamic.Interface, discoveryClient discovery.DiscoveryInterface, storage storage.EventStorage) *EventArchiver {
+	return &EventArchiver{
+		cluster:         cluster,
+		dynamicClient:   dynamicClient,
+		discoveryClient: discoveryClient,
+		storage:         storage,
+		logger:          ctrl.Log.WithName("event-archiver").WithValues("cluster", cluster),
+	}
+}
+
+// Run watches the events of the cluster and archives them until the context
+// is done.
+func (a *EventArchiver) Run(ctx context.Context) {
+	gvr := a.eventGVR()
+	a.logger.Info("Starting event archiver", "gvr", gvr)
+
+	informer := dynamicinformer.NewFilteredDynamicInformer(a.dynamicClient, gvr, metav1.NamespaceAll, 0, clientgocache.Indexers{}, nil).Informer()
+	informer.AddEventHandler(clientgocache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			a.archive(ctx, obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			a.archive(ctx, obj)
+		},
+	})
+	informer.Run(ctx.Done())
+	a.logger.Info("Event archiver was stopped")
+}
+
+// eventGVR returns the resource the events of the cluster are watched
+// through.
+func (a *EventArchiver) eventGVR() schema.GroupVersionResource {
+	resources, err := a.discoveryClient.ServerResourcesForGroupVersion(eventsV1GVR.GroupVersion().String())
+	if err != nil {
+		return coreEventsGVR
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == eventsV1GVR.Resource {
+			return eventsV1GVR
+		}
+	}
+	return coreEventsGVR
+}
+
+// archive saves the event into the event storage.
+func (a *EventArchiver) archive(ctx context.Context, obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	event := archivedEventFrom(a.cluster, u)
+	if err := a.storage.ArchiveEvent(ctx, string(u.GetUID()), event); err != nil {
+		a.logger.Error(err, "failed to archive event", "namespace", u.GetNamespace(), "name", u.GetName())
+	}
+}
+
+// archivedEventFrom converts an event of either v1 or events.k8s.io/v1 into
+// an archived event.
+func archivedEventFrom(cluster string, u *unstructured.Unstructured) *entity.ArchivedEvent {
+	obj := u.Object
+	event := &entity.ArchivedEvent{}
+
+	var object map[string]interface{}
+	var count int64
+	var firstTimestamp, lastTimestamp *metav1.Time
+	if u.GetAPIVersion() == eventsV1GVR.GroupVersion().String() {
+		object, _, _ = unstructured.NestedMap(obj, "regarding")
+		event.Message, _, _ = unstructured.NestedString(obj, "note")
+		event.Source = firstString(obj, []string{"reportingController"}, []string{"deprecatedSource", "component"})
+		count = firstInt(obj, []string{"series", "count"}, []string{"deprecatedCount"})
+		firstTimestamp = firstTime(obj, []string{"deprecatedFirstTimestamp"}, []string{"eventTime"})
+		lastTimestamp = firstTime(obj, []string{"series", "lastObservedTime"}, []string{"deprecatedLastTimestamp"}, []string{"eventTime"})
+	} else {
+		object, _, _ = unstructured.NestedMap(obj, "involvedObject")
+		event.Message, _, _ = unstructured.NestedString(obj, "message")
+		event.Source = firstString(obj, []string{"source", "component"}, []string{"reportingComponent"})
+		count = firstInt(obj, []string{"count"}, []string{"series", "count"})
+		firstTimestamp = firstTime(obj, []string{"firstTimestamp"}, []string{"eventTime"})
+		lastTimestamp = firstTime(obj, []string{"series", "lastObservedTime"}, []string{"lastTimestamp"}, []string{"eventTime"})
+	}
+	event.Type, _, _ = unstructured.NestedString(obj, "type")
+	event.Reason, _, _ = unstructured.NestedString(obj, "reason")
+
+	event.InvolvedObject = entity.ResourceGroup{Cluster: cluster}
+	event.InvolvedObject.APIVersion, _, _ = unstructured.NestedString(object, "apiVersion")
+	event.InvolvedObject.Kind, _, _ = unstructured.NestedString(object, "kind")
+	event.InvolvedObject.Namespace, _, _ = unstructured.NestedString(object, "namespace")
+	event.InvolvedObject.Name, _, _ = unstructured.NestedString(object, "name")
+	event.UID, _, _ = unstructured.NestedString(object, "uid")
+
+	event.Count = int(count)
+	if event.Count < 1 {
+		event.Count = 1
+	}
+
+	created := u.GetCreationTimestamp()
+	if firstTimestamp == nil {
+		firstTimestamp = &created
+	}
+	if lastTimestamp == nil {
+		lastTimestamp = firstTimestamp
+	}
+	event.FirstTimestamp = firstTimestamp
+	event.LastTimestamp = lastTimestamp
+
+	event.ID = entity.ArchivedEventID(event.InvolvedObject, event.UID, event.Type, event.Reason, event.Message)
+	return event
+}
+
+// firstString returns the first non-empty string field of the object.
+func firstString(obj map[string]interface{}, fields ...[]string) string {
+	for _, field := range fields {
+		if v, _, _ := unstructured.NestedString(obj, field...); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// firstInt returns the first positive integer field of the object.
+func firstInt(obj map[string]interface{}, fields ...[]string) int64 {
+	for _, field := range fields {
+		if v, _, _ := unstructured.NestedInt64(obj, field...); v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// firstTime returns the first time field of the object which is set.
+func firstTime(obj map[string]interface{}, fields ...[]string) *metav1.Time {
+	for _, field := range fields {
+		v, _, _ := unstructured.NestedString(obj, field...)
+		if v == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return &metav1.Time{Time: t}
+		}
+	}
+	return nil
+}
+
+// EventPurger deletes the archived events which last occurred longer than
+// the retention ago.
+type EventPurger struct {
+	storage   storage.EventStorage
+	retention time.Duration
+
+	logger logr.Logger
+}
+
+// NewEventPurger creates a new EventPurger with the given retention.
+func NewEventPurger(storage storage.EventStorage, retention time.Duration) *EventPurger {
+	return &EventPurger{
+		storage:   storage,
+		retention: retention,
+		logger:    ctrl.Log.WithName("event-purger"),
+	}
+}
+
+// Start purges the expired archived events periodically until the context
+// is done, it implements the manager.Runnable interface.
+func (p *EventPurger) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, p.purge, eventPurgeInterval)
+	return nil
+}
+
+// purge deletes the expired archived events.
+func (p *EventPurger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+	if err := p.storage.DeleteArchivedEventsBefore(ctx, before); err != nil {
+		p.logger.Error(err, "failed to purge archived events", "before", before)
+		return
+	}
+	p.logger.V(1).Info("purged archived events", "before", before)
+}
@@ -0,0 +1,232 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/core/entity"
+	"github.com/KusionStack/karpor/pkg/infra/search/storage"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// fakeEventStorage records the archived events and the purges.
+type fakeEventStorage struct {
+	storage.EventStorage
+	archived map[string]*entity.ArchivedEvent
+	before   time.Time
+}
+
+func (s *fakeEventStorage) ArchiveEvent(_ context.Context, eventUID string, event *entity.ArchivedEvent) error {
+	if s.archived == nil {
+		s.archived = map[string]*entity.ArchivedEvent{}
+	}
+	s.archived[eventUID] = event
+	return nil
+}
+
+func (s *fakeEventStorage) DeleteArchivedEventsBefore(_ context.Context, before time.Time) error {
+	s.before = before
+	return nil
+}
+
+func TestArchivedEventFrom(t *testing.T) {
+	first := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	last := metav1.NewTime(time.Date(2024, 5, 1, 11, 30, 0, 0, time.UTC))
+	created := metav1.NewTime(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want *entity.ArchivedEvent
+	}{
+		{
+			name: "core v1 event",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Event",
+				"metadata":   map[string]interface{}{"name": "foo.1", "namespace": "default", "uid": "e1"},
+				"involvedObject": map[string]interface{}{
+					"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "foo", "uid": "p1",
+				},
+				"type":           "Warning",
+				"reason":         "BackOff",
+				"message":        "Back-off restarting failed container",
+				"source":         map[string]interface{}{"component": "kubelet"},
+				"count":          int64(5),
+				"firstTimestamp": "2024-05-01T10:00:00Z",
+				"lastTimestamp":  "2024-05-01T11:30:00Z",
+			},
+			want: &entity.ArchivedEvent{
+				InvolvedObject: entity.ResourceGroup{Cluster: "cluster1", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "foo"},
+				UID:            "p1",
+				Type:           "Warning",
+				Reason:         "BackOff",
+				Message:        "Back-off restarting failed container",
+				Source:         "kubelet",
+				Count:          5,
+				FirstTimestamp: &first,
+				LastTimestamp:  &last,
+			},
+		},
+		{
+			name: "events.k8s.io v1 event with series",
+			obj: map[string]interface{}{
+				"apiVersion": "events.k8s.io/v1",
+				"kind":       "Event",
+				"metadata":   map[string]interface{}{"name": "bar.1", "namespace": "default", "uid": "e2"},
+				"regarding": map[string]interface{}{
+					"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "bar",
+				},
+				"type":                "Normal",
+				"reason":              "ScalingReplicaSet",
+				"note":                "Scaled up replica set bar-1 to 3",
+				"reportingController": "deployment-controller",
+				"eventTime":           "2024-05-01T10:00:00.000000Z",
+				"series":              map[string]interface{}{"count": int64(3), "lastObservedTime": "2024-05-01T11:30:00.123456Z"},
+			},
+			want: &entity.ArchivedEvent{
+				InvolvedObject: entity.ResourceGroup{Cluster: "cluster1", APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "bar"},
+				Type:           "Normal",
+				Reason:         "ScalingReplicaSet",
+				Message:        "Scaled up replica set bar-1 to 3",
+				Source:         "deployment-controller",
+				Count:          3,
+				FirstTimestamp: &first,
+				LastTimestamp:  &metav1.Time{Time: time.Date(2024, 5, 1, 11, 30, 0, 123456000, time.UTC)},
+			},
+		},
+		{
+			name: "event without count and timestamps",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Event",
+				"metadata": map[string]interface{}{
+					"name": "node1.1", "namespace": "default", "uid": "e3", "creationTimestamp": "2024-05-01T09:00:00Z",
+				},
+				"involvedObject": map[string]interface{}{"kind": "Node", "name": "node1"},
+				"type":           "Normal",
+				"reason":         "NodeReady",
+			},
+			want: &entity.ArchivedEvent{
+				InvolvedObject: entity.ResourceGroup{Cluster: "cluster1", Kind: "Node", Name: "node1"},
+				Type:           "Normal",
+				Reason:         "NodeReady",
+				Count:          1,
+				FirstTimestamp: &created,
+				LastTimestamp:  &created,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := archivedEventFrom("cluster1", &unstructured.Unstructured{Object: tt.obj})
+			tt.want.ID = entity.ArchivedEventID(tt.want.InvolvedObject, tt.want.UID, tt.want.Type, tt.want.Reason, tt.want.Message)
+			require.Equal(t, tt.want.ID, got.ID)
+			require.Equal(t, tt.want.InvolvedObject, got.InvolvedObject)
+			require.Equal(t, tt.want.UID, got.UID)
+			require.Equal(t, tt.want.Type, got.Type)
+			require.Equal(t, tt.want.Reason, got.Reason)
+			require.Equal(t, tt.want.Message, got.Message)
+			require.Equal(t, tt.want.Source, got.Source)
+			require.Equal(t, tt.want.Count, got.Count)
+			require.True(t, tt.want.FirstTimestamp.Equal(got.FirstTimestamp), "firstTimestamp %v", got.FirstTimestamp)
+			require.True(t, tt.want.LastTimestamp.Equal(got.LastTimestamp), "lastTimestamp %v", got.LastTimestamp)
+		})
+	}
+}
+
+func TestArchivedEventIDIsStablePerSeries(t *testing.T) {
+	event := func(name, uid string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion":     "v1",
+			"kind":           "Event",
+			"metadata":       map[string]interface{}{"name": name, "namespace": "default", "uid": uid},
+			"involvedObject": map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "foo", "uid": "p1"},
+			"type":           "Warning",
+			"reason":         "BackOff",
+			"message":        "Back-off restarting failed container",
+		}}
+	}
+
+	// The events recreated after they expired belong to the same series.
+	require.Equal(t, archivedEventFrom("cluster1", event("foo.1", "e1")).ID, archivedEventFrom("cluster1", event("foo.2", "e2")).ID)
+	// The same event in another cluster belongs to another series.
+	require.NotEqual(t, archivedEventFrom("cluster1", event("foo.1", "e1")).ID, archivedEventFrom("cluster2", event("foo.1", "e1")).ID)
+}
+
+func TestEventArchiverArchive(t *testing.T) {
+	eventStorage := &fakeEventStorage{}
+	archiver := NewEventArchiver("cluster1", nil, nil, eventStorage)
+
+	archiver.archive(context.TODO(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion":     "v1",
+		"kind":           "Event",
+		"metadata":       map[string]interface{}{"name": "foo.1", "namespace": "default", "uid": "e1"},
+		"involvedObject": map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "foo"},
+		"reason":         "Killing",
+	}})
+	// Objects other than events are ignored.
+	archiver.archive(context.TODO(), "foo")
+
+	require.Len(t, eventStorage.archived, 1)
+	require.Equal(t, "Killing", eventStorage.archived["e1"].Reason)
+}
+
+func TestEventArchiverEventGVR(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      string
+	}{
+		{
+			name: "events.k8s.io v1 is preferred",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "events"}}},
+				{GroupVersion: "events.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "events"}}},
+			},
+			want: "events.k8s.io/v1, Resource=events",
+		},
+		{
+			name: "fall back to core v1",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "events"}}},
+			},
+			want: "/v1, Resource=events",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: tt.resources}}
+			archiver := NewEventArchiver("cluster1", nil, discoveryClient, &fakeEventStorage{})
+			require.Equal(t, tt.want, archiver.eventGVR().String())
+		})
+	}
+}
+
+func TestEventPurgerPurge(t *testing.T) {
+	eventStorage := &fakeEventStorage{}
+	purger := NewEventPurger(eventStorage, 24*time.Hour)
+
+	purger.purge(context.TODO())
+
+	require.WithinDuration(t, time.Now().Add(-24*time.Hour), eventStorage.before, time.Minute)
+}
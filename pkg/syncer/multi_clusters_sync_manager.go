@@ -34,19 +34,24 @@ type MultiClusterSyncManager interface {
 
 // multiClusterSyncManager is the concrete implementation of the MultiClusterSyncManager interface.
 type multiClusterSyncManager struct {
-	storage    storage.ResourceStorage
-	controller controller.Controller
+	storage      storage.ResourceStorage
+	eventStorage storage.EventStorage
+	syncRecorder *SyncRecorder
+	controller   controller.Controller
 
 	managers map[string]SingleClusterSyncManager
 	sync.RWMutex
 }
 
-// NewMultiClusterSyncManager creates a new MultiClusterSyncManager instance with the given context, controller and storage.
-func NewMultiClusterSyncManager(baseContext context.Context, controller controller.Controller, storage storage.ResourceStorage) MultiClusterSyncManager {
+// NewMultiClusterSyncManager creates a new MultiClusterSyncManager instance with the given context, controller, storage, event storage and sync recorder.
+// The events of the clusters are not archived if the event storage is nil, and the sync times are not recorded if the sync recorder is nil.
+func NewMultiClusterSyncManager(baseContext context.Context, controller controller.Controller, storage storage.ResourceStorage, eventStorage storage.EventStorage, syncRecorder *SyncRecorder) MultiClusterSyncManager {
 	return &multiClusterSyncManager{
-		managers:   make(map[string]SingleClusterSyncManager),
-		controller: controller,
-		storage:    storage,
+		managers:     make(map[string]SingleClusterSyncManager),
+		controller:   controller,
+		storage:      storage,
+		eventStorage: eventStorage,
+		syncRecorder: syncRecorder,
 	}
 }
 
@@ -58,7 +63,7 @@ func (s *multiClusterSyncManager) Create(ctx context.Context, clusterName string
 		return mgr, nil
 	}
 
-	mgr, err := NewSingleClusterSyncManager(ctx, clusterName, config, s.controller, s.storage)
+	mgr, err := NewSingleClusterSyncManager(ctx, clusterName, config, s.controller, s.storage, s.eventStorage, s.syncRecorder)
 	if err != nil {
 		return nil, err
 	}
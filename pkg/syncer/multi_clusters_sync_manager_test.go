@@ -71,7 +71,7 @@ func Test_multiClusterSyncManager_Create(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewMultiClusterSyncManager(context.TODO(), nil, nil)
+			s := NewMultiClusterSyncManager(context.TODO(), nil, nil, nil, nil)
 			_, err := s.Create(context.TODO(), "cluster1", tt.config)
 			if tt.wantErr {
 				require.Error(t, err)
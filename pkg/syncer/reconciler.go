@@ -20,11 +20,13 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/KusionStack/karpor/pkg/infra/multicluster"
 	"github.com/KusionStack/karpor/pkg/infra/search/storage"
 	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
 	searchv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/search/v1beta1"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -35,10 +37,12 @@ import (
 	"k8s.io/klog/v2"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -48,10 +52,26 @@ const (
 	anyResource = "*"
 )
 
+// Reasons of the syncing condition of clusters.
+const (
+	reasonSyncing          = "Syncing"
+	reasonNoResources      = "NoResources"
+	reasonClusterUnhealthy = "ClusterUnhealthy"
+)
+
 // SyncReconciler is the main structure that holds the state and dependencies for the multi-cluster syncer reconciler.
 type SyncReconciler struct {
 	storage storage.ResourceStorage
-
+	// eventStorage archives the events of the synced clusters, the events
+	// are not archived if it is nil.
+	eventStorage storage.EventStorage
+	// syncRecorder records the last sync time of the synced clusters, it is
+	// not recorded if it is nil.
+	syncRecorder *SyncRecorder
+
+	// hubConfig reaches the clusters in Agent access mode through the
+	// cluster proxy of the hub.
+	hubConfig  *rest.Config
 	client     client.Client
 	controller controller.Controller
 	mgr        MultiClusterSyncManager
@@ -62,10 +82,24 @@ func NewSyncReconciler(storage storage.ResourceStorage) *SyncReconciler {
 	return &SyncReconciler{storage: storage}
 }
 
+// WithEventArchive archives the events of the synced clusters into the event
+// storage.
+func (r *SyncReconciler) WithEventArchive(eventStorage storage.EventStorage) *SyncReconciler {
+	r.eventStorage = eventStorage
+	return r
+}
+
+// WithSyncRecorder records the last time the resources of the synced
+// clusters were synced with the recorder.
+func (r *SyncReconciler) WithSyncRecorder(recorder *SyncRecorder) *SyncReconciler {
+	r.syncRecorder = recorder
+	return r
+}
+
 // SetupWithManager sets up the SyncReconciler with the given manager and registers it as a controller.
 func (r *SyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	controller, err := ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1beta1.Cluster{}).
+		For(&clusterv1beta1.Cluster{}, builder.WithPredicates(predicate.Funcs{UpdateFunc: clusterUpdateNeedsSync})).
 		Watches(&source.Kind{Type: &searchv1beta1.SyncRegistry{}}, &handler.Funcs{
 			CreateFunc: r.CreateEvent,
 			UpdateFunc: r.UpdateEvent,
@@ -78,10 +112,11 @@ func (r *SyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+	r.hubConfig = mgr.GetConfig()
 	r.client = mgr.GetClient()
 	r.controller = controller
 	// TODO:
-	r.mgr = NewMultiClusterSyncManager(context.Background(), r.controller, r.storage)
+	r.mgr = NewMultiClusterSyncManager(context.Background(), r.controller, r.storage, r.eventStorage, r.syncRecorder)
 	return nil
 }
 
@@ -140,13 +175,66 @@ func (r *SyncReconciler) Reconcile(ctx context.Context, req reconcile.Request) (
 		return reconcile.Result{}, r.stopCluster(ctx, cluster.Name)
 	}
 
-	// TODO: it's danger
-	// if !cluster.Status.Healthy {
-	// 	logger.Info("cluster is unhealthy", "cluster", cluster.Name)
-	// 	return reconcile.Result{}, r.stopCluster(ctx, cluster.Name)
-	// }
+	// Pause syncing the unhealthy clusters rather than retrying blindly, the
+	// synced resources are kept and the syncing resumes once the cluster is
+	// healthy again.
+	if isClusterUnhealthy(&cluster) {
+		logger.Info("cluster is unhealthy, pause syncing it", "cluster", cluster.Name)
+		r.mgr.Stop(ctx, cluster.Name)
+		return reconcile.Result{}, r.setSyncingCondition(ctx, &cluster, metav1.ConditionFalse,
+			reasonClusterUnhealthy, "Syncing is paused until the cluster is healthy")
+	}
+
+	if err := r.handleClusterAddOrUpdate(ctx, cluster.DeepCopy()); err != nil {
+		return reconcile.Result{}, err
+	}
+	if _, syncing := r.mgr.GetForCluster(cluster.Name); !syncing {
+		return reconcile.Result{}, r.setSyncingCondition(ctx, &cluster, metav1.ConditionFalse,
+			reasonNoResources, "No resources of the cluster are selected by the sync registries")
+	}
+	return reconcile.Result{}, r.setSyncingCondition(ctx, &cluster, metav1.ConditionTrue,
+		reasonSyncing, "The resources of the cluster are being synced")
+}
+
+// setSyncingCondition sets the syncing condition in the status of the
+// cluster if it has changed.
+func (r *SyncReconciler) setSyncingCondition(ctx context.Context, cluster *clusterv1beta1.Cluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1beta1.ClusterConditionSyncing)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
 
-	return reconcile.Result{}, r.handleClusterAddOrUpdate(ctx, cluster.DeepCopy())
+	cluster = cluster.DeepCopy()
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:    clusterv1beta1.ClusterConditionSyncing,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	return r.client.Status().Update(ctx, cluster)
+}
+
+// isClusterUnhealthy returns true if the health prober found the cluster
+// unreachable or its credential rejected. The clusters which have not been
+// probed are not considered unhealthy.
+func isClusterUnhealthy(cluster *clusterv1beta1.Cluster) bool {
+	return meta.IsStatusConditionFalse(cluster.Status.Conditions, clusterv1beta1.ClusterConditionReachable) ||
+		meta.IsStatusConditionFalse(cluster.Status.Conditions, clusterv1beta1.ClusterConditionAuthenticated)
+}
+
+// clusterUpdateNeedsSync filters out the updates of clusters which change
+// neither what nor whether to sync, such as the periodic status updates of
+// the health prober.
+func clusterUpdateNeedsSync(e event.UpdateEvent) bool {
+	oldCluster, okOld := e.ObjectOld.(*clusterv1beta1.Cluster)
+	newCluster, okNew := e.ObjectNew.(*clusterv1beta1.Cluster)
+	if !okOld || !okNew {
+		return true
+	}
+	return !reflect.DeepEqual(oldCluster.Spec, newCluster.Spec) ||
+		!reflect.DeepEqual(oldCluster.Labels, newCluster.Labels) ||
+		!oldCluster.DeletionTimestamp.Equal(newCluster.DeletionTimestamp) ||
+		isClusterUnhealthy(oldCluster) != isClusterUnhealthy(newCluster)
 }
 
 // stopCluster stops the reconciliation process for the given cluster.
@@ -186,7 +274,12 @@ func (r *SyncReconciler) handleClusterAddOrUpdate(ctx context.Context, cluster *
 		return r.stopCluster(ctx, cluster.Name)
 	}
 
-	clusterConfig, err := buildClusterConfig(cluster)
+	var clusterConfig *rest.Config
+	if cluster.Spec.Access.Mode == clusterv1beta1.ClusterAccessModeAgent {
+		clusterConfig = multicluster.NewProxyConfig(r.hubConfig, cluster.Name)
+	} else {
+		clusterConfig, err = buildClusterConfig(cluster)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "failed to build config for cluster %s", cluster.Name)
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -241,36 +242,93 @@ func TestSyncReconciler_DeleteEvent(t *testing.T) {
 }
 
 func TestSyncReconciler_Reconcile(t *testing.T) {
+	unhealthy := metav1.Condition{Type: clusterv1beta1.ClusterConditionReachable, Status: metav1.ConditionFalse, Reason: "ProbeFailed"}
 	tests := []struct {
-		name    string
-		cluster *clusterv1beta1.Cluster
-		req     reconcile.Request
-		wantErr bool
+		name          string
+		cluster       *clusterv1beta1.Cluster
+		req           reconcile.Request
+		syncing       bool
+		wantErr       bool
+		wantStopped   bool
+		wantCondition metav1.ConditionStatus
+		wantReason    string
 	}{
 		{
-			"test no error",
-			&clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
-			reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1"}},
-			false,
+			name:          "test no error",
+			cluster:       &clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+			req:           reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1"}},
+			syncing:       true,
+			wantCondition: metav1.ConditionTrue,
+			wantReason:    reasonSyncing,
+		},
+		{
+			name:          "test no resources",
+			cluster:       &clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+			req:           reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1"}},
+			wantCondition: metav1.ConditionFalse,
+			wantReason:    reasonNoResources,
+		},
+		{
+			name: "test unhealthy cluster paused",
+			cluster: &clusterv1beta1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+				Status:     clusterv1beta1.ClusterStatus{Conditions: []metav1.Condition{unhealthy}},
+			},
+			req:           reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1"}},
+			wantStopped:   true,
+			wantCondition: metav1.ConditionFalse,
+			wantReason:    reasonClusterUnhealthy,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			m := &mock.Mock{}
+			m.On("GetForCluster", "cluster1").Return(nil, tt.syncing)
+			m.On("Stop", mock.Anything, "cluster1").Return()
 			r := &SyncReconciler{
 				client: fake.NewClientBuilder().WithRuntimeObjects(tt.cluster).WithScheme(scheme.Scheme).Build(),
+				mgr:    &fakeMultiClusterSyncManager{m},
 			}
-			m := mockey.Mock((*SyncReconciler).handleClusterAddOrUpdate).Return(nil).Build()
-			defer m.UnPatch()
+			m1 := mockey.Mock((*SyncReconciler).handleClusterAddOrUpdate).Return(nil).Build()
+			defer m1.UnPatch()
 			_, err := r.Reconcile(context.TODO(), tt.req)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
 			}
+			if tt.wantStopped {
+				m.AssertCalled(t, "Stop", mock.Anything, "cluster1")
+			} else {
+				m.AssertNotCalled(t, "Stop", mock.Anything, "cluster1")
+			}
+
+			var cluster clusterv1beta1.Cluster
+			require.NoError(t, r.client.Get(context.TODO(), tt.req.NamespacedName, &cluster))
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1beta1.ClusterConditionSyncing)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+			require.Equal(t, tt.wantReason, condition.Reason)
 		})
 	}
 }
 
+func Test_clusterUpdateNeedsSync(t *testing.T) {
+	cluster := &clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	probed := cluster.DeepCopy()
+	probed.Status.LatencyMillis = 10
+	probed.Status.Conditions = []metav1.Condition{{Type: clusterv1beta1.ClusterConditionReachable, Status: metav1.ConditionTrue}}
+	unhealthy := cluster.DeepCopy()
+	unhealthy.Status.Conditions = []metav1.Condition{{Type: clusterv1beta1.ClusterConditionAuthenticated, Status: metav1.ConditionFalse}}
+	changed := cluster.DeepCopy()
+	changed.Spec.Access.Endpoint = "https://localhost:6443"
+
+	require.False(t, clusterUpdateNeedsSync(event.UpdateEvent{ObjectOld: cluster, ObjectNew: probed}))
+	require.True(t, clusterUpdateNeedsSync(event.UpdateEvent{ObjectOld: probed, ObjectNew: unhealthy}))
+	require.True(t, clusterUpdateNeedsSync(event.UpdateEvent{ObjectOld: unhealthy, ObjectNew: probed}))
+	require.True(t, clusterUpdateNeedsSync(event.UpdateEvent{ObjectOld: cluster, ObjectNew: changed}))
+}
+
 func TestSyncReconciler_getNormalizedResource(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -74,6 +74,12 @@ type singleClusterSyncManager struct {
 	// TODO: use pointer
 	syncers sync.Map // map[schema.GroupVersionResource]*ResourceSyncer
 	storage storage.ResourceStorage
+	// eventStorage archives the events of the cluster, the events are not
+	// archived if it is nil.
+	eventStorage storage.EventStorage
+	// syncRecorder records the last sync time of the cluster, it is not
+	// recorded if it is nil.
+	syncRecorder *SyncRecorder
 
 	logger logr.Logger
 
@@ -81,12 +87,14 @@ type singleClusterSyncManager struct {
 	gvkToGVRCache   sync.Map
 }
 
-// NewSingleClusterSyncManager creates a new instance of the singleClusterSyncManager with the given context, cluster name, config, controller, and storage.
+// NewSingleClusterSyncManager creates a new instance of the singleClusterSyncManager with the given context, cluster name, config, controller, storage, event storage and sync recorder.
 func NewSingleClusterSyncManager(baseContext context.Context,
 	clusterName string,
 	config *rest.Config,
 	controller controller.Controller,
 	storage storage.ResourceStorage,
+	eventStorage storage.EventStorage,
+	syncRecorder *SyncRecorder,
 ) (SingleClusterSyncManager, error) {
 	config = rest.CopyConfig(config)
 	dynamicClient, err := dynamic.NewForConfig(config)
@@ -106,6 +114,8 @@ func NewSingleClusterSyncManager(baseContext context.Context,
 		ch:            make(chan struct{}, 1),
 		controller:    controller,
 		storage:       storage,
+		eventStorage:  eventStorage,
+		syncRecorder:  syncRecorder,
 		logger:        ctrl.LoggerFrom(baseContext).WithName("single-cluster-manager").WithValues("cluster", clusterName),
 
 		discoveryClient: discoveryClient,
@@ -137,6 +147,12 @@ func (s *singleClusterSyncManager) Start(ctx context.Context) error {
 
 		go s.process()
 
+		if s.eventStorage != nil {
+			archiver := NewEventArchiver(s.clusterName, s.dynamicClient, s.discoveryClient, s.eventStorage)
+			//nolint:contextcheck
+			s.wg.StartWithContext(s.ctx, archiver.Run)
+		}
+
 		s.startLock.Lock()
 		s.started = true
 		s.startLock.Unlock()
@@ -330,6 +346,7 @@ func (s *singleClusterSyncManager) registerTmplFuncs() {
 func (s *singleClusterSyncManager) startResource(_ context.Context, gvr schema.GroupVersionResource, rsr *searchv1beta1.ResourceSyncRule) {
 	s.logger.Info("create resource syncer", "rsr", rsr)
 	syncer := NewResourceSyncer(s.clusterName, s.dynamicClient, *rsr, s.storage)
+	syncer.recorder = s.syncRecorder
 	s.syncers.Store(gvr, syncer)
 	s.controller.Watch(syncer.Source(), handler.Funcs{
 		CreateFunc: func(ce event.CreateEvent, rli workqueue.RateLimitingInterface) {
@@ -0,0 +1,113 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// syncTimeUpdateInterval is the interval the last sync time of the synced
+// clusters is written to their status, so that the resources changing
+// constantly do not update the clusters on every change.
+const syncTimeUpdateInterval = 5 * time.Second
+
+// SyncRecorder records the last time the resources of each cluster were
+// synced in the status of the cluster, which notifies the watchers of the
+// clusters, such as the alert rules triggered by syncs.
+type SyncRecorder struct {
+	client client.Client
+
+	lock   sync.Mutex
+	synced map[string]time.Time
+
+	logger logr.Logger
+}
+
+// NewSyncRecorder creates a new SyncRecorder updating the clusters with the
+// client.
+func NewSyncRecorder(client client.Client) *SyncRecorder {
+	return &SyncRecorder{
+		client: client,
+		synced: map[string]time.Time{},
+		logger: ctrl.Log.WithName("sync-recorder"),
+	}
+}
+
+// Record records that the resources of the cluster were synced. It is a
+// no-op on a nil SyncRecorder.
+func (r *SyncRecorder) Record(cluster string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.synced[cluster] = time.Now()
+}
+
+// Start writes the recorded sync times to the clusters periodically until
+// the context is done, it implements the manager.Runnable interface.
+func (r *SyncRecorder) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, r.flush, syncTimeUpdateInterval)
+	return nil
+}
+
+// flush writes the sync times recorded since the last flush to the status of
+// the clusters. The sync times failed to be written are kept for the next
+// flush unless the clusters are gone.
+func (r *SyncRecorder) flush(ctx context.Context) {
+	r.lock.Lock()
+	synced := r.synced
+	r.synced = map[string]time.Time{}
+	r.lock.Unlock()
+
+	for cluster, syncedAt := range synced {
+		err := r.updateSyncTime(ctx, cluster, syncedAt)
+		if err == nil || apierrors.IsNotFound(err) {
+			continue
+		}
+		r.logger.Error(err, "failed to update the last sync time of cluster", "cluster", cluster)
+		r.lock.Lock()
+		if _, ok := r.synced[cluster]; !ok {
+			r.synced[cluster] = syncedAt
+		}
+		r.lock.Unlock()
+	}
+}
+
+// updateSyncTime patches the last sync time in the status of the cluster.
+func (r *SyncRecorder) updateSyncTime(ctx context.Context, cluster string, syncedAt time.Time) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"lastSyncTime": metav1.NewTime(syncedAt),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	obj := &clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: cluster}}
+	return r.client.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
+}
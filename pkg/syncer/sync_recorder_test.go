@@ -0,0 +1,52 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"testing"
+
+	clusterv1beta1 "github.com/KusionStack/karpor/pkg/kubernetes/apis/cluster/v1beta1"
+	"github.com/KusionStack/karpor/pkg/kubernetes/scheme"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSyncRecorder(t *testing.T) {
+	ctx := context.Background()
+	cluster := &clusterv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).WithScheme(scheme.Scheme).Build()
+	recorder := NewSyncRecorder(cl)
+
+	// Nothing is written before any resource is synced.
+	recorder.flush(ctx)
+	got := &clusterv1beta1.Cluster{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "cluster1"}, got))
+	require.Nil(t, got.Status.LastSyncTime)
+
+	// The sync times of the missing clusters are dropped.
+	recorder.Record("cluster1")
+	recorder.Record("missing")
+	recorder.flush(ctx)
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "cluster1"}, got))
+	require.NotNil(t, got.Status.LastSyncTime)
+	require.Empty(t, recorder.synced)
+
+	// A nil recorder records nothing.
+	var nilRecorder *SyncRecorder
+	nilRecorder.Record("cluster1")
+}
@@ -58,6 +58,9 @@ type deleted struct {
 type ResourceSyncer struct {
 	source  SyncSource
 	storage storage.ResourceStorage
+	// recorder records the last sync time of the cluster, it is not
+	// recorded if it is nil.
+	recorder *SyncRecorder
 
 	queue  workqueue.RateLimitingInterface
 	ctx    context.Context
@@ -275,6 +278,7 @@ func (s *ResourceSyncer) sync(ctx context.Context, key string) error {
 		return err
 	}
 
+	s.recorder.Record(s.source.Cluster())
 	s.logger.V(1).Info("successfully sync", "key", key, "op", op)
 	return nil
 }
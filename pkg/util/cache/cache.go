@@ -72,6 +72,29 @@ func (c *Cache[K, V]) Set(key K, data V) {
 	}
 }
 
+// DeleteExpired removes the expired items from the cache, which are
+// otherwise only removed when they are retrieved.
+func (c *Cache[K, V]) DeleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, item := range c.cache {
+		if now.After(item.ExpiryTime) {
+			delete(c.cache, key)
+		}
+	}
+}
+
+// Len returns the number of items in the cache, including the expired items
+// which are not removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.cache)
+}
+
 // zeroValue returns the zero value of type V.
 func zeroValue[V any]() V {
 	var zero V
@@ -91,3 +91,22 @@ func TestCache_ExpiredKeyIsDeleted(t *testing.T) {
 		t.Error("Expected expired key to be automatically deleted from the cache.")
 	}
 }
+
+func TestCache_DeleteExpired(t *testing.T) {
+	expiration := 100 * time.Millisecond
+	cache := NewCache[int, string](expiration)
+
+	cache.Set(1, MockCacheValue)
+	time.Sleep(expiration + 50*time.Millisecond)
+	cache.Set(2, MockCacheValue)
+
+	cache.DeleteExpired()
+
+	// Only the expired key is deleted without being accessed
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 item in cache, got %d", cache.Len())
+	}
+	if _, exists := cache.Get(2); !exists {
+		t.Error("Expected unexpired key to remain in cache.")
+	}
+}
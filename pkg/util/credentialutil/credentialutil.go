@@ -0,0 +1,111 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentialutil inspects the credentials of clusters, such as the
+// expiry of certificates and service account tokens.
+package credentialutil
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// serviceAccountSubjectPrefix is the prefix of the subject of service
+// account tokens, followed by "<namespace>:<name>".
+const serviceAccountSubjectPrefix = "system:serviceaccount:"
+
+var (
+	ErrNoCertificate = errors.New("no certificate found in PEM data")
+	ErrMalformedJWT  = errors.New("malformed JWT")
+)
+
+// tokenClaims are the claims of a service account token used by karpor.
+type tokenClaims struct {
+	Expiry  *int64 `json:"exp,omitempty"`
+	Subject string `json:"sub,omitempty"`
+}
+
+// CertificateExpiration returns the NotAfter of the first certificate in the
+// PEM data, which is the leaf certificate of a client certificate chain.
+func CertificateExpiration(data []byte) (*time.Time, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoCertificate
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse certificate")
+		}
+		notAfter := cert.NotAfter
+		return &notAfter, nil
+	}
+}
+
+// TokenExpiration returns the expiry of the JWT, or nil if the token does
+// not expire, such as legacy service account tokens. The signature of the
+// token is not verified.
+func TokenExpiration(token string) (*time.Time, error) {
+	claims, err := parseClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Expiry == nil {
+		return nil, nil
+	}
+	expiry := time.Unix(*claims.Expiry, 0)
+	return &expiry, nil
+}
+
+// ServiceAccountFromToken returns the namespace and name of the service
+// account the token was issued for.
+func ServiceAccountFromToken(token string) (namespace, name string, err error) {
+	claims, err := parseClaims(token)
+	if err != nil {
+		return "", "", err
+	}
+	serviceAccount, ok := strings.CutPrefix(claims.Subject, serviceAccountSubjectPrefix)
+	parts := strings.Split(serviceAccount, ":")
+	if !ok || len(parts) != 2 {
+		return "", "", errors.Errorf("token subject %q is not a service account", claims.Subject)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseClaims decodes the claims of the JWT without verifying it.
+func parseClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedJWT
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedJWT, err.Error())
+	}
+	claims := &tokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, errors.Wrap(ErrMalformedJWT, err.Error())
+	}
+	return claims, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The Karpor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentialutil
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KusionStack/karpor/pkg/util/certgenerator"
+	"github.com/stretchr/testify/require"
+)
+
+// newToken returns an unsigned JWT with the given claims.
+func newToken(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestCertificateExpiration(t *testing.T) {
+	key, err := certgenerator.NewPrivateKey(x509.RSA)
+	require.NoError(t, err)
+	cert, err := certgenerator.NewSelfSignedCACert(key, "karpor", nil, time.Hour*24)
+	require.NoError(t, err)
+
+	data := append([]byte("-----BEGIN EC PARAMETERS-----\nBggqhkjOPQMBBw==\n-----END EC PARAMETERS-----\n"), certgenerator.EncodeCertPEM(cert)...)
+	expiration, err := CertificateExpiration(data)
+	require.NoError(t, err)
+	require.True(t, expiration.Equal(cert.NotAfter))
+
+	_, err = CertificateExpiration([]byte("not a certificate"))
+	require.ErrorIs(t, err, ErrNoCertificate)
+}
+
+func TestTokenExpiration(t *testing.T) {
+	expiration, err := TokenExpiration(newToken(t, map[string]interface{}{"exp": 1700000000}))
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1700000000, 0), *expiration)
+
+	expiration, err = TokenExpiration(newToken(t, map[string]interface{}{"sub": "system:serviceaccount:default:karpor"}))
+	require.NoError(t, err)
+	require.Nil(t, expiration)
+
+	_, err = TokenExpiration("opaque-token")
+	require.ErrorIs(t, err, ErrMalformedJWT)
+	_, err = TokenExpiration("a.%%%.c")
+	require.ErrorIs(t, err, ErrMalformedJWT)
+}
+
+func TestServiceAccountFromToken(t *testing.T) {
+	namespace, name, err := ServiceAccountFromToken(newToken(t, map[string]interface{}{"sub": "system:serviceaccount:kube-system:karpor"}))
+	require.NoError(t, err)
+	require.Equal(t, "kube-system", namespace)
+	require.Equal(t, "karpor", name)
+
+	_, _, err = ServiceAccountFromToken(newToken(t, map[string]interface{}{"sub": "alice"}))
+	require.Error(t, err)
+	_, _, err = ServiceAccountFromToken(newToken(t, map[string]interface{}{"sub": "system:serviceaccount:karpor"}))
+	require.Error(t, err)
+}
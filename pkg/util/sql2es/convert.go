@@ -64,6 +64,13 @@ func Convert(sql string) (dsl, table string, err error) {
 // ConvertWithDefaultFilter appends the filter to sql where clause if the
 // filter column names have no intersection with where clause.
 func ConvertWithDefaultFilter(sql string, filter sqlparser.Expr) (dsl, table string, err error) {
+	return ConvertWithRequiredFilter(sql, filter, nil)
+}
+
+// ConvertWithRequiredFilter appends the default filter to sql where clause
+// like ConvertWithDefaultFilter, and always appends the required filter to
+// it, no matter which columns are in the where clause.
+func ConvertWithRequiredFilter(sql string, defaultFilter, requiredFilter sqlparser.Expr) (dsl, table string, err error) {
 	stmt, err := sqlparser.Parse(sql)
 	if err != nil {
 		return "", "", err
@@ -80,7 +87,10 @@ func ConvertWithDefaultFilter(sql string, filter sqlparser.Expr) (dsl, table str
 		return "", "", fmt.Errorf("only one table supported")
 	}
 
-	sel = applyDefaultFilter(sel, filter)
+	sel = applyDefaultFilter(sel, defaultFilter)
+	if requiredFilter != nil {
+		sel.AddWhere(requiredFilter)
+	}
 	return handleSelect(sel)
 }
 
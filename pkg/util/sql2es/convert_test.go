@@ -341,3 +341,17 @@ func Test_applyDefaultFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertWithRequiredFilter(t *testing.T) {
+	required := &sqlparser.ComparisonExpr{
+		Operator: sqlparser.EqualStr,
+		Left:     &sqlparser.ColName{Name: sqlparser.NewColIdent("cluster")},
+		Right:    sqlparser.NewStrVal([]byte("prod")),
+	}
+
+	dsl, table, err := ConvertWithRequiredFilter("SELECT * FROM mock_table WHERE name = 'John' OR age > 30", nil, required)
+	require.NoError(t, err)
+	require.Equal(t, "mock_table", table)
+	// The required filter is ANDed with the whole WHERE clause.
+	require.Equal(t, `{"query" : {"bool" : {"must" : [{"bool" : {"should" : [{"match_phrase" : {"name" : {"query" : "John"}}},{"range" : {"age" : {"gt" : "30"}}}]}},{"match_phrase" : {"cluster" : {"query" : "prod"}}}]}},"from" : 0,"size" : 1}`, dsl)
+}